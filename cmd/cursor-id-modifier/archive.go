@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/yuaotian/go-cursor-help/internal/audit"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+)
+
+// runExportBackup: export-backup子命令
+// 将storage.json、state.vscdb、machineid以及Windows上的注册表导出连同清单打包为一个归档
+// 输出路径以.zip结尾时使用zip格式，否则使用tar.gz格式
+// 参数:
+//   - env: 子命令运行时需要的组件
+//   - args: 子命令参数
+//
+// 返回值:
+//   - error: 如果打包失败，则返回错误
+func runExportBackup(env *commandEnv, args []string) error {
+	flags := flag.NewFlagSet("export-backup", flag.ContinueOnError)
+	out := flags.String("out", "", "path of the archive to create, .zip or .tar.gz (default: inside the backups directory)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// 关闭Cursor，保证state.vscdb处于一致状态
+	if _, err := handleCursorProcesses(env.ctx, env.display, env.processManager); err != nil {
+		return err
+	}
+
+	archivePath, manifest, err := env.configManager.ExportBackupSet(*out)
+	recordAudit(audit.Entry{Action: "export-backup", Backups: auditBackups(archivePath)}, err)
+	if err != nil {
+		return err
+	}
+	env.display.ShowSuccess(lang.Format(lang.GetText().BackupSetCreated, lang.Values{"Count": len(manifest.Files), "Path": archivePath}))
+	return nil
+}
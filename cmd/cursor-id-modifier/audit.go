@@ -0,0 +1,168 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/yuaotian/go-cursor-help/internal/audit"
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/hosts"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/report"
+	"github.com/yuaotian/go-cursor-help/internal/settings"
+)
+
+// auditLogFileName: 工具状态目录中审计日志的文件名
+const auditLogFileName = "audit.jsonl"
+
+var (
+	// auditLog: 操作审计日志，无法定位工具状态目录时为nil
+	auditLog *audit.Log
+	// auditUser: 记录到审计日志中的执行用户
+	auditUser string
+)
+
+// initAuditLog: 定位工具状态目录中的审计日志
+// 参数:
+//   - username: 用户名，通过sudo运行时为原始用户
+func initAuditLog(username string) {
+	auditUser = username
+	stateDir, err := settings.StateDir(username)
+	if err != nil {
+		log.Warn("Failed to locate state directory:", err)
+		return
+	}
+	auditLog = audit.Open(filepath.Join(stateDir, auditLogFileName))
+}
+
+// recordAudit: 将一次操作追加到审计日志，写入失败只记录警告，不影响操作本身
+// 参数:
+//   - entry: 操作名称、涉及的文件、标识符的变化和备份，用户、主机和结果由本函数填写
+//   - err: 操作的错误，为nil表示成功
+func recordAudit(entry audit.Entry, err error) {
+	if auditLog == nil {
+		return
+	}
+	entry.User = auditUser
+	if host, hostErr := os.Hostname(); hostErr == nil {
+		entry.Host = host
+	}
+	entry.Outcome = audit.OutcomeSuccess
+	if err != nil {
+		entry.Outcome = audit.OutcomeFailure
+		entry.Error = err.Error()
+	}
+	if _, err := auditLog.Append(entry); err != nil {
+		log.Warn("Failed to write audit log:", err)
+	}
+}
+
+// recordSave: 记录一次写入storage.json的操作
+// 参数:
+//   - action: 操作名称
+//   - configPath: storage.json的路径
+//   - oldConfig: 写入前的配置，文件不存在时为nil
+//   - result: 保存的结果，失败时可能为nil
+//   - err: 保存的错误
+func recordSave(action string, configPath string, oldConfig *config.StorageConfig, result *config.SaveResult, err error) {
+	entry := audit.Entry{Action: action, Targets: []string{configPath}}
+	if result != nil {
+		entry.Backups = auditBackups(result.BackupPath)
+		if result.Written != nil {
+			entry.Changes = auditIDChanges(idChanges(oldConfig, result))
+		}
+	}
+	recordAudit(entry, err)
+}
+
+// recordHostsChange: 记录一次修改hosts文件的操作，文件没有变化时不记录
+// 参数:
+//   - action: 操作名称
+//   - result: 修改的结果，失败时可能为nil
+//   - err: 修改的错误
+func recordHostsChange(action string, result *hosts.Result, err error) {
+	if err == nil && !result.Changed {
+		return
+	}
+	entry := audit.Entry{Action: action, Targets: []string{hosts.DefaultPath()}}
+	if result != nil {
+		entry.Backups = auditBackups(result.BackupPath)
+	}
+	recordAudit(entry, err)
+}
+
+// auditIDChanges: 将标识符的变化转换为审计记录，只保留值的哈希
+func auditIDChanges(changes []report.Change) []audit.Change {
+	result := make([]audit.Change, len(changes))
+	for i, change := range changes {
+		result[i] = audit.Change{Key: change.Field, Old: audit.HashValue(change.Old), New: audit.HashValue(change.New)}
+	}
+	return result
+}
+
+// auditKeyChanges: 将storage.json顶层键的变化转换为审计记录，只保留值的哈希
+func auditKeyChanges(changes []config.KeyChange) []audit.Change {
+	result := make([]audit.Change, len(changes))
+	for i, change := range changes {
+		result[i] = audit.Change{Key: change.Key, Old: audit.HashValue(change.Before), New: audit.HashValue(change.After)}
+	}
+	return result
+}
+
+// auditBackups: 返回非空的备份路径
+func auditBackups(paths ...string) []string {
+	var backups []string
+	for _, path := range paths {
+		if path != "" {
+			backups = append(backups, path)
+		}
+	}
+	return backups
+}
+
+// runAudit: audit子命令
+// audit verify：逐行校验审计日志的哈希链，发现被修改、插入或删除的记录
+// 参数:
+//   - env: 子命令运行时需要的组件
+//   - args: 子命令参数
+//
+// 返回值:
+//   - error: 如果审计日志无法读取或哈希链断开，则返回错误
+func runAudit(env *commandEnv, args []string) error {
+	if len(args) == 0 || args[0] != "verify" {
+		return fmt.Errorf("usage: audit verify [-file <audit log>]")
+	}
+	flags := flag.NewFlagSet("audit verify", flag.ContinueOnError)
+	defaultPath := ""
+	if auditLog != nil {
+		defaultPath = auditLog.Path()
+	}
+	path := flags.String("file", defaultPath, "audit log to verify, e.g. a copy taken from another machine")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *path == "" {
+		return fmt.Errorf("failed to locate the audit log, use -file")
+	}
+
+	text := lang.GetText()
+	result, err := audit.Verify(*path)
+	var chainErr *audit.ChainError
+	if errors.As(err, &chainErr) {
+		env.display.ShowWarning(lang.Format(text.AuditIntactEntries, lang.Values{"Count": result.Entries}))
+		return fmt.Errorf("%s: %w", *path, err)
+	}
+	if err != nil {
+		return err
+	}
+	if result.Entries == 0 {
+		env.display.ShowInfo(lang.Format(text.AuditEmpty, lang.Values{"Path": *path}))
+		return nil
+	}
+	env.display.ShowSuccess(lang.Format(text.AuditVerified, lang.Values{"Count": result.Entries, "Path": *path}))
+	env.display.ShowInfo(lang.Format(text.AuditHead, lang.Values{"Hash": result.Head, "Time": result.Last.Local().Format("2006-01-02 15:04:05")}))
+	return nil
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/pkg/idgen"
+)
+
+// -cm-json支持的期望状态
+const (
+	// cmStatePresent: 确保ids中的值，其他字段已有值时保留，缺失时生成；已一致时不修改
+	cmStatePresent = "present"
+	// cmStateReset: 生成新的标识符，keep指定的字段保留原有值；每次运行都会修改
+	cmStateReset = "reset"
+)
+
+// cmRequest: -cm-json模式从标准输入读取的期望状态
+type cmRequest struct {
+	// State: 期望状态，present（默认）或reset
+	State string `json:"state"`
+	// IDs: present状态下要确保的标识符，键为storage.json中的键名或-keep使用的字段名
+	IDs map[string]string `json:"ids"`
+	// Keep: reset状态下保留原有值的字段，未指定时使用-keep参数
+	Keep []string `json:"keep"`
+	// Backup: 修改前是否备份storage.json，默认为是
+	Backup *bool `json:"backup"`
+	// CloseCursor: 修改前是否关闭Cursor，默认为是
+	CloseCursor *bool `json:"close_cursor"`
+	// CheckMode: 只报告是否需要修改，不写入
+	CheckMode bool `json:"check_mode"`
+	// AnsibleCheckMode: Ansible传入的check_mode
+	AnsibleCheckMode bool `json:"_ansible_check_mode"`
+}
+
+// cmResult: -cm-json模式写到标准输出的结果，字段名与Ansible模块的返回值一致
+type cmResult struct {
+	// Changed: 是否修改了（检查模式下为是否需要修改）storage.json
+	Changed bool `json:"changed"`
+	// Failed: 是否失败
+	Failed bool `json:"failed"`
+	// Msg: 结果说明或失败原因
+	Msg string `json:"msg"`
+	// Diff: 修改前后的标识符
+	Diff *cmDiff `json:"diff,omitempty"`
+	// BackupFile: 修改前创建的备份
+	BackupFile string `json:"backup_file,omitempty"`
+}
+
+// cmDiff: 修改前后的标识符，键为storage.json中的键名
+type cmDiff struct {
+	Before map[string]string `json:"before"`
+	After  map[string]string `json:"after"`
+}
+
+// cmFatalHook: -cm-json模式下将log.Fatal的消息作为失败结果写出，之后由logrus退出程序
+type cmFatalHook struct {
+	stdout io.Writer
+}
+
+// Levels: 实现logrus.Hook
+func (h cmFatalHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.FatalLevel}
+}
+
+// Fire: 实现logrus.Hook
+func (h cmFatalHook) Fire(entry *logrus.Entry) error {
+	writeCMResult(h.stdout, &cmResult{Failed: true, Msg: entry.Message})
+	return nil
+}
+
+// runCMJSON: -cm-json模式，供Ansible模块、Chef资源等配置管理工具调用
+// 从标准输入读取JSON格式的期望状态，不询问、不显示界面和日志，只在标准输出写出一个结果对象
+// 参数:
+//   - stdin: 期望状态的来源
+//   - stdout: 结果的去向
+//
+// 返回值:
+//   - int: 进程退出码，失败时为1
+func runCMJSON(stdin io.Reader, stdout io.Writer) (code int) {
+	log.SetOutput(io.Discard)
+	log.AddHook(cmFatalHook{stdout: stdout})
+	defer func() {
+		if r := recover(); r != nil {
+			code = writeCMResult(stdout, &cmResult{Failed: true, Msg: fmt.Sprint("panic: ", r)})
+		}
+	}()
+
+	result, err := applyCMRequest(context.Background(), stdin)
+	if err != nil {
+		result.Changed = false
+		result.Failed = true
+		result.Msg = err.Error()
+	}
+	return writeCMResult(stdout, result)
+}
+
+// applyCMRequest: 读取期望状态，与当前的storage.json比较，需要时写入
+// 返回值:
+//   - *cmResult: 结果，出错时也不为nil，包含已经得到的差异
+//   - error: 期望状态无效或写入失败时返回错误
+func applyCMRequest(ctx context.Context, stdin io.Reader) (*cmResult, error) {
+	result := &cmResult{}
+	request, err := readCMRequest(stdin)
+	if err != nil {
+		return result, err
+	}
+
+	username := getCurrentUser()
+	initAuditLog(username)
+	loadSettings(username)
+	target := resolveTarget()
+	configManager := initConfigManager(username, target)
+	generator := initGenerator()
+
+	// 与控制台流程一样，无法解析的文件按没有旧值处理
+	current, err := configManager.ReadConfig(ctx)
+	if err != nil {
+		current = nil
+	}
+	desired, err := desiredIdentity(generator, request, current)
+	if err != nil {
+		return result, err
+	}
+	keys := target.TelemetryKeys()
+	result.Diff = &cmDiff{Before: cmIDs(current, keys), After: cmIDs(desired, keys)}
+	if maps.Equal(result.Diff.Before, result.Diff.After) {
+		result.Msg = "identifiers already match the desired state"
+		return result, nil
+	}
+	if request.CheckMode || request.AnsibleCheckMode {
+		result.Changed = true
+		result.Msg = "storage.json would be updated"
+		return result, nil
+	}
+
+	if request.CloseCursor == nil || *request.CloseCursor {
+		if err := initProcessManager(target).KillCursorProcesses(ctx); err != nil {
+			return result, fmt.Errorf("failed to close %s: %w", target.DisplayName(), err)
+		}
+	}
+	saveOptions, err := buildSaveOptions(generator)
+	if err != nil {
+		return result, err
+	}
+	if request.Backup != nil && !*request.Backup {
+		saveOptions.Backup = nil
+	}
+	saved, err := configManager.SaveConfigWithOptions(ctx, desired, saveOptions)
+	var protectedErr *config.WriteProtectedError
+	if errors.As(err, &protectedErr) {
+		if err := configManager.Unprotect(); err != nil {
+			return result, err
+		}
+		saved, err = configManager.SaveConfigWithOptions(ctx, desired, saveOptions)
+	}
+	action := "set-ids"
+	if request.State == cmStateReset {
+		action = "reset"
+	}
+	recordSave(action, configManager.ConfigPath(), current, saved, err)
+	if err != nil {
+		return result, err
+	}
+	if err := configManager.SaveGuardSnapshot(saved.Written); err != nil {
+		log.Warn("Failed to save guard snapshot:", err)
+	}
+
+	// fill-missing策略下实际写入的值可能与期望不同
+	result.Diff.After = cmIDs(saved.Written, keys)
+	result.Changed = true
+	result.BackupFile = saved.BackupPath
+	result.Msg = "storage.json updated"
+	return result, nil
+}
+
+// readCMRequest: 解析期望状态，未知的参数视为错误，Ansible附加的_ansible_*参数除外
+func readCMRequest(stdin io.Reader) (*cmRequest, error) {
+	var raw map[string]json.RawMessage
+	if err := json.NewDecoder(stdin).Decode(&raw); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to parse desired state: %w", err)
+	}
+	for key := range raw {
+		if strings.HasPrefix(key, "_ansible_") && key != "_ansible_check_mode" {
+			delete(raw, key)
+		}
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &cmRequest{State: cmStatePresent}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(request); err != nil {
+		return nil, fmt.Errorf("invalid desired state: %w", err)
+	}
+	switch request.State {
+	case cmStatePresent:
+		if request.Keep != nil {
+			return nil, fmt.Errorf("keep can only be used with state %q", cmStateReset)
+		}
+	case cmStateReset:
+		if len(request.IDs) > 0 {
+			return nil, fmt.Errorf("ids can only be used with state %q", cmStatePresent)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported state %q, supported: %s, %s", request.State, cmStatePresent, cmStateReset)
+	}
+	return request, nil
+}
+
+// desiredIdentity: 根据期望状态和当前配置确定要写入的标识符
+// 参数:
+//   - generator: ID生成器
+//   - request: 期望状态
+//   - current: 当前配置，文件不存在时为nil
+//
+// 返回值:
+//   - *config.StorageConfig: 要写入的标识符
+//   - error: 字段名或标识符格式无效时返回错误
+func desiredIdentity(generator *idgen.Generator, request *cmRequest, current *config.StorageConfig) (*config.StorageConfig, error) {
+	existing := &idgen.IdentitySet{}
+	if current != nil {
+		existing = current.Identity()
+	}
+
+	policy := generationPolicy()
+	if request.State == cmStateReset && request.Keep != nil {
+		fields, err := idgen.ParseFields(strings.Join(request.Keep, ","))
+		if err != nil {
+			return nil, err
+		}
+		policy = idgen.KeepFields(fields...)
+	}
+	if request.State == cmStatePresent {
+		for key, value := range request.IDs {
+			fields, err := idgen.ParseFields(key)
+			if err != nil || len(fields) != 1 {
+				return nil, fmt.Errorf("unknown ID field %q in ids", key)
+			}
+			if !generator.ValidateID(value, fields[0]) {
+				return nil, fmt.Errorf("invalid value for %s: %q", key, value)
+			}
+			setIdentityField(existing, fields[0], value)
+		}
+		// 所有已有的值都保留，只生成缺失的字段
+		policy = idgen.KeepFields("machineID", "macMachineID", "deviceID", "sqmID")
+	}
+
+	identity, err := generator.GenerateAll(idgen.GenerateOptions{Existing: existing, Policy: policy})
+	if err != nil {
+		return nil, err
+	}
+	return config.FromIdentity(identity), nil
+}
+
+// setIdentityField: 设置标识符中的一个字段，field为idgen.ParseFields返回的字段名
+func setIdentityField(identity *idgen.IdentitySet, field, value string) {
+	switch field {
+	case "machineID":
+		identity.MachineID = value
+	case "macMachineID":
+		identity.MacMachineID = value
+	case "deviceID":
+		identity.DeviceID = value
+	case "sqmID":
+		identity.SQMID = value
+	}
+}
+
+// cmIDs: 取出目标编辑器使用的标识符，键为storage.json中的键名；cfg为nil时各值为空
+func cmIDs(cfg *config.StorageConfig, keys []string) map[string]string {
+	if cfg == nil {
+		cfg = &config.StorageConfig{}
+	}
+	values := map[string]string{
+		"telemetry.machineId":    cfg.TelemetryMachineId,
+		"telemetry.macMachineId": cfg.TelemetryMacMachineId,
+		"telemetry.devDeviceId":  cfg.TelemetryDevDeviceId,
+		"telemetry.sqmId":        cfg.TelemetrySqmId,
+	}
+	ids := make(map[string]string, len(keys))
+	for _, key := range keys {
+		ids[key] = values[key]
+	}
+	return ids
+}
+
+// writeCMResult: 将结果写为一行JSON
+// 返回值:
+//   - int: 进程退出码，失败时为1
+func writeCMResult(stdout io.Writer, result *cmResult) int {
+	json.NewEncoder(stdout).Encode(result)
+	if result.Failed {
+		return 1
+	}
+	return 0
+}
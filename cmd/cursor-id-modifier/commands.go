@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/process"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+	"github.com/yuaotian/go-cursor-help/pkg/idgen"
+)
+
+// commandEnv: 子命令运行时需要的组件
+type commandEnv struct {
+	// ctx: 子命令的上下文，用于取消耗时的IO操作
+	ctx context.Context
+	// username: 当前用户名，用于定位用户目录
+	username string
+	// display: 用户界面显示组件
+	display *ui.Display
+	// configManager: 配置管理器
+	configManager *config.Manager
+	// processManager: 进程管理器
+	processManager *process.Manager
+	// generator: ID生成器，用于校验标识符格式
+	generator *idgen.Generator
+}
+
+// subcommands: 所有可用的子命令，键为命令名
+// 每个子命令自行解析其参数
+var subcommands = map[string]func(env *commandEnv, args []string) error{
+	"restore":          runRestore,
+	"snapshot":         runSnapshot,
+	"restore-snapshot": runRestoreSnapshot,
+	"discover":         runDiscover,
+	"export-backup":    runExportBackup,
+	"verify-ids":       runVerifyIDs,
+	"selftest":         runSelfTest,
+	"firewall":         runFirewall,
+	"fleet":            runFleet,
+	"audit":            runAudit,
+	"serve":            runServe,
+}
+
+// unprivilegedSubcommands: 不需要管理员权限的子命令，在权限检查之前运行
+// discover、verify-ids、selftest和audit只读取文件，不必为此提权
+// fleet只通过SSH修改远程主机，需要使用当前用户的SSH密钥和ssh-agent，sudo会丢弃SSH_AUTH_SOCK
+// serve的所有接口都会修改本机配置，仍需要管理员权限
+var unprivilegedSubcommands = map[string]bool{
+	"discover":   true,
+	"verify-ids": true,
+	"selftest":   true,
+	"audit":      true,
+	"fleet":      true,
+}
+
+// handleSubcommand: 运行命令行中的子命令，显示错误后退出
+// 参数:
+//   - env: 子命令运行时需要的组件
+func handleSubcommand(env *commandEnv) {
+	if err := runSubcommand(env, flag.Args()); err != nil {
+		log.Error(err)
+		env.display.ShowError(err.Error())
+	}
+	if os.Getenv("AUTOMATED_MODE") != "1" {
+		waitExit()
+	}
+}
+
+// runSubcommand: 运行子命令
+// 参数:
+//   - env: 子命令运行时需要的组件
+//   - args: 命令行中的非标志参数，第一个元素为子命令名
+//
+// 返回值:
+//   - error: 如果子命令不存在或执行失败，则返回错误
+func runSubcommand(env *commandEnv, args []string) error {
+	command, ok := subcommands[args[0]]
+	if !ok {
+		names := make([]string, 0, len(subcommands))
+		for name := range subcommands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown command %q, available commands: %s", args[0], strings.Join(names, ", "))
+	}
+	return command(env, args[1:])
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+)
+
+// runDiscover: discover子命令
+// 扫描常见位置中的所有storage.json并按修改时间列出
+// 交互模式下可以选择其中一个，保存到工具配置文件中作为之后运行的默认目标
+// 参数:
+//   - env: 子命令运行时需要的组件
+//   - args: 子命令参数
+//
+// 返回值:
+//   - error: 如果扫描或保存失败，则返回错误
+func runDiscover(env *commandEnv, args []string) error {
+	flags := flag.NewFlagSet("discover", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	text := lang.GetText()
+	candidates, err := config.DiscoverStorage(env.username)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		env.display.ShowInfo(text.DiscoverEmpty)
+		return nil
+	}
+
+	env.display.ShowInfo(lang.Format(text.DiscoverHeader, lang.Values{"Count": len(candidates)}))
+	options := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		app := candidate.App
+		if candidate.Portable {
+			app += " (portable)"
+		}
+		if candidate.Packaged {
+			app += " (store)"
+		}
+		options[i] = fmt.Sprintf("%-20s %s  %-8s %s", app, candidate.ModTime.Format("2006-01-02 15:04"), ui.FormatSize(candidate.Size), candidate.Path)
+	}
+
+	// 自动化模式下只列出，不做选择
+	index := prompt.Select(text.DiscoverPrompt, options, -1) + 1
+	if index == 0 {
+		return nil
+	}
+
+	// 保存到工具配置文件，之后的运行通过-storage的默认值使用该路径
+	if toolSettingsPath == "" {
+		return fmt.Errorf("cannot determine the settings file location, use -storage %s instead", candidates[index-1].Path)
+	}
+	toolSettings.StoragePath = candidates[index-1].Path
+	if err := toolSettings.Save(toolSettingsPath); err != nil {
+		return err
+	}
+	env.display.ShowSuccess(lang.Format(text.DiscoverSelected, lang.Values{"Path": candidates[index-1].Path}))
+	return nil
+}
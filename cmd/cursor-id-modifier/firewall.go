@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yuaotian/go-cursor-help/internal/audit"
+	"github.com/yuaotian/go-cursor-help/internal/firewall"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+)
+
+// firewallTimeout: 解析域名和修改防火墙规则的最长时间
+const firewallTimeout = time.Minute
+
+// firewallTargets: 返回防火墙规则要屏蔽的目标，包括遥测域名和工具配置文件中的额外地址
+func firewallTargets() []string {
+	return append(append([]string{}, telemetryDomains()...), toolSettings.TelemetryAddresses...)
+}
+
+// runFirewall: firewall子命令
+// 作为修改hosts文件的替代方式，管理阻止Cursor遥测地址出站连接的Windows防火墙规则
+// 操作为block（创建或更新规则）、unblock（移除规则）和status（列出规则，默认）
+// 参数:
+//   - env: 子命令运行时需要的组件
+//   - args: 子命令参数
+//
+// 返回值:
+//   - error: 如果操作不存在或修改防火墙规则失败，则返回错误
+func runFirewall(env *commandEnv, args []string) error {
+	flags := flag.NewFlagSet("firewall", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	action := flags.Arg(0)
+	if action == "" {
+		action = "status"
+	}
+
+	ctx, cancel := context.WithTimeout(env.ctx, firewallTimeout)
+	defer cancel()
+	text := lang.GetText()
+	switch action {
+	case "block":
+		rules, err := firewall.Block(ctx, firewallTargets())
+		recordAudit(audit.Entry{Action: "firewall-block", Targets: firewallTargets()}, err)
+		if err != nil {
+			return err
+		}
+		env.display.ShowSuccess(lang.Format(text.FirewallRulesCreated, lang.Values{"Count": len(rules)}))
+		showFirewallRules(env.display, rules)
+	case "unblock":
+		count, err := firewall.Unblock(ctx)
+		if err != nil || count > 0 {
+			recordAudit(audit.Entry{Action: "firewall-unblock", Targets: []string{firewall.RuleGroup}}, err)
+		}
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			env.display.ShowInfo(text.FirewallNoRules)
+			return nil
+		}
+		env.display.ShowSuccess(lang.Format(text.FirewallRulesRemoved, lang.Values{"Count": count}))
+	case "status":
+		rules, err := firewall.Status(ctx)
+		if err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			env.display.ShowInfo(text.FirewallNoRules)
+			return nil
+		}
+		showFirewallRules(env.display, rules)
+	default:
+		return fmt.Errorf("unknown firewall action %q, available actions: block, unblock, status", action)
+	}
+	return nil
+}
+
+// showFirewallRules: 列出规则屏蔽的目标和地址，已停用的规则另外标出
+func showFirewallRules(display *ui.Display, rules []firewall.Rule) {
+	text := lang.GetText()
+	items := make([]ui.SummaryItem, len(rules))
+	for i, rule := range rules {
+		value := strings.Join(rule.Addresses, "\n")
+		if !rule.Enabled {
+			value = text.FirewallRuleDisabled + "\n" + value
+		}
+		items[i] = ui.SummaryItem{Label: rule.Target, Value: value}
+	}
+	display.ShowSummary(text.FirewallStatusTitle, items)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sync"
+
+	"github.com/yuaotian/go-cursor-help/internal/audit"
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/fleet"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/pkg/idgen"
+)
+
+// runFleet: fleet子命令
+// fleet apply -inventory hosts.yaml：通过SSH连接清单中的每台主机，关闭Cursor，
+// 为每台主机生成新的标识符并写入远程storage.json，适用于有几十台机器的机房和教室
+// 参数:
+//   - env: 子命令运行时需要的组件
+//   - args: 子命令参数
+//
+// 返回值:
+//   - error: 如果清单无效，或有主机处理失败，则返回错误
+func runFleet(env *commandEnv, args []string) error {
+	if len(args) == 0 || args[0] != "apply" {
+		return fmt.Errorf("usage: fleet apply -inventory <hosts.yaml>")
+	}
+	flags := flag.NewFlagSet("fleet apply", flag.ContinueOnError)
+	inventory := flags.String("inventory", "", "YAML file listing the hosts to update")
+	parallel := flags.Int("parallel", 8, "number of hosts to update at the same time")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *inventory == "" {
+		return fmt.Errorf("-inventory is required")
+	}
+	hosts, err := fleet.LoadInventory(*inventory, env.username)
+	if err != nil {
+		return err
+	}
+
+	// 基于名称的UUID v5按清单中的主机名派生，否则所有主机会得到相同的设备ID和SQM ID
+	// -uuid-name只表示本机，这里不使用
+	var namespace *[16]byte
+	if *uuidNamespace != "" {
+		parsed, err := idgen.ParseNamespace(*uuidNamespace)
+		if err != nil {
+			return err
+		}
+		namespace = &parsed
+	}
+
+	text := lang.GetText()
+	target := resolveTarget()
+	policy := generationPolicy()
+	// 每台主机写入的标识符变化，按主机名记录；Update和Done可能同时运行，访问时需加锁
+	var changesMu sync.Mutex
+	changes := make(map[string][]audit.Change, len(hosts))
+	env.display.ShowInfo(lang.Format(text.FleetStarting, lang.Values{"Count": len(hosts), "Inventory": *inventory}))
+	results := fleet.Apply(env.ctx, hosts, fleet.Options{
+		AppName:  target.DisplayName(),
+		Parallel: *parallel,
+		Update: func(host fleet.Host, existing []byte) ([]byte, error) {
+			if namespace != nil {
+				// Update不会被并发调用，可以为每台主机切换生成器使用的名称
+				if err := env.generator.SetNameBased(*namespace, host.Name); err != nil {
+					return nil, err
+				}
+			}
+			content, err := remoteStorage(env.generator, target, policy, existing)
+			if err == nil {
+				changesMu.Lock()
+				changes[host.Name] = storageChanges(existing, content)
+				changesMu.Unlock()
+			}
+			return content, err
+		},
+		Done: func(result fleet.Result) {
+			changesMu.Lock()
+			hostChanges := changes[result.Host.Name]
+			changesMu.Unlock()
+			recordAudit(audit.Entry{
+				Action:  "fleet-apply",
+				Targets: []string{fmt.Sprintf("%s@%s:%s", result.Host.User, result.Host.Address, result.Storage)},
+				Changes: hostChanges,
+				Backups: auditBackups(result.BackupPath),
+			}, result.Err)
+			if result.Err != nil {
+				env.display.ShowError(lang.Format(text.FleetHostFailed, lang.Values{"Host": result.Host.Name, "Error": result.Err}))
+				return
+			}
+			env.display.ShowSuccess(lang.Format(text.FleetHostUpdated, lang.Values{"Host": result.Host.Name, "Path": result.Storage}))
+		},
+	})
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	env.display.ShowInfo(lang.Format(text.FleetSummary, lang.Values{"Succeeded": len(results) - failed, "Total": len(results)}))
+	if failed > 0 {
+		return fmt.Errorf("%d of %d hosts failed", failed, len(results))
+	}
+	return nil
+}
+
+// remoteStorage: 为一台远程主机生成新的storage.json内容
+// 与本机流程一样，-keep指定的字段保留原有值，其他键保持不变
+// 参数:
+//   - generator: ID生成器
+//   - target: 目标编辑器，决定要重写的遥测键
+//   - policy: 各字段保留还是重新生成
+//   - existing: 远程storage.json的现有内容，文件不存在时为nil
+//
+// 返回值:
+//   - []byte: 新的文件内容
+//   - error: 如果生成标识符或合并失败，则返回错误
+func remoteStorage(generator *idgen.Generator, target config.Target, policy idgen.GenerationPolicy, existing []byte) ([]byte, error) {
+	opts := idgen.GenerateOptions{Policy: policy}
+	if existing != nil {
+		// 无法解析的文件按没有旧值处理，与本机流程一致
+		if oldConfig, err := config.ParseStorageConfig(existing); err == nil {
+			opts.Existing = oldConfig.Identity()
+		}
+	}
+	identity, err := generator.GenerateAll(opts)
+	if err != nil {
+		return nil, err
+	}
+	content, _, err := config.MergeStorageJSON(existing, target, config.FromIdentity(identity), config.SaveOptions{})
+	return content, err
+}
+
+// storageChanges: 比较远程storage.json写入前后的标识符，用于审计日志
+func storageChanges(existing, content []byte) []audit.Change {
+	oldConfig, _ := config.ParseStorageConfig(existing)
+	newConfig, err := config.ParseStorageConfig(content)
+	if err != nil {
+		return nil
+	}
+	return auditIDChanges(idChanges(oldConfig, &config.SaveResult{Written: newConfig}))
+}
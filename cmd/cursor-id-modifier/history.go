@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/settings"
+	"github.com/yuaotian/go-cursor-help/pkg/idgen"
+)
+
+// idHistoryFileName: 工具状态目录中保存已生成ID哈希的文件名
+const idHistoryFileName = "generated_ids"
+
+// loadIDHistory: 加载已生成ID的历史记录并设置到生成器
+// 当前配置中的ID也会加入记录，新ID不会与它们重复
+// 记录无法读取时只记录警告，不检查冲突
+// 参数:
+//   - username: 用户名，用于定位工具状态目录
+//   - generator: ID生成器
+//   - oldConfig: 现有配置，可能为nil
+//
+// 返回值:
+//   - *idgen.History: 历史记录，读取失败时为nil
+func loadIDHistory(username string, generator *idgen.Generator, oldConfig *config.StorageConfig) *idgen.History {
+	stateDir, err := settings.StateDir(username)
+	if err != nil {
+		log.Warn("Failed to locate state directory:", err)
+		return nil
+	}
+	history, err := idgen.LoadHistory(filepath.Join(stateDir, idHistoryFileName))
+	if err != nil {
+		log.Warn("Failed to load ID history:", err)
+		return nil
+	}
+	if oldConfig != nil {
+		history.Add(oldConfig.TelemetryMachineId, oldConfig.TelemetryMacMachineId,
+			oldConfig.TelemetryDevDeviceId, oldConfig.TelemetrySqmId)
+	}
+	log.Debug("Loaded ID history entries: ", history.Len())
+	generator.SetHistory(history)
+	return history
+}
+
+// saveIDHistory: 保存已生成ID的历史记录
+// 通过sudo运行时目录和文件归还给原始用户，之后以普通用户运行时仍可更新
+// 参数:
+//   - history: 历史记录
+//
+// 返回值:
+//   - error: 如果写入失败，则返回错误
+func saveIDHistory(history *idgen.History) error {
+	if err := settings.MkdirAllOwned(filepath.Dir(history.Path()), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err := history.Save(); err != nil {
+		return err
+	}
+	return settings.RestoreOwnership(history.Path())
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/settings"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+)
+
+// languageCacheFileName: 工具状态目录中缓存系统语言检测结果的文件名
+const languageCacheFileName = "detected-language"
+
+// configureLanguageDetection: 设置系统语言检测结果的缓存文件
+// 参数:
+//   - username: 用户名，用于定位工具状态目录
+func configureLanguageDetection(username string) {
+	stateDir, err := settings.StateDir(username)
+	if err != nil {
+		log.Warn("Failed to locate state directory:", err)
+		return
+	}
+	lang.SetDetectionCache(filepath.Join(stateDir, languageCacheFileName))
+}
+
+// idTableHeaders: 返回当前语言的标识符表格列标题
+func idTableHeaders() ui.IDTableHeaders {
+	text := lang.GetText()
+	return ui.IDTableHeaders{
+		Field: text.TableField,
+		Old:   text.TableOld,
+		New:   text.TableNew,
+		File:  text.TableFile,
+	}
+}
+
+// accessibleText: 返回当前语言的无障碍模式文本
+func accessibleText() ui.AccessibleText {
+	text := lang.GetText()
+	return ui.AccessibleText{
+		StepStarted:  text.AccessibleStepStarted,
+		StepFinished: text.AccessibleStepFinished,
+		StepFailed:   text.AccessibleStepFailed,
+		Finished:     text.AccessibleFinished,
+		Failed:       text.AccessibleFailed,
+		Success:      text.AccessibleSuccess,
+		Warning:      text.AccessibleWarning,
+		Error:        text.AccessibleError,
+	}
+}
+
+// promptText: 返回当前语言的提问文本
+func promptText() ui.PromptText {
+	text := lang.GetText()
+	return ui.PromptText{
+		YesHint:       text.PromptYesHint,
+		NoHint:        text.PromptNoHint,
+		Yes:           text.PromptYes,
+		No:            text.PromptNo,
+		InvalidChoice: text.PromptInvalidChoice,
+		TimedOut:      text.PromptTimedOut,
+	}
+}
+
+// switchLanguage: 在交互界面中切换界面语言
+// 之后的所有输出（包括显示组件和提问组件中的文本）都使用新的语言，
+// 并将选择保存到工具配置文件，之后的运行默认使用该语言
+// 参数:
+//   - display: 用户界面显示组件，更新其中按语言提供的文本
+//   - language: 新的界面语言
+//
+// 返回值:
+//   - string: 保存语言设置的配置文件路径，无法确定配置文件位置时为空
+//   - error: 保存配置文件失败时返回错误，此时语言已经切换
+func switchLanguage(display *ui.Display, language lang.Language) (string, error) {
+	lang.SetLanguage(language)
+	display.SetIDTableHeaders(idTableHeaders())
+	display.SetRTL(language.IsRTL())
+	if display.IsAccessible() {
+		display.SetAccessible(accessibleText())
+	}
+	prompt.SetText(promptText())
+
+	if toolSettingsPath == "" {
+		return "", nil
+	}
+	toolSettings.Language = string(language)
+	if err := toolSettings.Save(toolSettingsPath); err != nil {
+		return "", err
+	}
+	return toolSettingsPath, nil
+}
+
+// nextLanguage: 返回支持的语言列表中当前语言的下一个，用于全屏界面中按键循环切换
+func nextLanguage() lang.Language {
+	languages := lang.Languages()
+	current := lang.GetCurrentLanguage()
+	for i, language := range languages {
+		if language == current {
+			return languages[(i+1)%len(languages)]
+		}
+	}
+	return languages[0]
+}
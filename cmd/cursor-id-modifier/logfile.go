@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/yuaotian/go-cursor-help/internal/settings"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+)
+
+// defaultLogFileName: 工具状态目录中默认日志文件的文件名，只保留最近一次运行
+const defaultLogFileName = "last-run.log"
+
+// logFileHook 将日志条目同时写入日志文件
+type logFileHook struct {
+	// 日志文件的记录
+	transcript *ui.Transcript
+	// 写入文件时使用的格式，不带颜色
+	formatter logrus.Formatter
+}
+
+// Levels 返回需要写入文件的日志级别
+func (h *logFileHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire 将一条日志条目写入文件
+func (h *logFileHook) Fire(entry *logrus.Entry) error {
+	line, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	_, err = h.transcript.WriteEntry(line)
+	return err
+}
+
+// openLogFile: 按-log-file参数打开本次运行的日志文件
+// 控制台输出（包括直接用fmt输出的内容）和日志条目都会写入其中，便于反馈问题时附上
+// 提权后的进程追加到同一个文件，其他情况下每次运行重新开始
+// 日志文件无法打开时只记录警告
+// 参数:
+//   - username: 用户名，用于定位工具状态目录
+//
+// 返回值:
+//   - func(): 恢复标准输出并关闭日志文件
+func openLogFile(username string) func() {
+	path := *logFilePath
+	switch path {
+	case "none":
+		return func() {}
+	case "":
+		stateDir, err := settings.StateDir(username)
+		if err != nil {
+			log.Warn("Failed to locate state directory:", err)
+			return func() {}
+		}
+		path = filepath.Join(stateDir, defaultLogFileName)
+	}
+
+	if err := settings.MkdirAllOwned(filepath.Dir(path), 0755); err != nil {
+		log.Warn("Failed to create log directory:", err)
+		return func() {}
+	}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if os.Getenv("AUTOMATED_MODE") != "1" {
+		flags |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(path, flags, 0600)
+	if err != nil {
+		log.Warn("Failed to open log file:", err)
+		return func() {}
+	}
+	if err := settings.RestoreOwnership(path); err != nil {
+		log.Warn(err)
+	}
+
+	transcript := ui.NewTranscript(file)
+	restore, err := ui.TeeOutput(transcript)
+	if err != nil {
+		log.Warn("Failed to copy console output to the log file:", err)
+		file.Close()
+		return func() {}
+	}
+	log.AddHook(&logFileHook{
+		transcript: transcript,
+		formatter:  &logrus.TextFormatter{FullTimestamp: true, DisableColors: true},
+	})
+	log.Debug("Writing log file: ", path)
+
+	// log.Fatal直接退出进程，退出前需要把管道中剩余的输出复制到控制台
+	var once sync.Once
+	closeFile := func() {
+		once.Do(func() {
+			restore()
+			file.Close()
+		})
+	}
+	logrus.RegisterExitHandler(closeFile)
+	return closeFile
+}
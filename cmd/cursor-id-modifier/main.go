@@ -1,21 +1,28 @@
 package main
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
 	"os/user"
 	"runtime"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/yuaotian/go-cursor-help/internal/audit"
 	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/diff"
 	"github.com/yuaotian/go-cursor-help/internal/lang"
 	"github.com/yuaotian/go-cursor-help/internal/process"
+	"github.com/yuaotian/go-cursor-help/internal/report"
+	"github.com/yuaotian/go-cursor-help/internal/settings"
 	"github.com/yuaotian/go-cursor-help/internal/ui"
 	"github.com/yuaotian/go-cursor-help/pkg/idgen"
 )
@@ -27,9 +34,126 @@ var (
 	// setReadOnly: 命令行标志，用于设置storage.json文件为只读模式
 	// 当设置为true时，保存配置后会将文件权限设置为只读
 	setReadOnly = flag.Bool("r", false, "set storage.json to read-only mode")
+	// protectLevel: 命令行标志，用于指定storage.json的写保护级别
+	// 可选值为none、readonly和strong，strong会使用ACL/chattr/chflags等系统级保护
+	protectLevel = flag.String("protect", "", "write protection level for storage.json: none, readonly or strong")
+	// unlock: 命令行标志，用于移除storage.json上的写保护后退出
+	unlock = flag.Bool("unlock", false, "remove write protection from storage.json and exit")
+	// guard: 命令行标志，用于检查并恢复被Cursor改写的标识符后退出
+	guard = flag.Bool("guard", false, "re-apply the last generated IDs if Cursor has overwritten them, then exit")
+	// watch: 命令行标志，用于持续监视storage.json并在被改写时重新写入标识符
+	watch = flag.Bool("watch", false, "keep watching storage.json and re-apply the IDs whenever Cursor overwrites them")
+	// watchInterval: 命令行标志，监视模式下两次检查之间的间隔
+	watchInterval = flag.Duration("watch-interval", 5*time.Second, "interval between checks in watch mode")
+	// mergeStrategy: 命令行标志，用于指定写入storage.json时的合并策略
+	// overwrite覆盖所有遥测键，fill-missing只写入缺失或无效的键，replace-file写入精简的新文件
+	mergeStrategy = flag.String("merge", "overwrite", "how to merge with the existing storage.json: overwrite, fill-missing or replace-file")
+	// backupPassphraseFlag: 命令行标志，用于加密备份文件的口令
+	// 也可以通过CURSOR_BACKUP_PASSPHRASE环境变量提供
+	backupPassphraseFlag = flag.String("backup-passphrase", "", "encrypt backups with this passphrase (or set CURSOR_BACKUP_PASSPHRASE)")
+	// backupKeychain: 命令行标志，用于从系统钥匙串读取备份口令
+	backupKeychain = flag.Bool("backup-keychain", false, "read the backup passphrase from the OS keychain")
+	// clearWorkspace: 命令行标志，用于在修改ID后清理workspaceStorage中的旧工作区数据
+	clearWorkspace = flag.Bool("clear-workspace-storage", false, "clear or prune workspaceStorage entries left by the previous identity")
+	// signOut: 命令行标志，用于清除Cursor缓存的登录令牌和会话数据
+	signOut = flag.Bool("sign-out", false, "clear Cursor's cached auth tokens and session data (a backup is taken first)")
+	// blockTelemetryFlag: 命令行标志，用于在hosts文件中屏蔽Cursor的遥测域名
+	blockTelemetryFlag = flag.Bool("block-telemetry", false, "block Cursor's telemetry domains in the hosts file (the hosts file is backed up first)")
+	// unblockTelemetry: 命令行标志，用于移除-block-telemetry添加的hosts条目后退出
+	unblockTelemetry = flag.Bool("unblock-telemetry", false, "remove the hosts file entries added by -block-telemetry and exit")
+	// appName: 命令行标志，用于指定Cursor衍生编辑器的产品名称
+	// 决定配置目录的位置、要关闭的进程名称以及提示信息中显示的名称
+	appName = flag.String("app-name", config.DefaultAppName, "product name of the Cursor-based editor (data directory and process names)")
+	// editor: 命令行标志，用于选择要重置遥测ID的编辑器
+	editor = flag.String("editor", "cursor", "editor whose telemetry IDs to reset: "+strings.Join(config.TargetNames(), ", "))
+	// settingsPath: 命令行标志，用于指定本工具配置文件的路径
+	// 配置文件中的值作为默认值，命令行参数优先
+	settingsPath = flag.String("config", "", "path of the tool configuration file (default: cursor-id-modifier/config.yaml in the user config directory)")
+	// languageFlag: 命令行标志，用于指定界面语言
+	languageFlag = flag.String("lang", "", "interface language: cn, zh-tw, en, ja, ko, ru, de, fr, es, pt-br, pt-pt, ar or he; pseudo shows pseudo-localized text for testing (default: detected from the system)")
+	// storagePath: 命令行标志，用于直接指定storage.json的路径，跳过自动检测
+	storagePath = flag.String("storage", "", "path of storage.json to modify (default: detected from the editor; see the discover command)")
+	// backupDir: 命令行标志，用于指定备份目录
+	backupDir = flag.String("backup-dir", "", "directory for backups (default: backups under globalStorage)")
+	// pauseOneDrive: 命令行标志，用于在写入期间暂时关闭OneDrive，避免产生同步冲突副本
+	pauseOneDrive = flag.Bool("pause-onedrive", false, "close OneDrive while storage.json is rewritten when it lives in a OneDrive folder (Windows)")
+	// refuseExternalSymlinks: 命令行标志，storage.json通过符号链接指向编辑器数据目录之外时拒绝写入
+	refuseExternalSymlinks = flag.Bool("refuse-external-symlinks", false, "refuse to write when storage.json is a symlink pointing outside the editor's data directory")
+	// uuidVersion: 命令行标志，用于选择设备ID和SQM ID使用的UUID版本
+	uuidVersion = flag.Int("uuid-version", 4, "UUID version for devDeviceId and sqmId: 4 (random) or 7 (time-ordered)")
+	// uuidNamespace: 命令行标志，指定后设备ID和SQM ID改为基于该命名空间和-uuid-name生成的UUID v5
+	uuidNamespace = flag.String("uuid-namespace", "", "derive devDeviceId and sqmId as UUID v5 in this namespace (a UUID, or dns, url, oid, x500)")
+	// uuidName: 命令行标志，生成UUID v5时使用的名称，默认为计算机名
+	uuidName = flag.String("uuid-name", "", "name for UUID v5 derivation, e.g. an asset tag (default: the hostname)")
+	// keepFields: 命令行标志，用于指定保留原有值而不重新生成的字段
+	keepFields = flag.String("keep", "sqmId", "comma-separated ID fields (machineId, macMachineId, devDeviceId, sqmId) to keep when they already have a value; empty regenerates all")
+	// plausibleFields: 命令行标志，用于指定按真实Cursor安装的分布生成的字段
+	plausibleFields = flag.String("plausible", "", "comma-separated ID fields (machineId, macMachineId, devDeviceId, sqmId) or \"all\" to generate like a real Cursor install")
+	// cursorVersion: 命令行标志，用于指定Cursor版本，决定生成的标识符格式
+	cursorVersion = flag.String("cursor-version", "", "Cursor version whose ID formats to use (default: detected from the installation)")
+	// restartCursorFlag: 命令行标志，用于在修改完成后按原来的命令行重新启动Cursor
+	restartCursorFlag = flag.Bool("restart-cursor", false, "relaunch Cursor with its original command line (as the original user) after the IDs are saved")
+	// resetServerMachineID: 同时重置cursor-server的machineid文件
+	resetServerMachineID = flag.Bool("reset-server-machineid", false, "with -editor cursor-server, also replace the server's data/machineid file")
+	// killAttempts: 关闭Cursor的最大尝试次数，为0时使用配置文件或默认值
+	killAttempts = flag.Int("kill-attempts", 0, "maximum attempts to close Cursor (default 3)")
+	// killRetryDelay: 第一次强制终止后等待进程退出的时间，之后按killBackoff增加
+	killRetryDelay = flag.Duration("kill-retry-delay", 0, "time to wait for Cursor to exit after the first forced kill (default 2s)")
+	// killBackoff: 每次重试的等待时间相对上一次的倍数
+	killBackoff = flag.Float64("kill-backoff", 0, "factor by which the wait grows on each retry (default 2)")
+	// killMaxDelay: 重试等待时间的上限
+	killMaxDelay = flag.Duration("kill-max-delay", 0, "upper bound for the wait between retries (default 15s)")
+	// skipDaemons: 不关闭CLI隧道、更新程序等后台守护进程
+	skipDaemons = flag.Bool("skip-daemons", false, "leave Cursor's CLI tunnel and updater daemons running")
+	// restartDaemons: 修改完成后按原来的命令行重新启动被关闭的后台守护进程
+	restartDaemons = flag.Bool("restart-daemons", false, "restart the CLI tunnel and other daemons that were closed (as the original user) after the IDs are saved")
+	// force: 命令行标志，用于在Cursor运行于其他用户账户下时仍然关闭这些进程
+	force = flag.Bool("force", false, "close Cursor even when some of its processes belong to another user account")
+	// showDiff: 命令行标志，用于输出storage.json修改前后的统一差异，并记录到日志中供审计
+	showDiff = flag.Bool("diff", false, "print a unified diff of storage.json (before vs. after) and record it in the log")
+	// installDirs: 逗号分隔的编辑器安装目录，加入默认目录中，可执行文件位于其中的进程会被关闭
+	installDirs = flag.String("install-dirs", "", "comma-separated install directories (wildcards allowed) whose processes are closed, in addition to the defaults (e.g. for renamed forks)")
+	// allowedExecutables: 逗号分隔的可执行文件路径，这些进程也会被关闭
+	allowedExecutables = flag.String("allowed-executables", "", "comma-separated executable paths to close in addition to those in the install directories (e.g. portable builds)")
+	// addProcessPatterns: 逗号分隔的进程名称模式，加入默认模式中
+	addProcessPatterns = flag.String("add-process-patterns", "", "comma-separated process name patterns to add to the defaults; only used for processes whose executable path cannot be read, use -install-dirs or -allowed-executables otherwise")
+	// removeProcessPatterns: 逗号分隔的进程名称模式，从默认模式中去掉
+	removeProcessPatterns = flag.String("remove-process-patterns", "", "comma-separated process name patterns to drop from the defaults; only used for processes whose executable path cannot be read")
+	// exactProcessMatch: 只按完整进程名称匹配，*不再作为通配符
+	exactProcessMatch = flag.Bool("exact-process-match", false, "match process names exactly against the patterns, without wildcards (patterns are only a fallback when the executable path cannot be read)")
+	// excludeProcesses: 逗号分隔的不得终止的进程，每项为PID、路径或名称模式
+	excludeProcesses = flag.String("exclude-processes", "", "comma-separated processes never to close: PIDs, executable or directory paths, or name patterns")
+	// listProcesses: 只列出关闭Cursor时会终止的进程，不终止进程也不修改配置
+	listProcesses = flag.Bool("list-processes", false, "list the processes that would be closed (with path and user) and exit without changing anything")
+	// tuiMode: 使用全屏交互界面代替逐行输出
+	tuiMode = flag.Bool("tui", false, "use the interactive full-screen interface (menu, live process list, step progress and ID changes)")
+	// accessible: 无障碍模式，不使用动画、颜色和光标移动，每次状态变化输出一句完整的话
+	accessible = flag.Bool("accessible", false, "screen-reader friendly output: no animation, colors or cursor movement, one full sentence per state change")
+	// ciMode: 适合CI日志的输出，也可以通过环境变量CURSOR_HELPER_CI=1启用
+	ciMode = flag.Bool("ci", false, "CI-friendly output: one line per step with its result and time, no spinner or carriage returns (or set CURSOR_HELPER_CI=1)")
+	// theme: 控制台输出的配色主题
+	theme = flag.String("theme", "", "color theme for console output: "+strings.Join(ui.ThemeNames(), ", ")+" (default \"default\")")
+	// noLogo: 不显示启动时的Logo
+	noLogo = flag.Bool("no-logo", false, "do not print the ASCII logo at startup")
+	// showIDs: 显示完整的标识符，默认只显示开头和结尾各4个字符
+	showIDs = flag.Bool("show-ids", false, "print identifier values in full instead of only their first and last 4 characters")
+	// logFilePath: 本次运行的日志文件，控制台输出和日志条目同时写入其中，none表示不记录
+	logFilePath = flag.String("log-file", "", "file that receives a copy of the console output and log entries of this run, or none (default: last-run.log in the tool state directory)")
+	// eventsPath: 将各步骤的进展写为JSON行的文件，-表示标准错误
+	eventsPath = flag.String("events", "", "also write step events as JSON lines to this file (- for stderr), for scripts and wrappers")
+	// cmJSON: 供配置管理工具调用，从标准输入读取JSON格式的期望状态，只在标准输出写出一个JSON结果
+	cmJSON = flag.Bool("cm-json", false, "config-management mode: read the desired state as JSON from stdin and write a single JSON result (changed, diff, failed, msg) to stdout, with no other output")
+	// assumeYes: 对所有确认提示回答是，其他提问使用默认值
+	assumeYes = flag.Bool("yes", false, "answer yes to confirmation prompts and use the defaults for other questions")
+	// promptTimeout: 标准输入不是终端时等待回答的时间
+	promptTimeout = flag.Duration("prompt-timeout", 30*time.Second, "when stdin is not a terminal, how long to wait for an answer before using the default (0 waits forever)")
 	// showVersion: 命令行标志，用于显示程序版本信息
 	// 当设置为true时，程序会显示版本号并退出
 	showVersion = flag.Bool("v", false, "show version information")
+	// prompt: 向用户提问并读取回答
+	prompt = ui.NewPrompt(os.Stdin)
+	// reporter: 重置流程报告进展的目标，包括控制台显示和-events指定的JSON事件
+	reporter report.Reporter
 	// log: 全局日志记录器实例，使用logrus库提供高级日志功能
 	// 用于记录程序运行过程中的各种信息、警告和错误
 	log = logrus.New()
@@ -41,24 +165,68 @@ func main() {
 	// 2025-04-08 11:35:26 by cc 捕获了个寂寞？？？
 	// 设置错误恢复机制，防止程序因panic而崩溃
 	setupErrorRecovery()
+	// 记录开始时间，用于在摘要中显示总耗时
+	started := time.Now()
 	// 解析并处理命令行参数
 	handleFlags()
 	// 配置日志记录器的格式和级别
 	setupLogger()
 
+	// Ansible模块、Chef资源等只读取标准输出中的一个JSON结果，不能有任何其他输出
+	if *cmJSON {
+		os.Exit(runCMJSON(os.Stdin, os.Stdout))
+	}
+
 	// 获取当前用户名，用于定位配置文件
 	username := getCurrentUser()
 	log.Debug("Running as user:", username)
 
+	// 系统语言的检测结果缓存在工具状态目录中，之后的运行不必再调用系统命令
+	configureLanguageDetection(username)
+
+	// 修改配置的操作追加记录到工具状态目录中的审计日志
+	initAuditLog(username)
+
+	// 加载工具配置文件，未在命令行中指定的参数使用配置文件中的值
+	loadSettings(username)
+
+	// 控制台输出和日志条目同时写入本次运行的日志文件
+	closeLogFile := openLogFile(username)
+	defer closeLogFile()
+
+	// 确定目标编辑器，提示信息中使用其产品名称
+	target := resolveTarget()
+	lang.SetAppName(target.DisplayName())
+
 	// 初始化各个组件
 	// display: 用户界面显示组件，负责输出信息到控制台
 	display := ui.NewDisplay(nil)
+	configureDisplay(display)
+	configurePrompt()
+	// 各步骤的进展同时报告给控制台和JSON事件文件
+	events, closeEvents := openEventsReporter()
+	defer closeEvents()
+	reporter = report.Multi(display, events)
 	// configManager: 配置管理器，负责读取和保存配置文件
-	configManager := initConfigManager(username)
+	configManager := initConfigManager(username, target)
 	// generator: ID生成器，用于生成各种唯一标识符
-	generator := idgen.NewGenerator()
+	generator := initGenerator()
 	// processManager: 进程管理器，用于管理Cursor进程
-	processManager := process.NewManager(nil, log)
+	processManager := initProcessManager(target)
+
+	// 不修改本机文件的子命令不需要管理员权限；以普通用户运行才能使用其SSH密钥、known_hosts和ssh-agent
+	if flag.NArg() > 0 && unprivilegedSubcommands[flag.Arg(0)] {
+		setupDisplay(display)
+		handleSubcommand(&commandEnv{
+			ctx:            context.Background(),
+			username:       username,
+			display:        display,
+			configManager:  configManager,
+			processManager: processManager,
+			generator:      generator,
+		})
+		return
+	}
 
 	// 检查并处理程序运行权限，确保有足够权限修改配置文件
 	if err := handlePrivileges(display); err != nil {
@@ -70,24 +238,201 @@ func main() {
 
 	// 获取当前语言的文本资源，用于多语言支持
 	text := lang.GetText()
+	// ctx: 贯穿配置读写操作的上下文
+	ctx := context.Background()
+
+	// 仅移除写保护时，处理完成后直接退出
+	if *unlock {
+		handleUnlock(display, configManager)
+		return
+	}
+
+	// 仅移除hosts文件中屏蔽遥测的条目时，处理完成后直接退出
+	if *unblockTelemetry {
+		handleUnblockTelemetry(ctx, display, configManager.BackupDir())
+		return
+	}
+
+	// 只列出匹配的进程，用于在真正关闭前确认匹配结果
+	if *listProcesses {
+		handleListProcesses(ctx, display, processManager)
+		return
+	}
+
+	// 处理子命令，例如restore
+	if flag.NArg() > 0 {
+		handleSubcommand(&commandEnv{
+			ctx:            ctx,
+			username:       username,
+			display:        display,
+			configManager:  configManager,
+			processManager: processManager,
+			generator:      generator,
+		})
+		return
+	}
+
+	// 守护模式下不关闭Cursor，只检查并恢复被改写的标识符
+	if *guard || *watch {
+		handleGuard(ctx, display, configManager)
+		return
+	}
+
+	// 全屏交互界面自行完成关闭、生成和保存等步骤
+	if *tuiMode {
+		env := &commandEnv{
+			ctx:            ctx,
+			username:       username,
+			display:        display,
+			configManager:  configManager,
+			processManager: processManager,
+			generator:      generator,
+		}
+		if err := runTUI(env); err != nil {
+			log.Error(err)
+			display.ShowError(err.Error())
+		}
+		return
+	}
+
+	// 不带参数在终端中运行（例如双击程序）时先显示操作菜单，避免误操作直接重置
+	if shouldShowMenu() {
+		env := &commandEnv{
+			ctx:            ctx,
+			username:       username,
+			display:        display,
+			configManager:  configManager,
+			processManager: processManager,
+			generator:      generator,
+		}
+		if !runMenu(env) {
+			return
+		}
+	}
+
+	// 记录Cursor打开的工作区和主进程的命令行，修改完成后可以重新打开
+	workspaces := captureWorkspaces(configManager)
+	var restartCommands [][]string
+	if *restartCursorFlag || *restartDaemons {
+		restartCommands = captureRestartCommands(ctx, processManager, *restartCursorFlag, *restartDaemons)
+	}
+
+	// 有未保存的编辑器时，强制关闭前需要用户确认
+	if !confirmUnsavedWork(ctx, display, configManager, processManager) {
+		return
+	}
+
+	// 关闭进程、读取、生成、保存和验证依次显示为带编号的步骤，自动化模式下不关闭进程
+	steps := 5
+	if os.Getenv("AUTOMATED_MODE") == "1" {
+		steps--
+	}
+	display.BeginSteps(steps)
 
 	// 处理Cursor进程，确保在修改配置前关闭所有Cursor实例
-	if err := handleCursorProcesses(display, processManager); err != nil {
+	closedProcesses, err := handleCursorProcesses(ctx, display, processManager)
+	if err != nil {
+		return
+	}
+
+	// 确认没有其他进程（例如崩溃后残留的Helper）仍打开着storage.json
+	if err := checkFileHolders(ctx, display, configManager); err != nil {
 		return
 	}
 
 	// 读取现有配置，获取当前的Cursor配置信息
-	oldConfig := readExistingConfig(display, configManager, text)
+	oldConfig := readExistingConfig(ctx, display, configManager, text)
+	// 加载以往生成过的ID，保证新ID不与其中的值以及当前值重复
+	history := loadIDHistory(username, generator, oldConfig)
 	// 生成新的配置，包括新的机器ID、设备ID等
 	newConfig := generateNewConfig(display, generator, oldConfig, text)
 
-	// 保存新配置到storage.json文件
-	if err := saveConfiguration(display, configManager, newConfig); err != nil {
+	// 配置目录被OneDrive重定向时提示风险，并按需暂时关闭OneDrive
+	resumeOneDrive := prepareOneDrive(display, configManager)
+
+	// 备份现有配置并保存新配置到storage.json文件，备份失败时不继续修改
+	saveResult, err := saveConfiguration(ctx, display, configManager, generator, newConfig)
+	resumeOneDrive()
+	recordSave("reset", configManager.ConfigPath(), oldConfig, saveResult, err)
+	if err != nil {
 		return
 	}
+	fmt.Println()
+	reportOneDriveConflicts(display, configManager)
+	if history != nil {
+		if err := saveIDHistory(history); err != nil {
+			log.Warn("Failed to save ID history:", err)
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"path":   saveResult.Path,
+		"backup": saveResult.BackupPath,
+		"bytes":  saveResult.BytesWritten,
+		"mode":   saveResult.FileMode,
+	}).Debug("Configuration saved")
+
+	// 按需输出并记录修改前后的完整差异
+	if *showDiff {
+		printSaveDiff(display, saveResult)
+	}
+
+	// 按需重置远程服务器的machineid文件，失败时只提示不影响已完成的修改
+	var machineIDPath string
+	if *resetServerMachineID {
+		if machineIDPath, err = resetMachineIDFile(display, configManager, generator, username); err != nil {
+			log.Warn("Failed to reset machine ID file:", err)
+			display.ShowError(err.Error())
+		}
+	}
+
+	// 按需清理workspaceStorage，失败时只提示不影响已完成的修改
+	if *clearWorkspace {
+		if err := clearWorkspaceStorage(display, configManager); err != nil {
+			log.Warn("Failed to clear workspace storage:", err)
+			display.ShowError(err.Error())
+		}
+		fmt.Println()
+	}
+
+	// 按需清除登录状态，使ID重置后以全新的登录状态启动
+	if *signOut {
+		if err := clearLoginState(ctx, display, configManager); err != nil {
+			log.Warn("Failed to clear login state:", err)
+			display.ShowError(err.Error())
+		}
+		fmt.Println()
+	}
+
+	// 按需在hosts文件中屏蔽遥测域名，失败时只提示不影响已完成的修改
+	if *blockTelemetryFlag {
+		if err := blockTelemetry(ctx, display, configManager.BackupDir()); err != nil {
+			log.Warn("Failed to block telemetry domains:", err)
+			display.ShowError(err.Error())
+		}
+		fmt.Println()
+	}
+
+	// 显示各标识符修改前后的值，提示用户重启Cursor
+	changes := idChanges(oldConfig, saveResult)
+	showCompletionMessages(display, changes)
+
+	// 按需重新启动Cursor，Cursor会自行恢复窗口；否则提示重新打开关闭前的工作区
+	if *restartCursorFlag || *restartDaemons {
+		restartCursor(display, restartCommands)
+	} else {
+		offerWorkspaceRelaunch(display, workspaces)
+	}
 
-	// 显示操作完成的消息，提示用户重启Cursor
-	showCompletionMessages(display)
+	// 最后汇总本次运行修改的文件、备份、标识符、写保护和关闭的进程
+	fmt.Println()
+	showRunSummary(display, runSummary{
+		elapsed:         time.Since(started),
+		files:           modifiedFiles(saveResult, machineIDPath),
+		backup:          saveResult.BackupPath,
+		changes:         changes,
+		protection:      saveResult.Protection,
+		processesClosed: closedProcesses,
+	})
 
 	// 如果不是自动化模式（通常是权限提升后的进程），则等待用户按Enter键退出
 	// 这样用户可以看到程序的输出结果
@@ -113,9 +458,12 @@ func setupErrorRecovery() {
 // 解析命令行标志，并根据标志执行相应操作
 // 如果设置了showVersion标志，则显示版本信息并退出程序
 func handleFlags() {
+	// -palette是-theme的别名，例如-palette cvd
+	flag.StringVar(theme, "palette", "", "alias of -theme")
 	flag.Parse()
 	if *showVersion {
-		fmt.Printf("Cursor ID Modifier v%s\n", version)
+		applyLanguage() // 版本信息在加载配置文件之前输出，只使用-lang参数
+		fmt.Println(lang.Format(lang.GetText().VersionInfo, lang.Values{"Version": version}))
 		os.Exit(0)
 	}
 }
@@ -152,21 +500,185 @@ func getCurrentUser() string {
 	return user.Username // 返回用户名
 }
 
+// resolveTarget: 确定目标编辑器
+// 指定了-app-name时使用该名称的Cursor衍生编辑器，否则使用-editor选择的编辑器
+// 返回值:
+//   - config.Target: 目标编辑器
+func resolveTarget() config.Target {
+	if *appName != config.DefaultAppName {
+		return config.AppTarget(*appName)
+	}
+	target, err := config.TargetByName(*editor)
+	if err != nil {
+		log.Fatal(err) // 如果编辑器名称无效，记录错误并终止程序
+	}
+	return target
+}
+
 // initConfigManager: 初始化配置管理器
-// 创建一个新的配置管理器实例，用于读取和保存Cursor的配置文件
+// 创建一个新的配置管理器实例，用于读取和保存目标编辑器的配置文件
 // 参数:
 //   - username: 用户名，用于定位配置文件路径
+//   - target: 目标编辑器
 //
 // 返回值:
 //   - *config.Manager: 配置管理器实例
-func initConfigManager(username string) *config.Manager {
-	configManager, err := config.NewManager(username)
+func initConfigManager(username string, target config.Target) *config.Manager {
+	var configManager *config.Manager
+	var err error
+	if *storagePath != "" {
+		configManager, err = config.NewManagerForPath(*storagePath, target) // 使用指定的storage.json
+	} else {
+		configManager, err = config.NewManagerForTarget(username, target)
+	}
 	if err != nil {
 		log.Fatal(err) // 如果创建配置管理器失败，记录错误并终止程序
 	}
+	if *backupDir != "" {
+		configManager.SetBackupDir(*backupDir) // 使用自定义的备份目录
+	}
+	if stateDir, err := settings.StateDir(username); err == nil {
+		configManager.SetStateDir(stateDir) // 守护快照保存在工具的状态目录中
+	} else {
+		log.Warn(err)
+	}
+	configManager.SetRefuseExternalSymlinks(*refuseExternalSymlinks)
 	return configManager // 返回配置管理器实例
 }
 
+// initGenerator: 初始化ID生成器
+// 按-uuid-version参数设置设备ID和SQM ID使用的UUID版本，
+// 并根据Cursor版本选择标识符的格式配置，-plausible指定的字段按真实安装的分布生成
+// 指定了-uuid-namespace时设备ID和SQM ID改为基于名称生成的UUID v5
+// 返回值:
+//   - *idgen.Generator: ID生成器实例
+func initGenerator() *idgen.Generator {
+	version, err := idgen.ParseUUIDVersion(*uuidVersion)
+	if err != nil {
+		log.Fatal(err) // 如果UUID版本无效，记录错误并终止程序
+	}
+	generator := idgen.NewGenerator(
+		idgen.WithUUIDVersion(version),
+		idgen.WithProfile(resolveIDProfile()),
+	)
+
+	if *plausibleFields != "" {
+		fields, err := idgen.ParseFields(*plausibleFields)
+		if err != nil {
+			log.Fatal(err) // 如果字段名无效，记录错误并终止程序
+		}
+		for _, field := range fields {
+			if err := generator.SetFieldMode(field, idgen.ModePlausible); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	if *uuidNamespace != "" {
+		namespace, err := idgen.ParseNamespace(*uuidNamespace)
+		if err != nil {
+			log.Fatal(err) // 如果命名空间无效，记录错误并终止程序
+		}
+		name := *uuidName
+		if name == "" {
+			if name, err = os.Hostname(); err != nil {
+				log.Fatal("Failed to get hostname:", err)
+			}
+		}
+		if err := generator.SetNameBased(namespace, name); err != nil {
+			log.Fatal(err)
+		}
+		log.Debug("Deriving UUIDs from name: ", name)
+	}
+	return generator
+}
+
+// resolveIDProfile: 选择标识符格式配置
+// -cursor-version参数优先，未指定时从已安装的Cursor中检测版本
+// 无法确定版本时使用最新的格式配置
+// 返回值:
+//   - idgen.Profile: 适用的格式配置
+func resolveIDProfile() idgen.Profile {
+	version := *cursorVersion
+	if version == "" {
+		detected, err := config.DetectCursorVersion()
+		if err != nil {
+			log.Debug("Failed to detect Cursor version: ", err)
+		}
+		version = detected
+	}
+
+	profile := idgen.ProfileForVersion(version)
+	log.WithFields(logrus.Fields{
+		"version": version,
+		"profile": profile.Name,
+	}).Debug("Selected ID format profile")
+	return profile
+}
+
+// initProcessManager: 初始化进程管理器
+// 按安装目录和允许的可执行文件查找目标编辑器的进程，进程名称模式只在无法读取可执行文件路径时使用
+// 参数:
+//   - target: 目标编辑器
+//
+// 返回值:
+//   - *process.Manager: 进程管理器实例
+func initProcessManager(target config.Target) *process.Manager {
+	processConfig := process.DefaultConfig()
+	processConfig.ProcessPatterns = target.ProcessPatterns()
+	processConfig.InstallDirs = target.InstallDirs()
+	if target.Name() == config.DefaultTarget().Name() {
+		// 加上在PATH等位置找到的安装目录，覆盖安装在非默认位置的Cursor
+		processConfig.InstallDirs = append(processConfig.InstallDirs, config.CursorInstallDirs()...)
+	}
+
+	// 应用配置文件中的进程设置
+	if len(toolSettings.Process.Patterns) > 0 {
+		processConfig.ProcessPatterns = toolSettings.Process.Patterns
+	}
+	processConfig.ProcessPatterns = process.MergePatterns(processConfig.ProcessPatterns,
+		append(toolSettings.Process.AddPatterns, splitList(*addProcessPatterns)...),
+		append(toolSettings.Process.RemovePatterns, splitList(*removeProcessPatterns)...))
+	processConfig.ExactMatch = *exactProcessMatch || toolSettings.Process.ExactMatch
+	processConfig.Exclude = append(toolSettings.Process.Exclude, splitList(*excludeProcesses)...)
+	processConfig.IncludeDaemons = !*skipDaemons && !toolSettings.Process.SkipDaemons
+	log.Debug("Process name patterns: ", strings.Join(processConfig.ProcessPatterns, ", "))
+	if toolSettings.Process.MaxAttempts > 0 {
+		processConfig.MaxAttempts = toolSettings.Process.MaxAttempts
+	}
+	if toolSettings.Process.RetryDelay > 0 {
+		processConfig.RetryDelay = toolSettings.Process.RetryDelay
+	}
+	if toolSettings.Process.BackoffFactor > 0 {
+		processConfig.BackoffFactor = toolSettings.Process.BackoffFactor
+	}
+	if toolSettings.Process.MaxRetryDelay > 0 {
+		processConfig.MaxRetryDelay = toolSettings.Process.MaxRetryDelay
+	}
+	// 命令行参数优先于配置文件
+	if *killAttempts > 0 {
+		processConfig.MaxAttempts = *killAttempts
+	}
+	if *killRetryDelay > 0 {
+		processConfig.RetryDelay = *killRetryDelay
+	}
+	if *killBackoff > 0 {
+		processConfig.BackoffFactor = *killBackoff
+	}
+	if *killMaxDelay > 0 {
+		processConfig.MaxRetryDelay = *killMaxDelay
+	}
+	if len(toolSettings.Process.InstallDirs) > 0 {
+		processConfig.InstallDirs = toolSettings.Process.InstallDirs
+	}
+	processConfig.InstallDirs = append(processConfig.InstallDirs, splitList(*installDirs)...)
+	processConfig.AllowedExecutables = append(toolSettings.Process.AllowedExecutables, splitList(*allowedExecutables)...)
+	if toolSettings.Process.GracePeriod > 0 {
+		processConfig.GracePeriod = toolSettings.Process.GracePeriod
+	}
+	return process.NewManager(processConfig, log)
+}
+
 // handlePrivileges: 处理权限检查
 // 检查程序是否具有足够的权限（管理员/root权限）来修改配置文件
 // 如果没有足够权限，会尝试提升权限或显示错误消息
@@ -194,7 +706,7 @@ func handlePrivileges(display *ui.Display) error {
 		display.ShowPrivilegeError(
 			lang.GetText().PrivilegeError,
 			lang.GetText().RunWithSudo,
-			lang.GetText().SudoExample,
+			sudoExample(),
 		)
 		waitExit()                                   // 等待用户按键退出
 		return fmt.Errorf("insufficient privileges") // 返回权限不足错误
@@ -202,6 +714,15 @@ func handlePrivileges(display *ui.Display) error {
 	return nil // 权限检查通过，返回nil
 }
 
+// sudoExample: 返回使用sudo运行本程序的示例命令
+//
+// 返回值:
+//   - string: 填入当前可执行文件路径的示例
+func sudoExample() string {
+	exe, _ := os.Executable()
+	return lang.Format(lang.GetText().SudoExample, lang.Values{"Executable": exe})
+}
+
 // handleWindowsPrivileges: 处理Windows系统的权限提升
 // 在Windows系统上尝试自动提升程序权限到管理员级别
 // 参数:
@@ -210,12 +731,8 @@ func handlePrivileges(display *ui.Display) error {
 // 返回值:
 //   - error: 如果权限提升失败，则返回错误
 func handleWindowsPrivileges(display *ui.Display) error {
-	// 显示请求管理员权限的消息，根据当前语言选择不同文本
-	message := "\nRequesting administrator privileges..."
-	if lang.GetCurrentLanguage() == lang.CN {
-		message = "\n请求管理员权限..."
-	}
-	fmt.Println(message)
+	// 显示请求管理员权限的消息
+	fmt.Println(lang.GetText().RequestingPrivileges)
 
 	// 尝试自我提升权限，启动一个新的具有管理员权限的进程
 	if err := selfElevate(); err != nil {
@@ -225,7 +742,7 @@ func handleWindowsPrivileges(display *ui.Display) error {
 			lang.GetText().PrivilegeError,
 			lang.GetText().RunAsAdmin,
 			lang.GetText().RunWithSudo,
-			lang.GetText().SudoExample,
+			sudoExample(),
 		)
 		waitExit() // 等待用户按键退出
 		return err // 返回错误
@@ -233,6 +750,62 @@ func handleWindowsPrivileges(display *ui.Display) error {
 	return nil // 权限提升成功或已启动新进程，返回nil
 }
 
+// configureDisplay: 按命令行参数和配置文件调整输出方式
+// 参数:
+//   - display: 用户界面显示组件
+func configureDisplay(display *ui.Display) {
+	display.SetIDTableHeaders(idTableHeaders())
+	display.SetRTL(lang.GetCurrentLanguage().IsRTL())
+	// 配置文件中的banner为none时不显示Logo，为其他值时代替默认Logo
+	switch banner := toolSettings.UI.Banner; {
+	case *noLogo || banner == "none":
+		display.SetLogo("")
+	case banner != "":
+		display.SetLogo(strings.TrimRight(banner, "\n"))
+	}
+	display.SetRevealIDs(*showIDs)
+	if *ciMode || os.Getenv("CURSOR_HELPER_CI") == "1" {
+		display.SetCI()
+	}
+	if *theme != "" {
+		palette, err := ui.ThemeByName(*theme)
+		if err != nil {
+			log.Fatal(err) // 如果主题名称无效，记录错误并终止程序
+		}
+		display.SetPalette(palette)
+	}
+	if *accessible || toolSettings.UI.Accessible {
+		display.SetAccessible(accessibleText())
+	}
+}
+
+// configurePrompt: 按界面语言和-yes、-prompt-timeout参数设置提问组件
+// 自动化模式下不提问，直接使用默认值
+func configurePrompt() {
+	prompt.SetText(promptText())
+	prompt.SetAssumeYes(*assumeYes)
+	prompt.SetTimeout(*promptTimeout)
+	prompt.SetNonInteractive(os.Getenv("AUTOMATED_MODE") == "1")
+}
+
+// openEventsReporter: 按-events参数创建JSON事件报告
+// 返回值:
+//   - report.Reporter: JSON事件报告，未指定-events时为nil
+//   - func(): 关闭事件文件
+func openEventsReporter() (report.Reporter, func()) {
+	switch *eventsPath {
+	case "":
+		return nil, func() {}
+	case "-":
+		return report.NewJSONReporter(os.Stderr), func() {}
+	}
+	file, err := os.OpenFile(*eventsPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		log.Fatal("Failed to open events file:", err) // 如果无法打开事件文件，记录错误并终止程序
+	}
+	return report.NewJSONReporter(file), func() { file.Close() }
+}
+
 // setupDisplay: 设置显示界面
 // 清屏并显示程序logo，为用户提供清晰的界面
 // 参数:
@@ -242,83 +815,136 @@ func setupDisplay(display *ui.Display) {
 	if err := display.ClearScreen(); err != nil {
 		log.Warn("Failed to clear screen:", err)
 	}
-	// 显示程序logo
-	display.ShowLogo()
-	// 打印空行，增加界面可读性
-	fmt.Println()
+	// 显示程序logo，并打印空行增加界面可读性
+	if display.ShowLogo() {
+		fmt.Println()
+	}
+}
+
+// checkOtherUsersProcesses: 检查是否有Cursor进程属于其他用户账户
+// 列出这些进程及其所属用户，未指定-force时拒绝继续
+// 参数:
+//   - ctx: 上下文，用于取消进程枚举
+//   - display: 用户界面显示组件，用于显示警告
+//   - processManager: 进程管理器，用于查找Cursor进程
+//
+// 返回值:
+//   - error: 如果存在其他用户的进程且未指定-force，则返回错误
+func checkOtherUsersProcesses(ctx context.Context, display *ui.Display, processManager *process.Manager) error {
+	others, err := processManager.OtherUsersProcesses(ctx, getCurrentUser())
+	if err != nil {
+		log.Warn("Failed to check process owners:", err)
+		return nil
+	}
+	if len(others) == 0 {
+		return nil
+	}
+
+	text := lang.GetText()
+	display.ShowWarning(text.OtherUserProcesses)
+	for _, p := range others {
+		fmt.Println(lang.Format(text.OtherUserProcessEntry, lang.Values{"PID": p.PID, "Name": p.Name, "User": p.Username}))
+	}
+	if *force {
+		log.Warn("Closing Cursor processes owned by other users because -force was given")
+		return nil
+	}
+	display.ShowError(text.OtherUserRefused)
+	return fmt.Errorf("cursor is running under another user account")
 }
 
 // handleCursorProcesses: 处理Cursor进程
 // 尝试关闭所有运行中的Cursor进程，确保在修改配置前没有Cursor实例在运行
 // 参数:
+//   - ctx: 上下文，取消后停止等待和重试
 //   - display: 用户界面显示组件，用于显示进度和错误消息
 //   - processManager: 进程管理器，用于管理Cursor进程
 //
 // 返回值:
+//   - int: 关闭的进程数
 //   - error: 如果无法关闭Cursor进程，则返回错误
-func handleCursorProcesses(display *ui.Display, processManager *process.Manager) error {
+func handleCursorProcesses(ctx context.Context, display *ui.Display, processManager *process.Manager) (int, error) {
 	// 自动化模式下跳过关闭Cursor进程
 	// 这通常是在权限提升后的新进程中，避免重复操作
 	if os.Getenv("AUTOMATED_MODE") == "1" {
 		log.Debug("Running in automated mode, skipping Cursor process closing")
-		return nil
+		return 0, nil
+	}
+
+	// Cursor在其他用户的会话中运行时，关闭它可能失败，也说明要修改的storage.json可能不是其正在使用的那个
+	if err := checkOtherUsersProcesses(ctx, display, processManager); err != nil {
+		waitExit()
+		return 0, err
 	}
 
-	// 显示正在关闭Cursor的进度信息
-	display.ShowProgress("Closing Cursor...")
+	// 显示正在关闭Cursor的进度信息，关闭过程中更新剩余的进程数
+	reporter.StepStarted(lang.GetText().ClosingProcesses)
 	log.Debug("Attempting to close Cursor processes")
+	closed := 0
+	processManager.SetProgressFunc(closingProgress(reporter, &closed))
+	defer processManager.SetProgressFunc(nil)
 
 	// 尝试终止所有Cursor进程
-	if err := processManager.KillCursorProcesses(); err != nil {
+	if err := processManager.KillCursorProcesses(ctx); err != nil {
 		log.Error("Failed to close Cursor:", err) // 记录错误
-		display.StopProgress()                    // 停止进度显示
+		var running *process.StillRunningError
+		if errors.As(err, &running) {
+			for _, p := range running.Processes {
+				log.Error("Still running: ", p)
+			}
+		}
+		reporter.StepFailed(err) // 停止进度显示并标记步骤失败
+		// Cursor以更高权限运行时说明原因，而不是只提示关闭失败
+		var elevated *process.ElevatedProcessError
+		if errors.As(err, &elevated) {
+			display.ShowWarning(lang.Format(lang.GetText().ElevatedProcess, lang.Values{"App": resolveTarget().DisplayName(), "PID": elevated.PID, "Level": elevated.Level}))
+		}
 		// 显示错误消息，提示用户手动关闭Cursor
-		display.ShowError("Failed to close Cursor. Please close it manually and try again.")
-		waitExit() // 等待用户按键退出
-		return err // 返回错误
+		display.ShowError(lang.Format(lang.GetText().CloseFailed, lang.Values{"App": resolveTarget().DisplayName()}))
+		waitExit()    // 等待用户按键退出
+		return 0, err // 返回错误
 	}
 
 	// 再次检查是否仍有Cursor进程在运行
 	// 这是一个额外的安全检查，确保所有进程都已关闭
-	if processManager.IsCursorRunning() {
+	if processManager.IsCursorRunning(ctx) {
 		log.Error("Cursor processes still detected after closing")
-		display.StopProgress() // 停止进度显示
+		err := fmt.Errorf("cursor still running")
+		reporter.StepFailed(err) // 停止进度显示并标记步骤失败
 		// 显示错误消息，提示用户手动关闭Cursor
-		display.ShowError("Failed to close Cursor completely. Please close it manually and try again.")
-		waitExit()                                // 等待用户按键退出
-		return fmt.Errorf("cursor still running") // 返回错误
+		display.ShowError(lang.Format(lang.GetText().CloseIncomplete, lang.Values{"App": resolveTarget().DisplayName()}))
+		waitExit()    // 等待用户按键退出
+		return 0, err // 返回错误
 	}
 
 	// 成功关闭所有Cursor进程
 	log.Debug("Successfully closed all Cursor processes")
-	display.StopProgress() // 停止进度显示
-	fmt.Println()          // 打印空行，增加界面可读性
-	return nil             // 返回nil表示成功
+	reporter.StepSucceeded() // 停止进度显示并输出步骤耗时
+	return closed, nil       // 返回关闭的进程数，nil表示成功
 }
 
 // readExistingConfig: 读取现有配置
 // 尝试读取Cursor的现有配置文件，获取当前的配置信息
 // 参数:
+//   - ctx: 上下文，用于取消读取操作
 //   - display: 用户界面显示组件，用于显示进度
 //   - configManager: 配置管理器，用于读取配置文件
 //   - text: 语言文本资源，用于多语言支持
 //
 // 返回值:
 //   - *config.StorageConfig: 读取到的配置，如果读取失败则返回nil
-func readExistingConfig(display *ui.Display, configManager *config.Manager, text lang.TextResource) *config.StorageConfig {
-	fmt.Println()                            // 打印空行，增加界面可读性
-	display.ShowProgress(text.ReadingConfig) // 显示正在读取配置的进度信息
+func readExistingConfig(ctx context.Context, display *ui.Display, configManager config.ConfigStore, text lang.TextResource) *config.StorageConfig {
+	reporter.StepStarted(text.ReadingConfig) // 显示正在读取配置的进度信息
 
 	// 尝试读取现有配置
-	oldConfig, err := configManager.ReadConfig()
+	oldConfig, err := configManager.ReadConfig(ctx)
 	if err != nil {
 		log.Warn("Failed to read existing config:", err) // 记录警告
 		oldConfig = nil                                  // 如果读取失败，设置为nil
 	}
 
-	display.StopProgress() // 停止进度显示
-	fmt.Println()          // 打印空行，增加界面可读性
-	return oldConfig       // 返回读取到的配置或nil
+	reporter.StepSucceeded() // 停止进度显示并输出步骤耗时
+	return oldConfig         // 返回读取到的配置或nil
 }
 
 // generateNewConfig: 生成新的配置
@@ -326,100 +952,499 @@ func readExistingConfig(display *ui.Display, configManager *config.Manager, text
 // 参数:
 //   - display: 用户界面显示组件，用于显示进度
 //   - generator: ID生成器，用于生成各种唯一标识符
-//   - oldConfig: 现有配置，用于保留某些值（如SQM ID）
+//   - oldConfig: 现有配置，用于保留-keep指定的字段（默认为SQM ID）
 //   - text: 语言文本资源，用于多语言支持
 //
 // 返回值:
 //   - *config.StorageConfig: 生成的新配置
 func generateNewConfig(display *ui.Display, generator *idgen.Generator, oldConfig *config.StorageConfig, text lang.TextResource) *config.StorageConfig {
-	display.ShowProgress(text.GeneratingIds) // 显示正在生成ID的进度信息
-	newConfig := &config.StorageConfig{}     // 创建新的配置对象
+	reporter.StepStarted(text.GeneratingIds) // 显示正在生成ID的进度信息
 
-	// 生成机器ID
-	if machineID, err := generator.GenerateMachineID(); err != nil {
-		log.Fatal("Failed to generate machine ID:", err) // 如果生成失败，记录错误并终止程序
-	} else {
-		newConfig.TelemetryMachineId = machineID // 设置新的机器ID
+	// -keep指定的字段（默认为SQM ID）在旧配置中有值时保留，其他字段生成新值
+	opts := idgen.GenerateOptions{Policy: generationPolicy()}
+	if oldConfig != nil {
+		opts.Existing = oldConfig.Identity()
 	}
-
-	// 生成Mac机器ID（特定于macOS的标识符）
-	if macMachineID, err := generator.GenerateMacMachineID(); err != nil {
-		log.Fatal("Failed to generate MAC machine ID:", err) // 如果生成失败，记录错误并终止程序
-	} else {
-		newConfig.TelemetryMacMachineId = macMachineID // 设置新的Mac机器ID
+	identity, err := generator.GenerateAll(opts)
+	if err != nil {
+		reporter.StepFailed(err)
+		log.Fatal(err) // 如果生成失败，记录错误并终止程序
 	}
+	newConfig := config.FromIdentity(identity)
 
-	// 生成设备ID
-	if deviceID, err := generator.GenerateDeviceID(); err != nil {
-		log.Fatal("Failed to generate device ID:", err) // 如果生成失败，记录错误并终止程序
-	} else {
-		newConfig.TelemetryDevDeviceId = deviceID // 设置新的设备ID
-	}
+	reporter.StepSucceeded() // 停止进度显示并输出步骤耗时
+	return newConfig         // 返回生成的新配置
+}
 
-	// 生成或保留SQM ID
-	// 如果存在旧配置且SQM ID不为空，则保留原有的SQM ID
-	// 否则生成新的SQM ID
-	if oldConfig != nil && oldConfig.TelemetrySqmId != "" {
-		newConfig.TelemetrySqmId = oldConfig.TelemetrySqmId // 保留原有的SQM ID
-	} else if sqmID, err := generator.GenerateSQMID(); err != nil {
-		log.Fatal("Failed to generate SQM ID:", err) // 如果生成失败，记录错误并终止程序
-	} else {
-		newConfig.TelemetrySqmId = sqmID // 设置新的SQM ID
+// generationPolicy: 根据-keep参数确定保留哪些字段
+// 返回值:
+//   - idgen.GenerationPolicy: 生成策略
+func generationPolicy() idgen.GenerationPolicy {
+	if *keepFields == "" {
+		return nil // 全部重新生成
+	}
+	fields, err := idgen.ParseFields(*keepFields)
+	if err != nil {
+		log.Fatal(err) // 如果字段名无效，记录错误并终止程序
 	}
+	return idgen.KeepFields(fields...)
+}
 
-	display.StopProgress() // 停止进度显示
-	fmt.Println()          // 打印空行，增加界面可读性
-	return newConfig       // 返回生成的新配置
+// backupPassphrase: 获取备份口令
+// 依次使用-backup-passphrase参数、CURSOR_BACKUP_PASSPHRASE环境变量和系统钥匙串
+// 返回值:
+//   - string: 备份口令，未配置时为空字符串（不加密）
+//   - error: 如果要求从钥匙串读取但读取失败，则返回错误
+func backupPassphrase() (string, error) {
+	if *backupPassphraseFlag != "" {
+		return *backupPassphraseFlag, nil
+	}
+	if passphrase := os.Getenv("CURSOR_BACKUP_PASSPHRASE"); passphrase != "" {
+		return passphrase, nil
+	}
+	if *backupKeychain {
+		return config.KeychainPassphrase()
+	}
+	return "", nil
 }
 
 // saveConfiguration: 保存配置
-// 将新生成的配置保存到Cursor的配置文件中
+// 备份现有配置后，将新生成的配置保存到Cursor的配置文件中
+// 指定了备份口令时备份使用AES-GCM加密
 // 参数:
+//   - ctx: 上下文，用于取消保存操作
 //   - display: 用户界面显示组件，用于显示进度
 //   - configManager: 配置管理器，用于保存配置文件
+//   - generator: ID生成器，用于在fill-missing策略下校验已有的标识符
 //   - newConfig: 要保存的新配置
 //
 // 返回值:
-//   - error: 如果保存失败，则返回错误
-func saveConfiguration(display *ui.Display, configManager *config.Manager, newConfig *config.StorageConfig) error {
-	display.ShowProgress("Saving configuration...") // 显示正在保存配置的进度信息
-
-	// 保存新配置到文件，并根据setReadOnly标志决定是否设置为只读
-	if err := configManager.SaveConfig(newConfig, *setReadOnly); err != nil {
+//   - *config.SaveResult: 保存结果，包括写入路径和备份路径
+//   - error: 如果备份或保存失败，则返回错误
+func saveConfiguration(ctx context.Context, display *ui.Display, configManager config.ConfigStore, generator *idgen.Generator, newConfig *config.StorageConfig) (*config.SaveResult, error) {
+	// 按命令行参数确定写保护级别、合并策略和备份口令，参数无效时直接报错
+	saveOptions, err := buildSaveOptions(generator)
+	if err != nil {
 		log.Error(err) // 记录错误
 		waitExit()     // 等待用户按键退出
-		return err     // 返回错误
+		return nil, err
 	}
 
-	display.StopProgress() // 停止进度显示
-	fmt.Println()          // 打印空行，增加界面可读性
-	return nil             // 返回nil表示成功
+	reporter.StepStarted(lang.GetText().SavingConfig) // 显示正在保存配置的进度信息
+
+	// 保存新配置到文件，并施加指定级别的写保护
+	var result *config.SaveResult
+	// unprotected: 是否已移除过写保护，移除后仍检测到保护时不再重试，避免无限循环
+	unprotected := false
+	for {
+		result, err = configManager.SaveConfigWithOptions(ctx, newConfig, saveOptions)
+		if err == nil {
+			break
+		}
+		display.StopProgress() // 停止进度显示
+
+		// 之前的运行施加了写保护时，征得同意后临时移除，写入后按本次要求重新施加
+		var protectedErr *config.WriteProtectedError
+		if errors.As(err, &protectedErr) && !unprotected {
+			display.ShowWarning(lang.Format(lang.GetText().WriteProtectedDetected, lang.Values{"Level": protectedErr.Level}))
+			// 自动化模式下不提问，只有指定-yes时才移除
+			if prompt.Confirm(lang.GetText().LiftProtectionPrompt, false) {
+				if err := configManager.Unprotect(); err != nil {
+					log.Error(err)
+					waitExit()
+					return nil, err
+				}
+				unprotected = true
+				display.ShowProgress(lang.GetText().SavingConfig)
+				continue
+			}
+		}
+
+		// 文件被其他进程锁定时，提示用户关闭占用的进程后重试
+		var lockedErr *config.FileLockedError
+		if errors.As(err, &lockedErr) {
+			display.ShowWarning(lang.GetText().FileLocked)
+			if os.Getenv("AUTOMATED_MODE") != "1" && !*assumeYes && prompt.Confirm(lang.GetText().RetryPrompt, false) {
+				display.ShowProgress(lang.GetText().SavingConfig)
+				continue
+			}
+		}
+
+		// 运行期间文件被其他进程修改时，询问用户是否重新读取后重试
+		if errors.Is(err, config.ErrConcurrentModification) {
+			display.ShowWarning(lang.GetText().ConcurrentModification)
+			if os.Getenv("AUTOMATED_MODE") != "1" && !*assumeYes && prompt.Confirm(lang.GetText().RetryPrompt, false) {
+				if _, err := configManager.ReadConfig(ctx); err != nil {
+					log.Warn("Failed to re-read config:", err)
+				}
+				display.ShowProgress(lang.GetText().SavingConfig)
+				continue
+			}
+		}
+
+		reporter.StepFailed(err) // 标记步骤失败
+		log.Error(err)           // 记录错误
+		// 写入后重新读取发现标识符或权限不一致时，向用户说明具体问题
+		var verifyErr *config.VerificationError
+		if errors.As(err, &verifyErr) {
+			display.ShowError(verifyErr.Error())
+		}
+		waitExit() // 等待用户按键退出
+		return nil, err
+	}
+
+	reporter.StepSucceeded() // 停止进度显示并输出步骤耗时
+	if result.BackupPath != "" {
+		display.ShowInfo(lang.Format(lang.GetText().BackupCreated, lang.Values{"Path": result.BackupPath}))
+	}
+
+	// 记录实际写入的标识符，供守护模式检测Cursor是否改写
+	if err := configManager.SaveGuardSnapshot(result.Written); err != nil {
+		log.Warn("Failed to save guard snapshot:", err)
+	}
+
+	// 系统级保护会阻止Cursor写入，需要明确提醒用户
+	if saveOptions.Protection == config.ProtectStrong {
+		display.ShowWarning(lang.GetText().StrongProtectionWarning)
+		fmt.Println()
+	}
+	return result, nil
+}
+
+// buildSaveOptions: 根据命令行参数构造保存选项
+// 包括写保护级别、合并策略、备份口令和遥测键校验
+// 参数:
+//   - generator: ID生成器，用于在fill-missing策略下校验已有的标识符
+//
+// 返回值:
+//   - config.SaveOptions: 保存选项
+//   - error: 如果参数无效或无法获取备份口令，则返回错误
+func buildSaveOptions(generator *idgen.Generator) (config.SaveOptions, error) {
+	protection, err := resolveProtectionLevel()
+	if err != nil {
+		return config.SaveOptions{}, err
+	}
+	strategy, err := config.ParseMergeStrategy(*mergeStrategy)
+	if err != nil {
+		return config.SaveOptions{}, err
+	}
+	passphrase, err := backupPassphrase()
+	if err != nil {
+		return config.SaveOptions{}, fmt.Errorf("failed to get backup passphrase: %w", err)
+	}
+	return config.SaveOptions{
+		Protection: protection,
+		Strategy:   strategy,
+		Backup:     &config.BackupOptions{Passphrase: passphrase},
+		Validate:   telemetryValidator(generator),
+	}, nil
+}
+
+// telemetryValidator: 创建遥测键校验函数
+// 将storage.json中的遥测键映射为ID生成器的ID类型后进行格式校验
+// 参数:
+//   - generator: ID生成器，提供ID格式校验
+//
+// 返回值:
+//   - func(key, value string) bool: 校验函数，未知的键视为有效
+func telemetryValidator(generator *idgen.Generator) func(key, value string) bool {
+	idTypes := map[string]string{
+		"telemetry.machineId":    "machineID",
+		"telemetry.macMachineId": "macMachineID",
+		"telemetry.devDeviceId":  "deviceID",
+		"telemetry.sqmId":        "sqmID",
+	}
+	return func(key, value string) bool {
+		idType, ok := idTypes[key]
+		return !ok || generator.ValidateID(value, idType)
+	}
+}
+
+// resolveProtectionLevel: 解析写保护级别
+// -protect参数优先，未指定时根据-r标志决定是否使用只读模式
+// 返回值:
+//   - config.ProtectionLevel: 解析得到的写保护级别
+//   - error: 如果-protect参数无效，则返回错误
+func resolveProtectionLevel() (config.ProtectionLevel, error) {
+	if *protectLevel != "" {
+		return config.ParseProtectionLevel(*protectLevel)
+	}
+	if *setReadOnly {
+		return config.ProtectReadOnly, nil
+	}
+	return config.ProtectNone, nil
+}
+
+// handleUnlock: 移除写保护
+// 移除之前运行时施加在storage.json上的只读或系统级写保护
+// 参数:
+//   - display: 用户界面显示组件，用于显示结果
+//   - configManager: 配置管理器，用于操作配置文件
+func handleUnlock(display *ui.Display, configManager config.ConfigStore) {
+	err := configManager.Unprotect()
+	recordAudit(audit.Entry{Action: "unlock", Targets: []string{configManager.ConfigPath()}}, err)
+	if err != nil {
+		log.Error(err)                 // 记录错误
+		display.ShowError(err.Error()) // 显示错误消息
+	} else {
+		display.ShowSuccess(lang.GetText().UnlockSuccess) // 显示成功消息
+	}
+
+	if os.Getenv("AUTOMATED_MODE") != "1" {
+		waitExit()
+	}
+}
+
+// 监视模式下检查失败后重试的最长间隔
+const maxGuardRetryDelay = 5 * time.Minute
+
+// handleGuard: 守护模式
+// 检查storage.json中的标识符是否被Cursor改写，如被改写则重新写入上次生成的值
+// 使用-watch时按固定间隔持续检查，直到用户按下Ctrl+C；暂时的读写失败按指数退避重试
+// 参数:
+//   - ctx: 上下文，取消后停止检查
+//   - display: 用户界面显示组件，用于显示检查结果
+//   - configManager: 配置存储，用于读取和写入配置文件
+func handleGuard(ctx context.Context, display *ui.Display, configManager config.ConfigStore) {
+	protection, err := resolveProtectionLevel()
+	if err != nil {
+		log.Error(err)
+		display.ShowError(err.Error())
+		return
+	}
+
+	// 执行一次检查，返回检查中遇到的错误
+	check := func() error {
+		result, err := configManager.Guard(ctx, protection)
+		if errors.Is(err, context.Canceled) {
+			return err
+		}
+		if err != nil || result.Reapplied {
+			entry := audit.Entry{Action: "guard", Targets: []string{configManager.ConfigPath()}}
+			if result != nil {
+				// 被改写的值来自Cursor，只记录重新写入的键
+				for _, key := range result.ChangedKeys {
+					entry.Changes = append(entry.Changes, audit.Change{Key: key})
+				}
+			}
+			recordAudit(entry, err)
+		}
+		if err != nil {
+			log.Error("Guard check failed:", err)
+			display.ShowError(err.Error())
+			return err
+		}
+		if result.Reapplied {
+			log.WithField("keys", result.ChangedKeys).Info("Re-applied identifiers overwritten by Cursor")
+			display.ShowWarning(lang.Format(lang.GetText().GuardReapplied, lang.Values{"Keys": strings.Join(result.ChangedKeys, ", ")}))
+		} else if !*watch {
+			display.ShowSuccess(lang.GetText().GuardUnchanged)
+		}
+		return nil
+	}
+
+	if !*watch {
+		check()
+		if os.Getenv("AUTOMATED_MODE") != "1" {
+			waitExit()
+		}
+		return
+	}
+
+	// 监视模式：Ctrl+C取消上下文，中断正在进行的读写并干净退出
+	display.ShowInfo(lang.GetText().GuardWatching)
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	watchGuard(ctx, check, *watchInterval)
+}
+
+// watchGuard: 持续执行守护检查
+// 检查成功后按固定间隔继续；失败时（例如Cursor正在写入文件）等待时间逐次加倍，
+// 最长为maxGuardRetryDelay，成功后恢复正常间隔。只有上下文被取消或还没有快照时才停止
+// 参数:
+//   - ctx: 上下文，取消后停止检查
+//   - check: 执行一次检查
+//   - interval: 两次检查之间的正常间隔
+func watchGuard(ctx context.Context, check func() error, interval time.Duration) {
+	delay := interval
+	for {
+		err := check()
+		switch {
+		case err == nil:
+			delay = interval
+		case errors.Is(err, config.ErrNoGuardSnapshot), ctx.Err() != nil:
+			return
+		default:
+			delay = max(min(delay*2, maxGuardRetryDelay), interval)
+			log.WithField("retry_in", delay).Warn("Guard check failed, retrying")
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// printSaveDiff: 输出统一差异
+// 将storage.json修改前后的统一差异输出到控制台，并写入日志
+// 参数:
+//   - display: 用户界面显示组件，提供配色
+//   - result: 保存结果，包含修改前后的文件内容
+func printSaveDiff(display *ui.Display, result *config.SaveResult) {
+	text := diff.Unified(result.Path+" (before)", result.Path+" (after)", result.Before, result.After, diff.DefaultContext)
+	if text == "" {
+		return
+	}
+
+	palette := display.Palette()
+	fmt.Println()
+	for _, line := range strings.SplitAfter(text, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			fmt.Print(palette.Emphasis.Sprint(line))
+		case strings.HasPrefix(line, "@@"):
+			fmt.Print(palette.Info.Sprint(line))
+		case strings.HasPrefix(line, "+"):
+			fmt.Print(palette.Success.Sprint(line))
+		case strings.HasPrefix(line, "-"):
+			fmt.Print(palette.Error.Sprint(line))
+		default:
+			fmt.Print(line)
+		}
+	}
+	fmt.Println()
+
+	log.WithField("diff", text).Info("storage.json changed")
+}
+
+// prepareOneDrive: 处理OneDrive重定向
+// 检测配置目录是否位于OneDrive同步文件夹中，提示冲突风险
+// 使用-pause-onedrive时暂时关闭OneDrive，否则建议用户手动暂停同步
+// 参数:
+//   - display: 用户界面显示组件，用于显示提示
+//   - configManager: 配置管理器，用于定位配置目录
+//
+// 返回值:
+//   - func(): 写入完成后调用，重新启动被关闭的OneDrive
+func prepareOneDrive(display *ui.Display, configManager config.ConfigStore) func() {
+	root := configManager.OneDriveFolder()
+	if root == "" {
+		return func() {}
+	}
+	display.ShowWarning(lang.Format(lang.GetText().OneDriveDetected, lang.Values{"Dir": root}))
+
+	if !*pauseOneDrive {
+		display.ShowInfo(lang.GetText().OneDriveAdvice)
+		return func() {}
+	}
+
+	resume, err := config.PauseOneDrive()
+	if err != nil {
+		log.Warn("Failed to pause OneDrive:", err)
+		display.ShowInfo(lang.GetText().OneDriveAdvice)
+		return func() {}
+	}
+	display.ShowInfo(lang.GetText().OneDrivePaused)
+	return func() {
+		if err := resume(); err != nil {
+			log.Warn(err)
+		}
+	}
+}
+
+// reportOneDriveConflicts: 报告OneDrive冲突副本
+// 配置目录位于OneDrive中时，列出同步冲突生成的storage.json副本
+// 参数:
+//   - display: 用户界面显示组件，用于显示提示
+//   - configManager: 配置管理器，用于定位配置目录
+func reportOneDriveConflicts(display *ui.Display, configManager config.ConfigStore) {
+	if configManager.OneDriveFolder() == "" {
+		return
+	}
+	copies, err := configManager.OneDriveConflictCopies()
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	if len(copies) > 0 {
+		display.ShowWarning(lang.Format(lang.GetText().OneDriveConflictCopies, lang.Values{"Files": strings.Join(copies, ", ")}))
+	}
+}
+
+// clearLoginState: 清除登录状态
+// 备份后删除state.vscdb中的登录键和Session Storage目录
+// 参数:
+//   - ctx: 上下文，用于取消sqlite3操作
+//   - display: 用户界面显示组件，用于显示结果
+//   - configManager: 配置管理器，用于定位和修改登录数据
+//
+// 返回值:
+//   - error: 如果清除失败，则返回错误
+func clearLoginState(ctx context.Context, display *ui.Display, configManager config.ConfigStore) error {
+	result, err := configManager.SignOut(ctx)
+	entry := audit.Entry{Action: "sign-out", Targets: []string{configManager.StateDatabasePath()}}
+	if result != nil {
+		entry.Backups = result.BackupPaths
+	}
+	recordAudit(entry, err)
+	if result != nil {
+		for _, backupPath := range result.BackupPaths {
+			display.ShowInfo(lang.Format(lang.GetText().SignOutBackup, lang.Values{"Path": backupPath}))
+		}
+	}
+	if err != nil {
+		return err
+	}
+	display.ShowSuccess(lang.Format(lang.GetText().SignOutSuccess, lang.Values{"Count": result.RemovedKeys}))
+	return nil
 }
 
 // showCompletionMessages: 显示完成消息
-// 显示操作成功完成的消息，提示用户重启Cursor
+// 以表格显示各标识符修改前后的值，并提示用户重启Cursor
 // 参数:
 //   - display: 用户界面显示组件，用于显示成功和信息消息
-func showCompletionMessages(display *ui.Display) {
-	// 显示成功消息，包括操作成功和需要重启Cursor的提示
-	display.ShowSuccess(lang.GetText().SuccessMessage, lang.GetText().RestartMessage)
+//   - changes: 各标识符修改前后的值
+func showCompletionMessages(display *ui.Display, changes []report.Change) {
+	text := lang.GetText()
+	display.ShowSuccess(text.SuccessMessage)
 	fmt.Println() // 打印空行，增加界面可读性
+	reporter.Summary(changes)
+	fmt.Println()
+	display.ShowSuccess(text.RestartMessage)
+	fmt.Println()
+	display.ShowInfo(text.OperationCompleted) // 显示操作完成消息
+}
 
-	// 根据当前语言显示操作完成消息
-	message := "Operation completed!"
-	if lang.GetCurrentLanguage() == lang.CN {
-		message = "操作完成！"
+// idChanges: 整理各遥测标识符修改前后的值
+// 参数:
+//   - oldConfig: 修改前的配置，读取失败时为nil
+//   - result: 保存结果，包含实际写入的值和文件路径
+//
+// 返回值:
+//   - []report.Change: 各标识符修改前后的值
+func idChanges(oldConfig *config.StorageConfig, result *config.SaveResult) []report.Change {
+	if oldConfig == nil {
+		oldConfig = &config.StorageConfig{}
+	}
+	written := result.Written
+	return []report.Change{
+		{Field: "telemetry.machineId", Old: oldConfig.TelemetryMachineId, New: written.TelemetryMachineId, File: result.Path},
+		{Field: "telemetry.macMachineId", Old: oldConfig.TelemetryMacMachineId, New: written.TelemetryMacMachineId, File: result.Path},
+		{Field: "telemetry.devDeviceId", Old: oldConfig.TelemetryDevDeviceId, New: written.TelemetryDevDeviceId, File: result.Path},
+		{Field: "telemetry.sqmId", Old: oldConfig.TelemetrySqmId, New: written.TelemetrySqmId, File: result.Path},
 	}
-	display.ShowInfo(message) // 显示信息消息
 }
 
 // waitExit: 等待用户按下Enter键退出
 // 显示提示消息并等待用户按下Enter键，然后程序退出
 // 这使用户有时间阅读程序输出的信息
 func waitExit() {
-	fmt.Print(lang.GetText().PressEnterToExit) // 显示按Enter退出的提示
-	os.Stdout.Sync()                           // 刷新标准输出缓冲区，确保消息立即显示
-	bufio.NewReader(os.Stdin).ReadString('\n') // 读取用户输入，直到按下Enter键
+	prompt.Wait(lang.GetText().PressEnterToExit) // 显示按Enter退出的提示，读取用户输入直到按下Enter键
 }
 
 // checkAdminPrivileges: 检查是否具有管理员权限
@@ -492,10 +1517,10 @@ func selfElevate() error {
 		// append([]string{exe}, os.Args[1:]...) 将可执行文件路径和原始参数组合成新的参数列表
 		cmd := exec.Command("sudo", append([]string{exe}, os.Args[1:]...)...)
 		// 将标准输入、输出和错误流连接到当前进程的对应流
-		cmd.Stdin = os.Stdin   // 允许用户输入sudo密码
-		cmd.Stdout = os.Stdout // 显示命令输出，保持用户可以看到程序的输出信息
-		cmd.Stderr = os.Stderr // 显示错误信息，确保错误信息能够正确显示给用户
-		return cmd.Run()       // 执行命令并返回可能的错误
+		cmd.Stdin = os.Stdin      // 允许用户输入sudo密码
+		cmd.Stdout = ui.Console() // 直接连接终端，os.Stdout可能已被替换为写入日志文件的管道
+		cmd.Stderr = os.Stderr    // 显示错误信息，确保错误信息能够正确显示给用户
+		return cmd.Run()          // 执行命令并返回可能的错误
 
 	default:
 		// 对于不支持的操作系统，返回错误
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+)
+
+func TestHandleGuardWithMemoryStore(t *testing.T) {
+	t.Setenv("AUTOMATED_MODE", "1")
+	ctx := context.Background()
+	store := config.NewMemoryStore(nil, []byte(`{"telemetry.machineId": "overwritten", "other": true}`))
+	if err := store.SaveGuardSnapshot(&config.StorageConfig{TelemetryMachineId: "expected"}); err != nil {
+		t.Fatal(err)
+	}
+
+	handleGuard(ctx, ui.NewDisplay(nil), store)
+
+	current, err := store.ReadConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.TelemetryMachineId != "expected" {
+		t.Errorf("machineId = %q, want %q", current.TelemetryMachineId, "expected")
+	}
+}
+
+func TestClearLoginStateWithMemoryStore(t *testing.T) {
+	store := config.NewMemoryStore(nil, nil)
+	store.SetState(map[string]string{"cursorAuth/refreshToken": "token", "theme": "dark"})
+
+	if err := clearLoginState(context.Background(), ui.NewDisplay(nil), store); err != nil {
+		t.Fatal(err)
+	}
+	if state := store.State(); len(state) != 1 || state["theme"] != "dark" {
+		t.Errorf("unexpected remaining state: %v", state)
+	}
+	if len(store.Backups()) != 1 {
+		t.Errorf("expected the state database to be backed up, got %v", store.Backups())
+	}
+}
+
+func TestPrepareOneDriveOutsideOneDrive(t *testing.T) {
+	// 不在OneDrive中的存储不需要暂停同步，返回的函数可以直接调用
+	resume := prepareOneDrive(ui.NewDisplay(nil), config.NewMemoryStore(nil, nil))
+	resume()
+	reportOneDriveConflicts(ui.NewDisplay(nil), config.NewMemoryStore(nil, nil))
+}
+
+func TestWatchGuardRetriesTransientErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	check := func() error {
+		calls++
+		switch calls {
+		case 1, 2:
+			return errors.New("storage.json is being written")
+		case 3:
+			cancel()
+		}
+		return nil
+	}
+	watchGuard(ctx, check, time.Millisecond)
+	if calls != 3 {
+		t.Errorf("check called %d times, want 3", calls)
+	}
+}
+
+func TestWatchGuardStopsWithoutSnapshot(t *testing.T) {
+	calls := 0
+	watchGuard(context.Background(), func() error {
+		calls++
+		return config.ErrNoGuardSnapshot
+	}, time.Millisecond)
+	if calls != 1 {
+		t.Errorf("check called %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/yuaotian/go-cursor-help/internal/audit"
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+)
+
+// 启动菜单中的操作
+const (
+	menuModify = iota
+	menuStatus
+	menuRestore
+	menuLock
+	menuLanguage
+	menuExit
+)
+
+// shouldShowMenu: 判断是否在启动时显示操作菜单
+// 只有不带任何参数、在终端中交互运行（例如双击程序）时显示，脚本和自动化运行保持原有行为
+//
+// 返回值:
+//   - bool: 是否显示菜单
+func shouldShowMenu() bool {
+	return len(os.Args) == 1 &&
+		os.Getenv("AUTOMATED_MODE") != "1" &&
+		ui.IsTerminal(os.Stdin) && ui.IsTerminal(ui.Console())
+}
+
+// runMenu: 显示启动菜单，直到用户选择修改标识符或退出
+// 查看状态、恢复备份、加/去写保护和切换语言执行后回到菜单，切换语言后菜单以新的语言显示
+// 参数:
+//   - env: 菜单操作需要的组件
+//
+// 返回值:
+//   - bool: 用户选择修改标识符时返回true
+func runMenu(env *commandEnv) bool {
+	for {
+		text := lang.GetText()
+		lockLabel := text.MenuLock
+		if level, err := env.configManager.DetectProtection(); err == nil && level != config.ProtectNone {
+			lockLabel = text.MenuUnlock
+		}
+
+		fmt.Println(text.MenuTitle)
+		choice := prompt.Select(text.MenuPrompt, []string{
+			text.MenuModify,
+			text.MenuStatus,
+			text.MenuRestore,
+			lockLabel,
+			text.MenuLanguage,
+			text.MenuExit,
+		}, menuExit)
+		fmt.Println()
+
+		var err error
+		switch choice {
+		case menuModify:
+			return true
+		case menuStatus:
+			err = showStatus(env)
+		case menuRestore:
+			err = restoreFromMenu(env)
+		case menuLock:
+			err = toggleProtection(env)
+		case menuLanguage:
+			err = chooseLanguage(env)
+		default:
+			return false
+		}
+		if err != nil {
+			log.Error(err)
+			env.display.ShowError(err.Error())
+		}
+		fmt.Println()
+	}
+}
+
+// showStatus: 显示storage.json的位置、当前标识符（默认打码）、写保护级别和运行中的进程数
+// 参数:
+//   - env: 菜单操作需要的组件
+//
+// 返回值:
+//   - error: 读取配置或列出进程失败时返回错误
+func showStatus(env *commandEnv) error {
+	text := lang.GetText()
+	path := env.configManager.ConfigPath()
+
+	current, err := env.configManager.ReadConfig(env.ctx)
+	if err != nil {
+		return err
+	}
+	items := []ui.SummaryItem{{Label: text.StatusConfig, Value: path}}
+	if current == nil {
+		items[0].Value += " " + text.StatusMissing
+	} else {
+		items = append(items,
+			ui.SummaryItem{Label: "telemetry.machineId", Value: env.display.FormatID(current.TelemetryMachineId)},
+			ui.SummaryItem{Label: "telemetry.macMachineId", Value: env.display.FormatID(current.TelemetryMacMachineId)},
+			ui.SummaryItem{Label: "telemetry.devDeviceId", Value: env.display.FormatID(current.TelemetryDevDeviceId)},
+			ui.SummaryItem{Label: "telemetry.sqmId", Value: env.display.FormatID(current.TelemetrySqmId)},
+		)
+	}
+
+	level, err := env.configManager.DetectProtection()
+	if err != nil {
+		return err
+	}
+	processes, err := env.processManager.ListCursorProcesses(env.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list processes: %w", err)
+	}
+	items = append(items,
+		ui.SummaryItem{Label: text.SummaryProtection, Value: string(level)},
+		ui.SummaryItem{Label: text.StatusProcesses, Value: fmt.Sprint(len(processes))},
+	)
+
+	env.display.ShowSummary(text.StatusTitle, items)
+	return nil
+}
+
+// restoreFromMenu: 列出本工具创建的备份，选择后按restore子命令的流程恢复
+// 参数:
+//   - env: 菜单操作需要的组件
+//
+// 返回值:
+//   - error: 列出备份或恢复失败时返回错误
+func restoreFromMenu(env *commandEnv) error {
+	text := lang.GetText()
+	backups, err := env.configManager.ListBackups()
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		env.display.ShowWarning(lang.Format(text.MenuNoBackups, lang.Values{"Dir": env.configManager.BackupDir()}))
+		return nil
+	}
+
+	choice := prompt.Select(text.MenuBackupPrompt, backups, -1)
+	if choice < 0 {
+		return nil
+	}
+	return runRestore(env, []string{"-from", backups[choice]})
+}
+
+// toggleProtection: storage.json已有写保护时移除，否则施加写保护
+// 施加的级别与-protection参数或配置文件一致，未指定时使用只读保护
+// 参数:
+//   - env: 菜单操作需要的组件
+//
+// 返回值:
+//   - error: 检测、施加或移除写保护失败时返回错误
+func toggleProtection(env *commandEnv) error {
+	text := lang.GetText()
+	current, err := env.configManager.DetectProtection()
+	if err != nil {
+		return err
+	}
+	if current != config.ProtectNone {
+		err := env.configManager.Unprotect()
+		recordAudit(audit.Entry{Action: "unlock", Targets: []string{env.configManager.ConfigPath()}}, err)
+		if err != nil {
+			return err
+		}
+		env.display.ShowSuccess(text.UnlockSuccess)
+		return nil
+	}
+
+	level, err := resolveProtectionLevel()
+	if err != nil {
+		return err
+	}
+	if level == config.ProtectNone {
+		level = config.ProtectReadOnly
+	}
+	err = env.configManager.Protect(env.ctx, level)
+	recordAudit(audit.Entry{Action: "protect", Targets: []string{env.configManager.ConfigPath()}}, err)
+	if err != nil {
+		return err
+	}
+	env.display.ShowSuccess(lang.Format(text.LockSuccess, lang.Values{"Level": level}))
+	return nil
+}
+
+// chooseLanguage: 列出支持的语言（以各自的文字显示），切换到选中的语言并保存到工具配置文件
+// 参数:
+//   - env: 菜单操作需要的组件
+//
+// 返回值:
+//   - error: 保存语言设置失败时返回错误，此时语言已经切换
+func chooseLanguage(env *commandEnv) error {
+	languages := lang.Languages()
+	names := make([]string, len(languages))
+	current := -1
+	for i, language := range languages {
+		names[i] = language.Name()
+		if language == lang.GetCurrentLanguage() {
+			current = i
+		}
+	}
+
+	choice := prompt.Select(lang.GetText().LanguagePrompt, names, current)
+	if choice < 0 || languages[choice] == lang.GetCurrentLanguage() {
+		return nil
+	}
+	path, err := switchLanguage(env.display, languages[choice])
+	if err != nil {
+		return err
+	}
+	if path != "" {
+		env.display.ShowInfo(lang.Format(lang.GetText().LanguageSaved, lang.Values{"Path": path}))
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/process"
+	"github.com/yuaotian/go-cursor-help/internal/report"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+)
+
+// handleListProcesses: 处理-list-processes参数
+// 列出关闭Cursor时会终止的进程及其路径、用户和命令行，不终止任何进程
+// 参数:
+//   - ctx: 上下文，用于取消进程枚举
+//   - display: 用户界面显示组件
+//   - processManager: 进程管理器，使用与实际关闭时相同的匹配规则
+func handleListProcesses(ctx context.Context, display *ui.Display, processManager *process.Manager) {
+	text := lang.GetText()
+	name := resolveTarget().DisplayName()
+
+	processes, err := processManager.ListCursorProcesses(ctx)
+	if err != nil {
+		log.Error(err)
+		display.ShowError(err.Error())
+		return
+	}
+	if len(processes) == 0 {
+		display.ShowSuccess(lang.Format(text.ProcessListEmpty, lang.Values{"App": name}))
+		return
+	}
+
+	display.ShowInfo(lang.Format(text.ProcessListHeader, lang.Values{"App": name, "Count": len(processes)}))
+	for _, p := range processes {
+		started := "-"
+		if !p.StartTime.IsZero() {
+			started = p.StartTime.Format("2006-01-02 15:04:05")
+		}
+		username := p.Username
+		if username == "" {
+			username = "-"
+		}
+		name := p.Name
+		if p.Daemon {
+			name = lang.Format(text.ProcessListDaemon, lang.Values{"Name": name})
+		}
+		// 模板不支持宽度，先按列宽填充，保持各行对齐
+		fmt.Println(lang.Format(text.ProcessListEntry, lang.Values{
+			"PID":     fmt.Sprintf("%-7d", p.PID),
+			"PPID":    fmt.Sprintf("%-7d", p.PPID),
+			"User":    runewidth.FillRight(username, 16),
+			"Started": started,
+			"Name":    name,
+		}))
+		if p.Exe != "" {
+			fmt.Printf("      %s\n", p.Exe)
+		}
+		if commandLine := p.CommandLine(); commandLine != "" && commandLine != p.Exe {
+			fmt.Printf("      %s\n", commandLine)
+		}
+	}
+}
+
+// closingProgress: 创建关闭进程时更新进度消息的回调
+// 参数:
+//   - reporter: 接收进度说明的报告目标
+//   - closed: 记录需要关闭的进程总数，为nil时不记录
+//
+// 返回值:
+//   - process.ProgressFunc: 将剩余进程数报告为步骤进度的回调
+func closingProgress(reporter report.Reporter, closed *int) process.ProgressFunc {
+	total := 0
+	return func(event process.Event) {
+		switch event.Kind {
+		case process.EventAttempt, process.EventWaiting:
+			if event.Remaining > total {
+				total = event.Remaining
+			}
+			if closed != nil {
+				*closed = total
+			}
+			reporter.StepProgress(lang.Format(lang.GetText().ClosingRemaining, lang.Values{"Total": total, "Remaining": event.Remaining}))
+		case process.EventKill:
+			log.Debug("Killed process ", event.Process)
+		}
+	}
+}
+
+// checkFileHolders: 检查是否仍有进程打开着storage.json
+// 即使没有匹配名称的进程在运行，崩溃后残留的Helper等进程仍可能持有该文件并在之后改写它
+// 检查本身失败时只记录日志，不阻止修改
+// 参数:
+//   - ctx: 上下文，用于取消检查
+//   - display: 用户界面显示组件
+//   - configManager: 配置管理器，提供storage.json的路径
+//
+// 返回值:
+//   - error: 如果有进程打开着该文件，则返回错误
+func checkFileHolders(ctx context.Context, display *ui.Display, configManager *config.Manager) error {
+	path := configManager.ConfigPath()
+	holders, err := process.FileHolders(ctx, path)
+	if err != nil {
+		log.Debug("Failed to check for processes holding storage.json: ", err)
+		return nil
+	}
+	if len(holders) == 0 {
+		return nil
+	}
+
+	text := lang.GetText()
+	display.ShowWarning(lang.Format(text.FileInUse, lang.Values{"File": path}))
+	for _, p := range holders {
+		fmt.Printf("  %s\n", p)
+	}
+	display.ShowError(text.FileInUseHint)
+	waitExit()
+	return fmt.Errorf("%s is open in %d other processes", path, len(holders))
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/process"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+)
+
+// captureWorkspaces: 在关闭Cursor之前记录其打开的工作区
+// 读取失败时只记录日志，不影响后续操作
+// 参数:
+//   - configManager: 配置管理器，用于读取storage.json中的窗口状态
+//
+// 返回值:
+//   - []config.Workspace: 打开的工作区
+func captureWorkspaces(configManager *config.Manager) []config.Workspace {
+	workspaces, err := configManager.OpenWorkspaces()
+	if err != nil {
+		log.Debug("Failed to read open workspaces: ", err)
+		return nil
+	}
+	log.Debug("Open workspaces before closing: ", len(workspaces))
+	return workspaces
+}
+
+// confirmUnsavedWork: 检查Cursor是否有未保存的编辑器，有时提示强制关闭可能丢失修改并要求确认
+// Cursor未运行或自动化模式下不检查
+// 参数:
+//   - ctx: 上下文，用于取消进程枚举
+//   - display: 用户界面显示组件
+//   - configManager: 配置管理器，用于定位Backups目录
+//   - processManager: 进程管理器，用于检查Cursor是否在运行
+//
+// 返回值:
+//   - bool: 是否继续
+func confirmUnsavedWork(ctx context.Context, display *ui.Display, configManager *config.Manager, processManager *process.Manager) bool {
+	if os.Getenv("AUTOMATED_MODE") == "1" || !processManager.IsCursorRunning(ctx) {
+		return true
+	}
+
+	backups, err := configManager.UnsavedBackups()
+	if err != nil {
+		log.Debug("Failed to check for unsaved editors: ", err)
+		return true
+	}
+	if len(backups) == 0 {
+		return true
+	}
+
+	text := lang.GetText()
+	display.ShowWarning(lang.Format(text.UnsavedWorkWarning, lang.Values{"Count": len(backups)}))
+	return prompt.Confirm(text.UnsavedWorkPrompt, false)
+}
+
+// offerWorkspaceRelaunch: 修改完成后提示重新打开关闭前的工作区
+// 自动化模式下不提示
+// 参数:
+//   - display: 用户界面显示组件
+//   - workspaces: 关闭前打开的工作区
+func offerWorkspaceRelaunch(display *ui.Display, workspaces []config.Workspace) {
+	if len(workspaces) == 0 || os.Getenv("AUTOMATED_MODE") == "1" {
+		return
+	}
+
+	text := lang.GetText()
+	display.ShowInfo(text.WorkspacesOpen)
+	for _, workspace := range workspaces {
+		fmt.Println("  - " + workspace.String())
+	}
+	if !prompt.Confirm(lang.Format(text.RelaunchWorkspacesPrompt, lang.Values{"Count": len(workspaces)}), false) {
+		return
+	}
+
+	launched := 0
+	for _, workspace := range workspaces {
+		option := "--folder-uri"
+		if workspace.IsFile {
+			option = "--file-uri"
+		}
+		if err := launchCursor(option, workspace.URI); err != nil {
+			log.Warn("Failed to reopen workspace ", workspace.String(), ": ", err)
+			continue
+		}
+		launched++
+	}
+	display.ShowSuccess(lang.Format(text.WorkspacesRelaunched, lang.Values{"Count": launched}))
+}
+
+// launchCursor: 以原始用户的身份启动Cursor，不等待其退出
+// 参数:
+//   - args: 传给Cursor命令行的参数
+//
+// 返回值:
+//   - error: 如果找不到Cursor或启动失败，则返回错误
+func launchCursor(args ...string) error {
+	cmd, err := cursorCommand(args...)
+	if err != nil {
+		return err
+	}
+	return asOriginalUser(cmd).Start()
+}
+
+// cursorCommand: 构造启动Cursor的命令
+// 优先使用PATH中的命令行启动器（例如cursor、code），macOS上找不到时通过open启动应用
+// 参数:
+//   - args: 传给Cursor命令行的参数
+//
+// 返回值:
+//   - *exec.Cmd: 启动命令
+//   - error: 如果找不到Cursor，则返回错误
+func cursorCommand(args ...string) (*exec.Cmd, error) {
+	name := resolveTarget().DisplayName()
+	if path, err := exec.LookPath(strings.ToLower(name)); err == nil {
+		return exec.Command(path, args...), nil
+	}
+	if runtime.GOOS == "darwin" {
+		return exec.Command("open", append([]string{"-na", name, "--args"}, args...)...), nil
+	}
+	return nil, fmt.Errorf("%s command line launcher not found in PATH", name)
+}
+
+// captureRestartCommands: 在关闭Cursor之前记录其主进程的命令行，用于-restart-cursor和-restart-daemons
+// 参数:
+//   - ctx: 上下文，用于取消进程枚举
+//   - processManager: 进程管理器，用于查找Cursor进程
+//   - editor: 是否记录编辑器本身的命令行
+//   - daemons: 是否记录CLI隧道等后台守护进程的命令行
+//
+// 返回值:
+//   - [][]string: 去重后的命令行
+func captureRestartCommands(ctx context.Context, processManager *process.Manager, editor, daemons bool) [][]string {
+	processes, err := processManager.ListCursorProcesses(ctx)
+	if err != nil {
+		log.Warn("Failed to record Cursor command lines:", err)
+		return nil
+	}
+
+	var commands [][]string
+	seen := make(map[string]bool)
+	for _, p := range process.MainProcesses(processes) {
+		if (p.Daemon && !daemons) || (!p.Daemon && !editor) {
+			continue
+		}
+		command := p.Cmdline
+		if len(command) == 0 && p.Exe != "" {
+			command = []string{p.Exe}
+		}
+		key := strings.Join(command, "\x00")
+		if len(command) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		commands = append(commands, command)
+	}
+	log.Debug("Recorded Cursor command lines: ", commands)
+	return commands
+}
+
+// restartCursor: 以原始用户的身份按记录的命令行重新启动Cursor
+// 参数:
+//   - display: 用户界面显示组件
+//   - commands: captureRestartCommands记录的命令行
+func restartCursor(display *ui.Display, commands [][]string) {
+	if len(commands) == 0 {
+		return
+	}
+	restarted := 0
+	for _, command := range commands {
+		if err := asOriginalUser(exec.Command(command[0], command[1:]...)).Start(); err != nil {
+			log.Warn("Failed to restart ", command[0], ": ", err)
+			continue
+		}
+		restarted++
+	}
+	if restarted > 0 {
+		display.ShowSuccess(lang.Format(lang.GetText().CursorRestarted, lang.Values{"App": resolveTarget().DisplayName()}))
+	}
+}
+
+// asOriginalUser: 以提升权限前的用户身份执行命令
+// 避免以root或管理员身份启动编辑器，导致其创建的文件归root所有或编辑器以管理员权限运行
+// macOS/Linux上通过sudo -u切换回调用sudo的用户；
+// Windows上没有参数时通过资源管理器启动（资源管理器以当前登录用户的普通权限运行），
+// 有参数时使用runas /trustlevel以受限的普通用户令牌启动
+// 参数:
+//   - cmd: 要执行的命令
+//
+// 返回值:
+//   - *exec.Cmd: 可能被包装后的命令
+func asOriginalUser(cmd *exec.Cmd) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		if isAdmin, _ := checkAdminPrivileges(); !isAdmin {
+			return cmd
+		}
+		if len(cmd.Args) <= 1 {
+			return exec.Command("explorer.exe", cmd.Path)
+		}
+		quoted := make([]string, len(cmd.Args))
+		for i, arg := range cmd.Args {
+			quoted[i] = `"` + strings.ReplaceAll(arg, `"`, `\"`) + `"`
+		}
+		quoted[0] = `"` + cmd.Path + `"`
+		return exec.Command("runas", "/trustlevel:0x20000", strings.Join(quoted, " "))
+	}
+
+	sudoUser := os.Getenv("SUDO_USER")
+	if sudoUser == "" || os.Geteuid() != 0 {
+		return cmd
+	}
+	// 保留图形会话相关的环境变量，否则编辑器无法连接到用户的桌面
+	args := []string{"-u", sudoUser, "--preserve-env=DISPLAY,WAYLAND_DISPLAY,XAUTHORITY,XDG_RUNTIME_DIR,DBUS_SESSION_BUS_ADDRESS", "--", cmd.Path}
+	return exec.Command("sudo", append(args, cmd.Args[1:]...)...)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/yuaotian/go-cursor-help/internal/audit"
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+)
+
+// runRestore: restore子命令
+// 从备份文件恢复storage.json，加密备份会使用备份口令自动解密
+// 除本工具创建的备份外，也接受用户提供的任意storage.json：
+// 恢复前校验其结构和标识符格式，显示与当前配置的差异并确认
+// 恢复前会关闭Cursor，避免恢复的内容被立即覆盖
+// 参数:
+//   - env: 子命令运行时需要的组件
+//   - args: 子命令参数
+//
+// 返回值:
+//   - error: 如果校验或恢复失败，则返回错误
+func runRestore(env *commandEnv, args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	from := flags.String("from", "", "path of the backup file or storage.json to restore")
+	force := flags.Bool("force", false, "restore even if the file fails validation")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *from == "" {
+		return fmt.Errorf("restore requires -from <backup file>")
+	}
+
+	passphrase, err := backupPassphrase()
+	if err != nil {
+		return err
+	}
+
+	text := lang.GetText()
+	data, err := config.ReadBackupFile(*from, passphrase)
+	if err != nil {
+		return err
+	}
+
+	// 校验结构和标识符格式
+	keys := env.configManager.Target().TelemetryKeys()
+	if problems := config.ValidateStorageJSON(data, keys, telemetryValidator(env.generator)); len(problems) > 0 {
+		env.display.ShowWarning(text.RestoreInvalid)
+		for _, problem := range problems {
+			fmt.Println("  - " + problem)
+		}
+		if !*force {
+			return fmt.Errorf("%s failed validation, use -force to restore it anyway", *from)
+		}
+	}
+
+	// 显示与当前配置的差异
+	current, err := os.ReadFile(env.configManager.ConfigPath())
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	changes, err := config.DiffStorageJSON(current, data)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		env.display.ShowInfo(text.RestoreNoChanges)
+		return nil
+	}
+	env.display.ShowInfo(text.RestoreDiffHeader)
+	printKeyChanges(env.display.Palette(), changes)
+
+	if os.Getenv("AUTOMATED_MODE") != "1" && !prompt.Confirm(text.RestorePrompt, false) {
+		return nil
+	}
+
+	if _, err := handleCursorProcesses(env.ctx, env.display, env.processManager); err != nil {
+		return err
+	}
+
+	err = env.configManager.RestoreContent(data)
+	recordAudit(audit.Entry{
+		Action:  "restore",
+		Targets: []string{env.configManager.ConfigPath()},
+		Source:  *from,
+		Changes: auditKeyChanges(changes),
+	}, err)
+	if err != nil {
+		return err
+	}
+	env.display.ShowSuccess(lang.Format(text.RestoreSuccess, lang.Values{"Path": *from}))
+	return nil
+}
+
+// printKeyChanges: 打印storage.json顶层键的变化
+// 参数:
+//   - palette: 配色，新增、删除和修改的键使用不同颜色
+//   - changes: 发生变化的键
+func printKeyChanges(palette ui.Palette, changes []config.KeyChange) {
+	for _, change := range changes {
+		switch {
+		case change.Added():
+			fmt.Printf("  %s %s: %s\n", palette.Success.Sprint("+"), change.Key, change.After)
+		case change.Removed():
+			fmt.Printf("  %s %s: %s\n", palette.Error.Sprint("-"), change.Key, change.Before)
+		default:
+			fmt.Printf("  %s %s: %s -> %s\n", palette.Warning.Sprint("~"), change.Key, change.Before, change.After)
+		}
+	}
+}
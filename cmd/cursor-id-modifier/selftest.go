@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/pkg/idgen"
+)
+
+// runSelfTest: selftest子命令
+// 生成大量样本并做基本的统计检查，确认当前环境的随机来源可用，
+// 避免在chroot、精简容器等环境中生成可预测或重复的标识符
+// 参数:
+//   - env: 子命令运行时需要的组件
+//   - args: 子命令参数
+//
+// 返回值:
+//   - error: 如果有检查未通过，则返回错误
+func runSelfTest(env *commandEnv, args []string) error {
+	flags := flag.NewFlagSet("selftest", flag.ContinueOnError)
+	samples := flags.Int("samples", 10000, "number of ID sets to generate")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *samples <= 0 {
+		return fmt.Errorf("-samples must be positive")
+	}
+
+	text := lang.GetText()
+	env.display.ShowProgress(lang.Format(text.SelfTestRunning, lang.Values{"Count": *samples}))
+	results := idgen.SelfTest(*samples)
+	env.display.StopProgress()
+	fmt.Println()
+
+	palette := env.display.Palette()
+	passMark, failMark := palette.Marks("√", "×")
+	failed := 0
+	for _, result := range results {
+		if result.Passed {
+			fmt.Printf("  %s %s: %s\n", palette.Success.Sprint(passMark), result.Name, result.Detail)
+			continue
+		}
+		failed++
+		fmt.Printf("  %s %s: %s\n", palette.Error.Sprint(failMark), result.Name, result.Detail)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d self-test checks failed", failed)
+	}
+	env.display.ShowSuccess(text.SelfTestPassed)
+	return nil
+}
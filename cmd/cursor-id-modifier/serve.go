@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/yuaotian/go-cursor-help/internal/api"
+	"github.com/yuaotian/go-cursor-help/internal/audit"
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/report"
+)
+
+// defaultListenAddress: serve子命令默认监听的地址，只接受本机连接
+const defaultListenAddress = "127.0.0.1:8765"
+
+// runServe: serve子命令
+// 在本机提供HTTP API（GET /status、POST /modify、POST /backup、POST /restore、GET /history），
+// 指定-grpc-listen时同时提供gRPC服务（proto/cursorid/v1/cursorid.proto），两者共用同一个令牌，
+// 图形界面和管理脚本不必为每个操作启动一次命令行；按Ctrl+C停止
+// 参数:
+//   - env: 子命令运行时需要的组件
+//   - args: 子命令参数
+//
+// 返回值:
+//   - error: 如果无法监听地址或服务异常退出，则返回错误
+func runServe(env *commandEnv, args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	listen := flags.String("listen", defaultListenAddress, "address to listen on; use a loopback address unless remote management is intended")
+	grpcListen := flags.String("grpc-listen", "", "also serve the gRPC API on this address (disabled by default)")
+	token := flags.String("token", "", "access token clients send as \"Authorization: Bearer <token>\" (default: $CURSOR_API_TOKEN or a random token printed at startup)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *token == "" {
+		*token = os.Getenv("CURSOR_API_TOKEN")
+	}
+	if *token == "" {
+		generated, err := randomToken()
+		if err != nil {
+			return err
+		}
+		*token = generated
+	}
+
+	listener, err := listenAPI(env, *listen, lang.GetText().ServeListening)
+	if err != nil {
+		return err
+	}
+	var grpcListener net.Listener
+	if *grpcListen != "" {
+		if grpcListener, err = listenAPI(env, *grpcListen, lang.GetText().ServeGRPCListening); err != nil {
+			listener.Close()
+			return err
+		}
+	}
+	env.display.ShowInfo(lang.Format(lang.GetText().ServeToken, lang.Values{"Token": *token}))
+
+	ctx, stop := signal.NotifyContext(env.ctx, os.Interrupt)
+	defer stop()
+	policy := generationPolicy()
+	service := api.NewService(api.Options{
+		Token:  *token,
+		Status: func(ctx context.Context) (*api.Status, error) { return apiStatusOf(ctx, env) },
+		Modify: func(ctx context.Context, reporter report.Reporter) error {
+			return runResetSteps(ctx, &resetRun{env: env, policy: policy}, reporter)
+		},
+		Backup: func(ctx context.Context) (string, error) { return backupFromAPI(env) },
+		Restore: func(ctx context.Context, request api.RestoreRequest) (*api.RestoreResult, error) {
+			return restoreFromAPI(ctx, env, request)
+		},
+		History: func(ctx context.Context) ([]api.BackupInfo, error) { return apiHistory(env) },
+	})
+
+	if grpcListener == nil {
+		return api.Serve(ctx, listener, service.Handler())
+	}
+	// 任一服务异常退出时停止另一个
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	grpcDone := make(chan error, 1)
+	go func() {
+		grpcDone <- api.ServeGRPC(ctx, grpcListener, service.GRPCServer())
+		cancel()
+	}()
+	err = api.Serve(ctx, listener, service.Handler())
+	cancel()
+	if grpcErr := <-grpcDone; err == nil {
+		err = grpcErr
+	}
+	return err
+}
+
+// listenAPI: 监听地址并显示实际监听的地址，不是本机回环地址时提示风险
+// 参数:
+//   - env: 子命令运行时需要的组件
+//   - address: 要监听的地址，端口为0时由系统分配
+//   - message: 显示监听地址的文本，包含{{.Address}}占位符
+//
+// 返回值:
+//   - net.Listener: 监听器
+//   - error: 无法监听时返回错误
+func listenAPI(env *commandEnv, address string, message string) (net.Listener, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+	actual := listener.Addr().(*net.TCPAddr)
+	if !actual.IP.IsLoopback() {
+		env.display.ShowWarning(lang.GetText().ServeNotLoopback)
+	}
+	env.display.ShowInfo(lang.Format(message, lang.Values{"Address": actual.String()}))
+	return listener, nil
+}
+
+// randomToken: 生成随机的访问令牌
+func randomToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// runResetSteps: 依次执行重置流程的各步骤，第一个失败的步骤结束流程
+// 参数:
+//   - ctx: 请求的上下文
+//   - run: 重置流程的状态
+//   - reporter: 接收各步骤的进展
+//
+// 返回值:
+//   - error: 失败步骤的错误
+func runResetSteps(ctx context.Context, run *resetRun, reporter report.Reporter) error {
+	for _, step := range run.steps() {
+		reporter.StepStarted(step.Title)
+		if err := step.Run(ctx, reporter); err != nil {
+			reporter.StepFailed(err)
+			return err
+		}
+		reporter.StepSucceeded()
+	}
+	return nil
+}
+
+// apiStatusOf: 收集与菜单中“查看状态”相同的信息，标识符与控制台一样默认打码，-show-ids时显示完整值
+func apiStatusOf(ctx context.Context, env *commandEnv) (*api.Status, error) {
+	status := &api.Status{ConfigPath: env.configManager.ConfigPath()}
+	current, err := env.configManager.ReadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil {
+		status.Exists = true
+		status.IDs = map[string]string{
+			"telemetry.machineId":    env.display.FormatID(current.TelemetryMachineId),
+			"telemetry.macMachineId": env.display.FormatID(current.TelemetryMacMachineId),
+			"telemetry.devDeviceId":  env.display.FormatID(current.TelemetryDevDeviceId),
+			"telemetry.sqmId":        env.display.FormatID(current.TelemetrySqmId),
+		}
+	}
+
+	level, err := env.configManager.DetectProtection()
+	if err != nil {
+		return nil, err
+	}
+	status.Protection = string(level)
+	processes, err := env.processManager.ListCursorProcesses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+	status.Processes = len(processes)
+	return status, nil
+}
+
+// apiHistory: 列出本工具创建的备份，最新的在前
+func apiHistory(env *commandEnv) ([]api.BackupInfo, error) {
+	backups, err := env.configManager.ListBackups()
+	if err != nil {
+		return nil, err
+	}
+	history := make([]api.BackupInfo, 0, len(backups))
+	for _, path := range backups {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		history = append(history, api.BackupInfo{Path: path, Time: info.ModTime(), Size: info.Size()})
+	}
+	return history, nil
+}
+
+// backupFromAPI: 立即备份storage.json，与修改前的备份一样按需使用备份口令加密
+func backupFromAPI(env *commandEnv) (string, error) {
+	passphrase, err := backupPassphrase()
+	if err != nil {
+		return "", err
+	}
+	path, err := env.configManager.Backup(config.BackupOptions{Passphrase: passphrase})
+	recordAudit(audit.Entry{Action: "backup", Targets: []string{env.configManager.ConfigPath()}, Backups: auditBackups(path)}, err)
+	return path, err
+}
+
+// restoreFromAPI: 按restore子命令的流程从备份恢复storage.json，但不询问确认
+// 只接受本工具创建的备份，避免通过API读取任意文件
+// 参数:
+//   - ctx: 请求的上下文
+//   - env: 子命令运行时需要的组件
+//   - request: 要恢复的备份
+//
+// 返回值:
+//   - *api.RestoreResult: 恢复的备份和配置的变化
+//   - error: 备份不存在、未通过校验或恢复失败时返回错误
+func restoreFromAPI(ctx context.Context, env *commandEnv, request api.RestoreRequest) (*api.RestoreResult, error) {
+	backups, err := env.configManager.ListBackups()
+	if err != nil {
+		return nil, err
+	}
+	var from string
+	for _, backup := range backups {
+		if backup == request.Backup || filepath.Base(backup) == request.Backup {
+			from = backup
+			break
+		}
+	}
+	if from == "" {
+		return nil, fmt.Errorf("%w: %s is not a backup in %s", api.ErrBadRequest, request.Backup, env.configManager.BackupDir())
+	}
+
+	passphrase, err := backupPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	data, err := config.ReadBackupFile(from, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	keys := env.configManager.Target().TelemetryKeys()
+	if problems := config.ValidateStorageJSON(data, keys, telemetryValidator(env.generator)); len(problems) > 0 && !request.Force {
+		return nil, fmt.Errorf("%w: %s failed validation (%d problems), set force to restore it anyway", api.ErrBadRequest, from, len(problems))
+	}
+
+	current, err := os.ReadFile(env.configManager.ConfigPath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	changes, err := config.DiffStorageJSON(current, data)
+	if err != nil {
+		return nil, err
+	}
+	result := &api.RestoreResult{Backup: from, Changes: make([]api.KeyChange, len(changes))}
+	for i, change := range changes {
+		result.Changes[i] = api.KeyChange{Key: change.Key, Before: change.Before, After: change.After}
+	}
+	if len(changes) == 0 {
+		return result, nil
+	}
+
+	// 与控制台流程一样先关闭Cursor，避免恢复的内容被立即覆盖
+	if err := (&resetRun{env: env}).closeCursor(ctx, report.Multi()); err != nil {
+		return nil, err
+	}
+	err = env.configManager.RestoreContent(data)
+	recordAudit(audit.Entry{
+		Action:  "restore",
+		Targets: []string{env.configManager.ConfigPath()},
+		Source:  from,
+		Changes: auditKeyChanges(changes),
+	}, err)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
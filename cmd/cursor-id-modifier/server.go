@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/yuaotian/go-cursor-help/internal/audit"
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+	"github.com/yuaotian/go-cursor-help/pkg/idgen"
+)
+
+// resetMachineIDFile: 处理-reset-server-machineid参数
+// 将cursor-server数据目录中的machineid替换为新的UUID
+// 参数:
+//   - display: 用户界面显示组件
+//   - configManager: 配置管理器，其目标需要有machineid文件
+//   - generator: ID生成器，用于生成新的UUID
+//   - username: 当前用户名，用于定位服务器数据目录
+//
+// 返回值:
+//   - string: 被替换的machineid文件路径，文件不存在时为空
+//   - error: 如果目标不支持或写入失败，则返回错误
+func resetMachineIDFile(display *ui.Display, configManager *config.Manager, generator *idgen.Generator, username string) (string, error) {
+	target, ok := configManager.Target().(config.MachineIDFileTarget)
+	if !ok {
+		return "", fmt.Errorf("-reset-server-machineid requires -editor cursor-server")
+	}
+
+	id, err := generator.GenerateDeviceID()
+	if err != nil {
+		return "", err
+	}
+	old, err := configManager.ResetMachineIDFile(username, id)
+	path, pathErr := target.MachineIDPath(username)
+	if err != nil || old != "" {
+		recordAudit(audit.Entry{
+			Action:  "reset-server-machineid",
+			Targets: []string{path},
+			Changes: []audit.Change{{Key: "machineid", Old: audit.HashValue(old), New: audit.HashValue(id)}},
+		}, err)
+	}
+	if err != nil {
+		return "", err
+	}
+	if old == "" {
+		display.ShowWarning(lang.GetText().ServerMachineIDMissing)
+		return "", nil
+	}
+	log.WithField("old", old).WithField("new", id).Debug("Machine ID file reset")
+	display.ShowSuccess(lang.GetText().ServerMachineIDReset)
+	return path, pathErr
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/settings"
+)
+
+// toolSettings: 从工具配置文件加载的设置，未找到配置文件时为空设置
+var toolSettings = &settings.Settings{}
+
+// toolSettingsPath: 工具配置文件的路径，无法确定时为空
+var toolSettingsPath string
+
+// loadSettings: 加载工具配置文件
+// 读取-config指定的（或默认位置的）配置文件，并将其中的值应用到未在命令行中指定的参数上
+// 配置文件无效时记录警告并继续使用默认值
+// 参数:
+//   - username: 用户名，用于定位默认的配置文件路径
+func loadSettings(username string) {
+	path := *settingsPath
+	if path == "" {
+		defaultPath, err := settings.DefaultPath(username)
+		if err != nil {
+			log.Warn("Failed to locate settings file:", err)
+			return
+		}
+		path = defaultPath
+	}
+
+	toolSettingsPath = path
+
+	loaded, err := settings.Load(path)
+	if err != nil {
+		log.Warn("Failed to load settings:", err)
+		return
+	}
+	toolSettings = loaded
+	log.Debug("Loaded settings from ", path)
+
+	// 记录命令行中显式指定的参数，这些参数优先于配置文件
+	explicit := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	overrideString := func(name string, target *string, value string) {
+		if !explicit[name] && value != "" {
+			*target = value
+		}
+	}
+	overrideString("lang", languageFlag, toolSettings.Language)
+	overrideString("protect", protectLevel, toolSettings.Protection)
+	overrideString("backup-dir", backupDir, toolSettings.BackupDir)
+	overrideString("storage", storagePath, toolSettings.StoragePath)
+	overrideString("editor", editor, toolSettings.Editor)
+	overrideString("app-name", appName, toolSettings.AppName)
+	overrideString("merge", mergeStrategy, toolSettings.MergeStrategy)
+	overrideString("plausible", plausibleFields, toolSettings.Plausible)
+	overrideString("theme", theme, toolSettings.UI.Theme)
+	if !explicit["keep"] && toolSettings.Keep != nil {
+		*keepFields = strings.Join(toolSettings.Keep, ",")
+	}
+	overrideString("uuid-namespace", uuidNamespace, toolSettings.UUIDNamespace)
+	overrideString("uuid-name", uuidName, toolSettings.UUIDName)
+	if !explicit["r"] && toolSettings.ReadOnly {
+		*setReadOnly = true
+	}
+	if !explicit["block-telemetry"] && toolSettings.BlockTelemetry {
+		*blockTelemetryFlag = true
+	}
+	if !explicit["uuid-version"] && toolSettings.UUIDVersion != 0 {
+		*uuidVersion = toolSettings.UUIDVersion
+	}
+
+	applyLanguage()
+	applyMessageOverrides()
+}
+
+// applyLanguage: 应用-lang参数或配置文件中指定的界面语言
+// 未指定时保持自动检测的结果
+func applyLanguage() {
+	if *languageFlag == "" {
+		return
+	}
+	language, err := lang.ParseLanguage(*languageFlag)
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	lang.SetLanguage(language)
+}
+
+// applyMessageOverrides: 应用配置文件messages中覆盖的文本，例如替换SudoExample为组织内部的操作说明
+// 未知的文本名称记录警告，其余文本仍然生效
+func applyMessageOverrides() {
+	if len(toolSettings.Messages) == 0 {
+		return
+	}
+	if err := lang.SetOverrides(toolSettings.Messages); err != nil {
+		log.Warn("Invalid message overrides in settings: ", err)
+	}
+}
+
+// splitList: 拆分逗号分隔的命令行参数，去掉空白和空项
+// 参数:
+//   - value: 参数值，例如"cursor,my-fork"
+//
+// 返回值:
+//   - []string: 拆分后的各项
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
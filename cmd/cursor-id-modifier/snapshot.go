@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/yuaotian/go-cursor-help/internal/audit"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+)
+
+// runSnapshot: snapshot子命令
+// 将整个globalStorage目录（包括state.vscdb）打包为一个tar.gz归档
+// 打包前会关闭Cursor，保证数据库文件处于一致状态
+// 参数:
+//   - env: 子命令运行时需要的组件
+//   - args: 子命令参数
+//
+// 返回值:
+//   - error: 如果打包失败，则返回错误
+func runSnapshot(env *commandEnv, args []string) error {
+	flags := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	out := flags.String("out", "", "path of the archive to create (default: inside the backups directory)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if _, err := handleCursorProcesses(env.ctx, env.display, env.processManager); err != nil {
+		return err
+	}
+
+	archivePath, err := env.configManager.Snapshot(*out)
+	recordAudit(audit.Entry{Action: "snapshot", Targets: []string{filepath.Dir(env.configManager.ConfigPath())}, Backups: auditBackups(archivePath)}, err)
+	if err != nil {
+		return err
+	}
+	env.display.ShowSuccess(lang.Format(lang.GetText().SnapshotCreated, lang.Values{"Path": archivePath}))
+	return nil
+}
+
+// runRestoreSnapshot: restore-snapshot子命令
+// 用snapshot子命令创建的归档替换整个globalStorage目录
+// 参数:
+//   - env: 子命令运行时需要的组件
+//   - args: 子命令参数
+//
+// 返回值:
+//   - error: 如果恢复失败，则返回错误
+func runRestoreSnapshot(env *commandEnv, args []string) error {
+	flags := flag.NewFlagSet("restore-snapshot", flag.ContinueOnError)
+	from := flags.String("from", "", "path of the snapshot archive to restore")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *from == "" {
+		return fmt.Errorf("restore-snapshot requires -from <snapshot archive>")
+	}
+
+	if _, err := handleCursorProcesses(env.ctx, env.display, env.processManager); err != nil {
+		return err
+	}
+
+	err := env.configManager.RestoreSnapshot(*from)
+	recordAudit(audit.Entry{Action: "restore-snapshot", Targets: []string{filepath.Dir(env.configManager.ConfigPath())}, Source: *from}, err)
+	if err != nil {
+		return err
+	}
+	env.display.ShowSuccess(lang.Format(lang.GetText().SnapshotRestored, lang.Values{"Path": *from}))
+	return nil
+}
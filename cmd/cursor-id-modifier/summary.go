@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/report"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+)
+
+// runSummary 一次重置运行的结果，在结束时汇总显示
+type runSummary struct {
+	// 从启动到结束的总耗时
+	elapsed time.Duration
+	// 修改的文件路径
+	files []string
+	// 备份路径，未备份时为空
+	backup string
+	// 各标识符修改前后的值
+	changes []report.Change
+	// 施加的写保护级别
+	protection config.ProtectionLevel
+	// 关闭的进程数
+	processesClosed int
+}
+
+// showRunSummary: 显示运行摘要，用户不必在进度输出中查找本次运行做了什么
+// 摘要只列出被修改的标识符名称，不重复显示标识符的值
+// 参数:
+//   - display: 用户界面显示组件
+//   - summary: 本次运行的结果
+func showRunSummary(display *ui.Display, summary runSummary) {
+	text := lang.GetText()
+
+	backup := summary.backup
+	if backup == "" {
+		backup = text.SummaryNone
+	}
+
+	var changed []string
+	for _, change := range summary.changes {
+		if change.Old != change.New {
+			changed = append(changed, change.Field)
+		}
+	}
+	ids := text.SummaryNone
+	if len(changed) > 0 {
+		ids = lang.Format(text.SummaryIDsValue, lang.Values{"Changed": len(changed), "Total": len(summary.changes), "Fields": strings.Join(changed, ", ")})
+	}
+
+	display.ShowSummary(text.SummaryTitle, []ui.SummaryItem{
+		{Label: text.SummaryFiles, Value: strings.Join(summary.files, "\n")},
+		{Label: text.SummaryBackup, Value: backup},
+		{Label: text.SummaryIDs, Value: ids},
+		{Label: text.SummaryProtection, Value: string(summary.protection)},
+		{Label: text.SummaryProcesses, Value: fmt.Sprint(summary.processesClosed)},
+		{Label: text.SummaryTime, Value: summary.elapsed.Round(100 * time.Millisecond).String()},
+	})
+}
+
+// modifiedFiles: 整理本次运行修改的文件
+// 参数:
+//   - result: 保存结果
+//   - machineIDPath: 被替换的machineid文件路径，未替换时为空
+//
+// 返回值:
+//   - []string: 修改的文件路径
+func modifiedFiles(result *config.SaveResult, machineIDPath string) []string {
+	files := []string{result.Path}
+	if machineIDPath != "" {
+		files = append(files, machineIDPath)
+	}
+	return files
+}
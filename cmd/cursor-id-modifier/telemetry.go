@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/yuaotian/go-cursor-help/internal/hosts"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+)
+
+// flushDNSTimeout: 清除DNS缓存的最长时间
+const flushDNSTimeout = 10 * time.Second
+
+// telemetryDomains: 返回要屏蔽的遥测域名，工具配置文件中指定时使用配置的列表
+func telemetryDomains() []string {
+	if len(toolSettings.TelemetryDomains) > 0 {
+		return toolSettings.TelemetryDomains
+	}
+	return hosts.DefaultDomains
+}
+
+// blockTelemetry: 在hosts文件中添加屏蔽遥测域名的条目，修改后清除DNS缓存
+// 参数:
+//   - ctx: 上下文，用于取消清除DNS缓存的命令
+//   - display: 用户界面显示组件，用于显示结果
+//   - backupDir: 修改前备份hosts文件的目录
+//
+// 返回值:
+//   - error: 如果读取或写入hosts文件失败，则返回错误
+func blockTelemetry(ctx context.Context, display *ui.Display, backupDir string) error {
+	text := lang.GetText()
+	result, err := hosts.Block(hosts.DefaultPath(), telemetryDomains(), backupDir)
+	recordHostsChange("block-telemetry", result, err)
+	if err != nil {
+		return err
+	}
+	if !result.Changed {
+		display.ShowInfo(lang.Format(text.TelemetryAlreadyBlocked, lang.Values{"Path": result.Path}))
+		return nil
+	}
+	display.ShowInfo(lang.Format(text.HostsBackupCreated, lang.Values{"Path": result.BackupPath}))
+	flushDNS(ctx)
+	display.ShowSuccess(lang.Format(text.TelemetryBlocked, lang.Values{"Count": result.Domains, "Path": result.Path}))
+	return nil
+}
+
+// handleUnblockTelemetry: 移除-block-telemetry添加的hosts条目后退出
+// 参数:
+//   - ctx: 上下文，用于取消清除DNS缓存的命令
+//   - display: 用户界面显示组件，用于显示结果
+//   - backupDir: 修改前备份hosts文件的目录
+func handleUnblockTelemetry(ctx context.Context, display *ui.Display, backupDir string) {
+	text := lang.GetText()
+	result, err := hosts.Unblock(hosts.DefaultPath(), backupDir)
+	recordHostsChange("unblock-telemetry", result, err)
+	switch {
+	case err != nil:
+		log.Error(err)
+		display.ShowError(err.Error())
+	case !result.Changed:
+		display.ShowInfo(lang.Format(text.TelemetryNotBlocked, lang.Values{"Path": result.Path}))
+	default:
+		display.ShowInfo(lang.Format(text.HostsBackupCreated, lang.Values{"Path": result.BackupPath}))
+		flushDNS(ctx)
+		display.ShowSuccess(lang.Format(text.TelemetryUnblocked, lang.Values{"Path": result.Path}))
+	}
+
+	if os.Getenv("AUTOMATED_MODE") != "1" {
+		waitExit()
+	}
+}
+
+// flushDNS: 清除DNS缓存，失败时只记录日志，hosts文件的修改在缓存过期后仍会生效
+func flushDNS(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, flushDNSTimeout)
+	defer cancel()
+	if err := hosts.FlushDNS(ctx); err != nil {
+		log.Debug(err)
+	}
+}
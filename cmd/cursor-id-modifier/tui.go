@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/process"
+	"github.com/yuaotian/go-cursor-help/internal/report"
+	"github.com/yuaotian/go-cursor-help/internal/tui"
+	"github.com/yuaotian/go-cursor-help/pkg/idgen"
+)
+
+// runTUI: 运行全屏交互界面
+// 界面运行期间日志输出被暂时关闭，避免打乱界面
+// 参数:
+//   - env: 命令执行环境，提供上下文、配置管理器、进程管理器和ID生成器
+//
+// 返回值:
+//   - error: 如果界面无法启动，则返回错误
+func runTUI(env *commandEnv) error {
+	// 输出被重定向时无法显示全屏界面
+	if env.display.IsAccessible() {
+		return fmt.Errorf("-tui cannot be combined with -accessible")
+	}
+	if env.display.IsPlain() {
+		return fmt.Errorf("-tui needs an interactive terminal")
+	}
+
+	// 在关闭日志前解析-keep，参数无效时仍能看到错误
+	run := &resetRun{env: env, policy: generationPolicy()}
+
+	output := log.Out
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(output)
+
+	return tui.Run(env.ctx, tui.Options{
+		Labels:     tuiLabels(),
+		Palette:    env.display.Palette(),
+		RevealIDs:  *showIDs,
+		Processes:  env.processManager.ListCursorProcesses,
+		ResetSteps: run.steps,
+		// 切换后的语言保存到工具配置文件，界面运行期间无法显示保存失败，只记录日志
+		SwitchLanguage: func() tui.Labels {
+			if _, err := switchLanguage(env.display, nextLanguage()); err != nil {
+				log.Warn("Failed to save the language setting:", err)
+			}
+			return tuiLabels()
+		},
+	})
+}
+
+// tuiLabels: 返回当前语言的全屏界面文本
+func tuiLabels() tui.Labels {
+	text := lang.GetText()
+	return tui.Labels{
+		Title:           text.TUITitle,
+		Language:        lang.GetCurrentLanguage().Name(),
+		MenuReset:       text.TUIMenuReset,
+		MenuProcesses:   text.TUIMenuProcesses,
+		MenuQuit:        text.TUIMenuQuit,
+		ProcessesHeader: text.TUIProcessesHeader,
+		NoProcesses:     text.TUINoProcesses,
+		ChangesHeader:   text.TUIChangesHeader,
+		OldValue:        text.TUIOldValue,
+		NewValue:        text.TUINewValue,
+		Succeeded:       text.TUISucceeded,
+		Failed:          text.TUIFailed,
+		MenuHelp:        text.TUIMenuHelp,
+		BackHelp:        text.TUIBackHelp,
+		RunningHelp:     text.TUIRunningHelp,
+	}
+}
+
+// resetRun 一次重置流程中各步骤之间传递的状态，全屏界面和HTTP API共用
+type resetRun struct {
+	// 命令执行环境
+	env *commandEnv
+	// -keep指定的生成策略
+	policy idgen.GenerationPolicy
+	// 修改前的配置，读取失败时为nil
+	oldConfig *config.StorageConfig
+	// 生成的新配置
+	newConfig *config.StorageConfig
+	// 保存结果
+	result *config.SaveResult
+}
+
+// steps: 返回重置流程的各个步骤，与控制台流程的顺序一致
+// 返回值:
+//   - []tui.Step: 重置步骤
+func (r *resetRun) steps() []tui.Step {
+	*r = resetRun{env: r.env, policy: r.policy}
+	text := lang.GetText()
+	return []tui.Step{
+		{Title: text.TUIStepClose, Run: r.closeCursor},
+		{Title: text.TUIStepCheckFile, Run: r.checkFile},
+		{Title: text.TUIStepRead, Run: r.readConfig},
+		{Title: text.TUIStepGenerate, Run: r.generate},
+		{Title: text.TUIStepSave, Run: r.save},
+		{Title: text.TUIStepVerify, Run: r.verify},
+	}
+}
+
+// closeCursor: 关闭Cursor，其他用户的进程只在指定了-force时关闭
+func (r *resetRun) closeCursor(ctx context.Context, reporter report.Reporter) error {
+	if os.Getenv("AUTOMATED_MODE") == "1" {
+		return nil
+	}
+	pm := r.env.processManager
+	others, err := pm.OtherUsersProcesses(ctx, r.env.username)
+	if err == nil && len(others) > 0 && !*force {
+		return fmt.Errorf("%d %s processes belong to another user account (use -force to close them)", len(others), resolveTarget().DisplayName())
+	}
+
+	pm.SetProgressFunc(closingProgress(reporter, nil))
+	defer pm.SetProgressFunc(nil)
+	if err := pm.KillCursorProcesses(ctx); err != nil {
+		return err
+	}
+	if pm.IsCursorRunning(ctx) {
+		return fmt.Errorf("cursor still running")
+	}
+	return nil
+}
+
+// checkFile: 确认没有其他进程仍打开着storage.json
+func (r *resetRun) checkFile(ctx context.Context, reporter report.Reporter) error {
+	path := r.env.configManager.ConfigPath()
+	holders, err := process.FileHolders(ctx, path)
+	if err != nil || len(holders) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s is still open in %s", path, holders[0])
+}
+
+// readConfig: 读取现有配置，文件不存在或无法解析时按没有旧值处理
+func (r *resetRun) readConfig(ctx context.Context, reporter report.Reporter) error {
+	oldConfig, err := r.env.configManager.ReadConfig(ctx)
+	if err != nil {
+		reporter.StepProgress(err.Error())
+		return nil
+	}
+	r.oldConfig = oldConfig
+	return nil
+}
+
+// generate: 生成新的标识符，-keep指定的字段保留原有值
+func (r *resetRun) generate(ctx context.Context, reporter report.Reporter) error {
+	opts := idgen.GenerateOptions{Policy: r.policy}
+	if r.oldConfig != nil {
+		opts.Existing = r.oldConfig.Identity()
+	}
+	identity, err := r.env.generator.GenerateAll(opts)
+	if err != nil {
+		return err
+	}
+	r.newConfig = config.FromIdentity(identity)
+	return nil
+}
+
+// save: 备份并保存新配置，之前施加的写保护会被临时移除
+func (r *resetRun) save(ctx context.Context, reporter report.Reporter) error {
+	saveOptions, err := buildSaveOptions(r.env.generator)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.env.configManager.SaveConfigWithOptions(ctx, r.newConfig, saveOptions)
+	var protectedErr *config.WriteProtectedError
+	if errors.As(err, &protectedErr) {
+		reporter.StepProgress(lang.Format(lang.GetText().WriteProtectedDetected, lang.Values{"Level": protectedErr.Level}))
+		if err := r.env.configManager.Unprotect(); err != nil {
+			return err
+		}
+		result, err = r.env.configManager.SaveConfigWithOptions(ctx, r.newConfig, saveOptions)
+	}
+	recordSave("reset", r.env.configManager.ConfigPath(), r.oldConfig, result, err)
+	if err != nil {
+		return err
+	}
+	r.result = result
+
+	if err := r.env.configManager.SaveGuardSnapshot(result.Written); err != nil {
+		log.Warn("Failed to save guard snapshot:", err)
+	}
+	return nil
+}
+
+// verify: 报告各标识符修改前后的值
+// SaveConfigWithOptions写入后已重新读取并校验标识符和文件权限，校验失败时保存步骤即已失败
+func (r *resetRun) verify(ctx context.Context, reporter report.Reporter) error {
+	reporter.Summary(idChanges(r.oldConfig, r.result))
+	return nil
+}
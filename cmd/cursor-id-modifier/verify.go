@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+)
+
+// runVerifyIDs: verify-ids子命令
+// 校验storage.json（或-file指定的文件）中的标识符是否符合当前Cursor版本的格式，
+// 用于在Cursor读取前确认手工编辑或导入的配置有效
+// 参数:
+//   - env: 子命令运行时需要的组件
+//   - args: 子命令参数
+//
+// 返回值:
+//   - error: 如果读取失败或存在无效的标识符，则返回错误
+func runVerifyIDs(env *commandEnv, args []string) error {
+	flags := flag.NewFlagSet("verify-ids", flag.ContinueOnError)
+	file := flags.String("file", "", "path of the storage.json to check (default: the current configuration)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		*file = env.configManager.ConfigPath()
+	}
+
+	passphrase, err := backupPassphrase()
+	if err != nil {
+		return err
+	}
+	data, err := config.ReadBackupFile(*file, passphrase)
+	if err != nil {
+		return err
+	}
+	var storage config.StorageConfig
+	if err := json.Unmarshal(data, &storage); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", *file, err)
+	}
+
+	text := lang.GetText()
+	env.display.ShowInfo(lang.Format(text.VerifyHeader, lang.Values{"Path": *file}))
+	palette := env.display.Palette()
+	passMark, failMark := palette.Marks("√", "×")
+	invalid := 0
+	for _, verdict := range env.generator.ValidateAll(storage.Identity()) {
+		if verdict.Valid {
+			fmt.Printf("  %s %s: %s\n", palette.Success.Sprint(passMark), verdict.Field, env.display.FormatID(verdict.Value))
+			continue
+		}
+		invalid++
+		fmt.Printf("  %s %s: %s (%s)\n", palette.Error.Sprint(failMark), verdict.Field, env.display.FormatID(verdict.Value), verdict.Reason)
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d identifiers are invalid or missing", invalid)
+	}
+	env.display.ShowSuccess(text.VerifyPassed)
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yuaotian/go-cursor-help/internal/audit"
+	"github.com/yuaotian/go-cursor-help/internal/config"
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+)
+
+// clearWorkspaceStorage: 清理workspaceStorage
+// 列出所有工作区条目及其大小，由用户选择要删除的条目
+// 自动化模式下不询问，也不删除任何条目
+// 参数:
+//   - display: 用户界面显示组件，用于显示条目列表和结果
+//   - configManager: 配置管理器，用于定位和删除workspaceStorage条目
+//
+// 返回值:
+//   - error: 如果读取或删除失败，则返回错误
+func clearWorkspaceStorage(display *ui.Display, configManager *config.Manager) error {
+	text := lang.GetText()
+	entries, err := configManager.ListWorkspaceStorage()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		display.ShowInfo(text.WorkspaceStorageEmpty)
+		return nil
+	}
+
+	// 显示条目列表和总大小
+	var total int64
+	display.ShowInfo(lang.Format(text.WorkspaceStorageHeader, lang.Values{"Dir": configManager.WorkspaceStorageDir()}))
+	for i, entry := range entries {
+		folder := entry.Folder
+		if folder == "" {
+			folder = entry.ID
+		}
+		fmt.Printf("  [%d] %-10s %s  %s\n", i+1, ui.FormatSize(entry.Size), entry.ModTime.Format("2006-01-02"), folder)
+		total += entry.Size
+	}
+	display.ShowInfo(lang.Format(text.WorkspaceStorageTotal, lang.Values{"Count": len(entries), "Size": ui.FormatSize(total)}))
+
+	// 自动化模式下直接回答默认值，即不删除；删除全部需要明确输入all
+	selected, err := selectWorkspaces(entries, prompt.Input(text.WorkspaceStoragePrompt, ""))
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+
+	freed, err := configManager.RemoveWorkspaceStorage(selected)
+	removed := make([]string, len(selected))
+	for i, entry := range selected {
+		removed[i] = entry.Path
+	}
+	recordAudit(audit.Entry{Action: "clear-workspace-storage", Targets: removed}, err)
+	if err != nil {
+		return err
+	}
+	display.ShowSuccess(lang.Format(text.WorkspaceStorageCleared, lang.Values{"Count": len(selected), "Size": ui.FormatSize(freed)}))
+	return nil
+}
+
+// selectWorkspaces: 解析用户的选择
+// 支持"all"（全部）、空输入或"none"（不删除）以及逗号分隔的序号和范围，例如"1,3,5-7"
+// 参数:
+//   - entries: 可选的工作区条目
+//   - answer: 用户输入
+//
+// 返回值:
+//   - []config.WorkspaceEntry: 被选中的条目
+//   - error: 如果输入无法解析，则返回错误
+func selectWorkspaces(entries []config.WorkspaceEntry, answer string) ([]config.WorkspaceEntry, error) {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	switch answer {
+	case "all":
+		return entries, nil
+	case "", "none", "n":
+		return nil, nil
+	}
+
+	chosen := make(map[int]bool)
+	for _, part := range strings.Split(answer, ",") {
+		part = strings.TrimSpace(part)
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(first))
+		if err != nil {
+			return nil, fmt.Errorf("invalid selection %q", part)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(strings.TrimSpace(last)); err != nil {
+				return nil, fmt.Errorf("invalid selection %q", part)
+			}
+		}
+		if start < 1 || end > len(entries) || start > end {
+			return nil, fmt.Errorf("selection %q is out of range", part)
+		}
+		for i := start; i <= end; i++ {
+			chosen[i-1] = true
+		}
+	}
+
+	var selected []config.WorkspaceEntry
+	for i, entry := range entries {
+		if chosen[i] {
+			selected = append(selected, entry)
+		}
+	}
+	return selected, nil
+}
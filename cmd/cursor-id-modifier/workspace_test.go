@@ -0,0 +1,55 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yuaotian/go-cursor-help/internal/config"
+)
+
+func TestSelectWorkspaces(t *testing.T) {
+	entries := []config.WorkspaceEntry{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}, {ID: "e"}}
+	tests := []struct {
+		answer  string
+		want    []string
+		wantErr bool
+	}{
+		// 空输入和none不删除任何条目，删除全部必须输入all
+		{answer: "", want: nil},
+		{answer: "   ", want: nil},
+		{answer: "none", want: nil},
+		{answer: "N", want: nil},
+		{answer: "all", want: []string{"a", "b", "c", "d", "e"}},
+		{answer: " ALL ", want: []string{"a", "b", "c", "d", "e"}},
+		{answer: "2", want: []string{"b"}},
+		{answer: "1,3", want: []string{"a", "c"}},
+		{answer: "2-4", want: []string{"b", "c", "d"}},
+		{answer: "5, 1-2, 2", want: []string{"a", "b", "e"}},
+		{answer: "y", wantErr: true},
+		{answer: "0", wantErr: true},
+		{answer: "6", wantErr: true},
+		{answer: "4-2", wantErr: true},
+		{answer: "1-x", wantErr: true},
+		{answer: "1,,2", wantErr: true},
+	}
+	for _, tt := range tests {
+		selected, err := selectWorkspaces(entries, tt.answer)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("selectWorkspaces(%q) = %v, want an error", tt.answer, selected)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("selectWorkspaces(%q) returned error: %v", tt.answer, err)
+			continue
+		}
+		var ids []string
+		for _, entry := range selected {
+			ids = append(ids, entry.ID)
+		}
+		if !reflect.DeepEqual(ids, tt.want) {
+			t.Errorf("selectWorkspaces(%q) = %v, want %v", tt.answer, ids, tt.want)
+		}
+	}
+}
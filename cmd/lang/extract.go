@@ -0,0 +1,386 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/yuaotian/go-cursor-help/internal/lang"
+)
+
+// templateHeader: 模板文件开头的说明
+const templateHeader = `# 翻译模板，由 go run ./cmd/lang extract 生成，请勿手动编辑
+# messages: 所有可翻译文本，source为英文原文，missing为尚未翻译该文本的语言
+# hardcoded: 没有经过文本资源、直接输出的文本，应改为使用lang.GetText()
+`
+
+// template: 翻译模板文件的内容
+type template struct {
+	// Messages: 文本资源中的所有文本，按字段顺序排列
+	Messages []templateMessage `yaml:"messages"`
+	// Hardcoded: 扫描到的硬编码文本
+	Hardcoded []hardcodedString `yaml:"hardcoded,omitempty"`
+}
+
+// templateMessage: 模板中的一条文本
+type templateMessage struct {
+	// ID: TextResource中的字段名
+	ID string `yaml:"id"`
+	// Source: 英文原文
+	Source string `yaml:"source"`
+	// Placeholders: 翻译中需要保留的占位符名称
+	Placeholders []string `yaml:"placeholders,omitempty"`
+	// UsedIn: 使用该文本的源文件，为空表示没有代码使用
+	UsedIn []string `yaml:"used_in,omitempty"`
+	// Missing: 尚未翻译该文本的语言，地区语言只包含与其回退语言不同的文本，不在此列出
+	Missing []string `yaml:"missing,omitempty"`
+}
+
+// hardcodedString: 扫描到的一条硬编码文本
+type hardcodedString struct {
+	// Location: 源文件和行号
+	Location string `yaml:"location"`
+	// Text: 文本内容
+	Text string `yaml:"text"`
+}
+
+// printFuncs: 向用户输出文本的fmt函数
+var printFuncs = map[string]bool{
+	"Print": true, "Printf": true, "Println": true,
+	"Fprint": true, "Fprintf": true, "Fprintln": true,
+}
+
+// formatFuncs: 返回格式化文本的fmt函数，作为输出调用的参数时检查其中的文本
+var formatFuncs = map[string]bool{
+	"Sprint": true, "Sprintf": true, "Sprintln": true, "Errorf": true,
+}
+
+// promptMethods: 向用户提问的方法，显示组件的Show*方法另外判断
+var promptMethods = map[string]bool{
+	"Confirm": true, "Select": true, "Input": true,
+}
+
+// formatVerbPattern: 匹配fmt格式化动词，判断文本是否包含文字时忽略
+var formatVerbPattern = regexp.MustCompile(`%[-+# 0-9.*]*[a-zA-Z%]`)
+
+// runExtract: extract命令
+// 扫描代码中对文本资源字段的使用和直接输出的硬编码文本，生成翻译模板
+// 与上次生成的模板相比新增的文本必须在所有非地区语言中都有翻译，否则返回错误且不写入模板
+// 参数:
+//   - args: 命令参数
+//
+// 返回值:
+//   - error: 扫描失败、新增文本缺少翻译或（-check时）模板不是最新时返回错误
+func runExtract(args []string) error {
+	flags := flag.NewFlagSet("extract", flag.ContinueOnError)
+	root := flags.String("root", ".", "repository root to scan")
+	output := flags.String("o", filepath.Join("internal", "lang", "messages.yaml"), "template file, relative to -root")
+	check := flags.Bool("check", false, "only verify that the template is up to date, do not write it")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	path := filepath.Join(*root, *output)
+
+	usage, hardcoded, err := scanSource(*root)
+	if err != nil {
+		return err
+	}
+	tmpl := buildTemplate(usage, hardcoded)
+
+	previous, err := readTemplate(path)
+	if err != nil {
+		return err
+	}
+	if err := checkNewMessages(tmpl, previous); err != nil {
+		return err
+	}
+
+	data, err := encodeTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+	if *check {
+		current, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to read template: %w", err)
+		}
+		if !bytes.Equal(current, data) {
+			return fmt.Errorf("%s is out of date, run go run ./cmd/lang extract", path)
+		}
+	} else if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write template: %w", err)
+	}
+
+	unused := 0
+	for _, message := range tmpl.Messages {
+		if len(message.UsedIn) == 0 {
+			unused++
+		}
+	}
+	fmt.Printf("%d messages (%d unused), %d hardcoded strings: %s\n", len(tmpl.Messages), unused, len(tmpl.Hardcoded), path)
+	return nil
+}
+
+// scanSource: 扫描root下除语言包和本工具以外的Go源文件（不含测试文件）
+// 参数:
+//   - root: 仓库根目录
+//
+// 返回值:
+//   - map[string][]string: 各文本资源字段被使用的源文件，路径相对于root
+//   - []hardcodedString: 直接输出的硬编码文本
+//   - error: 遍历或解析源文件失败时返回错误
+func scanSource(root string) (map[string][]string, []hardcodedString, error) {
+	fields := make(map[string]bool)
+	resourceType := reflect.TypeOf(lang.TextResource{})
+	for i := 0; i < resourceType.NumField(); i++ {
+		fields[resourceType.Field(i).Name] = true
+	}
+
+	langDir := filepath.Join(root, "internal", "lang")
+	toolDir := filepath.Join(root, "cmd", "lang")
+	if _, err := os.Stat(langDir); err != nil {
+		return nil, nil, fmt.Errorf("%s not found, run from the repository root or pass -root: %w", langDir, err)
+	}
+
+	usage := make(map[string][]string)
+	var hardcoded []hardcodedString
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path == langDir || path == toolDir || name == "vendor" || name == "testdata" || (strings.HasPrefix(name, ".") && path != root) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		used := make(map[string]bool)
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.SelectorExpr:
+				// 只按字段名判断，TextResource的字段名足够独特
+				if fields[node.Sel.Name] && !used[node.Sel.Name] {
+					used[node.Sel.Name] = true
+					usage[node.Sel.Name] = append(usage[node.Sel.Name], rel)
+				}
+			case *ast.CallExpr:
+				if !isOutputCall(node.Fun) {
+					return true
+				}
+				for _, literal := range stringLiterals(node.Args) {
+					text, err := strconv.Unquote(literal.Value)
+					if err != nil || !hasWords(text) {
+						continue
+					}
+					hardcoded = append(hardcoded, hardcodedString{
+						Location: fmt.Sprintf("%s:%d", rel, fset.Position(literal.Pos()).Line),
+						Text:     text,
+					})
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to scan source: %w", err)
+	}
+	return usage, hardcoded, nil
+}
+
+// isOutputCall: 判断调用是否向用户输出文本，包括fmt的打印函数、显示组件的Show*方法和提问方法
+func isOutputCall(fun ast.Expr) bool {
+	selector, ok := fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	name := selector.Sel.Name
+	if pkg, ok := selector.X.(*ast.Ident); ok && pkg.Name == "fmt" {
+		return printFuncs[name]
+	}
+	return strings.HasPrefix(name, "Show") || promptMethods[name]
+}
+
+// stringLiterals: 收集参数中的字符串字面量
+// 参数是fmt.Sprintf、fmt.Errorf等格式化调用时递归检查其参数，以找出先格式化再输出的文本
+func stringLiterals(args []ast.Expr) []*ast.BasicLit {
+	var literals []*ast.BasicLit
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case *ast.BasicLit:
+			if arg.Kind == token.STRING {
+				literals = append(literals, arg)
+			}
+		case *ast.CallExpr:
+			if isFormatCall(arg.Fun) {
+				literals = append(literals, stringLiterals(arg.Args)...)
+			}
+		}
+	}
+	return literals
+}
+
+// isFormatCall: 判断调用是否为返回格式化文本的fmt函数
+func isFormatCall(fun ast.Expr) bool {
+	selector, ok := fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := selector.X.(*ast.Ident)
+	return ok && pkg.Name == "fmt" && formatFuncs[selector.Sel.Name]
+}
+
+// hasWords: 判断文本去掉格式化动词后是否包含至少两个连续的字母
+func hasWords(text string) bool {
+	run := 0
+	for _, r := range formatVerbPattern.ReplaceAllString(text, "") {
+		if unicode.IsLetter(r) {
+			if run++; run >= 2 {
+				return true
+			}
+		} else {
+			run = 0
+		}
+	}
+	return false
+}
+
+// buildTemplate: 根据文本资源和扫描结果生成模板
+// 参数:
+//   - usage: 各文本资源字段被使用的源文件
+//   - hardcoded: 硬编码文本
+//
+// 返回值:
+//   - template: 生成的模板
+func buildTemplate(usage map[string][]string, hardcoded []hardcodedString) template {
+	source := reflect.ValueOf(lang.Translation(lang.EN))
+	var translations []lang.Language
+	var values []reflect.Value
+	for _, language := range lang.Languages() {
+		if language == lang.EN || len(language.Fallbacks()) > 0 {
+			continue
+		}
+		translations = append(translations, language)
+		values = append(values, reflect.ValueOf(lang.Translation(language)))
+	}
+
+	tmpl := template{Hardcoded: hardcoded}
+	resourceType := source.Type()
+	for i := 0; i < resourceType.NumField(); i++ {
+		name := resourceType.Field(i).Name
+		message := templateMessage{
+			ID:           name,
+			Source:       source.Field(i).String(),
+			Placeholders: lang.Placeholders(source.Field(i).String()),
+			UsedIn:       usage[name],
+		}
+		sort.Strings(message.UsedIn)
+		for j, value := range values {
+			if value.Field(i).String() == "" {
+				message.Missing = append(message.Missing, string(translations[j]))
+			}
+		}
+		tmpl.Messages = append(tmpl.Messages, message)
+	}
+	return tmpl
+}
+
+// readTemplate: 读取上次生成的模板
+// 参数:
+//   - path: 模板文件路径
+//
+// 返回值:
+//   - *template: 上次生成的模板，文件不存在时为nil
+//   - error: 读取或解析失败时返回错误
+func readTemplate(path string) (*template, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	var tmpl template
+	if err := yaml.Unmarshal(data, &tmpl); err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+	return &tmpl, nil
+}
+
+// checkNewMessages: 检查文本是否缺少翻译
+// 英文是所有语言最终的回退，缺少英文原文总是错误；
+// 与上次的模板相比新增的文本必须在所有非地区语言中都有翻译，之前已缺少的翻译只在模板中列出
+// 参数:
+//   - tmpl: 本次生成的模板
+//   - previous: 上次生成的模板，为nil时（首次生成）不检查新增文本
+//
+// 返回值:
+//   - error: 有文本缺少翻译时返回错误，列出所有缺少的翻译
+func checkNewMessages(tmpl template, previous *template) error {
+	known := make(map[string]bool)
+	if previous != nil {
+		for _, message := range previous.Messages {
+			known[message.ID] = true
+		}
+	}
+
+	var problems []string
+	for _, message := range tmpl.Messages {
+		if message.Source == "" {
+			problems = append(problems, fmt.Sprintf("%s: missing in %s", message.ID, lang.EN))
+		}
+		if previous != nil && !known[message.ID] && len(message.Missing) > 0 {
+			problems = append(problems, fmt.Sprintf("%s: new message missing in %s", message.ID, strings.Join(message.Missing, ", ")))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("untranslated messages:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// encodeTemplate: 将模板编码为YAML，开头加上说明
+func encodeTemplate(tmpl template) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(templateHeader)
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(tmpl); err != nil {
+		return nil, fmt.Errorf("failed to encode template: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
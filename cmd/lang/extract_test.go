@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScanSourceHardcoded(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{name: "direct literal", body: `display.ShowError("literal text")`, want: []string{"literal text"}},
+		{name: "fmt print", body: `fmt.Printf("literal %s\n", x)`, want: []string{"literal %s\n"}},
+		{name: "wrapped in Sprintf", body: `display.ShowError(fmt.Sprintf("literal %s", x))`, want: []string{"literal %s"}},
+		{name: "wrapped in Errorf", body: `display.ShowError(fmt.Errorf("literal %s", x))`, want: []string{"literal %s"}},
+		{name: "nested Sprintf", body: `fmt.Println(fmt.Sprintf("%s", fmt.Sprint("literal text")))`, want: []string{"literal text"}},
+		{name: "Sprintf not passed to output", body: `_ = fmt.Sprintf("literal %s", x)`},
+		{name: "other nested call", body: `display.ShowError(strings.ToUpper("literal text"))`},
+		{name: "no words", body: `fmt.Printf("%s: %d\n", x, 1)`},
+		{name: "text resource", body: `display.ShowError(text.ErrorPrefix)`},
+	}
+	for _, tt := range tests {
+		root := t.TempDir()
+		if err := os.MkdirAll(filepath.Join(root, "internal", "lang"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		source := "package main\n\nfunc run() {\n\t" + tt.body + "\n}\n"
+		if err := os.WriteFile(filepath.Join(root, "main.go"), []byte(source), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, hardcoded, err := scanSource(root)
+		if err != nil {
+			t.Fatalf("%s: scanSource returned error: %v", tt.name, err)
+		}
+		var got []string
+		for _, entry := range hardcoded {
+			got = append(got, entry.Text)
+			if entry.Location != "main.go:4" {
+				t.Errorf("%s: location = %s, want main.go:4", tt.name, entry.Location)
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: hardcoded = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// lang: 语言包的开发工具，面向开发者和翻译人员，不随程序发布
+//
+// 用法:
+//
+//	go run ./cmd/lang extract [-root .] [-o internal/lang/messages.yaml] [-check]
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// commands: 所有可用的命令，键为命令名
+var commands = map[string]func(args []string) error{
+	"extract": runExtract,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	command, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+	if err := command(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// usage: 打印命令用法
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: go run ./cmd/lang extract [-root dir] [-o file] [-check]")
+}
@@ -0,0 +1,153 @@
+// api包，提供本地HTTP API和gRPC服务，供图形界面、Electron前端和管理脚本调用本工具的功能
+// 业务逻辑由调用方以函数的形式传入，本包只负责路由、令牌认证和编码
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yuaotian/go-cursor-help/internal/report"
+)
+
+// 停止服务时等待进行中的请求完成的最长时间
+const shutdownTimeout = 5 * time.Second
+
+// ErrBadRequest 表示请求参数无效，处理函数返回包装了它的错误时HTTP响应400，gRPC返回InvalidArgument
+var ErrBadRequest = errors.New("bad request")
+
+// ErrBusy 表示已有修改、备份或恢复在进行，HTTP响应409，gRPC返回Aborted
+var ErrBusy = errors.New("another operation is in progress")
+
+// Options 服务的配置和各操作的处理函数
+type Options struct {
+	// 访问令牌，HTTP请求需要带有Authorization: Bearer <令牌>头，gRPC请求在元数据中带有同样的值
+	Token string
+	// 返回当前状态
+	Status func(ctx context.Context) (*Status, error)
+	// 执行一次重置流程，各步骤的进展和标识符的变化报告给reporter
+	Modify func(ctx context.Context, reporter report.Reporter) error
+	// 立即备份storage.json，返回备份文件路径
+	Backup func(ctx context.Context) (string, error)
+	// 从备份恢复storage.json
+	Restore func(ctx context.Context, request RestoreRequest) (*RestoreResult, error)
+	// 列出可以恢复的备份，最新的在前
+	History func(ctx context.Context) ([]BackupInfo, error)
+}
+
+// Status 当前状态
+type Status struct {
+	// storage.json的路径
+	ConfigPath string `json:"config_path"`
+	// storage.json是否存在
+	Exists bool `json:"exists"`
+	// 当前的标识符，键为字段名
+	IDs map[string]string `json:"ids,omitempty"`
+	// 写保护级别
+	Protection string `json:"protection"`
+	// 运行中的Cursor进程数
+	Processes int `json:"processes"`
+}
+
+// BackupInfo 一个备份文件
+type BackupInfo struct {
+	// 备份文件路径，可用于恢复
+	Path string `json:"path"`
+	// 备份的修改时间
+	Time time.Time `json:"time"`
+	// 文件大小（字节）
+	Size int64 `json:"size"`
+}
+
+// RestoreRequest 恢复请求
+type RestoreRequest struct {
+	// 要恢复的备份，可以是完整路径或文件名
+	Backup string `json:"backup"`
+	// 备份未通过校验时是否仍然恢复
+	Force bool `json:"force,omitempty"`
+}
+
+// RestoreResult 恢复的结果
+type RestoreResult struct {
+	// 恢复的备份文件
+	Backup string `json:"backup"`
+	// 恢复前后storage.json顶层键的变化，为空表示备份与当前配置相同，未做修改
+	Changes []KeyChange `json:"changes"`
+}
+
+// KeyChange storage.json中一个顶层键的变化，值为JSON
+type KeyChange struct {
+	Key    string `json:"key"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// Service 串行执行各操作，HTTP API和gRPC服务共用同一个Service
+type Service struct {
+	opts Options
+	// 各操作读写同一个storage.json，同一时间只执行一个
+	mu sync.Mutex
+	// 重置流程的事件，转发给WatchEvents的订阅者
+	events *broadcaster
+}
+
+// NewService 创建Service
+func NewService(opts Options) *Service {
+	return &Service{opts: opts, events: newBroadcaster()}
+}
+
+// validToken 检查Authorization头或元数据中的令牌
+// 比较使用常数时间，避免通过响应时间猜测令牌
+func (s *Service) validToken(authorization string) bool {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.opts.Token)) == 1
+}
+
+// status 返回当前状态，等待进行中的操作完成
+func (s *Service) status(ctx context.Context) (*Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.opts.Status(ctx)
+}
+
+// history 列出备份，等待进行中的操作完成
+func (s *Service) history(ctx context.Context) ([]BackupInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.opts.History(ctx)
+}
+
+// modify 执行重置流程，进展同时报告给reporter和WatchEvents的订阅者
+// 已有操作在进行时返回ErrBusy，不排队等待；调用方断开后仍完成流程，避免storage.json停留在修改一半的状态
+func (s *Service) modify(ctx context.Context, reporter report.Reporter) error {
+	if !s.mu.TryLock() {
+		return ErrBusy
+	}
+	defer s.mu.Unlock()
+	return s.opts.Modify(context.WithoutCancel(ctx), report.Multi(reporter, newEventReporter(s.events.publish)))
+}
+
+// backup 立即备份storage.json，已有操作在进行时返回ErrBusy
+func (s *Service) backup(ctx context.Context) (string, error) {
+	if !s.mu.TryLock() {
+		return "", ErrBusy
+	}
+	defer s.mu.Unlock()
+	return s.opts.Backup(ctx)
+}
+
+// restore 从备份恢复storage.json，已有操作在进行时返回ErrBusy；与modify一样不随调用方断开而中止
+func (s *Service) restore(ctx context.Context, request RestoreRequest) (*RestoreResult, error) {
+	if request.Backup == "" {
+		return nil, fmt.Errorf("%w: backup is required", ErrBadRequest)
+	}
+	if !s.mu.TryLock() {
+		return nil, ErrBusy
+	}
+	defer s.mu.Unlock()
+	return s.opts.Restore(context.WithoutCancel(ctx), request)
+}
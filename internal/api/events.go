@@ -0,0 +1,113 @@
+package api
+
+import (
+	"sync"
+	"time"
+
+	"github.com/yuaotian/go-cursor-help/internal/report"
+)
+
+// 事件类型，与-events写出的JSON事件一致
+const (
+	EventStepStarted   = "step_started"
+	EventStepProgress  = "step_progress"
+	EventStepSucceeded = "step_succeeded"
+	EventStepFailed    = "step_failed"
+	EventSummary       = "summary"
+)
+
+// 每个订阅者最多缓存的事件数，订阅者读取过慢时丢弃新事件，不阻塞重置流程
+const subscriberBuffer = 64
+
+// Event 重置流程中的一个事件
+type Event struct {
+	// 事件类型
+	Type string
+	// 事件发生的时间
+	Time time.Time
+	// 步骤序号（从1开始）和标题，summary事件中为空
+	Step  int
+	Title string
+	// 进度说明
+	Message string
+	// 失败原因
+	Error string
+	// 各标识符修改前后的值，仅用于summary事件
+	Changes []report.Change
+}
+
+// eventReporter 将report.Reporter的调用转换为Event，交给emit处理
+type eventReporter struct {
+	emit func(Event)
+	// 当前步骤的序号和标题
+	step  int
+	title string
+}
+
+// newEventReporter 创建将事件交给emit的Reporter
+func newEventReporter(emit func(Event)) *eventReporter {
+	return &eventReporter{emit: emit}
+}
+
+// StepStarted 实现report.Reporter
+func (r *eventReporter) StepStarted(title string) {
+	r.step++
+	r.title = title
+	r.emit(Event{Type: EventStepStarted, Time: time.Now(), Step: r.step, Title: title})
+}
+
+// StepProgress 实现report.Reporter
+func (r *eventReporter) StepProgress(message string) {
+	r.emit(Event{Type: EventStepProgress, Time: time.Now(), Step: r.step, Title: r.title, Message: message})
+}
+
+// StepSucceeded 实现report.Reporter
+func (r *eventReporter) StepSucceeded() {
+	r.emit(Event{Type: EventStepSucceeded, Time: time.Now(), Step: r.step, Title: r.title})
+}
+
+// StepFailed 实现report.Reporter
+func (r *eventReporter) StepFailed(err error) {
+	r.emit(Event{Type: EventStepFailed, Time: time.Now(), Step: r.step, Title: r.title, Error: err.Error()})
+}
+
+// Summary 实现report.Reporter
+func (r *eventReporter) Summary(changes []report.Change) {
+	r.emit(Event{Type: EventSummary, Time: time.Now(), Changes: changes})
+}
+
+// broadcaster 将事件转发给所有订阅者
+type broadcaster struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// newBroadcaster 创建没有订阅者的broadcaster
+func newBroadcaster() *broadcaster {
+	return &broadcaster{subscribers: make(map[chan Event]struct{})}
+}
+
+// subscribe 添加订阅者，返回接收事件的通道和取消订阅的函数
+func (b *broadcaster) subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch, func() {
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		b.mu.Unlock()
+	}
+}
+
+// publish 将事件发给所有订阅者，缓存已满的订阅者收不到该事件
+func (b *broadcaster) publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/yuaotian/go-cursor-help/internal/report"
+	pb "github.com/yuaotian/go-cursor-help/pkg/rpc/cursoridv1"
+)
+
+// eventTypes Event.Type与protobuf枚举的对应关系
+var eventTypes = map[string]pb.EventType{
+	EventStepStarted:   pb.EventType_EVENT_TYPE_STEP_STARTED,
+	EventStepProgress:  pb.EventType_EVENT_TYPE_STEP_PROGRESS,
+	EventStepSucceeded: pb.EventType_EVENT_TYPE_STEP_SUCCEEDED,
+	EventStepFailed:    pb.EventType_EVENT_TYPE_STEP_FAILED,
+	EventSummary:       pb.EventType_EVENT_TYPE_SUMMARY,
+}
+
+// GRPCServer 返回提供CursorID服务的gRPC服务器，所有方法都需要令牌认证
+func (s *Service) GRPCServer() *grpc.Server {
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := s.authorize(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := s.authorize(stream.Context()); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	)
+	pb.RegisterCursorIDServer(server, &grpcService{service: s})
+	return server
+}
+
+// ServeGRPC 在listener上提供gRPC服务，直到ctx被取消
+// 取消后等待进行中的调用完成，最长shutdownTimeout，之后强制关闭（WatchEvents等流不会自行结束）
+func ServeGRPC(ctx context.Context, listener net.Listener, server *grpc.Server) error {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		timer := time.AfterFunc(shutdownTimeout, server.Stop)
+		defer timer.Stop()
+		server.GracefulStop()
+	}()
+
+	if err := server.Serve(listener); err != nil {
+		return fmt.Errorf("failed to serve gRPC: %w", err)
+	}
+	<-done
+	return nil
+}
+
+// authorize 检查元数据中的authorization令牌
+func (s *Service) authorize(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if s.validToken(value) {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid token")
+}
+
+// grpcService 实现pb.CursorIDServer，将调用转换为Service的操作
+type grpcService struct {
+	pb.UnimplementedCursorIDServer
+	service *Service
+}
+
+// Status 实现pb.CursorIDServer
+func (g *grpcService) Status(ctx context.Context, _ *pb.StatusRequest) (*pb.StatusResponse, error) {
+	current, err := g.service.status(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.StatusResponse{
+		ConfigPath: current.ConfigPath,
+		Exists:     current.Exists,
+		Ids:        current.IDs,
+		Protection: current.Protection,
+		Processes:  int32(current.Processes),
+	}, nil
+}
+
+// Modify 实现pb.CursorIDServer，每个事件发送给调用方后再执行下一步
+func (g *grpcService) Modify(_ *pb.ModifyRequest, stream pb.CursorID_ModifyServer) error {
+	reporter := newEventReporter(func(event Event) {
+		// 调用方断开后发送失败，流程仍继续完成
+		stream.Send(protoEvent(event))
+	})
+	return grpcError(g.service.modify(stream.Context(), reporter))
+}
+
+// Backup 实现pb.CursorIDServer
+func (g *grpcService) Backup(ctx context.Context, _ *pb.BackupRequest) (*pb.BackupResponse, error) {
+	path, err := g.service.backup(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.BackupResponse{Path: path}, nil
+}
+
+// ListBackups 实现pb.CursorIDServer
+func (g *grpcService) ListBackups(ctx context.Context, _ *pb.ListBackupsRequest) (*pb.ListBackupsResponse, error) {
+	backups, err := g.service.history(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	response := &pb.ListBackupsResponse{Backups: make([]*pb.BackupInfo, len(backups))}
+	for i, backup := range backups {
+		response.Backups[i] = &pb.BackupInfo{Path: backup.Path, Time: timestamppb.New(backup.Time), Size: backup.Size}
+	}
+	return response, nil
+}
+
+// Restore 实现pb.CursorIDServer
+func (g *grpcService) Restore(ctx context.Context, request *pb.RestoreRequest) (*pb.RestoreResponse, error) {
+	result, err := g.service.restore(ctx, RestoreRequest{Backup: request.GetBackup(), Force: request.GetForce()})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	response := &pb.RestoreResponse{Backup: result.Backup, Changes: make([]*pb.KeyChange, len(result.Changes))}
+	for i, change := range result.Changes {
+		response.Changes[i] = &pb.KeyChange{Key: change.Key, Before: change.Before, After: change.After}
+	}
+	return response, nil
+}
+
+// WatchEvents 实现pb.CursorIDServer，转发事件直到调用方取消或服务停止
+func (g *grpcService) WatchEvents(_ *pb.WatchEventsRequest, stream pb.CursorID_WatchEventsServer) error {
+	events, cancel := g.service.events.subscribe()
+	defer cancel()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			if err := stream.Send(protoEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// protoEvent 将Event转换为protobuf消息
+func protoEvent(event Event) *pb.Event {
+	message := &pb.Event{
+		Type:    eventTypes[event.Type],
+		Time:    timestamppb.New(event.Time),
+		Step:    int32(event.Step),
+		Title:   event.Title,
+		Message: event.Message,
+		Error:   event.Error,
+	}
+	for _, change := range event.Changes {
+		message.Changes = append(message.Changes, protoChange(change))
+	}
+	return message
+}
+
+// protoChange 将标识符的变化转换为protobuf消息
+func protoChange(change report.Change) *pb.Change {
+	return &pb.Change{Field: change.Field, Old: change.Old, New: change.New, File: change.File}
+}
+
+// grpcError 将错误转换为带状态码的gRPC错误
+func grpcError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrBadRequest):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrBusy):
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,181 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/yuaotian/go-cursor-help/internal/report"
+)
+
+// 请求体的最大长度
+const maxRequestBody = 1 << 20
+
+// ModifyResponse POST /modify的响应
+type ModifyResponse struct {
+	// 各步骤的结果
+	Steps []Step `json:"steps"`
+	// 各标识符修改前后的值，流程失败时为空
+	Changes []report.Change `json:"changes,omitempty"`
+	// 失败原因
+	Error string `json:"error,omitempty"`
+}
+
+// Step 重置流程中一个步骤的结果
+type Step struct {
+	// 步骤标题
+	Title string `json:"title"`
+	// 步骤状态：running、succeeded或failed
+	Status string `json:"status"`
+	// 步骤执行过程中的进度说明
+	Messages []string `json:"messages,omitempty"`
+	// 失败原因
+	Error string `json:"error,omitempty"`
+}
+
+// Handler 返回处理HTTP API请求的http.Handler，所有接口都需要令牌认证
+// 接口：GET /status、POST /modify、POST /backup、POST /restore、GET /history
+func (s *Service) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", method(http.MethodGet, s.handleStatus))
+	mux.HandleFunc("/modify", method(http.MethodPost, s.handleModify))
+	mux.HandleFunc("/backup", method(http.MethodPost, s.handleBackup))
+	mux.HandleFunc("/restore", method(http.MethodPost, s.handleRestore))
+	mux.HandleFunc("/history", method(http.MethodGet, s.handleHistory))
+	return s.authenticate(mux)
+}
+
+// Serve 在listener上提供HTTP API，直到ctx被取消
+// 取消后等待进行中的请求完成，最长shutdownTimeout
+func Serve(ctx context.Context, listener net.Listener, handler http.Handler) error {
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	err := srv.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		<-done
+		return nil
+	}
+	return fmt.Errorf("failed to serve API: %w", err)
+}
+
+// authenticate 检查请求的令牌，令牌不匹配时响应401
+func (s *Service) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.validToken(r.Header.Get("Authorization")) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// method 限制接口只接受指定的HTTP方法
+func method(method string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s requires %s", r.URL.Path, method))
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// handleStatus GET /status
+func (s *Service) handleStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := s.status(r.Context())
+	if err != nil {
+		writeError(w, statusCode(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// handleModify POST /modify
+func (s *Service) handleModify(w http.ResponseWriter, r *http.Request) {
+	recorder := &stepRecorder{}
+	err := s.modify(r.Context(), recorder)
+	response := recorder.response()
+	if err != nil {
+		response.Error = err.Error()
+		writeJSON(w, statusCode(err), response)
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleBackup POST /backup
+func (s *Service) handleBackup(w http.ResponseWriter, r *http.Request) {
+	path, err := s.backup(r.Context())
+	if err != nil {
+		writeError(w, statusCode(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"path": path})
+}
+
+// handleRestore POST /restore
+func (s *Service) handleRestore(w http.ResponseWriter, r *http.Request) {
+	var request RestoreRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBody))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	result, err := s.restore(r.Context(), request)
+	if err != nil {
+		writeError(w, statusCode(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleHistory GET /history
+func (s *Service) handleHistory(w http.ResponseWriter, r *http.Request) {
+	history, err := s.history(r.Context())
+	if err != nil {
+		writeError(w, statusCode(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, history)
+}
+
+// statusCode 返回错误对应的HTTP状态码
+func statusCode(err error) int {
+	switch {
+	case errors.Is(err, ErrBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrBusy):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// writeJSON 将value编码为JSON写入响应
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(value)
+}
+
+// writeError 将错误写为{"error": "..."}
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,59 @@
+package api
+
+import "github.com/yuaotian/go-cursor-help/internal/report"
+
+// stepRecorder 记录重置流程的进展，流程结束后作为POST /modify的响应返回
+// 处理函数在请求的goroutine中同步执行，不需要加锁
+type stepRecorder struct {
+	steps   []Step
+	changes []report.Change
+}
+
+// StepStarted 实现report.Reporter
+func (r *stepRecorder) StepStarted(title string) {
+	r.steps = append(r.steps, Step{Title: title, Status: "running"})
+}
+
+// StepProgress 实现report.Reporter
+func (r *stepRecorder) StepProgress(message string) {
+	if step := r.current(); step != nil {
+		step.Messages = append(step.Messages, message)
+	}
+}
+
+// StepSucceeded 实现report.Reporter
+func (r *stepRecorder) StepSucceeded() {
+	if step := r.current(); step != nil {
+		step.Status = "succeeded"
+	}
+}
+
+// StepFailed 实现report.Reporter
+func (r *stepRecorder) StepFailed(err error) {
+	if step := r.current(); step != nil {
+		step.Status = "failed"
+		step.Error = err.Error()
+	}
+}
+
+// Summary 实现report.Reporter
+func (r *stepRecorder) Summary(changes []report.Change) {
+	r.changes = changes
+}
+
+// current 返回最后开始的步骤，还没有步骤时返回nil
+func (r *stepRecorder) current() *Step {
+	if len(r.steps) == 0 {
+		return nil
+	}
+	return &r.steps[len(r.steps)-1]
+}
+
+// response 返回记录的结果
+func (r *stepRecorder) response() ModifyResponse {
+	steps := r.steps
+	if steps == nil {
+		steps = []Step{}
+	}
+	return ModifyResponse{Steps: steps, Changes: r.changes}
+}
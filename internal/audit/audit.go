@@ -0,0 +1,261 @@
+// audit包，以JSON行追加写入操作审计日志
+// 每条记录包含上一条记录的哈希，修改、插入或删除中间的记录都会使校验失败
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/yuaotian/go-cursor-help/internal/settings"
+)
+
+// 操作的结果
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// 查找最后一条记录时从文件末尾读取的最大字节数，单条记录不会超过该长度
+const tailSize = 64 << 10
+
+// hashSuffix 每行末尾的哈希成员，哈希为该成员之前内容（补上右花括号）的SHA-256
+var hashSuffix = regexp.MustCompile(`,"hash":"([0-9a-f]{64})"}$`)
+
+// Entry 审计日志中的一条记录
+type Entry struct {
+	// 序号，从1开始连续递增
+	Seq int `json:"seq"`
+	// 记录时间（UTC）
+	Time time.Time `json:"time"`
+	// 执行操作的用户
+	User string `json:"user"`
+	// 执行操作的计算机
+	Host string `json:"host"`
+	// 操作名称，例如reset、restore
+	Action string `json:"action"`
+	// 操作涉及的文件或主机
+	Targets []string `json:"targets,omitempty"`
+	// 恢复操作使用的备份或归档
+	Source string `json:"source,omitempty"`
+	// 标识符的变化，值为哈希
+	Changes []Change `json:"changes,omitempty"`
+	// 操作前创建的备份
+	Backups []string `json:"backups,omitempty"`
+	// 结果：success或failure
+	Outcome string `json:"outcome"`
+	// 失败原因
+	Error string `json:"error,omitempty"`
+	// 上一条记录的哈希，第一条记录为空
+	Prev string `json:"prev"`
+}
+
+// Change 一个标识符的变化，只记录值的哈希，审计日志不会泄露标识符本身
+type Change struct {
+	// storage.json中的键名
+	Key string `json:"key"`
+	// 修改前后的值的哈希，值为空时为空
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+// HashValue 返回记录到审计日志中的值的哈希，空值返回空字符串
+func HashValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(value))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// ChainError 表示审计日志的哈希链在某一行断开，日志被修改或损坏
+type ChainError struct {
+	// 出错的行号，从1开始
+	Line int
+	// 原因
+	Reason string
+}
+
+// Error 实现error接口
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("audit log line %d: %s", e.Line, e.Reason)
+}
+
+// Log 一个审计日志文件
+type Log struct {
+	path string
+}
+
+// Open 返回写入指定文件的审计日志，文件在第一次写入时创建
+func Open(path string) *Log {
+	return &Log{path: path}
+}
+
+// Path 返回审计日志文件的路径
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append 追加一条记录，序号、上一条记录的哈希和时间由本函数填写
+// 写入期间锁定文件，多个进程同时写入时记录不会交错
+// 返回值:
+//   - Entry: 实际写入的记录
+//   - error: 文件无法写入，或最后一条记录已损坏无法接续时返回错误
+func (l *Log) Append(entry Entry) (Entry, error) {
+	// 通过sudo运行时目录和文件归还给原始用户，之后以普通用户运行时仍可追加
+	if err := settings.MkdirAllOwned(filepath.Dir(l.path), 0700); err != nil {
+		return entry, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return entry, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+	if err := settings.RestoreOwnership(l.path); err != nil {
+		return entry, err
+	}
+	if err := lock(file); err != nil {
+		return entry, fmt.Errorf("failed to lock audit log: %w", err)
+	}
+	defer unlock(file)
+
+	last, hash, err := lastEntry(file)
+	if err != nil {
+		return entry, err
+	}
+	entry.Seq = last.Seq + 1
+	entry.Prev = hash
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	entry.Time = entry.Time.UTC()
+
+	line, err := encodeLine(entry)
+	if err != nil {
+		return entry, err
+	}
+	if _, err := file.Write(line); err != nil {
+		return entry, fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return entry, nil
+}
+
+// encodeLine 将记录编码为带哈希的一行
+func encodeLine(entry Entry) ([]byte, error) {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	line := append(body[:len(body)-1:len(body)-1], `,"hash":"`...)
+	line = append(line, hex.EncodeToString(sum[:])...)
+	return append(line, "\"}\n"...), nil
+}
+
+// decodeLine 解析一行并校验其哈希
+func decodeLine(line []byte) (Entry, string, error) {
+	var entry Entry
+	match := hashSuffix.FindSubmatchIndex(line)
+	if match == nil {
+		return entry, "", errors.New("missing or malformed hash")
+	}
+	hash := string(line[match[2]:match[3]])
+	body := append(line[:match[0]:match[0]], '}')
+	sum := sha256.Sum256(body)
+	if hex.EncodeToString(sum[:]) != hash {
+		return entry, "", errors.New("hash does not match the entry, it was modified")
+	}
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return entry, "", fmt.Errorf("invalid entry: %w", err)
+	}
+	return entry, hash, nil
+}
+
+// lastEntry 读取文件中的最后一条记录，文件为空时返回零值
+func lastEntry(file *os.File) (Entry, string, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return Entry{}, "", fmt.Errorf("failed to read audit log: %w", err)
+	}
+	if info.Size() == 0 {
+		return Entry{}, "", nil
+	}
+	offset := max(info.Size()-tailSize, 0)
+	tail := make([]byte, info.Size()-offset)
+	if _, err := file.ReadAt(tail, offset); err != nil && err != io.EOF {
+		return Entry{}, "", fmt.Errorf("failed to read audit log: %w", err)
+	}
+	if !bytes.HasSuffix(tail, []byte("\n")) {
+		return Entry{}, "", fmt.Errorf("audit log %s ends with an incomplete entry, run audit verify", file.Name())
+	}
+	tail = tail[:len(tail)-1]
+	line := tail[bytes.LastIndexByte(tail, '\n')+1:]
+	entry, hash, err := decodeLine(line)
+	if err != nil {
+		return Entry{}, "", fmt.Errorf("the last entry of audit log %s is damaged (%v), run audit verify", file.Name(), err)
+	}
+	return entry, hash, nil
+}
+
+// VerifyResult 校验的结果
+type VerifyResult struct {
+	// 记录数
+	Entries int
+	// 最后一条记录的哈希，可与另外保存的值比较，发现末尾的记录被删除
+	Head string
+	// 最后一条记录的时间
+	Last time.Time
+}
+
+// Verify 逐行校验审计日志的哈希链
+// 返回值:
+//   - *VerifyResult: 记录数和最后一条记录的哈希，文件不存在时记录数为0
+//   - error: 文件无法读取时返回错误；哈希链断开时返回*ChainError
+func Verify(path string) (*VerifyResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &VerifyResult{}, nil
+		}
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	result := &VerifyResult{}
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err == io.EOF && len(data) == 0 {
+			return result, nil
+		}
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read audit log: %w", err)
+		}
+		if !bytes.HasSuffix(data, []byte("\n")) {
+			return result, &ChainError{Line: line, Reason: "incomplete entry at the end of the file"}
+		}
+
+		entry, hash, err := decodeLine(data[:len(data)-1])
+		if err != nil {
+			return result, &ChainError{Line: line, Reason: err.Error()}
+		}
+		if entry.Seq != result.Entries+1 {
+			return result, &ChainError{Line: line, Reason: fmt.Sprintf("sequence number %d, expected %d; entries were removed or inserted", entry.Seq, result.Entries+1)}
+		}
+		if entry.Prev != result.Head {
+			return result, &ChainError{Line: line, Reason: "previous hash does not match the preceding entry"}
+		}
+		result.Entries++
+		result.Head = hash
+		result.Last = entry.Time
+	}
+}
@@ -0,0 +1,140 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeLog 写入三条记录，返回日志路径和各行内容（包含换行符）
+func writeLog(t *testing.T) (string, [][]byte) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit.log")
+	log := Open(path)
+	for _, action := range []string{"reset", "restore", "guard"} {
+		if _, err := log.Append(Entry{Action: action, Targets: []string{"storage.json"}, Outcome: OutcomeSuccess}); err != nil {
+			t.Fatalf("Append(%s) returned error: %v", action, err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	return path, lines[:len(lines)-1]
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name      string
+		tamper    func(lines [][]byte) [][]byte
+		wantLine  int
+		wantCount int
+	}{
+		{
+			name:      "intact",
+			tamper:    func(lines [][]byte) [][]byte { return lines },
+			wantCount: 3,
+		},
+		{
+			name: "modified field",
+			tamper: func(lines [][]byte) [][]byte {
+				lines[1] = bytes.Replace(lines[1], []byte(`"restore"`), []byte(`"reset"`), 1)
+				return lines
+			},
+			wantLine:  2,
+			wantCount: 1,
+		},
+		{
+			name: "removed entry",
+			tamper: func(lines [][]byte) [][]byte {
+				return [][]byte{lines[0], lines[2]}
+			},
+			wantLine:  2,
+			wantCount: 1,
+		},
+		{
+			name: "removed first entry",
+			tamper: func(lines [][]byte) [][]byte {
+				return lines[1:]
+			},
+			wantLine: 1,
+		},
+		{
+			name: "reordered entries",
+			tamper: func(lines [][]byte) [][]byte {
+				return [][]byte{lines[0], lines[2], lines[1]}
+			},
+			wantLine:  2,
+			wantCount: 1,
+		},
+		{
+			name: "truncated last entry",
+			tamper: func(lines [][]byte) [][]byte {
+				lines[2] = lines[2][:len(lines[2])-10]
+				return lines
+			},
+			wantLine:  3,
+			wantCount: 2,
+		},
+	}
+	for _, tt := range tests {
+		path, lines := writeLog(t)
+		if err := os.WriteFile(path, bytes.Join(tt.tamper(lines), nil), 0600); err != nil {
+			t.Fatal(err)
+		}
+		result, err := Verify(path)
+		if tt.wantLine == 0 {
+			if err != nil {
+				t.Errorf("%s: Verify returned error: %v", tt.name, err)
+				continue
+			}
+		} else {
+			var chainErr *ChainError
+			if !errors.As(err, &chainErr) {
+				t.Errorf("%s: Verify error = %v, want *ChainError", tt.name, err)
+				continue
+			}
+			if chainErr.Line != tt.wantLine {
+				t.Errorf("%s: chain broken at line %d, want %d", tt.name, chainErr.Line, tt.wantLine)
+			}
+		}
+		if result.Entries != tt.wantCount {
+			t.Errorf("%s: %d verified entries, want %d", tt.name, result.Entries, tt.wantCount)
+		}
+	}
+}
+
+func TestVerifyMissingFile(t *testing.T) {
+	result, err := Verify(filepath.Join(t.TempDir(), "missing.log"))
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if result.Entries != 0 || result.Head != "" {
+		t.Errorf("Verify = %+v, want an empty result", result)
+	}
+}
+
+func TestAppendContinuesChain(t *testing.T) {
+	path, _ := writeLog(t)
+	before, err := Verify(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := Open(path).Append(Entry{Action: "reset", Outcome: OutcomeFailure, Error: "denied"})
+	if err != nil {
+		t.Fatalf("Append returned error: %v", err)
+	}
+	if entry.Seq != 4 || entry.Prev != before.Head {
+		t.Errorf("appended entry seq=%d prev=%q, want seq=4 prev=%q", entry.Seq, entry.Prev, before.Head)
+	}
+	after, err := Verify(path)
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if after.Entries != 4 {
+		t.Errorf("%d verified entries, want 4", after.Entries)
+	}
+}
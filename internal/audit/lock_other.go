@@ -0,0 +1,13 @@
+//go:build !unix && !windows
+
+package audit
+
+import "os"
+
+// lock 不支持文件锁的系统上的空实现
+func lock(file *os.File) error {
+	return nil
+}
+
+// unlock 空实现
+func unlock(file *os.File) {}
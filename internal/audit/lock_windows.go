@@ -0,0 +1,25 @@
+package audit
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// 加锁的字节位置，位于文件末尾之外，不影响追加写入
+const (
+	lockOffsetHigh = 0x7fffffff
+	lockLength     = 1
+)
+
+// lock 获取审计日志的排他锁，其他进程持有锁时等待
+func lock(file *os.File) error {
+	overlapped := &windows.Overlapped{OffsetHigh: lockOffsetHigh}
+	return windows.LockFileEx(windows.Handle(file.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, lockLength, 0, overlapped)
+}
+
+// unlock 释放lock获取的锁
+func unlock(file *os.File) {
+	overlapped := &windows.Overlapped{OffsetHigh: lockOffsetHigh}
+	windows.UnlockFileEx(windows.Handle(file.Fd()), 0, lockLength, 0, overlapped)
+}
@@ -0,0 +1,249 @@
+package config
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// 备份集相关的常量
+const (
+	// 备份集归档文件名前缀
+	backupSetFilePrefix = "backup_set_"
+	// 归档中清单文件的名称
+	manifestFileName = "manifest.json"
+	// Cursor应用目录中保存机器ID的文件名
+	machineIDFile = "machineid"
+	// 备份的注册表项，包含Windows的MachineGuid
+	cryptographyRegistryKey = `HKEY_LOCAL_MACHINE\SOFTWARE\Microsoft\Cryptography`
+)
+
+// BackupManifest 描述备份集归档的内容
+type BackupManifest struct {
+	// 创建时间
+	CreatedAt time.Time `json:"createdAt"`
+	// 操作系统
+	OS string `json:"os"`
+	// 计算机名
+	Hostname string `json:"hostname,omitempty"`
+	// 目标编辑器的标识名
+	Editor string `json:"editor"`
+	// 归档中的文件
+	Files []ManifestFile `json:"files"`
+}
+
+// ManifestFile 描述备份集中的一个文件
+type ManifestFile struct {
+	// 归档中的文件名
+	Name string `json:"name"`
+	// 原始路径，注册表导出为注册表项路径
+	Source string `json:"source"`
+	// 文件大小（字节）
+	Size int64 `json:"size"`
+	// 内容的SHA-256
+	SHA256 string `json:"sha256"`
+}
+
+// backupSetItem 表示要加入备份集的一个来源
+type backupSetItem struct {
+	// 归档中的文件名
+	name string
+	// 要读取的文件路径
+	path string
+	// 写入清单的原始路径
+	source string
+}
+
+// machineIDPath 返回Cursor应用目录中machineid文件的路径，与User目录同级
+func (m *Manager) machineIDPath() string {
+	userDir := filepath.Dir(m.GlobalStorageDir())
+	return filepath.Join(filepath.Dir(userDir), machineIDFile)
+}
+
+// ExportBackupSet 将修改前的状态（storage.json、state.vscdb、machineid以及Windows上的注册表导出）
+// 连同清单打包为一个归档文件，便于保存或转移到其他机器
+// 归档格式由扩展名决定：.zip为zip，其他为tar.gz；archivePath为空时保存到备份目录
+func (m *Manager) ExportBackupSet(archivePath string) (string, *BackupManifest, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if archivePath == "" {
+		if err := m.mkdirAllOwned(m.BackupDir(), 0755); err != nil {
+			return "", nil, fmt.Errorf("failed to create backup directory: %w", err)
+		}
+		archivePath = filepath.Join(m.BackupDir(), backupSetFilePrefix+time.Now().Format(backupTimeFormat)+".tar.gz")
+	}
+
+	items := []backupSetItem{
+		{name: filepath.Base(m.configPath), path: m.configPath, source: m.configPath},
+		{name: stateDatabaseFile, path: m.StateDatabasePath(), source: m.StateDatabasePath()},
+		{name: machineIDFile, path: m.machineIDPath(), source: m.machineIDPath()},
+	}
+
+	// Windows上导出包含MachineGuid的注册表项
+	if runtime.GOOS == "windows" {
+		tmpDir, err := os.MkdirTemp("", "cursor-backup-set")
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		defer os.RemoveAll(tmpDir)
+
+		regPath := filepath.Join(tmpDir, "MachineGuid.reg")
+		if output, err := exec.Command("reg", "export", cryptographyRegistryKey, regPath, "/y").CombinedOutput(); err != nil {
+			return "", nil, fmt.Errorf("failed to export registry: %w: %s", err, strings.TrimSpace(string(output)))
+		}
+		items = append(items, backupSetItem{name: "MachineGuid.reg", path: regPath, source: cryptographyRegistryKey})
+	}
+
+	hostname, _ := os.Hostname()
+	manifest := &BackupManifest{
+		CreatedAt: time.Now().UTC(),
+		OS:        runtime.GOOS,
+		Hostname:  hostname,
+		Editor:    m.target.Name(),
+	}
+
+	file, err := os.OpenFile(archivePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create backup archive: %w", err)
+	}
+	defer file.Close()
+
+	writer := newArchiveWriter(file, archivePath)
+	writeErr := func() error {
+		for _, item := range items {
+			entry, err := addBackupSetItem(writer, item)
+			if err != nil {
+				return fmt.Errorf("failed to archive %s: %w", item.name, err)
+			}
+			if entry != nil {
+				manifest.Files = append(manifest.Files, *entry)
+			}
+		}
+
+		// 清单放在最后，其中包含前面各文件的校验和
+		content, err := json.MarshalIndent(manifest, "", "    ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal manifest: %w", err)
+		}
+		if err := writer.add(manifestFileName, int64(len(content)), manifest.CreatedAt, bytes.NewReader(content)); err != nil {
+			return fmt.Errorf("failed to archive manifest: %w", err)
+		}
+		return writer.close()
+	}()
+	if writeErr != nil {
+		os.Remove(archivePath)
+		return "", nil, writeErr
+	}
+
+	if err := m.restoreOwnership(archivePath); err != nil {
+		return "", nil, err
+	}
+	return archivePath, manifest, nil
+}
+
+// addBackupSetItem 将一个来源写入归档并计算校验和，来源不存在时跳过并返回nil
+func addBackupSetItem(writer archiveWriter, item backupSetItem) (*ManifestFile, error) {
+	file, err := os.Open(item.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	if err := writer.add(item.name, info.Size(), info.ModTime(), io.TeeReader(file, hash)); err != nil {
+		return nil, err
+	}
+	return &ManifestFile{
+		Name:   item.name,
+		Source: item.source,
+		Size:   info.Size(),
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// archiveWriter 统一tar.gz和zip两种归档格式的写入
+type archiveWriter interface {
+	// add 写入一个文件
+	add(name string, size int64, modTime time.Time, reader io.Reader) error
+	// close 完成归档
+	close() error
+}
+
+// newArchiveWriter 根据扩展名创建归档写入器
+func newArchiveWriter(w io.Writer, archivePath string) archiveWriter {
+	if strings.EqualFold(filepath.Ext(archivePath), ".zip") {
+		return &zipArchiveWriter{writer: zip.NewWriter(w)}
+	}
+	gzipWriter := gzip.NewWriter(w)
+	return &tarArchiveWriter{gzip: gzipWriter, writer: tar.NewWriter(gzipWriter)}
+}
+
+// tarArchiveWriter 写入tar.gz归档
+type tarArchiveWriter struct {
+	// gzip压缩层
+	gzip *gzip.Writer
+	// tar写入器
+	writer *tar.Writer
+}
+
+// add 写入一个文件
+func (w *tarArchiveWriter) add(name string, size int64, modTime time.Time, reader io.Reader) error {
+	header := &tar.Header{Name: name, Mode: 0600, Size: size, ModTime: modTime, Typeflag: tar.TypeReg}
+	if err := w.writer.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.CopyN(w.writer, reader, size)
+	return err
+}
+
+// close 完成归档
+func (w *tarArchiveWriter) close() error {
+	if err := w.writer.Close(); err != nil {
+		return err
+	}
+	return w.gzip.Close()
+}
+
+// zipArchiveWriter 写入zip归档
+type zipArchiveWriter struct {
+	// zip写入器
+	writer *zip.Writer
+}
+
+// add 写入一个文件
+func (w *zipArchiveWriter) add(name string, size int64, modTime time.Time, reader io.Reader) error {
+	header := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modTime}
+	header.SetMode(0600)
+	entry, err := w.writer.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.CopyN(entry, reader, size)
+	return err
+}
+
+// close 完成归档
+func (w *zipArchiveWriter) close() error {
+	return w.writer.Close()
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 备份相关的常量，与scripts/run下脚本的命名保持一致
+const (
+	// 备份目录名，位于globalStorage目录下
+	backupDirName = "backups"
+	// 备份文件名前缀
+	backupFilePrefix = "storage.json.backup_"
+	// 备份文件名中的时间格式，精确到纳秒，同一秒内的多次备份不会互相覆盖
+	// 前缀与scripts/run下脚本一致，字典序仍为时间顺序
+	backupTimeFormat = "20060102_150405.000000000"
+)
+
+// BackupOptions 备份选项
+type BackupOptions struct {
+	// 加密口令，为空时不加密
+	Passphrase string
+}
+
+// BackupDir 返回备份目录的路径
+func (m *Manager) BackupDir() string {
+	if m.backupDir != "" {
+		return m.backupDir
+	}
+	return filepath.Join(filepath.Dir(m.configPath), backupDirName)
+}
+
+// SetBackupDir 设置自定义的备份目录，为空时恢复默认位置
+func (m *Manager) SetBackupDir(dir string) {
+	m.backupDir = dir
+}
+
+// Backup 备份当前的storage.json，返回备份文件路径
+// 配置文件不存在时不做任何操作并返回空路径
+func (m *Manager) Backup(opts BackupOptions) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.backupLocked(opts)
+}
+
+// backupLocked 执行备份，调用者需持有锁
+func (m *Manager) backupLocked(opts BackupOptions) (string, error) {
+	data, err := os.ReadFile(m.configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	backupPath := filepath.Join(m.BackupDir(), backupFilePrefix+time.Now().Format(backupTimeFormat))
+	if opts.Passphrase != "" {
+		if data, err = encryptBackup(data, opts.Passphrase); err != nil {
+			return "", err
+		}
+		backupPath += encryptedBackupSuffix
+	}
+
+	if err := m.mkdirAllOwned(m.BackupDir(), 0755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	// 备份包含身份信息，仅允许所有者读写
+	if err := os.WriteFile(backupPath, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := m.restoreOwnership(backupPath); err != nil {
+		return "", err
+	}
+	return backupPath, nil
+}
+
+// ListBackups 返回备份目录中本工具创建的storage.json备份，最新的在前
+// 备份目录不存在时返回空列表
+func (m *Manager) ListBackups() ([]string, error) {
+	entries, err := os.ReadDir(m.BackupDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	var backups []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), backupFilePrefix) {
+			backups = append(backups, filepath.Join(m.BackupDir(), entry.Name()))
+		}
+	}
+	// 文件名中的时间戳按字典序即为时间顺序
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return backups, nil
+}
+
+// ReadBackupFile 读取备份文件的内容，加密备份会使用口令自动解密
+// 也接受用户提供的任意storage.json，带注释或尾随逗号的内容会被转换为严格的JSON
+func ReadBackupFile(backupPath string, passphrase string) ([]byte, error) {
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	if isEncryptedBackup(data) {
+		if data, err = decryptBackup(data, passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	if !json.Valid(data) {
+		if stripped := stripJSONC(data); json.Valid(stripped) {
+			return stripped, nil
+		}
+		return nil, fmt.Errorf("backup %s is not valid JSON", backupPath)
+	}
+	return data, nil
+}
+
+// RestoreBackup 从备份文件恢复storage.json，加密备份会使用口令自动解密
+func (m *Manager) RestoreBackup(backupPath string, passphrase string) error {
+	data, err := ReadBackupFile(backupPath, passphrase)
+	if err != nil {
+		return err
+	}
+	return m.RestoreContent(data)
+}
+
+// RestoreContent 用给定的内容替换storage.json，调用者负责校验内容
+func (m *Manager) RestoreContent(data []byte) error {
+	// 之前的运行可能施加了写保护，恢复前先移除
+	if err := m.Unprotect(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lock, err := m.lockTarget()
+	if err != nil {
+		return err
+	}
+	defer m.unlockTarget(lock)
+
+	if err := m.mkdirAllOwned(filepath.Dir(m.configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	return m.writeConfigFile(context.Background(), data, ProtectNone, false)
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackupsInSameSecondDoNotOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "storage.json")
+	if err := os.WriteFile(configPath, []byte(`{"telemetry.machineId": "first"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	manager, err := NewManagerForPath(configPath, DefaultTarget())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := manager.Backup(BackupOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte(`{"telemetry.machineId": "second"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	second, err := manager.Backup(BackupOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second {
+		t.Fatalf("both backups were written to %s", first)
+	}
+
+	backups, err := manager.ListBackups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 || backups[0] != second {
+		t.Fatalf("ListBackups() = %v, want the newest backup %s first", backups, second)
+	}
+	if data, _ := os.ReadFile(first); string(data) != `{"telemetry.machineId": "first"}` {
+		t.Errorf("first backup was overwritten: %s", data)
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// 文件不存在时使用的指纹
+const absentFingerprint = "absent"
+
+// ErrConcurrentModification 表示storage.json在读取之后被其他进程（例如Cursor或其更新程序）修改
+var ErrConcurrentModification = errors.New("storage.json was modified by another process during the run")
+
+// fingerprint 计算文件内容的SHA-256指纹
+func fingerprint(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// currentFingerprint 返回磁盘上配置文件当前的指纹
+func (m *Manager) currentFingerprint() (string, error) {
+	data, err := os.ReadFile(m.configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return absentFingerprint, nil
+		}
+		return "", fmt.Errorf("failed to read config file: %w", err)
+	}
+	return fingerprint(data), nil
+}
+
+// rememberFingerprint 记录读取（或写入）时配置文件的指纹
+func (m *Manager) rememberFingerprint(value string) {
+	m.readFingerprint.Store(&value)
+}
+
+// checkUnchanged 确认配置文件自上次读取后没有被其他进程修改
+// 尚未读取过配置文件时不做检查
+func (m *Manager) checkUnchanged() error {
+	expected := m.readFingerprint.Load()
+	if expected == nil {
+		return nil
+	}
+
+	current, err := m.currentFingerprint()
+	if err != nil {
+		return err
+	}
+	if current != *expected {
+		return ErrConcurrentModification
+	}
+	return nil
+}
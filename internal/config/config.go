@@ -2,12 +2,13 @@
 package config
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
-	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -33,20 +34,77 @@ type Manager struct {
 	configPath string
 	// 互斥锁，保证并发安全
 	mu         sync.RWMutex
+	// 写入后需要恢复的文件所有者（通过sudo运行时为原始用户）
+	owner *fileOwner
+	// 目标编辑器
+	target Target
+	// 自定义的备份目录，为空时使用globalStorage下的backups目录
+	backupDir string
+	// 工具的状态目录，保存守护快照
+	stateDir string
+	// 最近一次读取时配置文件的指纹，用于检测运行期间的并发修改
+	readFingerprint atomic.Pointer[string]
+	// 保证网络共享检测只执行一次
+	networkOnce sync.Once
+	// 配置文件是否位于网络共享上
+	network bool
+	// 是否拒绝写入通过符号链接指向数据目录之外的storage.json
+	refuseExternalLinks bool
+	// 读取-修改-写入期间持有的文件锁，由m.mu保护
+	activeLock *fileLock
 }
 
+// DefaultAppName 默认的应用程序名称，决定配置目录的位置
+const DefaultAppName = "Cursor"
+
 // NewManager 创建一个新的配置管理器
 func NewManager(username string) (*Manager, error) {
+	return NewManagerForTarget(username, DefaultTarget())
+}
+
+// NewManagerForApp 为指定名称的应用程序创建配置管理器
+// 用于与Cursor目录结构相同、但产品目录名称不同的衍生编辑器
+func NewManagerForApp(username, appName string) (*Manager, error) {
+	return NewManagerForTarget(username, AppTarget(appName))
+}
+
+// NewManagerForTarget 为指定的编辑器目标创建配置管理器
+func NewManagerForTarget(username string, target Target) (*Manager, error) {
 	// 获取配置文件路径
-	configPath, err := getConfigPath(username)
+	configPath, err := target.ConfigPath(username)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get config path: %w", err)
 	}
-	return &Manager{configPath: configPath}, nil
+	return &Manager{configPath: configPath, owner: sudoOwner(), target: target}, nil
+}
+
+// NewManagerForPath 为指定路径的storage.json创建配置管理器
+// 用于自动检测的路径不正确时，由用户从discover扫描结果中选择或手动指定
+func NewManagerForPath(configPath string, target Target) (*Manager, error) {
+	absPath, err := filepath.Abs(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve config path: %w", err)
+	}
+	return &Manager{configPath: absPath, owner: sudoOwner(), target: target}, nil
+}
+
+// Target 返回配置管理器对应的编辑器目标
+func (m *Manager) Target() Target {
+	return m.target
+}
+
+// ConfigPath 返回storage.json的路径
+func (m *Manager) ConfigPath() string {
+	return m.configPath
 }
 
 // ReadConfig 读取现有配置
-func (m *Manager) ReadConfig() (*StorageConfig, error) {
+// ctx被取消或超时时放弃读取并返回ctx.Err()
+func (m *Manager) ReadConfig(ctx context.Context) (*StorageConfig, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// 获取读锁
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -56,115 +114,254 @@ func (m *Manager) ReadConfig() (*StorageConfig, error) {
 	if err != nil {
 		// 如果文件不存在，返回nil
 		if os.IsNotExist(err) {
+			m.rememberFingerprint(absentFingerprint)
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
+	// 记录读取时的指纹，写入前据此检测文件是否被其他进程修改
+	m.rememberFingerprint(fingerprint(data))
 
-	// 解析JSON到配置结构体
+	return ParseStorageConfig(data)
+}
+
+// ParseStorageConfig 解析storage.json的内容，严格解析失败时按JSONC（注释、尾随逗号）宽松解析
+func ParseStorageConfig(data []byte) (*StorageConfig, error) {
 	var config StorageConfig
 	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+		if lenientErr := json.Unmarshal(stripJSONC(data), &config); lenientErr != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
 	}
-
 	return &config, nil
 }
 
-// SaveConfig 保存配置
-func (m *Manager) SaveConfig(config *StorageConfig, readOnly bool) error {
+// SaveResult 描述一次保存操作的结果
+type SaveResult struct {
+	// 实际写入的storage.json路径
+	Path string
+	// 写入前创建的备份路径，未备份时为空
+	BackupPath string
+	// 写入的字节数
+	BytesWritten int
+	// 施加的写保护级别
+	Protection ProtectionLevel
+	// 写入后的文件权限
+	FileMode os.FileMode
+	// 实际写入文件的遥测值（fill-missing策略下可能保留部分原值）
+	Written *StorageConfig
+	// 写入前的文件内容，文件不存在时为nil
+	Before []byte
+	// 写入的文件内容
+	After []byte
+}
+
+// SaveConfig 保存配置，并在写入后施加指定级别的写保护
+func (m *Manager) SaveConfig(ctx context.Context, config *StorageConfig, protection ProtectionLevel) (*SaveResult, error) {
+	return m.SaveConfigWithOptions(ctx, config, SaveOptions{Protection: protection})
+}
+
+// SaveConfigWithOptions 按照指定的合并策略保存配置
+// ctx在重命名之前被取消时不会修改storage.json，之后的取消只会中断写保护和校验
+func (m *Manager) SaveConfigWithOptions(ctx context.Context, config *StorageConfig, opts SaveOptions) (*SaveResult, error) {
+	if opts.Strategy == "" {
+		opts.Strategy = StrategyOverwrite
+	}
+	protection := opts.Protection
+
 	// 获取写锁
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	// 等待锁期间可能已被取消
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// 之前的运行可能施加了写保护，此时直接写入会以难以理解的方式失败
+	if level, err := m.DetectProtection(); err != nil {
+		return nil, err
+	} else if level != ProtectNone {
+		return nil, &WriteProtectedError{Path: m.configPath, Level: level}
+	}
+
+	// 锁定storage.json，避免与Cursor的后台进程同时读写
+	lock, err := m.lockTarget()
+	if err != nil {
+		return nil, err
+	}
+	defer m.unlockTarget(lock)
+
+	result := &SaveResult{Path: m.configPath, Protection: protection}
+
+	// 按需在写入前备份现有配置
+	if opts.Backup != nil {
+		backupPath, err := m.backupLocked(*opts.Backup)
+		if err != nil {
+			return nil, err
+		}
+		result.BackupPath = backupPath
+	}
+
 	// 确保父目录存在
-	if err := os.MkdirAll(filepath.Dir(m.configPath), 0755); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
+	if err := m.mkdirAllOwned(filepath.Dir(m.configPath), 0755); err != nil {
+		return result, fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	// 准备更新后的配置
-	updatedConfig := m.prepareUpdatedConfig(config)
+	// 准备更新后的配置，written为实际写入文件的遥测值
+	before, content, written, err := m.prepareUpdatedConfig(config, opts)
+	if err != nil {
+		return result, fmt.Errorf("failed to prepare config: %w", err)
+	}
+	result.Written = written
+	result.Before = before
+	result.After = content
 
 	// 写入配置
-	if err := m.writeConfigFile(updatedConfig, readOnly); err != nil {
-		return err
+	if err := m.writeConfigFile(ctx, content, protection, true); err != nil {
+		return result, err
 	}
+	m.rememberFingerprint(fingerprint(content))
+	result.BytesWritten = len(content)
 
-	return nil
+	// 系统级保护需在重命名之后施加到最终文件上
+	if protection == ProtectStrong {
+		target, err := m.writeTarget()
+		if err != nil {
+			return result, err
+		}
+		if err := applyStrongProtection(ctx, target); err != nil {
+			return result, err
+		}
+	}
+
+	// 重新读取文件，确认写入确实生效
+	if err := m.verifyLocked(written, protection); err != nil {
+		return result, err
+	}
+	result.FileMode = protection.fileMode()
+
+	return result, nil
 }
 
 // prepareUpdatedConfig 合并现有配置与更新
-func (m *Manager) prepareUpdatedConfig(config *StorageConfig) map[string]interface{} {
-	// 读取现有配置
-	originalFile := make(map[string]interface{})
-	if data, err := os.ReadFile(m.configPath); err == nil {
-		json.Unmarshal(data, &originalFile)
+// 返回现有的文件内容、新的文件内容以及实际写入的遥测值
+func (m *Manager) prepareUpdatedConfig(config *StorageConfig, opts SaveOptions) ([]byte, []byte, *StorageConfig, error) {
+	existing, err := os.ReadFile(m.configPath)
+	if err != nil {
+		existing = nil
+	}
+	content, written, err := mergeStorageJSON(existing, m.target.TelemetryKeys(), config, opts)
+	return existing, content, written, err
+}
+
+// MergeStorageJSON 将遥测值合并到storage.json的内容中，用于不经Manager写入的文件（如通过SSH读取的远程配置）
+// existing为nil表示文件不存在；返回新的文件内容和实际写入的遥测值
+func MergeStorageJSON(existing []byte, target Target, config *StorageConfig, opts SaveOptions) ([]byte, *StorageConfig, error) {
+	if opts.Strategy == "" {
+		opts.Strategy = StrategyOverwrite
 	}
+	return mergeStorageJSON(existing, target.TelemetryKeys(), config, opts)
+}
 
-	// 更新字段
-	originalFile["telemetry.sqmId"] = config.TelemetrySqmId
-	originalFile["telemetry.macMachineId"] = config.TelemetryMacMachineId
-	originalFile["telemetry.machineId"] = config.TelemetryMachineId
-	originalFile["telemetry.devDeviceId"] = config.TelemetryDevDeviceId
-	originalFile["lastModified"] = time.Now().UTC().Format(time.RFC3339)
-	// originalFile["version"] = "1.0.1"
+// mergeStorageJSON 将遥测值合并到现有的storage.json内容中
+// 使用保序的JSON编辑器，只修改遥测相关的键和lastModified，
+// 其他键的顺序、数值精度和格式保持原样
+// existing为nil表示文件不存在
+func mergeStorageJSON(existing []byte, keys []string, config *StorageConfig, opts SaveOptions) ([]byte, *StorageConfig, error) {
+	// 文件不存在、无法解析或使用replace-file策略时从空对象开始
+	doc, err := parseJSONDocument([]byte("{}"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if existing != nil && opts.Strategy != StrategyReplaceFile {
+		// 手动编辑留下的注释和尾随逗号会被移除，始终写出严格的JSON
+		if parsed, err := parseJSONDocument(existing); err == nil {
+			doc = parsed
+		} else if parsed, err := parseJSONDocument(stripJSONC(existing)); err == nil {
+			doc = parsed
+		}
+	}
 
-	return originalFile
+	// 更新目标编辑器使用的遥测字段
+	written := *config
+	for _, key := range keys {
+		if opts.Strategy == StrategyFillMissing {
+			if value, ok := opts.keepExistingValue(key, doc.Get(key)); ok {
+				setTelemetryValue(&written, key, value)
+				continue
+			}
+		}
+		if err := doc.Set(key, telemetryValue(config, key)); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := doc.Set("lastModified", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return nil, nil, err
+	}
+
+	return doc.Bytes(), &written, nil
 }
 
 // writeConfigFile 处理配置文件的原子写入
-func (m *Manager) writeConfigFile(config map[string]interface{}, readOnly bool) error {
-	// 带缩进格式化JSON
-	content, err := json.MarshalIndent(config, "", "    ")
+// checkChanges为true时，在重命名前确认文件自读取后未被其他进程修改
+// ctx在重命名前被取消时删除临时文件并保持原文件不变
+func (m *Manager) writeConfigFile(ctx context.Context, content []byte, protection ProtectionLevel, checkChanges bool) error {
+	// storage.json是符号链接时写入其指向的真实文件，保留链接本身
+	target, err := m.writeTarget()
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		return err
 	}
 
-	// 写入临时文件
-	tmpPath := m.configPath + ".tmp"
-	if err := os.WriteFile(tmpPath, content, 0666); err != nil {
-		return fmt.Errorf("failed to write temporary file: %w", err)
+	// 网络共享上的重命名语义不可靠，改用复制加同步的策略
+	if m.onNetworkShare() {
+		return m.writeConfigFileCopy(ctx, content, protection, checkChanges)
 	}
 
-	// 设置最终权限
-	fileMode := os.FileMode(0666)
-	if readOnly {
-		fileMode = 0444
+	// 写入临时文件，与目标文件位于同一目录，保证重命名是原子的
+	tmpPath := target + ".tmp"
+	if err := os.WriteFile(tmpPath, content, 0666); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
 	}
 
 	// 设置文件权限
-	if err := os.Chmod(tmpPath, fileMode); err != nil {
+	if err := os.Chmod(tmpPath, protection.fileMode()); err != nil {
 		os.Remove(tmpPath)
 		return fmt.Errorf("failed to set temporary file permissions: %w", err)
 	}
 
+	// 通过sudo运行时，将文件归还给原始用户，避免Cursor之后无法写入
+	if err := m.restoreOwnership(tmpPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	// 重命名前检测并发修改，避免覆盖其他进程刚写入的数据
+	if checkChanges {
+		if err := m.checkUnchanged(); err != nil {
+			os.Remove(tmpPath)
+			return err
+		}
+	}
+
+	// 最后一次检查取消，重命名之后的修改无法撤销
+	if err := ctx.Err(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
 	// 原子重命名
-	if err := os.Rename(tmpPath, m.configPath); err != nil {
+	m.beforeReplace()
+	if err := os.Rename(tmpPath, target); err != nil {
 		os.Remove(tmpPath)
 		return fmt.Errorf("failed to rename file: %w", err)
 	}
 
 	// 同步目录
-	if dir, err := os.Open(filepath.Dir(m.configPath)); err == nil {
+	if dir, err := os.Open(filepath.Dir(target)); err == nil {
 		defer dir.Close()
 		dir.Sync()
 	}
 
 	return nil
 }
-
-// getConfigPath 返回配置文件的路径
-func getConfigPath(username string) (string, error) {
-	var configDir string
-	// 根据操作系统确定配置目录路径
-	switch runtime.GOOS {
-	case "windows":
-		configDir = filepath.Join(os.Getenv("APPDATA"), "Cursor", "User", "globalStorage")
-	case "darwin":
-		configDir = filepath.Join("/Users", username, "Library", "Application Support", "Cursor", "User", "globalStorage")
-	case "linux":
-		configDir = filepath.Join("/home", username, ".config", "Cursor", "User", "globalStorage")
-	default:
-		return "", fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
-	}
-	return filepath.Join(configDir, "storage.json"), nil
-}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// 加密备份的常量
+const (
+	// 加密备份文件头，用于识别加密格式
+	encryptedBackupMagic = "CIDMENC1"
+	// 加密备份的文件名后缀
+	encryptedBackupSuffix = ".enc"
+	// 密钥派生使用的盐长度
+	backupSaltSize = 16
+	// AES-256密钥长度
+	backupKeySize = 32
+)
+
+// ErrPassphraseRequired 表示备份已加密但未提供口令
+var ErrPassphraseRequired = errors.New("backup is encrypted, a passphrase is required")
+
+// isEncryptedBackup 判断数据是否为加密备份
+func isEncryptedBackup(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedBackupMagic))
+}
+
+// deriveBackupKey 使用scrypt从口令和盐派生AES密钥
+func deriveBackupKey(passphrase string, salt []byte) ([]byte, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, backupKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive backup key: %w", err)
+	}
+	return key, nil
+}
+
+// newBackupCipher 创建AES-GCM加密器
+func newBackupCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := deriveBackupKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptBackup 使用口令加密备份数据
+// 格式：文件头 | 盐 | 随机数 | 密文
+func encryptBackup(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, backupSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	aead, err := newBackupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	header := append([]byte(encryptedBackupMagic), salt...)
+	header = append(header, nonce...)
+	// 将文件头作为附加数据参与认证，防止被篡改
+	return aead.Seal(header, nonce, data, header), nil
+}
+
+// decryptBackup 使用口令解密备份数据
+func decryptBackup(data []byte, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, ErrPassphraseRequired
+	}
+
+	offset := len(encryptedBackupMagic)
+	if len(data) < offset+backupSaltSize {
+		return nil, fmt.Errorf("encrypted backup is truncated")
+	}
+	salt := data[offset : offset+backupSaltSize]
+
+	aead, err := newBackupCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	headerSize := offset + backupSaltSize + aead.NonceSize()
+	if len(data) < headerSize {
+		return nil, fmt.Errorf("encrypted backup is truncated")
+	}
+	header := data[:headerSize]
+	nonce := data[offset+backupSaltSize : headerSize]
+
+	plaintext, err := aead.Open(nil, nonce, data[headerSize:], header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt backup, wrong passphrase or corrupted file")
+	}
+	return plaintext, nil
+}
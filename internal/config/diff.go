@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// KeyChange 表示storage.json中一个顶层键的变化
+type KeyChange struct {
+	// 键名
+	Key string
+	// 变化前的JSON值，新增的键为空
+	Before string
+	// 变化后的JSON值，删除的键为空
+	After string
+}
+
+// Added 返回该键是否为新增
+func (c KeyChange) Added() bool {
+	return c.Before == ""
+}
+
+// Removed 返回该键是否被删除
+func (c KeyChange) Removed() bool {
+	return c.After == ""
+}
+
+// DiffStorageJSON 比较两份storage.json内容的顶层键，按键名排序返回发生变化的键
+// before为nil表示文件不存在
+func DiffStorageJSON(before, after []byte) ([]KeyChange, error) {
+	beforeFields, err := topLevelFields(before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse current config: %w", err)
+	}
+	afterFields, err := topLevelFields(after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse new config: %w", err)
+	}
+
+	keys := make(map[string]bool)
+	for key := range beforeFields {
+		keys[key] = true
+	}
+	for key := range afterFields {
+		keys[key] = true
+	}
+
+	var changes []KeyChange
+	for key := range keys {
+		oldValue, newValue := beforeFields[key], afterFields[key]
+		if oldValue != newValue {
+			changes = append(changes, KeyChange{Key: key, Before: oldValue, After: newValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes, nil
+}
+
+// topLevelFields 解析JSON对象的顶层键，值为压缩后的JSON文本
+func topLevelFields(data []byte) (map[string]string, error) {
+	fields := make(map[string]string)
+	if data == nil {
+		return fields, nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		if err := json.Unmarshal(stripJSONC(data), &raw); err != nil {
+			return nil, err
+		}
+	}
+	for key, value := range raw {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, value); err != nil {
+			return nil, err
+		}
+		fields[key] = compact.String()
+	}
+	return fields, nil
+}
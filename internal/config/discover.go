@@ -0,0 +1,162 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// StorageCandidate 表示扫描到的一个storage.json
+type StorageCandidate struct {
+	// storage.json的完整路径
+	Path string
+	// 所属应用的数据目录名，例如Cursor或Code
+	App string
+	// 是否为便携版的数据目录
+	Portable bool
+	// 是否为Microsoft Store（MSIX）安装的重定向数据目录
+	Packaged bool
+	// 最后修改时间
+	ModTime time.Time
+	// 文件大小（字节）
+	Size int64
+}
+
+// 数据目录中storage.json的相对位置
+var storageRelativePath = filepath.Join("User", "globalStorage", "storage.json")
+
+// DiscoverStorage 扫描常见位置，列出所有storage.json候选文件，按修改时间从新到旧排序
+// 扫描范围包括APPDATA、LocalAppData、MSIX包的重定向目录、~/Library/Application Support、~/.config，
+// 以及已安装的Cursor可执行文件旁的便携版数据目录
+func DiscoverStorage(username string) ([]StorageCandidate, error) {
+	roots, err := discoveryRoots(username)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var candidates []StorageCandidate
+	add := func(path, app string, portable bool) {
+		if seen[path] {
+			return
+		}
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			return
+		}
+		seen[path] = true
+		candidates = append(candidates, StorageCandidate{
+			Path:     path,
+			App:      app,
+			Portable: portable,
+			Packaged: IsMSIXPath(path),
+			ModTime:  info.ModTime(),
+			Size:     info.Size(),
+		})
+	}
+
+	// 标准安装：<根目录>/<应用>/User/globalStorage/storage.json
+	for _, root := range roots {
+		matches, _ := filepath.Glob(filepath.Join(root, "*", storageRelativePath))
+		for _, match := range matches {
+			app := filepath.Base(filepath.Dir(filepath.Dir(filepath.Dir(match))))
+			add(match, app, false)
+		}
+	}
+
+	// 便携版：可执行文件旁的data/user-data或code-portable-data/user-data
+	for _, dir := range portableDataDirs() {
+		add(filepath.Join(dir, "user-data", storageRelativePath), DefaultAppName, true)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].ModTime.After(candidates[j].ModTime)
+	})
+	return candidates, nil
+}
+
+// discoveryRoots 返回各操作系统上存放编辑器数据目录的根目录
+func discoveryRoots(username string) ([]string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		var roots []string
+		for _, name := range []string{"APPDATA", "LOCALAPPDATA"} {
+			if dir := os.Getenv(name); dir != "" {
+				roots = append(roots, dir)
+			}
+		}
+		return append(roots, msixRoamingDirs()...), nil
+	case "darwin":
+		return []string{filepath.Join("/Users", username, "Library", "Application Support")}, nil
+	case "linux":
+		return []string{filepath.Join("/home", username, ".config")}, nil
+	default:
+		return nil, fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
+	}
+}
+
+// portableDataDirs 返回已安装的Cursor可执行文件旁可能存在的便携版数据目录
+func portableDataDirs() []string {
+	var dirs []string
+	for _, executable := range cursorExecutables() {
+		installDir := filepath.Dir(executable)
+		// macOS的便携数据目录位于.app旁边，其他系统位于安装目录中
+		if runtime.GOOS == "darwin" {
+			dirs = append(dirs, filepath.Join(installDir, "code-portable-data"))
+		} else {
+			dirs = append(dirs, filepath.Join(installDir, "data"))
+		}
+	}
+	return dirs
+}
+
+// CursorInstallDirs 返回在PATH和已知位置中找到的Cursor安装目录（macOS上为.app目录）
+// 用于识别安装在非默认位置的Cursor进程
+func CursorInstallDirs() []string {
+	var dirs []string
+	for _, executable := range cursorExecutables() {
+		if runtime.GOOS == "darwin" {
+			dirs = append(dirs, executable)
+			continue
+		}
+		dir := filepath.Dir(executable)
+		// Linux上PATH中的cursor通常是安装目录下bin中的启动脚本
+		if filepath.Base(dir) == "bin" {
+			dir = filepath.Dir(dir)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// cursorExecutables 返回已安装的Cursor可执行文件（macOS上为.app目录），符号链接已解析
+func cursorExecutables() []string {
+	var candidates []string
+	if path, err := exec.LookPath("cursor"); err == nil {
+		candidates = append(candidates, path)
+	}
+	switch runtime.GOOS {
+	case "windows":
+		candidates = append(candidates, filepath.Join(os.Getenv("LOCALAPPDATA"), "Programs", "cursor", "Cursor.exe"))
+	case "darwin":
+		candidates = append(candidates, "/Applications/Cursor.app")
+	case "linux":
+		candidates = append(candidates, "/opt/Cursor/cursor", "/opt/cursor/cursor", "/usr/share/cursor/cursor")
+	}
+
+	var executables []string
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		resolved, err := filepath.EvalSymlinks(candidate)
+		if err != nil || seen[resolved] {
+			continue
+		}
+		seen[resolved] = true
+		executables = append(executables, resolved)
+	}
+	return executables
+}
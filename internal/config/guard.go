@@ -0,0 +1,144 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// 旧版本保存在storage.json同一目录中的守护快照文件名，仅用于迁移
+const legacyGuardSnapshotFile = "cursor-id-modifier.guard.json"
+
+// ErrNoGuardSnapshot 表示还没有记录期望的遥测ID，需要先运行一次修改
+var ErrNoGuardSnapshot = errors.New("no guard snapshot found, run a modification first")
+
+// GuardResult 表示一次守护检查的结果
+type GuardResult struct {
+	// 是否重新写入了期望的ID
+	Reapplied bool
+	// 被Cursor改写的键
+	ChangedKeys []string
+}
+
+// SetStateDir 设置工具的状态目录，守护快照保存在其中而不是Cursor的globalStorage目录
+func (m *Manager) SetStateDir(dir string) {
+	m.stateDir = dir
+}
+
+// guardPath 返回守护快照文件的路径
+// 不同的编辑器和-storage指定的文件共用状态目录，文件名按storage.json的路径区分
+func (m *Manager) guardPath() string {
+	if m.stateDir == "" {
+		return m.legacyGuardPath()
+	}
+	sum := sha256.Sum256([]byte(m.configPath))
+	return filepath.Join(m.stateDir, "guard-"+hex.EncodeToString(sum[:8])+".json")
+}
+
+// legacyGuardPath 返回旧版本写入globalStorage目录的守护快照路径
+func (m *Manager) legacyGuardPath() string {
+	return filepath.Join(filepath.Dir(m.configPath), legacyGuardSnapshotFile)
+}
+
+// SaveGuardSnapshot 记录用户期望的遥测ID，供守护模式检测和恢复
+func (m *Manager) SaveGuardSnapshot(config *StorageConfig) error {
+	content, err := json.MarshalIndent(config, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal guard snapshot: %w", err)
+	}
+
+	path := m.guardPath()
+	if err := m.mkdirAllOwned(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		return fmt.Errorf("failed to write guard snapshot: %w", err)
+	}
+	if err := m.restoreOwnership(path); err != nil {
+		return err
+	}
+	// 新快照写入后移除旧版本留在globalStorage中的快照
+	if legacy := m.legacyGuardPath(); legacy != path {
+		if err := os.Remove(legacy); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove old guard snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+// LoadGuardSnapshot 读取守护快照，不存在时返回nil
+// 状态目录中没有快照时读取旧版本保存在globalStorage中的快照
+func (m *Manager) LoadGuardSnapshot() (*StorageConfig, error) {
+	data, err := os.ReadFile(m.guardPath())
+	if os.IsNotExist(err) && m.stateDir != "" {
+		data, err = os.ReadFile(m.legacyGuardPath())
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read guard snapshot: %w", err)
+	}
+
+	var snapshot StorageConfig
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse guard snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// Guard 检查storage.json中的遥测ID是否被改写，如被改写则重新写入快照中的值
+func (m *Manager) Guard(ctx context.Context, protection ProtectionLevel) (*GuardResult, error) {
+	return guardStore(ctx, m, protection)
+}
+
+// guardStore 对任意ConfigStore执行守护检查，Manager和MemoryStore共用
+func guardStore(ctx context.Context, store ConfigStore, protection ProtectionLevel) (*GuardResult, error) {
+	snapshot, err := store.LoadGuardSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, ErrNoGuardSnapshot
+	}
+
+	current, err := store.ReadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GuardResult{ChangedKeys: changedTelemetryKeys(store.Target().TelemetryKeys(), snapshot, current)}
+	if len(result.ChangedKeys) == 0 {
+		return result, nil
+	}
+
+	// 写保护会阻止重新写入，先移除再按要求重新施加
+	if err := store.Unprotect(); err != nil {
+		return nil, err
+	}
+	if _, err := store.SaveConfig(ctx, snapshot, protection); err != nil {
+		return nil, err
+	}
+	result.Reapplied = true
+	return result, nil
+}
+
+// changedTelemetryKeys 比较期望值和当前值，返回不一致的遥测键
+func changedTelemetryKeys(keys []string, expected, current *StorageConfig) []string {
+	if current == nil {
+		current = &StorageConfig{}
+	}
+
+	var changed []string
+	for _, key := range keys {
+		if telemetryValue(expected, key) != telemetryValue(current, key) {
+			changed = append(changed, key)
+		}
+	}
+	return changed
+}
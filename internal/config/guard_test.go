@@ -0,0 +1,52 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGuardSnapshotInStateDir(t *testing.T) {
+	dataDir := t.TempDir()
+	stateDir := filepath.Join(t.TempDir(), "state")
+	manager, err := NewManagerForPath(filepath.Join(dataDir, "storage.json"), DefaultTarget())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 旧版本的快照位于globalStorage中，设置状态目录后仍能读取
+	legacy := filepath.Join(dataDir, legacyGuardSnapshotFile)
+	if err := os.WriteFile(legacy, []byte(`{"telemetry.machineId": "old"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	manager.SetStateDir(stateDir)
+	snapshot, err := manager.LoadGuardSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot == nil || snapshot.TelemetryMachineId != "old" {
+		t.Fatalf("legacy snapshot not loaded: %+v", snapshot)
+	}
+
+	if err := manager.SaveGuardSnapshot(&StorageConfig{TelemetryMachineId: "new"}); err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(manager.guardPath()) != stateDir {
+		t.Errorf("guard snapshot written to %s, want a file in %s", manager.guardPath(), stateDir)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Errorf("legacy snapshot was not removed: %v", err)
+	}
+	entries, _ := os.ReadDir(dataDir)
+	if len(entries) != 0 {
+		t.Errorf("files left in the data directory: %v", entries)
+	}
+
+	snapshot, err = manager.LoadGuardSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.TelemetryMachineId != "new" {
+		t.Errorf("machineId = %q, want %q", snapshot.TelemetryMachineId, "new")
+	}
+}
@@ -0,0 +1,23 @@
+package config
+
+import "github.com/yuaotian/go-cursor-help/pkg/idgen"
+
+// Identity 取出配置中的标识符，供idgen生成和校验
+func (c *StorageConfig) Identity() *idgen.IdentitySet {
+	return &idgen.IdentitySet{
+		MachineID:    c.TelemetryMachineId,
+		MacMachineID: c.TelemetryMacMachineId,
+		DeviceID:     c.TelemetryDevDeviceId,
+		SQMID:        c.TelemetrySqmId,
+	}
+}
+
+// FromIdentity 将idgen生成的标识符转换为storage.json中的配置
+func FromIdentity(identity *idgen.IdentitySet) *StorageConfig {
+	return &StorageConfig{
+		TelemetryMachineId:    identity.MachineID,
+		TelemetryMacMachineId: identity.MacMachineID,
+		TelemetryDevDeviceId:  identity.DeviceID,
+		TelemetrySqmId:        identity.SQMID,
+	}
+}
@@ -0,0 +1,62 @@
+package config
+
+// stripJSONC 将JSONC风格的内容（注释、尾随逗号）转换为严格的JSON
+// 字符串中的内容保持不变，用于宽松读取被手动编辑过的storage.json
+func stripJSONC(data []byte) []byte {
+	return stripTrailingCommas(stripJSONComments(data))
+}
+
+// stripJSONComments 移除字符串之外的//行注释和/* */块注释
+// 行注释保留换行符，块注释替换为一个空格，以免相邻的记号粘连
+func stripJSONComments(data []byte) []byte {
+	result := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case c == '"':
+			end := skipJSONString(data, i)
+			result = append(result, data[i:end]...)
+			i = end - 1
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				result = append(result, '\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++
+			result = append(result, ' ')
+		default:
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// stripTrailingCommas 移除字符串之外、紧跟在}或]之前的逗号
+func stripTrailingCommas(data []byte) []byte {
+	result := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case '"':
+			end := skipJSONString(data, i)
+			result = append(result, data[i:end]...)
+			i = end - 1
+		case ',':
+			next := skipJSONSpace(data, i+1)
+			if next < len(data) && (data[next] == '}' || data[next] == ']') {
+				continue
+			}
+			result = append(result, c)
+		default:
+			result = append(result, c)
+		}
+	}
+	return result
+}
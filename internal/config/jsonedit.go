@@ -0,0 +1,248 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// 新增键时使用的默认缩进，与Cursor写出的storage.json保持一致
+const defaultJSONIndent = "    "
+
+// jsonMember 记录顶层对象中一个键值对在原始字节中的位置
+type jsonMember struct {
+	// 解码后的键名
+	key string
+	// 键（含引号）的起始位置
+	keyStart int
+	// 键（含引号）的结束位置
+	keyEnd int
+	// 值的起始位置
+	valueStart int
+	// 值的结束位置
+	valueEnd int
+}
+
+// jsonDocument 保留原始字节的顶层JSON对象编辑器
+// 只替换被修改的值，其余键的顺序、格式和内容保持逐字节不变
+type jsonDocument struct {
+	// 原始（或已编辑的）文档字节
+	data []byte
+	// 顶层成员列表，按出现顺序排列
+	members []jsonMember
+	// 顶层对象右花括号的位置
+	closeBrace int
+}
+
+// parseJSONDocument 解析顶层为对象的JSON文档并记录各成员位置
+func parseJSONDocument(data []byte) (*jsonDocument, error) {
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("invalid JSON document")
+	}
+
+	doc := &jsonDocument{data: data}
+	pos := skipJSONSpace(data, 0)
+	if pos >= len(data) || data[pos] != '{' {
+		return nil, fmt.Errorf("top-level JSON value is not an object")
+	}
+	pos++
+
+	for {
+		pos = skipJSONSpace(data, pos)
+		if data[pos] == '}' {
+			doc.closeBrace = pos
+			return doc, nil
+		}
+		if data[pos] == ',' {
+			pos = skipJSONSpace(data, pos+1)
+		}
+
+		var member jsonMember
+		member.keyStart = pos
+		member.keyEnd = skipJSONString(data, pos)
+		if err := json.Unmarshal(data[member.keyStart:member.keyEnd], &member.key); err != nil {
+			return nil, fmt.Errorf("failed to decode key: %w", err)
+		}
+
+		// 跳过冒号
+		pos = skipJSONSpace(data, member.keyEnd) + 1
+		member.valueStart = skipJSONSpace(data, pos)
+		member.valueEnd = skipJSONValue(data, member.valueStart)
+		doc.members = append(doc.members, member)
+		pos = member.valueEnd
+	}
+}
+
+// Get 返回指定键的原始JSON值，不存在时返回nil
+func (d *jsonDocument) Get(key string) json.RawMessage {
+	if member, ok := d.find(key); ok {
+		return json.RawMessage(d.data[member.valueStart:member.valueEnd])
+	}
+	return nil
+}
+
+// Set 设置指定键的值，已存在时原地替换，不存在时追加到对象末尾
+func (d *jsonDocument) Set(key string, value interface{}) error {
+	encoded, err := marshalJSONValue(value)
+	if err != nil {
+		return err
+	}
+
+	var edited []byte
+	if member, ok := d.find(key); ok {
+		edited = spliceBytes(d.data, member.valueStart, member.valueEnd, encoded)
+	} else {
+		edited = d.appendMember(key, encoded)
+	}
+
+	// 重新解析以更新成员位置
+	updated, err := parseJSONDocument(edited)
+	if err != nil {
+		return fmt.Errorf("failed to update key %q: %w", key, err)
+	}
+	*d = *updated
+	return nil
+}
+
+// Bytes 返回当前文档的字节内容
+func (d *jsonDocument) Bytes() []byte {
+	return d.data
+}
+
+// find 查找指定键的成员
+// 键重复时返回最后一次出现的成员，与encoding/json和Cursor的JSON.parse取值一致
+func (d *jsonDocument) find(key string) (jsonMember, bool) {
+	for i := len(d.members) - 1; i >= 0; i-- {
+		if d.members[i].key == key {
+			return d.members[i], true
+		}
+	}
+	return jsonMember{}, false
+}
+
+// appendMember 在对象末尾追加新成员，沿用已有成员的缩进和分隔格式
+func (d *jsonDocument) appendMember(key string, encodedValue []byte) []byte {
+	encodedKey, _ := marshalJSONValue(key)
+
+	// 默认格式，用于空对象
+	indent := "\n" + defaultJSONIndent
+	separator := ": "
+	insertAt := d.closeBrace
+	prefix := ""
+	suffix := "\n"
+
+	if len(d.members) > 0 {
+		first := d.members[0]
+		last := d.members[len(d.members)-1]
+
+		// 复用第一个成员所在行的缩进，不复制其前面的空行（例如移除注释后留下的空行）
+		indentStart := first.keyStart
+		for indentStart > 0 && isJSONSpace(d.data[indentStart-1]) && d.data[indentStart-1] != '\n' {
+			indentStart--
+		}
+		if indentStart > 0 && d.data[indentStart-1] == '\n' {
+			indentStart--
+			if indentStart > 0 && d.data[indentStart-1] == '\r' {
+				indentStart--
+			}
+		}
+		indent = string(d.data[indentStart:first.keyStart])
+		separator = string(d.data[first.keyEnd:first.valueStart])
+
+		insertAt = last.valueEnd
+		prefix = ","
+		suffix = ""
+	}
+
+	var member bytes.Buffer
+	member.WriteString(prefix)
+	member.WriteString(indent)
+	member.Write(encodedKey)
+	member.WriteString(separator)
+	member.Write(encodedValue)
+	member.WriteString(suffix)
+
+	if len(d.members) == 0 {
+		// 空对象：丢弃花括号之间原有的空白
+		start := bytes.IndexByte(d.data, '{') + 1
+		return spliceBytes(d.data, start, insertAt, member.Bytes())
+	}
+	return spliceBytes(d.data, insertAt, insertAt, member.Bytes())
+}
+
+// marshalJSONValue 编码JSON值，不转义HTML字符以保持与原文件一致
+func marshalJSONValue(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// spliceBytes 用replacement替换data[start:end]，返回新的切片
+func spliceBytes(data []byte, start, end int, replacement []byte) []byte {
+	result := make([]byte, 0, len(data)-(end-start)+len(replacement))
+	result = append(result, data[:start]...)
+	result = append(result, replacement...)
+	return append(result, data[end:]...)
+}
+
+// isJSONSpace 判断字节是否为JSON空白字符
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// skipJSONSpace 跳过空白字符，返回下一个非空白字符的位置
+func skipJSONSpace(data []byte, pos int) int {
+	for pos < len(data) && isJSONSpace(data[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// skipJSONString 跳过从pos开始的字符串（含引号），返回其结束位置
+func skipJSONString(data []byte, pos int) int {
+	for i := pos + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(data)
+}
+
+// skipJSONValue 跳过从pos开始的任意JSON值，返回其结束位置
+// 调用前文档已通过json.Valid校验，因此这里只需处理嵌套和字符串
+func skipJSONValue(data []byte, pos int) int {
+	switch data[pos] {
+	case '"':
+		return skipJSONString(data, pos)
+	case '{', '[':
+		depth := 0
+		for i := pos; i < len(data); i++ {
+			switch data[i] {
+			case '"':
+				i = skipJSONString(data, i) - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		}
+		return len(data)
+	default:
+		// 数字、true、false、null
+		i := pos
+		for i < len(data) && !isJSONSpace(data[i]) && data[i] != ',' && data[i] != '}' && data[i] != ']' {
+			i++
+		}
+		return i
+	}
+}
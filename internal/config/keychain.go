@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// 系统钥匙串中保存备份口令的服务名
+const keychainService = "cursor-id-modifier"
+
+// KeychainPassphrase 从系统钥匙串读取备份口令
+// macOS使用security命令，Linux使用secret-tool（libsecret），Windows使用凭据管理器
+func KeychainPassphrase() (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		passphrase, err := readCredential(keychainService)
+		if err != nil {
+			return "", err
+		}
+		if passphrase == "" {
+			return "", fmt.Errorf("keychain entry %q is empty", keychainService)
+		}
+		return passphrase, nil
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", keychainService, "-a", "backup", "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", keychainService, "account", "backup")
+	default:
+		return "", fmt.Errorf("keychain is not supported on %s", runtime.GOOS)
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase from keychain: %w", err)
+	}
+
+	passphrase := strings.TrimRight(string(output), "\r\n")
+	if passphrase == "" {
+		return "", fmt.Errorf("keychain entry %q is empty", keychainService)
+	}
+	return passphrase, nil
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package config
+
+import "fmt"
+
+// readCredential 凭据管理器只在Windows上可用
+func readCredential(target string) (string, error) {
+	return "", fmt.Errorf("credential manager is only available on Windows")
+}
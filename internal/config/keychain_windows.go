@@ -0,0 +1,68 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf16"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// 凭据管理器的函数，x/sys/windows中没有封装
+var (
+	advapi32     = windows.NewLazySystemDLL("advapi32.dll")
+	procCredRead = advapi32.NewProc("CredReadW")
+	procCredFree = advapi32.NewProc("CredFree")
+)
+
+// credTypeGeneric 普通凭据（CRED_TYPE_GENERIC），cmdkey /generic创建的就是这种凭据
+const credTypeGeneric = 1
+
+// credential 对应CREDENTIALW结构
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        windows.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// readCredential 从Windows凭据管理器读取普通凭据的密码
+// 可以使用 cmdkey /generic:cursor-id-modifier /user:backup /pass:<口令> 创建
+func readCredential(target string) (string, error) {
+	name, err := windows.UTF16PtrFromString(target)
+	if err != nil {
+		return "", err
+	}
+
+	var cred *credential
+	if ret, _, callErr := procCredRead.Call(uintptr(unsafe.Pointer(name)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred))); ret == 0 {
+		if errors.Is(callErr, windows.ERROR_NOT_FOUND) {
+			return "", fmt.Errorf("failed to read passphrase from credential manager: no generic credential named %q", target)
+		}
+		return "", fmt.Errorf("failed to read passphrase from credential manager: %w", callErr)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	if cred.CredentialBlobSize == 0 || cred.CredentialBlob == nil {
+		return "", nil
+	}
+	blob := unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)
+	// cmdkey和凭据管理器界面以UTF-16LE保存密码，其他工具可能直接保存字节
+	if len(blob)%2 != 0 {
+		return string(blob), nil
+	}
+	chars := make([]uint16, len(blob)/2)
+	for i := range chars {
+		chars[i] = uint16(blob[2*i]) | uint16(blob[2*i+1])<<8
+	}
+	return string(utf16.Decode(chars)), nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+)
+
+// FileLockedError 表示storage.json正被其他进程锁定
+// 通常是Cursor的后台更新程序或另一个正在运行的本工具实例
+type FileLockedError struct {
+	// 被锁定的文件路径
+	Path string
+	// 底层错误
+	Err error
+}
+
+// Error 实现error接口
+func (e *FileLockedError) Error() string {
+	return fmt.Sprintf("%s is locked by another process: %v", e.Path, e.Err)
+}
+
+// Unwrap 返回底层错误
+func (e *FileLockedError) Unwrap() error {
+	return e.Err
+}
+
+// lockTarget 在读取-修改-写入期间锁定storage.json，调用者需持有m.mu
+// 文件不存在时没有需要保护的内容，返回空锁
+func (m *Manager) lockTarget() (*fileLock, error) {
+	lock, err := lockFile(m.configPath)
+	if err != nil {
+		return nil, err
+	}
+	m.activeLock = lock
+	return lock, nil
+}
+
+// unlockTarget 释放lockTarget获取的锁
+func (m *Manager) unlockTarget(lock *fileLock) {
+	m.activeLock = nil
+	lock.release()
+}
+
+// beforeReplace 在重命名替换storage.json之前调用
+// 某些系统不允许替换仍被打开的文件，需要提前释放锁
+func (m *Manager) beforeReplace() {
+	if m.activeLock != nil {
+		m.activeLock.beforeReplace()
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !unix && !windows
+
+package config
+
+// fileLock 不支持文件锁的系统上的空实现
+type fileLock struct{}
+
+// lockFile 不支持文件锁时直接返回空锁
+func lockFile(path string) (*fileLock, error) {
+	return &fileLock{}, nil
+}
+
+// beforeReplace 空实现
+func (l *fileLock) beforeReplace() {}
+
+// release 空实现
+func (l *fileLock) release() {}
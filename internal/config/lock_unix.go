@@ -0,0 +1,49 @@
+//go:build unix
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// fileLock 基于flock的建议锁
+type fileLock struct {
+	// 持有锁的文件，文件不存在时为nil
+	file *os.File
+}
+
+// lockFile 以非阻塞方式获取文件的排他锁，已被其他进程锁定时立即返回FileLockedError
+func lockFile(path string) (*fileLock, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &fileLock{}, nil
+		}
+		return nil, fmt.Errorf("failed to open %s for locking: %w", path, err)
+	}
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		file.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, &FileLockedError{Path: path, Err: err}
+		}
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	return &fileLock{file: file}, nil
+}
+
+// beforeReplace flock锁定的是inode，重命名替换不受影响，无需提前释放
+func (l *fileLock) beforeReplace() {}
+
+// release 释放锁并关闭文件
+func (l *fileLock) release() {
+	if l.file == nil {
+		return
+	}
+	syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+	l.file.Close()
+	l.file = nil
+}
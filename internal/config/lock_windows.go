@@ -0,0 +1,70 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// 加锁的字节位置，位于文件末尾之外，避免锁定区域阻塞对文件内容的正常读写
+const (
+	lockOffsetHigh = 0x7fffffff
+	lockLength     = 1
+)
+
+// fileLock 基于LockFileEx的锁
+type fileLock struct {
+	// 持有锁的文件句柄，文件不存在或已释放时为0
+	handle windows.Handle
+}
+
+// lockFile 以非阻塞方式获取文件的排他锁，已被其他进程锁定或独占打开时立即返回FileLockedError
+func lockFile(path string) (*fileLock, error) {
+	name, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// 允许共享读写和删除，避免影响同一进程中对文件的读取和替换
+	handle, err := windows.CreateFile(name, windows.GENERIC_READ,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, windows.OPEN_EXISTING, windows.FILE_ATTRIBUTE_NORMAL, 0)
+	if err != nil {
+		if errors.Is(err, windows.ERROR_FILE_NOT_FOUND) || errors.Is(err, windows.ERROR_PATH_NOT_FOUND) {
+			return &fileLock{}, nil
+		}
+		if errors.Is(err, windows.ERROR_SHARING_VIOLATION) {
+			return nil, &FileLockedError{Path: path, Err: err}
+		}
+		return nil, fmt.Errorf("failed to open %s for locking: %w", path, &os.PathError{Op: "open", Path: path, Err: err})
+	}
+
+	overlapped := &windows.Overlapped{OffsetHigh: lockOffsetHigh}
+	flags := uint32(windows.LOCKFILE_EXCLUSIVE_LOCK | windows.LOCKFILE_FAIL_IMMEDIATELY)
+	if err := windows.LockFileEx(handle, flags, 0, lockLength, 0, overlapped); err != nil {
+		windows.CloseHandle(handle)
+		if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+			return nil, &FileLockedError{Path: path, Err: err}
+		}
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	return &fileLock{handle: handle}, nil
+}
+
+// beforeReplace Windows不允许替换仍被打开的文件，重命名前释放锁
+func (l *fileLock) beforeReplace() {
+	l.release()
+}
+
+// release 释放锁并关闭文件句柄
+func (l *fileLock) release() {
+	if l.handle == 0 {
+		return
+	}
+	overlapped := &windows.Overlapped{OffsetHigh: lockOffsetHigh}
+	windows.UnlockFileEx(l.handle, 0, lockLength, 0, overlapped)
+	windows.CloseHandle(l.handle)
+	l.handle = 0
+}
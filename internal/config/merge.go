@@ -0,0 +1,80 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MergeStrategy 表示SaveConfig合并新旧配置的方式
+type MergeStrategy string
+
+const (
+	// StrategyOverwrite 覆盖所有遥测键，保留其他键（默认行为）
+	StrategyOverwrite MergeStrategy = "overwrite"
+	// StrategyFillMissing 只写入缺失或无效的遥测键，已有的有效值保持不变
+	StrategyFillMissing MergeStrategy = "fill-missing"
+	// StrategyReplaceFile 丢弃现有内容，写入只包含遥测键的精简文件
+	StrategyReplaceFile MergeStrategy = "replace-file"
+)
+
+// MergeStrategies 返回所有支持的合并策略
+func MergeStrategies() []MergeStrategy {
+	return []MergeStrategy{StrategyOverwrite, StrategyFillMissing, StrategyReplaceFile}
+}
+
+// ParseMergeStrategy 解析合并策略字符串，空字符串表示默认的overwrite
+func ParseMergeStrategy(value string) (MergeStrategy, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return StrategyOverwrite, nil
+	}
+	for _, strategy := range MergeStrategies() {
+		if MergeStrategy(value) == strategy {
+			return strategy, nil
+		}
+	}
+	return "", fmt.Errorf("unknown merge strategy: %s", value)
+}
+
+// SaveOptions 保存配置的选项
+type SaveOptions struct {
+	// 写入后施加的写保护级别
+	Protection ProtectionLevel
+	// 合并策略，为空时使用StrategyOverwrite
+	Strategy MergeStrategy
+	// 写入前的备份选项，为nil时不备份
+	Backup *BackupOptions
+	// 判断已有遥测值是否有效，仅用于StrategyFillMissing
+	// 为nil时非空字符串即视为有效
+	Validate func(key, value string) bool
+}
+
+// keepExistingValue 判断fill-missing策略下是否保留已有的值
+func (o SaveOptions) keepExistingValue(key string, raw json.RawMessage) (string, bool) {
+	if raw == nil {
+		return "", false
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil || value == "" {
+		return "", false
+	}
+	if o.Validate != nil && !o.Validate(key, value) {
+		return "", false
+	}
+	return value, true
+}
+
+// setTelemetryValue 设置配置中与遥测键对应的值
+func setTelemetryValue(config *StorageConfig, key, value string) {
+	switch key {
+	case keyMacMachineID:
+		config.TelemetryMacMachineId = value
+	case keyMachineID:
+		config.TelemetryMachineId = value
+	case keyDevDeviceID:
+		config.TelemetryDevDeviceId = value
+	case keySqmID:
+		config.TelemetrySqmId = value
+	}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"regexp"
+	"testing"
+)
+
+// lastModifiedPattern 匹配合并时写入的当前时间，比较结果前替换为固定值
+var lastModifiedPattern = regexp.MustCompile(`("lastModified": ?)"[^"]*"`)
+
+func TestMergeStorageJSON(t *testing.T) {
+	keys := []string{"telemetry.machineId", "telemetry.devDeviceId"}
+	values := &StorageConfig{TelemetryMachineId: "new-machine", TelemetryDevDeviceId: "new-device"}
+
+	tests := []struct {
+		name     string
+		existing string
+		strategy MergeStrategy
+		want     string
+	}{
+		{
+			name:     "missing file",
+			existing: "",
+			want:     "{\n    \"telemetry.machineId\": \"new-machine\",\n    \"telemetry.devDeviceId\": \"new-device\",\n    \"lastModified\": \"T\"\n}",
+		},
+		{
+			// 其他键的顺序、大整数和嵌套对象的格式逐字节保持不变，已有的遥测键原地替换
+			name:     "keeps order and formatting",
+			existing: "{\n\t\"zeta\": 12345678901234567890,\n\t\"telemetry.devDeviceId\": \"old-device\",\n\t\"nested\": {\"b\": 1, \"a\": [1.50, 2]},\n\t\"alpha\": \"<html>\",\n\t\"lastModified\": \"old\"\n}\n",
+			want:     "{\n\t\"zeta\": 12345678901234567890,\n\t\"telemetry.devDeviceId\": \"new-device\",\n\t\"nested\": {\"b\": 1, \"a\": [1.50, 2]},\n\t\"alpha\": \"<html>\",\n\t\"lastModified\": \"T\",\n\t\"telemetry.machineId\": \"new-machine\"\n}\n",
+		},
+		{
+			name:     "compact separators",
+			existing: `{"a":1,"telemetry.machineId":"old"}`,
+			want:     `{"a":1,"telemetry.machineId":"new-machine","telemetry.devDeviceId":"new-device","lastModified":"T"}`,
+		},
+		{
+			name:     "fill missing keeps existing values",
+			existing: "{\n  \"telemetry.machineId\": \"old-machine\",\n  \"telemetry.devDeviceId\": \"\"\n}",
+			strategy: StrategyFillMissing,
+			want:     "{\n  \"telemetry.machineId\": \"old-machine\",\n  \"telemetry.devDeviceId\": \"new-device\",\n  \"lastModified\": \"T\"\n}",
+		},
+		{
+			name:     "replace file drops other keys",
+			existing: "{\n  \"other\": true,\n  \"telemetry.machineId\": \"old\"\n}",
+			strategy: StrategyReplaceFile,
+			want:     "{\n    \"telemetry.machineId\": \"new-machine\",\n    \"telemetry.devDeviceId\": \"new-device\",\n    \"lastModified\": \"T\"\n}",
+		},
+		{
+			// 手动编辑留下的注释和尾随逗号被移除，键的顺序不变
+			name:     "jsonc input",
+			existing: "{\n  // comment\n  \"b\": 2,\n  \"a\": 1,\n}",
+			want:     "{\n  \n  \"b\": 2,\n  \"a\": 1,\n  \"telemetry.machineId\": \"new-machine\",\n  \"telemetry.devDeviceId\": \"new-device\",\n  \"lastModified\": \"T\"\n}",
+		},
+		{
+			// 键重复时替换最后一次出现的值，即Cursor实际读取的那个
+			name:     "duplicated key",
+			existing: "{\n  \"telemetry.machineId\": \"first\",\n  \"telemetry.machineId\": \"last\"\n}",
+			want:     "{\n  \"telemetry.machineId\": \"first\",\n  \"telemetry.machineId\": \"new-machine\",\n  \"telemetry.devDeviceId\": \"new-device\",\n  \"lastModified\": \"T\"\n}",
+		},
+		{
+			name:     "crlf line endings",
+			existing: "{\r\n  \"a\": 1\r\n}",
+			want:     "{\r\n  \"a\": 1,\r\n  \"telemetry.machineId\": \"new-machine\",\r\n  \"telemetry.devDeviceId\": \"new-device\",\r\n  \"lastModified\": \"T\"\r\n}",
+		},
+		{
+			name:     "unparseable file starts from scratch",
+			existing: "not json",
+			want:     "{\n    \"telemetry.machineId\": \"new-machine\",\n    \"telemetry.devDeviceId\": \"new-device\",\n    \"lastModified\": \"T\"\n}",
+		},
+	}
+	for _, tt := range tests {
+		var existing []byte
+		if tt.existing != "" {
+			existing = []byte(tt.existing)
+		}
+		strategy := tt.strategy
+		if strategy == "" {
+			strategy = StrategyOverwrite
+		}
+		content, written, err := mergeStorageJSON(existing, keys, values, SaveOptions{Strategy: strategy})
+		if err != nil {
+			t.Fatalf("%s: mergeStorageJSON returned error: %v", tt.name, err)
+		}
+		got := lastModifiedPattern.ReplaceAllString(string(content), `${1}"T"`)
+		if got != tt.want {
+			t.Errorf("%s: merged content =\n%s\nwant\n%s", tt.name, got, tt.want)
+		}
+		if tt.strategy == StrategyFillMissing && written.TelemetryMachineId != "old-machine" {
+			t.Errorf("%s: written machine ID = %q, want the kept value", tt.name, written.TelemetryMachineId)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// MSIXPackage 表示从Microsoft Store（MSIX）安装的编辑器
+// 打包应用对%APPDATA%的写入会被重定向到包目录下的LocalCache\Roaming中
+type MSIXPackage struct {
+	// 包目录名（Package Family Name），例如Anysphere.Cursor_xxxxxxxx
+	FamilyName string
+	// 重定向后的编辑器数据目录，即LocalCache\Roaming\<产品名称>
+	DataDir string
+}
+
+// DetectMSIXPackages 查找已安装并运行过的指定产品的MSIX包，非Windows系统返回空
+func DetectMSIXPackages(appName string) []MSIXPackage {
+	var packages []MSIXPackage
+	for _, roaming := range msixRoamingDirs() {
+		dataDir := filepath.Join(roaming, appName)
+		if info, err := os.Stat(dataDir); err != nil || !info.IsDir() {
+			continue
+		}
+		packages = append(packages, MSIXPackage{
+			FamilyName: filepath.Base(filepath.Dir(filepath.Dir(roaming))),
+			DataDir:    dataDir,
+		})
+	}
+	return packages
+}
+
+// msixRoamingDirs 返回所有MSIX包的重定向Roaming目录
+func msixRoamingDirs() []string {
+	localAppData := os.Getenv("LOCALAPPDATA")
+	if runtime.GOOS != "windows" || localAppData == "" {
+		return nil
+	}
+	dirs, _ := filepath.Glob(filepath.Join(localAppData, "Packages", "*", "LocalCache", "Roaming"))
+	return dirs
+}
+
+// IsMSIXPath 检查路径是否位于MSIX包的目录中（WindowsApps安装目录或Packages数据目录）
+func IsMSIXPath(path string) bool {
+	lower := strings.ToLower(filepath.ToSlash(path))
+	return strings.Contains(lower, "/windowsapps/") ||
+		(strings.Contains(lower, "/packages/") && strings.Contains(lower, "/localcache/"))
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// 网络共享上写入的重试参数，网络抖动或文件被占用时按递增间隔重试
+const (
+	// 最大尝试次数
+	networkWriteAttempts = 5
+	// 第一次重试前的等待时间，之后每次递增
+	networkRetryDelay = 500 * time.Millisecond
+)
+
+// onNetworkShare 返回配置文件是否位于网络共享上，结果只检测一次
+func (m *Manager) onNetworkShare() bool {
+	m.networkOnce.Do(func() {
+		m.network = isNetworkPath(m.configPath)
+	})
+	return m.network
+}
+
+// writeConfigFileCopy 网络共享上的写入策略
+// SMB等网络文件系统上重命名不保证原子性，且常因文件被占用而失败，
+// 因此先写入并同步完整的临时文件，再将内容复制到目标文件并同步，每一步失败都会重试
+// 复制失败时保留临时文件，其中是完整的新配置
+func (m *Manager) writeConfigFileCopy(ctx context.Context, content []byte, protection ProtectionLevel, checkChanges bool) error {
+	tmpPath := m.configPath + ".tmp"
+	if err := retryNetwork(ctx, func() error { return writeFileSync(tmpPath, content) }); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	// 读回临时文件，确认网络写入完整
+	written, err := os.ReadFile(tmpPath)
+	if err != nil || !bytes.Equal(written, content) {
+		os.Remove(tmpPath)
+		return fmt.Errorf("temporary file %s on network share is incomplete", tmpPath)
+	}
+
+	// 复制前检测并发修改，避免覆盖其他进程刚写入的数据
+	if checkChanges {
+		if err := m.checkUnchanged(); err != nil {
+			os.Remove(tmpPath)
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	// 复制到目标文件，失败时保留临时文件便于手动恢复
+	if err := retryNetwork(ctx, func() error { return writeFileSync(m.configPath, content) }); err != nil {
+		return fmt.Errorf("failed to copy config file on network share, complete copy kept at %s: %w", tmpPath, err)
+	}
+	os.Remove(tmpPath)
+
+	// 网络共享不一定支持修改权限，仅在需要写保护时报告失败
+	if err := os.Chmod(m.configPath, protection.fileMode()); err != nil && protection != ProtectNone {
+		return fmt.Errorf("failed to set config file permissions: %w", err)
+	}
+	return m.restoreOwnership(m.configPath)
+}
+
+// writeFileSync 写入文件并同步到存储，确保数据在返回前已落盘
+func writeFileSync(path string, content []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// retryNetwork 执行操作，失败时按递增的间隔重试，ctx被取消时立即返回
+func retryNetwork(ctx context.Context, operation func() error) error {
+	var err error
+	for attempt := 1; attempt <= networkWriteAttempts; attempt++ {
+		if err = operation(); err == nil {
+			return nil
+		}
+		if attempt == networkWriteAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * networkRetryDelay):
+		}
+	}
+	return err
+}
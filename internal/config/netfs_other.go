@@ -0,0 +1,8 @@
+//go:build !windows
+
+package config
+
+// isNetworkPath 只在Windows上检测网络共享，其他系统按本地路径处理
+func isNetworkPath(path string) bool {
+	return false
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// OneDrive设置的环境变量，分别对应当前账户、工作或学校账户和个人账户的同步根目录
+var oneDriveEnvVars = []string{"OneDrive", "OneDriveCommercial", "OneDriveConsumer"}
+
+// OneDriveFolder 返回包含storage.json的OneDrive同步根目录
+// 已知文件夹迁移（KFM）或手动重定向使AppData位于OneDrive中时返回根目录，否则返回空字符串
+func (m *Manager) OneDriveFolder() string {
+	if runtime.GOOS != "windows" {
+		return ""
+	}
+
+	configPath := strings.ToLower(filepath.Clean(m.configPath))
+	for _, name := range oneDriveEnvVars {
+		root := os.Getenv(name)
+		if root == "" {
+			continue
+		}
+		prefix := strings.ToLower(filepath.Clean(root)) + string(filepath.Separator)
+		if strings.HasPrefix(configPath, prefix) {
+			return root
+		}
+	}
+	return ""
+}
+
+// OneDriveConflictCopies 返回OneDrive同步冲突时生成的副本
+// OneDrive会将冲突的一方另存为storage-<计算机名>.json之类的文件，Cursor不会读取这些副本
+func (m *Manager) OneDriveConflictCopies() ([]string, error) {
+	ext := filepath.Ext(m.configPath)
+	base := strings.TrimSuffix(filepath.Base(m.configPath), ext)
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(m.configPath), base+"-*"+ext))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look for OneDrive conflict copies: %w", err)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// oneDriveExecutable 返回OneDrive客户端的路径
+func oneDriveExecutable() string {
+	return filepath.Join(os.Getenv("LOCALAPPDATA"), "Microsoft", "OneDrive", "OneDrive.exe")
+}
+
+// PauseOneDrive 关闭OneDrive客户端，避免写入期间同步产生冲突副本
+// OneDrive没有暂停同步的命令行接口，因此先关闭客户端，返回的函数用于重新启动它
+func PauseOneDrive() (func() error, error) {
+	if runtime.GOOS != "windows" {
+		return nil, fmt.Errorf("pausing OneDrive is only supported on Windows")
+	}
+
+	executable := oneDriveExecutable()
+	if _, err := os.Stat(executable); err != nil {
+		return nil, fmt.Errorf("OneDrive client not found at %s: %w", executable, err)
+	}
+	if output, err := exec.Command(executable, "/shutdown").CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to shut down OneDrive: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	resume := func() error {
+		if err := exec.Command(executable, "/background").Start(); err != nil {
+			return fmt.Errorf("failed to restart OneDrive: %w", err)
+		}
+		return nil
+	}
+	return resume, nil
+}
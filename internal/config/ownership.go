@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+)
+
+// fileOwner 表示写入文件后应恢复的所有者
+type fileOwner struct {
+	// 用户ID
+	uid int
+	// 组ID
+	gid int
+}
+
+// sudoOwner 从SUDO_UID/SUDO_GID获取调用sudo的原始用户
+// 在Windows上或未通过sudo运行时返回nil
+func sudoOwner() *fileOwner {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	uid, err := strconv.Atoi(os.Getenv("SUDO_UID"))
+	if err != nil {
+		return nil
+	}
+	gid, err := strconv.Atoi(os.Getenv("SUDO_GID"))
+	if err != nil {
+		return nil
+	}
+	return &fileOwner{uid: uid, gid: gid}
+}
+
+// restoreOwnership 将文件或目录的所有者恢复为原始用户
+// 当不需要恢复时（非sudo运行）直接返回nil
+func (m *Manager) restoreOwnership(paths ...string) error {
+	if m.owner == nil {
+		return nil
+	}
+	for _, path := range paths {
+		if err := os.Lchown(path, m.owner.uid, m.owner.gid); err != nil {
+			return fmt.Errorf("failed to restore ownership of %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// mkdirAllOwned 创建目录（含父目录），并把新创建的各级目录归还给原始用户
+func (m *Manager) mkdirAllOwned(dir string, perm os.FileMode) error {
+	// 记录需要新建的目录，从最深一级开始
+	var created []string
+	for current := filepath.Clean(dir); ; current = filepath.Dir(current) {
+		if _, err := os.Lstat(current); err == nil {
+			break
+		}
+		created = append(created, current)
+		if parent := filepath.Dir(current); parent == current {
+			break
+		}
+	}
+
+	if err := os.MkdirAll(dir, perm); err != nil {
+		return err
+	}
+	return m.restoreOwnership(created...)
+}
@@ -0,0 +1,193 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ProtectionLevel 表示写入storage.json后施加的写保护级别
+type ProtectionLevel string
+
+const (
+	// ProtectNone 不施加任何保护，文件权限为0666
+	ProtectNone ProtectionLevel = "none"
+	// ProtectReadOnly 将文件权限设置为0444，Cursor可以轻易恢复
+	ProtectReadOnly ProtectionLevel = "readonly"
+	// ProtectStrong 在只读基础上使用系统级保护：
+	// Windows使用拒绝写入的ACL，Linux使用chattr +i，macOS使用uchg标志
+	ProtectStrong ProtectionLevel = "strong"
+)
+
+// 所有人（Everyone）的SID，避免依赖本地化的组名
+const everyoneSID = "*S-1-1-0"
+
+// ParseProtectionLevel 解析保护级别字符串
+func ParseProtectionLevel(value string) (ProtectionLevel, error) {
+	switch level := ProtectionLevel(strings.ToLower(strings.TrimSpace(value))); level {
+	case "", ProtectNone:
+		return ProtectNone, nil
+	case ProtectReadOnly, ProtectStrong:
+		return level, nil
+	default:
+		return "", fmt.Errorf("unknown protection level: %s", value)
+	}
+}
+
+// fileMode 返回该保护级别对应的文件权限
+func (l ProtectionLevel) fileMode() os.FileMode {
+	if l == ProtectNone {
+		return 0666
+	}
+	return 0444
+}
+
+// WriteProtectedError 表示storage.json已被之前的运行施加了写保护
+type WriteProtectedError struct {
+	// 文件路径
+	Path string
+	// 检测到的保护级别
+	Level ProtectionLevel
+}
+
+// Error 实现error接口
+func (e *WriteProtectedError) Error() string {
+	return fmt.Sprintf("%s is write-protected (%s), remove the protection before writing", e.Path, e.Level)
+}
+
+// DetectProtection 检测storage.json当前的写保护级别，文件不存在时返回ProtectNone
+func (m *Manager) DetectProtection() (ProtectionLevel, error) {
+	info, err := os.Stat(m.configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ProtectNone, nil
+		}
+		return "", fmt.Errorf("failed to stat config file: %w", err)
+	}
+
+	if hasStrongProtection(m.realPath()) {
+		return ProtectStrong, nil
+	}
+	if info.Mode().Perm()&0222 == 0 {
+		return ProtectReadOnly, nil
+	}
+	return ProtectNone, nil
+}
+
+// Unprotect 移除storage.json上的系统级保护并恢复为可写
+func (m *Manager) Unprotect() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := os.Stat(m.configPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to stat config file: %w", err)
+	}
+
+	// 文件系统不支持系统级保护时移除会失败，此时以chmod的结果为准
+	protectErr := removeStrongProtection(m.realPath())
+	if err := os.Chmod(m.configPath, ProtectNone.fileMode()); err != nil {
+		if protectErr != nil {
+			return protectErr
+		}
+		return fmt.Errorf("failed to make config file writable: %w", err)
+	}
+	return nil
+}
+
+// Protect 对storage.json施加指定级别的写保护，不修改文件内容
+// 配置文件不存在时返回错误
+func (m *Manager) Protect(ctx context.Context, level ProtectionLevel) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := os.Stat(m.configPath); err != nil {
+		return fmt.Errorf("failed to stat config file: %w", err)
+	}
+	if err := os.Chmod(m.configPath, level.fileMode()); err != nil {
+		return fmt.Errorf("failed to change config file permissions: %w", err)
+	}
+	if level != ProtectStrong {
+		return nil
+	}
+	target, err := m.writeTarget()
+	if err != nil {
+		return err
+	}
+	return applyStrongProtection(ctx, target)
+}
+
+// applyStrongProtection 根据操作系统施加系统级写保护
+func applyStrongProtection(ctx context.Context, path string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.CommandContext(ctx, "icacls", path, "/deny", everyoneSID+":(W,D)")
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "chflags", "uchg", path)
+	case "linux":
+		cmd = exec.CommandContext(ctx, "chattr", "+i", path)
+	default:
+		return fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to apply strong protection: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// hasStrongProtection 检测文件上是否存在系统级写保护
+// Windows上直接读取DACL，其他系统上检测命令不可用或执行失败时视为没有保护
+func hasStrongProtection(path string) bool {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		return hasDenyWriteACE(path)
+	case "darwin":
+		cmd = exec.Command("ls", "-lO", path)
+	case "linux":
+		cmd = exec.Command("lsattr", path)
+	default:
+		return false
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return strings.Contains(string(output), "uchg")
+	default:
+		// lsattr输出格式为"----i---------e------- path"
+		fields := strings.Fields(string(output))
+		return len(fields) > 0 && strings.Contains(fields[0], "i")
+	}
+}
+
+// removeStrongProtection 根据操作系统移除系统级写保护
+func removeStrongProtection(path string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("icacls", path, "/remove:d", everyoneSID)
+	case "darwin":
+		cmd = exec.Command("chflags", "nouchg", path)
+	case "linux":
+		cmd = exec.Command("chattr", "-i", path)
+	default:
+		return fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove strong protection: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package config
+
+// hasDenyWriteACE 只在Windows上使用
+func hasDenyWriteACE(path string) bool {
+	return false
+}
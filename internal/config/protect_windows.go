@@ -0,0 +1,59 @@
+package config
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ACCESS_DENIED_ACE_TYPE，x/sys/windows中未定义
+const accessDeniedACEType = 1
+
+// aclHeader ACL的头部，之后依次为各ACE
+type aclHeader struct {
+	revision byte
+	sbz1     byte
+	size     uint16
+	aceCount uint16
+	sbz2     uint16
+}
+
+// accessACE ACCESS_ALLOWED_ACE和ACCESS_DENIED_ACE的布局，SID从sidStart开始
+type accessACE struct {
+	aceType  byte
+	aceFlags byte
+	aceSize  uint16
+	mask     uint32
+	sidStart uint32
+}
+
+// hasDenyWriteACE 检查文件的DACL中是否有本工具施加的拒绝写入规则，
+// 即针对所有人（Everyone）、拒绝写入数据的ACE；其他主体的拒绝规则不由本工具施加，也无法由Unprotect移除，因此不算
+func hasDenyWriteACE(path string) bool {
+	sd, err := windows.GetNamedSecurityInfo(path, windows.SE_FILE_OBJECT, windows.DACL_SECURITY_INFORMATION)
+	if err != nil {
+		return false
+	}
+	dacl, _, err := sd.DACL()
+	if err != nil || dacl == nil {
+		return false
+	}
+
+	everyone, err := windows.CreateWellKnownSid(windows.WinWorldSid)
+	if err != nil {
+		return false
+	}
+
+	header := (*aclHeader)(unsafe.Pointer(dacl))
+	entry := unsafe.Add(unsafe.Pointer(dacl), unsafe.Sizeof(*header))
+	for i := 0; i < int(header.aceCount); i++ {
+		ace := (*accessACE)(entry)
+		if ace.aceType == accessDeniedACEType && ace.mask&windows.FILE_WRITE_DATA != 0 {
+			if (*windows.SID)(unsafe.Pointer(&ace.sidStart)).Equals(everyone) {
+				return true
+			}
+		}
+		entry = unsafe.Add(entry, ace.aceSize)
+	}
+	return false
+}
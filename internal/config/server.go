@@ -0,0 +1,108 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// MachineIDFileTarget 由在单独的machineid文件中保存机器ID的目标实现，例如Cursor远程服务器
+type MachineIDFileTarget interface {
+	// MachineIDPath 返回指定用户的machineid文件路径
+	MachineIDPath(username string) (string, error)
+}
+
+// serverTarget 通过SSH远程开发时在服务器上运行的cursor-server
+// 其数据位于~/.cursor-server/data，进程为该目录下的node
+type serverTarget struct {
+	editorTarget
+	// 用户主目录下的服务器目录名，例如.cursor-server
+	dirName string
+}
+
+// dataDir 返回指定用户的服务器数据目录
+func (t *serverTarget) dataDir(username string) (string, error) {
+	var home string
+	switch runtime.GOOS {
+	case "linux":
+		home = filepath.Join("/home", username)
+		if username == "root" {
+			home = "/root"
+		}
+	case "darwin":
+		home = filepath.Join("/Users", username)
+	default:
+		return "", fmt.Errorf("%s is only supported on Linux and macOS", t.displayName)
+	}
+	return filepath.Join(home, t.dirName, "data"), nil
+}
+
+// ConfigPath 返回服务器的storage.json路径
+func (t *serverTarget) ConfigPath(username string) (string, error) {
+	dir, err := t.dataDir(username)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "User", "globalStorage", "storage.json"), nil
+}
+
+// MachineIDPath 返回服务器的machineid文件路径
+func (t *serverTarget) MachineIDPath(username string) (string, error) {
+	dir, err := t.dataDir(username)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "machineid"), nil
+}
+
+// ProcessPatterns 返回进程名称模式，服务器进程为node，只在无法读取可执行文件路径时按名称匹配
+func (t *serverTarget) ProcessPatterns() []string {
+	return []string{t.name}
+}
+
+// InstallDirs 返回服务器的安装目录，即各用户主目录下的服务器目录
+func (t *serverTarget) InstallDirs() []string {
+	switch runtime.GOOS {
+	case "linux":
+		return []string{filepath.Join("/home", "*", t.dirName), filepath.Join("/root", t.dirName)}
+	case "darwin":
+		return []string{filepath.Join("/Users", "*", t.dirName)}
+	default:
+		return nil
+	}
+}
+
+// ResetMachineIDFile 将目标的machineid文件替换为新的ID，返回原来的值
+// 目标没有machineid文件或文件不存在时不做修改，返回空字符串
+func (m *Manager) ResetMachineIDFile(username, id string) (string, error) {
+	target, ok := m.target.(MachineIDFileTarget)
+	if !ok {
+		return "", nil
+	}
+	path, err := target.MachineIDPath(username)
+	if err != nil {
+		return "", err
+	}
+
+	old, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read machine ID file: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read machine ID file: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(id), info.Mode().Perm()); err != nil {
+		return "", fmt.Errorf("failed to write machine ID file: %w", err)
+	}
+	if err := m.restoreOwnership(path); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(old)), nil
+}
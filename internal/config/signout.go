@@ -0,0 +1,131 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 保存Cursor登录状态的数据库文件名，位于globalStorage目录
+const stateDatabaseFile = "state.vscdb"
+
+// 清除登录状态时删除的state.vscdb键，使用SQL LIKE语法
+var authKeyPatterns = []string{
+	"cursorAuth/%",      // 访问令牌、刷新令牌、缓存的邮箱等
+	"secret://%cursor%", // Cursor相关的Secret Storage条目
+}
+
+// SignOutResult 表示清除登录状态的结果
+type SignOutResult struct {
+	// 操作前创建的备份文件或目录
+	BackupPaths []string
+	// 从state.vscdb中删除的键数量
+	RemovedKeys int
+	// 是否移除了Session Storage目录
+	SessionStorageRemoved bool
+}
+
+// StateDatabasePath 返回state.vscdb的路径
+func (m *Manager) StateDatabasePath() string {
+	return filepath.Join(m.GlobalStorageDir(), stateDatabaseFile)
+}
+
+// sessionStorageDir 返回Electron的Session Storage目录，与User目录同级
+func (m *Manager) sessionStorageDir() string {
+	userDir := filepath.Dir(m.GlobalStorageDir())
+	return filepath.Join(filepath.Dir(userDir), "Session Storage")
+}
+
+// SignOut 清除Cursor缓存的登录令牌和会话数据，操作前先备份
+// 修改state.vscdb需要系统中安装sqlite3命令行工具，ctx被取消时终止sqlite3
+func (m *Manager) SignOut(ctx context.Context) (*SignOutResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := &SignOutResult{}
+	stamp := time.Now().Format(backupTimeFormat)
+	if err := m.mkdirAllOwned(m.BackupDir(), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	// 备份并清理state.vscdb中的登录键
+	dbPath := m.StateDatabasePath()
+	if _, err := os.Stat(dbPath); err == nil {
+		backupPath := filepath.Join(m.BackupDir(), stateDatabaseFile+".backup_"+stamp)
+		if err := copyFile(dbPath, backupPath, 0600); err != nil {
+			return nil, fmt.Errorf("failed to back up %s: %w", stateDatabaseFile, err)
+		}
+		if err := m.restoreOwnership(backupPath); err != nil {
+			return nil, err
+		}
+		result.BackupPaths = append(result.BackupPaths, backupPath)
+
+		removed, err := deleteAuthKeys(ctx, dbPath)
+		if err != nil {
+			return result, err
+		}
+		result.RemovedKeys = removed
+	}
+
+	// 将Session Storage目录移动到备份目录，相当于备份后删除
+	sessionDir := m.sessionStorageDir()
+	if _, err := os.Stat(sessionDir); err == nil {
+		backupPath := filepath.Join(m.BackupDir(), "Session Storage.backup_"+stamp)
+		if err := os.Rename(sessionDir, backupPath); err != nil {
+			return result, fmt.Errorf("failed to remove Session Storage: %w", err)
+		}
+		result.BackupPaths = append(result.BackupPaths, backupPath)
+		result.SessionStorageRemoved = true
+	}
+
+	return result, nil
+}
+
+// deleteAuthKeys 使用sqlite3命令行工具删除登录相关的键，返回删除的数量
+func deleteAuthKeys(ctx context.Context, dbPath string) (int, error) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		return 0, fmt.Errorf("sqlite3 command not found, please install it to clear the login state")
+	}
+
+	conditions := make([]string, len(authKeyPatterns))
+	for i, pattern := range authKeyPatterns {
+		conditions[i] = fmt.Sprintf("key LIKE '%s'", pattern)
+	}
+	query := fmt.Sprintf("DELETE FROM ItemTable WHERE %s; SELECT changes();", strings.Join(conditions, " OR "))
+
+	output, err := exec.CommandContext(ctx, "sqlite3", dbPath, query).CombinedOutput()
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear login state: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	removed, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected sqlite3 output: %s", strings.TrimSpace(string(output)))
+	}
+	return removed, nil
+}
+
+// copyFile 复制文件内容到目标路径
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
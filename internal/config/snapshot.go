@@ -0,0 +1,245 @@
+package config
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// 快照归档文件名前缀
+const snapshotFilePrefix = "globalStorage_snapshot_"
+
+// renameDir 恢复快照时移动目录使用的函数，测试中替换以模拟移动失败
+var renameDir = os.Rename
+
+// GlobalStorageDir 返回storage.json所在的globalStorage目录
+func (m *Manager) GlobalStorageDir() string {
+	return filepath.Dir(m.configPath)
+}
+
+// Snapshot 将整个globalStorage目录（包括state.vscdb）打包为tar.gz归档
+// archivePath为空时保存到备份目录，备份目录本身不会被打包
+func (m *Manager) Snapshot(archivePath string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if archivePath == "" {
+		if err := m.mkdirAllOwned(m.BackupDir(), 0755); err != nil {
+			return "", fmt.Errorf("failed to create backup directory: %w", err)
+		}
+		archivePath = filepath.Join(m.BackupDir(), snapshotFilePrefix+time.Now().Format(backupTimeFormat)+".tar.gz")
+	}
+
+	file, err := os.OpenFile(archivePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create snapshot archive: %w", err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	root := m.GlobalStorageDir()
+	walkErr := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		// 跳过备份目录和归档文件本身
+		if path == m.BackupDir() {
+			return filepath.SkipDir
+		}
+		if path == root || path == archivePath {
+			return nil
+		}
+		return addToArchive(tarWriter, root, path, info)
+	})
+	if walkErr != nil {
+		os.Remove(archivePath)
+		return "", fmt.Errorf("failed to archive global storage: %w", walkErr)
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		os.Remove(archivePath)
+		return "", fmt.Errorf("failed to finish snapshot archive: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		os.Remove(archivePath)
+		return "", fmt.Errorf("failed to finish snapshot archive: %w", err)
+	}
+	if err := m.restoreOwnership(archivePath); err != nil {
+		return "", err
+	}
+	return archivePath, nil
+}
+
+// RestoreSnapshot 用快照归档替换globalStorage目录的内容
+// 先解压到临时目录，成功后再整体替换，备份目录会被保留
+func (m *Manager) RestoreSnapshot(archivePath string) error {
+	// 写保护可能阻止移动目录，先移除
+	if err := m.Unprotect(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	root := m.GlobalStorageDir()
+	stamp := time.Now().Format(backupTimeFormat)
+	stagingDir := root + ".restore_" + stamp
+	if err := extractSnapshot(archivePath, stagingDir); err != nil {
+		os.RemoveAll(stagingDir)
+		return err
+	}
+
+	// 任何一步失败时撤销已完成的移动：先放回原目录，再把备份目录移回其中
+	oldDir := root + ".old_" + stamp
+	stagedBackups := filepath.Join(stagingDir, backupDirName)
+	movedBackups, movedRoot := false, false
+	rollback := func(cause error) error {
+		errs := []error{cause}
+		if movedRoot {
+			if err := renameDir(oldDir, root); err != nil {
+				errs = append(errs, fmt.Errorf("failed to put original global storage back, it is kept at %s: %w", oldDir, err))
+			}
+		}
+		if movedBackups {
+			if err := renameDir(stagedBackups, m.BackupDir()); err != nil {
+				errs = append(errs, fmt.Errorf("failed to put backup directory back, it is kept at %s: %w", stagedBackups, err))
+			}
+		}
+		// 暂存目录中仍有未能移回的备份时保留它
+		if len(errs) == 1 {
+			os.RemoveAll(stagingDir)
+		}
+		return errors.Join(errs...)
+	}
+
+	// 把位于globalStorage中的备份目录移动到新目录中，避免丢失历史备份
+	if _, err := os.Stat(m.BackupDir()); err == nil && filepath.Dir(m.BackupDir()) == root {
+		if err := renameDir(m.BackupDir(), stagedBackups); err != nil {
+			return rollback(fmt.Errorf("failed to preserve backup directory: %w", err))
+		}
+		movedBackups = true
+	}
+
+	if err := renameDir(root, oldDir); err != nil {
+		if !os.IsNotExist(err) {
+			return rollback(fmt.Errorf("failed to move current global storage aside: %w", err))
+		}
+	} else {
+		movedRoot = true
+	}
+	if err := renameDir(stagingDir, root); err != nil {
+		return rollback(fmt.Errorf("failed to activate restored global storage: %w", err))
+	}
+	os.RemoveAll(oldDir)
+
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return m.restoreOwnership(path)
+	})
+}
+
+// addToArchive 将单个文件或目录写入tar归档，其他类型的文件被忽略
+func addToArchive(tarWriter *tar.Writer, root, path string, info os.FileInfo) error {
+	if !info.Mode().IsRegular() && !info.IsDir() {
+		return nil
+	}
+
+	relPath, err := filepath.Rel(root, path)
+	if err != nil {
+		return err
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(relPath)
+	if info.IsDir() {
+		header.Name += "/"
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(tarWriter, file)
+	return err
+}
+
+// extractSnapshot 将快照归档解压到目标目录，拒绝指向目录之外的条目
+func extractSnapshot(archivePath, destDir string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot archive: %w", err)
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot archive: %w", err)
+	}
+	defer gzipReader.Close()
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read snapshot archive: %w", err)
+		}
+
+		target := filepath.Join(destDir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(destDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("snapshot entry %q escapes the target directory", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := writeArchiveEntry(tarReader, target, os.FileMode(header.Mode).Perm()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeArchiveEntry 将归档条目的内容写入文件
+func writeArchiveEntry(reader io.Reader, target string, perm os.FileMode) error {
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, reader); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// snapshotFixture 创建包含storage.json和备份目录的globalStorage，返回对应的配置管理器
+func snapshotFixture(t *testing.T) (*Manager, string) {
+	t.Helper()
+	root := filepath.Join(t.TempDir(), "globalStorage")
+	if err := os.MkdirAll(filepath.Join(root, backupDirName), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"storage.json":                           `{"telemetry.machineId": "original"}`,
+		filepath.Join(backupDirName, "previous"): "backup",
+	} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manager, err := NewManagerForPath(filepath.Join(root, "storage.json"), DefaultTarget())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return manager, root
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	manager, root := snapshotFixture(t)
+	archive, err := manager.Snapshot(filepath.Join(t.TempDir(), "snapshot.tar.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(manager.ConfigPath(), []byte(`{"telemetry.machineId": "changed"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := manager.RestoreSnapshot(archive); err != nil {
+		t.Fatal(err)
+	}
+	assertFileContent(t, manager.ConfigPath(), `{"telemetry.machineId": "original"}`)
+	assertFileContent(t, filepath.Join(root, backupDirName, "previous"), "backup")
+	assertNoLeftovers(t, root)
+}
+
+func TestRestoreSnapshotRollsBackWhenActivationFails(t *testing.T) {
+	manager, root := snapshotFixture(t)
+	archive, err := manager.Snapshot(filepath.Join(t.TempDir(), "snapshot.tar.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(manager.ConfigPath(), []byte(`{"telemetry.machineId": "current"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// 模拟把解压后的目录移动到原位置时失败
+	defer func(original func(string, string) error) { renameDir = original }(renameDir)
+	renameDir = func(oldPath, newPath string) error {
+		if strings.Contains(oldPath, ".restore_") && newPath == root {
+			return errors.New("simulated failure")
+		}
+		return os.Rename(oldPath, newPath)
+	}
+
+	if err := manager.RestoreSnapshot(archive); err == nil {
+		t.Fatal("expected RestoreSnapshot to fail")
+	}
+	assertFileContent(t, manager.ConfigPath(), `{"telemetry.machineId": "current"}`)
+	assertFileContent(t, filepath.Join(root, backupDirName, "previous"), "backup")
+	assertNoLeftovers(t, root)
+}
+
+func assertFileContent(t *testing.T, path, want string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != want {
+		t.Errorf("%s = %q, want %q", path, data, want)
+	}
+}
+
+// assertNoLeftovers 检查恢复后没有留下暂存目录或旧目录
+func assertNoLeftovers(t *testing.T, root string) {
+	t.Helper()
+	entries, err := os.ReadDir(filepath.Dir(root))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != filepath.Base(root) {
+			t.Errorf("unexpected leftover %s", entry.Name())
+		}
+	}
+}
@@ -0,0 +1,391 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ConfigStore 抽象storage.json的读写操作
+// Manager操作真实文件系统，MemoryStore将数据保存在内存中，便于测试主流程和子命令
+type ConfigStore interface {
+	// Target 返回对应的编辑器目标
+	Target() Target
+	// ConfigPath 返回storage.json的路径
+	ConfigPath() string
+	// ReadConfig 读取现有配置，不存在时返回nil
+	ReadConfig(ctx context.Context) (*StorageConfig, error)
+	// SaveConfig 保存配置并施加指定级别的写保护
+	SaveConfig(ctx context.Context, config *StorageConfig, protection ProtectionLevel) (*SaveResult, error)
+	// SaveConfigWithOptions 按照指定选项保存配置
+	SaveConfigWithOptions(ctx context.Context, config *StorageConfig, opts SaveOptions) (*SaveResult, error)
+	// Backup 备份当前配置，返回备份路径
+	Backup(opts BackupOptions) (string, error)
+	// RestoreBackup 从备份恢复配置
+	RestoreBackup(backupPath string, passphrase string) error
+	// DetectProtection 检测配置文件当前的写保护级别
+	DetectProtection() (ProtectionLevel, error)
+	// Unprotect 移除配置文件的写保护
+	Unprotect() error
+	// SaveGuardSnapshot 记录期望的遥测ID
+	SaveGuardSnapshot(config *StorageConfig) error
+	// LoadGuardSnapshot 读取期望的遥测ID，不存在时返回nil
+	LoadGuardSnapshot() (*StorageConfig, error)
+	// Guard 检查遥测ID是否被改写，如被改写则重新写入快照中的值
+	Guard(ctx context.Context, protection ProtectionLevel) (*GuardResult, error)
+	// StateDatabasePath 返回保存登录状态的state.vscdb路径
+	StateDatabasePath() string
+	// SignOut 备份后清除缓存的登录状态
+	SignOut(ctx context.Context) (*SignOutResult, error)
+	// OneDriveFolder 返回包含storage.json的OneDrive同步根目录，不在OneDrive中时返回空字符串
+	OneDriveFolder() string
+	// OneDriveConflictCopies 返回OneDrive同步冲突生成的storage.json副本
+	OneDriveConflictCopies() ([]string, error)
+}
+
+// 确保两种实现都满足接口
+var (
+	_ ConfigStore = (*Manager)(nil)
+	_ ConfigStore = (*MemoryStore)(nil)
+)
+
+// MemoryStore 基于内存的ConfigStore实现，不访问文件系统
+type MemoryStore struct {
+	// 互斥锁，保证并发安全
+	mu sync.Mutex
+	// 编辑器目标
+	target Target
+	// 虚拟的配置文件路径
+	configPath string
+	// 配置文件内容，nil表示文件不存在
+	data []byte
+	// 当前的写保护级别
+	protection ProtectionLevel
+	// 备份内容，键为备份路径
+	backups map[string][]byte
+	// 守护快照
+	guard *StorageConfig
+	// state.vscdb中的键值，nil表示数据库不存在
+	state map[string]string
+}
+
+// NewMemoryStore 创建内存配置存储，initial为nil表示配置文件不存在
+func NewMemoryStore(target Target, initial []byte) *MemoryStore {
+	if target == nil {
+		target = DefaultTarget()
+	}
+	var data []byte
+	if initial != nil {
+		data = append([]byte(nil), initial...)
+	}
+	return &MemoryStore{
+		target:     target,
+		configPath: path.Join("memory:", target.DisplayName(), "User", "globalStorage", "storage.json"),
+		data:       data,
+		protection: ProtectNone,
+		backups:    make(map[string][]byte),
+	}
+}
+
+// Target 返回编辑器目标
+func (s *MemoryStore) Target() Target {
+	return s.target
+}
+
+// ConfigPath 返回虚拟的storage.json路径
+func (s *MemoryStore) ConfigPath() string {
+	return s.configPath
+}
+
+// Data 返回当前配置内容的副本，配置不存在时返回nil
+func (s *MemoryStore) Data() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data == nil {
+		return nil
+	}
+	return append([]byte(nil), s.data...)
+}
+
+// SetProtection 模拟之前的运行施加的写保护
+func (s *MemoryStore) SetProtection(level ProtectionLevel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.protection = level
+}
+
+// Backups 返回所有备份路径
+func (s *MemoryStore) Backups() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	paths := make([]string, 0, len(s.backups))
+	for p := range s.backups {
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// ReadConfig 解析内存中的配置
+func (s *MemoryStore) ReadConfig(ctx context.Context) (*StorageConfig, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data == nil {
+		return nil, nil
+	}
+
+	var config StorageConfig
+	if err := json.Unmarshal(s.data, &config); err != nil {
+		if lenientErr := json.Unmarshal(stripJSONC(s.data), &config); lenientErr != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	}
+	return &config, nil
+}
+
+// SaveConfig 保存配置
+func (s *MemoryStore) SaveConfig(ctx context.Context, config *StorageConfig, protection ProtectionLevel) (*SaveResult, error) {
+	return s.SaveConfigWithOptions(ctx, config, SaveOptions{Protection: protection})
+}
+
+// SaveConfigWithOptions 按照与Manager相同的合并规则更新内存中的配置
+func (s *MemoryStore) SaveConfigWithOptions(ctx context.Context, config *StorageConfig, opts SaveOptions) (*SaveResult, error) {
+	if opts.Strategy == "" {
+		opts.Strategy = StrategyOverwrite
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.protection != ProtectNone {
+		return nil, &WriteProtectedError{Path: s.configPath, Level: s.protection}
+	}
+
+	result := &SaveResult{Path: s.configPath, Protection: opts.Protection}
+	if opts.Backup != nil {
+		backupPath, err := s.backupLocked(*opts.Backup)
+		if err != nil {
+			return nil, err
+		}
+		result.BackupPath = backupPath
+	}
+
+	content, written, err := mergeStorageJSON(s.data, s.target.TelemetryKeys(), config, opts)
+	if err != nil {
+		return result, fmt.Errorf("failed to prepare config: %w", err)
+	}
+	result.Before = s.data
+	result.After = content
+	s.data = content
+	s.protection = opts.Protection
+	result.Written = written
+	result.BytesWritten = len(content)
+	result.FileMode = opts.Protection.fileMode()
+	return result, nil
+}
+
+// Backup 在内存中备份当前配置
+func (s *MemoryStore) Backup(opts BackupOptions) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.backupLocked(opts)
+}
+
+// backupLocked 执行备份，调用者需持有锁
+func (s *MemoryStore) backupLocked(opts BackupOptions) (string, error) {
+	if s.data == nil {
+		return "", nil
+	}
+
+	data := append([]byte(nil), s.data...)
+	// 同一秒内的多次备份追加序号，避免互相覆盖
+	backupPath := path.Join(path.Dir(s.configPath), backupDirName, backupFilePrefix+time.Now().Format(backupTimeFormat))
+	if _, exists := s.backups[backupPath]; exists {
+		backupPath = fmt.Sprintf("%s_%d", backupPath, len(s.backups))
+	}
+	if opts.Passphrase != "" {
+		var err error
+		if data, err = encryptBackup(data, opts.Passphrase); err != nil {
+			return "", err
+		}
+		backupPath += encryptedBackupSuffix
+	}
+	s.backups[backupPath] = data
+	return backupPath, nil
+}
+
+// RestoreBackup 从内存中的备份恢复配置
+func (s *MemoryStore) RestoreBackup(backupPath string, passphrase string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok := s.backups[backupPath]
+	if !ok {
+		return fmt.Errorf("failed to read backup: %s does not exist", backupPath)
+	}
+	if isEncryptedBackup(data) {
+		var err error
+		if data, err = decryptBackup(data, passphrase); err != nil {
+			return err
+		}
+	}
+	if !json.Valid(data) {
+		return fmt.Errorf("backup %s is not valid JSON", backupPath)
+	}
+
+	s.data = append([]byte(nil), data...)
+	s.protection = ProtectNone
+	return nil
+}
+
+// DetectProtection 返回当前模拟的写保护级别
+func (s *MemoryStore) DetectProtection() (ProtectionLevel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.protection, nil
+}
+
+// Unprotect 移除模拟的写保护
+func (s *MemoryStore) Unprotect() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.protection = ProtectNone
+	return nil
+}
+
+// SaveGuardSnapshot 记录期望的遥测ID
+func (s *MemoryStore) SaveGuardSnapshot(config *StorageConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := *config
+	s.guard = &snapshot
+	return nil
+}
+
+// LoadGuardSnapshot 返回期望的遥测ID，未记录时返回nil
+func (s *MemoryStore) LoadGuardSnapshot() (*StorageConfig, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.guard == nil {
+		return nil, nil
+	}
+	snapshot := *s.guard
+	return &snapshot, nil
+}
+
+// Guard 检查内存中的遥测ID是否被改写，如被改写则重新写入快照中的值
+func (s *MemoryStore) Guard(ctx context.Context, protection ProtectionLevel) (*GuardResult, error) {
+	return guardStore(ctx, s, protection)
+}
+
+// StateDatabasePath 返回虚拟的state.vscdb路径
+func (s *MemoryStore) StateDatabasePath() string {
+	return path.Join(path.Dir(s.configPath), stateDatabaseFile)
+}
+
+// SetState 模拟state.vscdb中的键值，nil表示数据库不存在
+func (s *MemoryStore) SetState(state map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = nil
+	if state != nil {
+		s.state = make(map[string]string, len(state))
+		for key, value := range state {
+			s.state[key] = value
+		}
+	}
+}
+
+// State 返回模拟的state.vscdb中剩余的键
+func (s *MemoryStore) State() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := make(map[string]string, len(s.state))
+	for key, value := range s.state {
+		state[key] = value
+	}
+	return state
+}
+
+// SignOut 按照与Manager相同的键模式删除模拟的登录状态，删除前先备份
+func (s *MemoryStore) SignOut(ctx context.Context) (*SignOutResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := &SignOutResult{}
+	if s.state == nil {
+		return result, nil
+	}
+
+	data, err := json.Marshal(s.state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to back up %s: %w", stateDatabaseFile, err)
+	}
+	backupPath := path.Join(path.Dir(s.configPath), backupDirName, stateDatabaseFile+".backup_"+time.Now().Format(backupTimeFormat))
+	s.backups[backupPath] = data
+	result.BackupPaths = append(result.BackupPaths, backupPath)
+
+	for key := range s.state {
+		for _, pattern := range authKeyPatterns {
+			if matchLike(pattern, key) {
+				delete(s.state, key)
+				result.RemovedKeys++
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// OneDriveFolder 内存存储不位于OneDrive中
+func (s *MemoryStore) OneDriveFolder() string {
+	return ""
+}
+
+// OneDriveConflictCopies 内存存储不会产生冲突副本
+func (s *MemoryStore) OneDriveConflictCopies() ([]string, error) {
+	return nil, nil
+}
+
+// matchLike 按SQLite LIKE的规则匹配，%匹配任意字符序列，_匹配单个字符，ASCII字母不区分大小写
+func matchLike(pattern, value string) bool {
+	pattern, value = strings.ToLower(pattern), strings.ToLower(value)
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '%':
+			for i := 0; i <= len(value); i++ {
+				if matchLike(pattern[1:], value[i:]) {
+					return true
+				}
+			}
+			return false
+		case '_':
+			if value == "" {
+				return false
+			}
+			_, size := utf8.DecodeRuneInString(value)
+			pattern, value = pattern[1:], value[size:]
+		default:
+			if value == "" || pattern[0] != value[0] {
+				return false
+			}
+			pattern, value = pattern[1:], value[1:]
+		}
+	}
+	return value == ""
+}
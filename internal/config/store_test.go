@@ -0,0 +1,123 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// storeFixture 模拟Cursor改写了遥测ID之后的storage.json
+const storeFixture = `{
+    "window.zoomLevel": 1,
+    "telemetry.machineId": "overwritten",
+    "telemetry.macMachineId": "mac",
+    "telemetry.devDeviceId": "device",
+    "telemetry.sqmId": "{SQM}"
+}`
+
+func TestMemoryStoreGuardReappliesSnapshot(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(nil, []byte(storeFixture))
+	expected := &StorageConfig{
+		TelemetryMachineId:    "expected",
+		TelemetryMacMachineId: "mac",
+		TelemetryDevDeviceId:  "device",
+		TelemetrySqmId:        "{SQM}",
+	}
+	if err := store.SaveGuardSnapshot(expected); err != nil {
+		t.Fatal(err)
+	}
+	store.SetProtection(ProtectReadOnly)
+
+	result, err := store.Guard(ctx, ProtectReadOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Reapplied || len(result.ChangedKeys) != 1 || result.ChangedKeys[0] != "telemetry.machineId" {
+		t.Fatalf("unexpected guard result: %+v", result)
+	}
+	current, err := store.ReadConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.TelemetryMachineId != "expected" {
+		t.Errorf("machineId = %q, want %q", current.TelemetryMachineId, "expected")
+	}
+	if level, _ := store.DetectProtection(); level != ProtectReadOnly {
+		t.Errorf("protection = %v, want %v", level, ProtectReadOnly)
+	}
+
+	// 第二次检查时值已一致，不应再次写入
+	result, err = store.Guard(ctx, ProtectReadOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Reapplied {
+		t.Errorf("second check re-applied %v", result.ChangedKeys)
+	}
+}
+
+func TestMemoryStoreGuardWithoutSnapshot(t *testing.T) {
+	store := NewMemoryStore(nil, []byte(storeFixture))
+	if _, err := store.Guard(context.Background(), ProtectNone); err == nil {
+		t.Fatal("expected an error without a guard snapshot")
+	}
+}
+
+func TestMemoryStoreSaveRespectsProtection(t *testing.T) {
+	store := NewMemoryStore(nil, []byte(storeFixture))
+	store.SetProtection(ProtectReadOnly)
+
+	_, err := store.SaveConfig(context.Background(), &StorageConfig{TelemetryMachineId: "new"}, ProtectNone)
+	var protected *WriteProtectedError
+	if !errors.As(err, &protected) {
+		t.Fatalf("expected WriteProtectedError, got %v", err)
+	}
+}
+
+func TestMemoryStoreSignOut(t *testing.T) {
+	store := NewMemoryStore(nil, nil)
+	store.SetState(map[string]string{
+		"cursorAuth/accessToken":        "token",
+		"cursorAuth/cachedEmail":        "user@example.com",
+		"secret://{\"key\":\"cursor\"}": "secret",
+		"workbench.panel.height":        "300",
+	})
+
+	result, err := store.SignOut(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RemovedKeys != 3 {
+		t.Errorf("RemovedKeys = %d, want 3", result.RemovedKeys)
+	}
+	if len(result.BackupPaths) != 1 {
+		t.Errorf("BackupPaths = %v, want one backup", result.BackupPaths)
+	}
+	state := store.State()
+	if len(state) != 1 || state["workbench.panel.height"] != "300" {
+		t.Errorf("unexpected remaining state: %v", state)
+	}
+}
+
+func TestMatchLike(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"cursorAuth/%", "cursorAuth/accessToken", true},
+		{"cursorAuth/%", "CURSORAUTH/refreshToken", true},
+		{"cursorAuth/%", "cursorAuth", false},
+		{"secret://%cursor%", "secret://{\"extensionId\":\"anysphere.cursor\"}", true},
+		{"secret://%cursor%", "secret://github", false},
+		{"a_c", "abc", true},
+		{"a_c", "ac", false},
+		{"%", "", true},
+	}
+	for _, tt := range tests {
+		if got := matchLike(tt.pattern, tt.value); got != tt.want {
+			t.Errorf("matchLike(%q, %q) = %v, want %v", tt.pattern, tt.value, got, tt.want)
+		}
+	}
+}
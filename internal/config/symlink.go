@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 解析符号链接的最大层数，超过时视为循环链接
+const maxSymlinkDepth = 40
+
+// SymlinkOutsideError 表示storage.json通过符号链接指向了编辑器数据目录之外的文件
+type SymlinkOutsideError struct {
+	// 配置文件路径
+	Path string
+	// 符号链接最终指向的文件
+	Target string
+	// 期望的数据目录
+	Root string
+}
+
+// Error 实现error接口
+func (e *SymlinkOutsideError) Error() string {
+	return fmt.Sprintf("%s resolves to %s, which is outside %s", e.Path, e.Target, e.Root)
+}
+
+// SetRefuseExternalSymlinks 设置是否拒绝写入通过符号链接指向数据目录之外的storage.json
+func (m *Manager) SetRefuseExternalSymlinks(refuse bool) {
+	m.refuseExternalLinks = refuse
+}
+
+// writeTarget 返回写入时实际要替换的文件
+// storage.json或其父目录是符号链接（例如由dotfile管理工具创建）时，
+// 直接在链接所在位置重命名会用普通文件替换链接，因此改为写入链接指向的真实文件
+func (m *Manager) writeTarget() (string, error) {
+	target, err := resolveSymlinks(m.configPath)
+	if err != nil {
+		return "", err
+	}
+	if !m.refuseExternalLinks || target == m.configPath {
+		return target, nil
+	}
+
+	root := m.expectedRoot()
+	if rel, err := filepath.Rel(root, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", &SymlinkOutsideError{Path: m.configPath, Target: target, Root: root}
+	}
+	return target, nil
+}
+
+// expectedRoot 返回编辑器的数据目录（User目录的上一级），作为符号链接允许指向的范围
+// 数据目录所在的上级目录（例如整个~/.config）本身是链接时视为正常
+func (m *Manager) expectedRoot() string {
+	root := filepath.Dir(filepath.Dir(m.GlobalStorageDir()))
+	if parent, err := filepath.EvalSymlinks(filepath.Dir(root)); err == nil {
+		return filepath.Join(parent, filepath.Base(root))
+	}
+	return root
+}
+
+// realPath 返回storage.json解析符号链接后的真实路径，解析失败时返回原路径
+// chattr、chflags等命令不会跟随符号链接，需要作用于真实文件
+func (m *Manager) realPath() string {
+	if target, err := resolveSymlinks(m.configPath); err == nil {
+		return target
+	}
+	return m.configPath
+}
+
+// resolveSymlinks 解析路径中的所有符号链接，返回真实文件的路径
+// 与filepath.EvalSymlinks不同，文件本身不存在（包括悬空链接）时仍返回链接最终指向的位置
+func resolveSymlinks(path string) (string, error) {
+	for depth := 0; ; depth++ {
+		if depth == maxSymlinkDepth {
+			return "", fmt.Errorf("failed to resolve %s: too many levels of symbolic links", path)
+		}
+		info, err := os.Lstat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				break
+			}
+			return "", fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			break
+		}
+		link, err := os.Readlink(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read symbolic link %s: %w", path, err)
+		}
+		if !filepath.IsAbs(link) {
+			link = filepath.Join(filepath.Dir(path), link)
+		}
+		path = link
+	}
+
+	// 父目录中的链接不影响重命名，但解析后才能正确判断文件所在的目录
+	dir, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return filepath.Clean(path), nil
+		}
+		return "", fmt.Errorf("failed to resolve %s: %w", filepath.Dir(path), err)
+	}
+	return filepath.Join(dir, filepath.Base(path)), nil
+}
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/yuaotian/go-cursor-help/internal/process"
+)
+
+// storage.json中的遥测键
+const (
+	keyMacMachineID = "telemetry.macMachineId"
+	keyMachineID    = "telemetry.machineId"
+	keyDevDeviceID  = "telemetry.devDeviceId"
+	keySqmID        = "telemetry.sqmId"
+)
+
+// Target 描述一个可以重置遥测ID的编辑器
+type Target interface {
+	// Name 返回目标的标识名，用于--editor参数
+	Name() string
+	// DisplayName 返回展示给用户的产品名称
+	DisplayName() string
+	// ConfigPath 返回指定用户的storage.json路径
+	ConfigPath(username string) (string, error)
+	// TelemetryKeys 返回需要重写的遥测键
+	TelemetryKeys() []string
+	// ProcessPatterns 返回用于查找编辑器进程的名称模式
+	ProcessPatterns() []string
+	// InstallDirs 返回编辑器的常见安装目录，只终止可执行文件位于这些目录中的进程
+	InstallDirs() []string
+}
+
+// editorTarget 基于VS Code目录结构的编辑器目标
+type editorTarget struct {
+	// 标识名
+	name string
+	// 产品名称，同时也是配置目录名和进程名
+	displayName string
+	// 配置目录名，为空时使用产品名称
+	dataDir string
+	// 需要重写的遥测键
+	keys []string
+	// 安装目录是否遵循Cursor的命名方式（以产品名称命名），是时按可执行文件路径查找进程
+	namedInstallDirs bool
+	// 安装目录名与产品名称不一致的编辑器（VS Code、VSCodium）的安装位置和可执行文件名
+	layout *installLayout
+}
+
+// installLayout 安装目录名与产品名称不一致的编辑器的安装位置
+type installLayout struct {
+	// Windows上Programs和Program Files中的目录名
+	windowsDir string
+	// macOS上的应用程序包名，不含.app
+	darwinApp string
+	// Linux上的安装目录
+	linuxDirs []string
+	// 可执行文件名，不含.exe；只在无法读取可执行文件路径时按名称精确匹配
+	executables []string
+}
+
+// VS Code和VSCodium的安装位置，名称中的code过于常见，不能按名称子串查找进程
+var (
+	vscodeLayout = &installLayout{
+		windowsDir:  "Microsoft VS Code",
+		darwinApp:   "Visual Studio Code",
+		linuxDirs:   []string{"/usr/share/code", "/usr/lib/code", "/opt/visual-studio-code", "/snap/code"},
+		executables: []string{"code"},
+	}
+	vscodiumLayout = &installLayout{
+		windowsDir:  "VSCodium",
+		darwinApp:   "VSCodium",
+		linuxDirs:   []string{"/usr/share/codium", "/opt/vscodium-bin", "/opt/VSCodium", "/snap/codium"},
+		executables: []string{"codium", "vscodium"},
+	}
+)
+
+// installDirs 返回当前系统上的安装目录，其中的*匹配任意用户名
+func (l *installLayout) installDirs() []string {
+	switch runtime.GOOS {
+	case "windows":
+		systemDrive := os.Getenv("SystemDrive") + `\`
+		return []string{
+			filepath.Join(systemDrive, "Users", "*", "AppData", "Local", "Programs", l.windowsDir),
+			filepath.Join(os.Getenv("ProgramFiles"), l.windowsDir),
+		}
+	case "darwin":
+		return []string{
+			filepath.Join("/Applications", l.darwinApp+".app"),
+			filepath.Join("/Users", "*", "Applications", l.darwinApp+".app"),
+		}
+	case "linux":
+		return l.linuxDirs
+	default:
+		return nil
+	}
+}
+
+// processPatterns 返回不带通配符的可执行文件名
+func (l *installLayout) processPatterns() []string {
+	var patterns []string
+	for _, name := range l.executables {
+		patterns = append(patterns, name, name+".exe")
+	}
+	return patterns
+}
+
+// 所有编辑器都写入的遥测键
+var commonTelemetryKeys = []string{keyMachineID, keyDevDeviceID, keySqmID}
+
+// 包含macMachineId的完整遥测键
+var allTelemetryKeys = []string{keyMacMachineID, keyMachineID, keyDevDeviceID, keySqmID}
+
+// targets 内置的编辑器目标，键为标识名
+var targets = map[string]Target{
+	"cursor":   &editorTarget{name: "cursor", displayName: "Cursor", keys: allTelemetryKeys, namedInstallDirs: true},
+	"vscode":   &editorTarget{name: "vscode", displayName: "Code", keys: commonTelemetryKeys, layout: vscodeLayout},
+	"vscodium": &editorTarget{name: "vscodium", displayName: "VSCodium", keys: commonTelemetryKeys, layout: vscodiumLayout},
+	"windsurf": &editorTarget{name: "windsurf", displayName: "Windsurf", keys: allTelemetryKeys, namedInstallDirs: true},
+	"cursor-server": &serverTarget{
+		editorTarget: editorTarget{name: "cursor-server", displayName: "Cursor Server", keys: allTelemetryKeys},
+		dirName:      ".cursor-server",
+	},
+}
+
+// DefaultTarget 返回默认的Cursor目标
+func DefaultTarget() Target {
+	return targets["cursor"]
+}
+
+// TargetByName 根据标识名返回内置的编辑器目标
+func TargetByName(name string) (Target, error) {
+	target, ok := targets[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("unknown editor %q, supported editors: %s", name, strings.Join(TargetNames(), ", "))
+	}
+	return target, nil
+}
+
+// TargetNames 返回所有内置编辑器目标的标识名
+func TargetNames() []string {
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AppTarget 为目录结构与Cursor相同、但产品名称不同的衍生编辑器创建目标
+func AppTarget(appName string) Target {
+	if appName == "" || appName == DefaultAppName {
+		return DefaultTarget()
+	}
+	return &editorTarget{
+		name:             strings.ToLower(appName),
+		displayName:      appName,
+		keys:             allTelemetryKeys,
+		namedInstallDirs: true,
+	}
+}
+
+// Name 返回目标的标识名
+func (t *editorTarget) Name() string {
+	return t.name
+}
+
+// DisplayName 返回产品名称
+func (t *editorTarget) DisplayName() string {
+	return t.displayName
+}
+
+// ConfigPath 返回storage.json的路径
+func (t *editorTarget) ConfigPath(username string) (string, error) {
+	dataDir := t.dataDir
+	if dataDir == "" {
+		dataDir = t.displayName
+	}
+
+	var configDir string
+	// 根据操作系统确定配置目录路径
+	switch runtime.GOOS {
+	case "windows":
+		configDir = filepath.Join(os.Getenv("APPDATA"), dataDir, "User", "globalStorage")
+		// 从Microsoft Store安装时数据位于包目录中，只有常规位置不存在配置时才使用
+		if _, err := os.Stat(filepath.Join(configDir, "storage.json")); os.IsNotExist(err) {
+			for _, pkg := range DetectMSIXPackages(dataDir) {
+				packaged := filepath.Join(pkg.DataDir, "User", "globalStorage")
+				if _, err := os.Stat(filepath.Join(packaged, "storage.json")); err == nil {
+					configDir = packaged
+					break
+				}
+			}
+		}
+	case "darwin":
+		configDir = filepath.Join("/Users", username, "Library", "Application Support", dataDir, "User", "globalStorage")
+	case "linux":
+		configDir = filepath.Join("/home", username, ".config", dataDir, "User", "globalStorage")
+	default:
+		return "", fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
+	}
+	return filepath.Join(configDir, "storage.json"), nil
+}
+
+// TelemetryKeys 返回需要重写的遥测键
+func (t *editorTarget) TelemetryKeys() []string {
+	return t.keys
+}
+
+// ProcessPatterns 返回进程名称模式
+func (t *editorTarget) ProcessPatterns() []string {
+	if t.layout != nil {
+		return t.layout.processPatterns()
+	}
+	return process.PatternsForApp(t.displayName)
+}
+
+// InstallDirs 返回安装目录
+func (t *editorTarget) InstallDirs() []string {
+	if t.layout != nil {
+		return t.layout.installDirs()
+	}
+	if !t.namedInstallDirs {
+		return nil
+	}
+	return process.InstallDirsForApp(t.displayName)
+}
+
+// telemetryValue 返回配置中与遥测键对应的值
+func telemetryValue(config *StorageConfig, key string) string {
+	switch key {
+	case keyMacMachineID:
+		return config.TelemetryMacMachineId
+	case keyMachineID:
+		return config.TelemetryMachineId
+	case keyDevDeviceID:
+		return config.TelemetryDevDeviceId
+	case keySqmID:
+		return config.TelemetrySqmId
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// StorageConfig中定义的、必须为字符串的键
+var storageStringKeys = []string{keyMacMachineID, keyMachineID, keyDevDeviceID, keySqmID, "lastModified", "version"}
+
+// ValidateStorageJSON 检查storage.json的内容是否符合StorageConfig的结构，返回发现的问题
+// keys为目标编辑器的遥测键，这些键必须存在；validate用于检查标识符格式，为nil时只检查结构
+func ValidateStorageJSON(data []byte, keys []string, validate func(key, value string) bool) []string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return []string{fmt.Sprintf("not a JSON object: %v", err)}
+	}
+
+	var problems []string
+	values := make(map[string]string)
+	for _, key := range storageStringKeys {
+		raw, ok := fields[key]
+		if !ok {
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s must be a string", key))
+			continue
+		}
+		values[key] = value
+	}
+
+	for _, key := range keys {
+		value, ok := values[key]
+		if !ok {
+			if _, present := fields[key]; !present {
+				problems = append(problems, fmt.Sprintf("%s is missing", key))
+			}
+			continue
+		}
+		if validate != nil && !validate(key, value) {
+			problems = append(problems, fmt.Sprintf("%s has an invalid format: %q", key, value))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// VerificationError 表示写入后校验发现的不一致
+// 通常由杀毒软件隔离、云同步干扰或特殊文件系统导致写入静默失败
+type VerificationError struct {
+	// 被校验的文件路径
+	Path string
+	// 发现的问题列表
+	Problems []string
+}
+
+// Error 实现error接口
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("verification of %s failed: %s", e.Path, strings.Join(e.Problems, "; "))
+}
+
+// Verify 重新读取storage.json，检查遥测ID和文件权限是否与期望一致
+func (m *Manager) Verify(config *StorageConfig, protection ProtectionLevel) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.verifyLocked(config, protection)
+}
+
+// verifyLocked 执行校验，调用者需持有锁
+func (m *Manager) verifyLocked(config *StorageConfig, protection ProtectionLevel) error {
+	verr := &VerificationError{Path: m.configPath}
+
+	info, err := os.Stat(m.configPath)
+	if err != nil {
+		verr.Problems = append(verr.Problems, fmt.Sprintf("cannot stat file: %v", err))
+		return verr
+	}
+	if mode := info.Mode().Perm(); mode != protection.fileMode() {
+		verr.Problems = append(verr.Problems, fmt.Sprintf("permissions are %#o, expected %#o", mode, protection.fileMode()))
+	}
+
+	data, err := os.ReadFile(m.configPath)
+	if err != nil {
+		verr.Problems = append(verr.Problems, fmt.Sprintf("cannot read file: %v", err))
+		return verr
+	}
+	doc, err := parseJSONDocument(data)
+	if err != nil {
+		verr.Problems = append(verr.Problems, fmt.Sprintf("file is not valid JSON: %v", err))
+		return verr
+	}
+
+	for _, key := range m.target.TelemetryKeys() {
+		expected, _ := marshalJSONValue(telemetryValue(config, key))
+		if actual := doc.Get(key); string(actual) != string(expected) {
+			verr.Problems = append(verr.Problems, fmt.Sprintf("%s was not written", key))
+		}
+	}
+
+	if len(verr.Problems) > 0 {
+		return verr
+	}
+	return nil
+}
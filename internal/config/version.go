@@ -0,0 +1,41 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// DetectCursorVersion 读取已安装Cursor的resources/app/package.json，返回其版本号
+func DetectCursorVersion() (string, error) {
+	for _, executable := range cursorExecutables() {
+		for _, path := range packageJSONPaths(executable) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			var manifest struct {
+				Version string `json:"version"`
+			}
+			if err := json.Unmarshal(data, &manifest); err == nil && manifest.Version != "" {
+				return manifest.Version, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no Cursor installation found")
+}
+
+// packageJSONPaths 返回可执行文件对应的package.json可能的位置
+// Linux上PATH中的cursor可能是安装目录下bin中的启动脚本，因此也检查上一级目录
+func packageJSONPaths(executable string) []string {
+	if runtime.GOOS == "darwin" {
+		return []string{filepath.Join(executable, "Contents", "Resources", "app", "package.json")}
+	}
+	installDir := filepath.Dir(executable)
+	return []string{
+		filepath.Join(installDir, "resources", "app", "package.json"),
+		filepath.Join(filepath.Dir(installDir), "resources", "app", "package.json"),
+	}
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// WorkspaceEntry 表示workspaceStorage中的一个工作区条目
+type WorkspaceEntry struct {
+	// 条目目录名（Cursor生成的哈希）
+	ID string
+	// 条目目录的完整路径
+	Path string
+	// 工作区对应的文件夹或工作区文件，未知时为空
+	Folder string
+	// 条目占用的字节数
+	Size int64
+	// 条目最后修改时间
+	ModTime time.Time
+}
+
+// WorkspaceStorageDir 返回与globalStorage同级的workspaceStorage目录
+func (m *Manager) WorkspaceStorageDir() string {
+	return filepath.Join(filepath.Dir(m.GlobalStorageDir()), "workspaceStorage")
+}
+
+// ListWorkspaceStorage 列出workspaceStorage中的所有条目，按最后修改时间从新到旧排序
+func (m *Manager) ListWorkspaceStorage() ([]WorkspaceEntry, error) {
+	dirEntries, err := os.ReadDir(m.WorkspaceStorageDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read workspace storage: %w", err)
+	}
+
+	var entries []WorkspaceEntry
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() {
+			continue
+		}
+		path := filepath.Join(m.WorkspaceStorageDir(), dirEntry.Name())
+		entry := WorkspaceEntry{
+			ID:     dirEntry.Name(),
+			Path:   path,
+			Folder: readWorkspaceFolder(path),
+		}
+		if info, err := dirEntry.Info(); err == nil {
+			entry.ModTime = info.ModTime()
+		}
+		entry.Size, _ = directorySize(path)
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime.After(entries[j].ModTime)
+	})
+	return entries, nil
+}
+
+// RemoveWorkspaceStorage 删除指定的工作区条目，返回释放的字节数
+func (m *Manager) RemoveWorkspaceStorage(entries []WorkspaceEntry) (int64, error) {
+	var freed int64
+	for _, entry := range entries {
+		// 只允许删除workspaceStorage目录下的条目
+		if filepath.Dir(entry.Path) != m.WorkspaceStorageDir() {
+			return freed, fmt.Errorf("refusing to remove %s outside workspace storage", entry.Path)
+		}
+		if err := os.RemoveAll(entry.Path); err != nil {
+			return freed, fmt.Errorf("failed to remove workspace %s: %w", entry.ID, err)
+		}
+		freed += entry.Size
+	}
+	return freed, nil
+}
+
+// readWorkspaceFolder 从workspace.json读取工作区对应的文件夹路径
+func readWorkspaceFolder(entryPath string) string {
+	data, err := os.ReadFile(filepath.Join(entryPath, "workspace.json"))
+	if err != nil {
+		return ""
+	}
+
+	var workspace struct {
+		Folder    string `json:"folder"`
+		Workspace string `json:"workspace"`
+	}
+	if err := json.Unmarshal(data, &workspace); err != nil {
+		return ""
+	}
+
+	location := workspace.Folder
+	if location == "" {
+		location = workspace.Workspace
+	}
+	// 将file:// URI转换为本地路径，便于阅读
+	if parsed, err := url.Parse(location); err == nil && parsed.Scheme == "file" {
+		return filepath.FromSlash(parsed.Path)
+	}
+	return location
+}
+
+// directorySize 计算目录中所有普通文件的总大小
+func directorySize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
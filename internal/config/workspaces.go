@@ -0,0 +1,144 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Workspace 表示关闭编辑器前打开的一个窗口
+type Workspace struct {
+	// 文件夹或.code-workspace文件的URI
+	URI string
+	// 是否为.code-workspace工作区文件，否则为文件夹
+	IsFile bool
+}
+
+// String 返回便于阅读的形式，本地文件显示为路径，远程工作区显示原始URI
+func (w Workspace) String() string {
+	parsed, err := url.Parse(w.URI)
+	if err != nil || parsed.Scheme != "file" {
+		return w.URI
+	}
+	path := parsed.Path
+	// Windows上的file URI形如file:///c%3A/Users/...
+	if runtime.GOOS == "windows" {
+		path = strings.TrimPrefix(path, "/")
+	}
+	return filepath.FromSlash(path)
+}
+
+// serializedWindow storage.json中windowsState记录的单个窗口
+// 不同版本的VS Code分别使用workspace.configPath和workspaceIdentifier.configURIPath
+type serializedWindow struct {
+	Folder    string `json:"folder"`
+	Workspace *struct {
+		ConfigPath string `json:"configPath"`
+	} `json:"workspace"`
+	WorkspaceIdentifier *struct {
+		ConfigURIPath string `json:"configURIPath"`
+	} `json:"workspaceIdentifier"`
+}
+
+// OpenWorkspaces 从storage.json的windowsState中读取编辑器上次打开的窗口
+// 需要在关闭编辑器之前调用；没有记录时返回空列表
+func (m *Manager) OpenWorkspaces() ([]Workspace, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data, err := os.ReadFile(m.configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var state struct {
+		WindowsState struct {
+			LastActiveWindow *serializedWindow  `json:"lastActiveWindow"`
+			OpenedWindows    []serializedWindow `json:"openedWindows"`
+		} `json:"windowsState"`
+	}
+	if err := json.Unmarshal(stripJSONC(data), &state); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	windows := state.WindowsState.OpenedWindows
+	if state.WindowsState.LastActiveWindow != nil {
+		windows = append(windows, *state.WindowsState.LastActiveWindow)
+	}
+
+	var workspaces []Workspace
+	seen := make(map[string]bool)
+	for _, window := range windows {
+		workspace, ok := window.workspace()
+		if !ok || seen[workspace.URI] {
+			continue
+		}
+		seen[workspace.URI] = true
+		workspaces = append(workspaces, workspace)
+	}
+	return workspaces, nil
+}
+
+// workspace 返回窗口打开的文件夹或工作区文件，空窗口返回false
+func (w serializedWindow) workspace() (Workspace, bool) {
+	switch {
+	case w.Folder != "":
+		return Workspace{URI: w.Folder}, true
+	case w.WorkspaceIdentifier != nil && w.WorkspaceIdentifier.ConfigURIPath != "":
+		return Workspace{URI: w.WorkspaceIdentifier.ConfigURIPath, IsFile: true}, true
+	case w.Workspace != nil && w.Workspace.ConfigPath != "":
+		// 旧版本记录的是文件系统路径，统一转换为URI
+		return Workspace{URI: fileURI(w.Workspace.ConfigPath), IsFile: true}, true
+	default:
+		return Workspace{}, false
+	}
+}
+
+// fileURI 将本地路径转换为file URI
+func fileURI(path string) string {
+	if strings.Contains(path, "://") {
+		return path
+	}
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // Windows路径，例如C:/Users/...
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// HotExitBackupsDir 返回编辑器保存未保存内容（hot exit）的Backups目录，与User目录同级
+func (m *Manager) HotExitBackupsDir() string {
+	userDir := filepath.Dir(m.GlobalStorageDir())
+	return filepath.Join(filepath.Dir(userDir), "Backups")
+}
+
+// UnsavedBackups 返回Backups目录中的备份文件，每个文件对应一个有未保存修改的编辑器
+// 目录中的workspaces.json等索引文件不计入
+func (m *Manager) UnsavedBackups() ([]string, error) {
+	root := m.HotExitBackupsDir()
+	var files []string
+	err := filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		// 只统计工作区子目录中的文件，根目录下的文件为索引
+		if entry.Type().IsRegular() && filepath.Dir(path) != root {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan hot exit backups: %w", err)
+	}
+	return files, nil
+}
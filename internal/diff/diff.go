@@ -0,0 +1,201 @@
+// diff包，生成文本文件的统一差异格式（unified diff）
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContext 默认的上下文行数，与diff -u一致
+const DefaultContext = 3
+
+// 差异操作类型
+const (
+	opEqual  = ' '
+	opDelete = '-'
+	opInsert = '+'
+)
+
+// operation 表示一行的差异操作
+type operation struct {
+	// 操作类型
+	kind byte
+	// 行内容，包含行尾的换行符
+	line string
+	// 操作前在原文件中已经过的行数
+	fromLine int
+	// 操作前在新文件中已经过的行数
+	toLine int
+}
+
+// Unified 生成from和to之间的统一差异文本，内容相同时返回空字符串
+// fromName和toName为文件头中显示的名称，context为每处修改前后保留的上下文行数
+func Unified(fromName, toName string, from, to []byte, context int) string {
+	if string(from) == string(to) {
+		return ""
+	}
+	if context < 0 {
+		context = DefaultContext
+	}
+
+	ops := diffLines(splitLines(string(from)), splitLines(string(to)))
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "--- %s\n+++ %s\n", fromName, toName)
+	for _, hunk := range groupHunks(ops, context) {
+		writeHunk(&builder, hunk)
+	}
+	return builder.String()
+}
+
+// splitLines 按行拆分文本，每行保留其换行符
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines 使用Myers算法计算两组行之间的最短编辑序列
+// 修改通常只涉及少数几行，O(ND)的复杂度对大文件也足够快
+func diffLines(a, b []string) []operation {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+
+	// trace记录每一步开始前的状态，用于回溯编辑路径
+	var trace [][]int
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[k-1+offset] < v[k+1+offset]) {
+				x = v[k+1+offset]
+			} else {
+				x = v[k-1+offset] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k+offset] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b, offset)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack 从终点回溯编辑路径，按顺序返回每一行的操作
+func backtrack(trace [][]int, a, b []string, offset int) []operation {
+	var reversed []operation
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[k-1+offset] < v[k+1+offset]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[prevK+offset]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			reversed = append(reversed, operation{kind: opEqual, line: a[x], fromLine: x, toLine: y})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			reversed = append(reversed, operation{kind: opInsert, line: b[y], fromLine: x, toLine: y})
+		} else {
+			x--
+			reversed = append(reversed, operation{kind: opDelete, line: a[x], fromLine: x, toLine: y})
+		}
+	}
+
+	ops := make([]operation, len(reversed))
+	for i, op := range reversed {
+		ops[len(reversed)-1-i] = op
+	}
+	return ops
+}
+
+// groupHunks 将操作分组为差异块，每块包含修改及其前后的上下文
+// 两处修改之间的相同行不超过2*context时合并为同一块
+func groupHunks(ops []operation, context int) [][]operation {
+	var hunks [][]operation
+	start, end := -1, -1
+	for i, op := range ops {
+		if op.kind == opEqual {
+			continue
+		}
+		lo, hi := i-context, i+context+1
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(ops) {
+			hi = len(ops)
+		}
+		if start >= 0 && lo <= end {
+			end = hi
+			continue
+		}
+		if start >= 0 {
+			hunks = append(hunks, ops[start:end])
+		}
+		start, end = lo, hi
+	}
+	if start >= 0 {
+		hunks = append(hunks, ops[start:end])
+	}
+	return hunks
+}
+
+// writeHunk 写出一个差异块，包括@@头和各行内容
+func writeHunk(builder *strings.Builder, hunk []operation) {
+	fromStart, toStart := hunk[0].fromLine, hunk[0].toLine
+	fromCount, toCount := 0, 0
+	for _, op := range hunk {
+		if op.kind != opInsert {
+			fromCount++
+		}
+		if op.kind != opDelete {
+			toCount++
+		}
+	}
+	fmt.Fprintf(builder, "@@ -%s +%s @@\n", hunkRange(fromStart, fromCount), hunkRange(toStart, toCount))
+
+	for _, op := range hunk {
+		builder.WriteByte(op.kind)
+		builder.WriteString(op.line)
+		if !strings.HasSuffix(op.line, "\n") {
+			builder.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange 格式化@@头中的行范围，行号从1开始，只有一行时省略行数
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
@@ -0,0 +1,91 @@
+// firewall包，管理阻止Cursor遥测地址出站连接的Windows防火墙规则
+package firewall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// RuleGroup 本工具创建的规则所属的组，列出和移除时只处理该组中的规则
+const RuleGroup = "cursor-id-modifier"
+
+// 规则显示名称的前缀，后面接规则屏蔽的域名或地址
+const rulePrefix = "cursor-id-modifier telemetry: "
+
+// ErrUnsupported 表示当前系统不支持管理防火墙规则
+var ErrUnsupported = errors.New("firewall rules can only be managed on Windows")
+
+// Rule 表示一条阻止出站连接的防火墙规则
+type Rule struct {
+	// 规则的显示名称
+	Name string
+	// 规则屏蔽的域名、IP地址或地址范围
+	Target string
+	// 阻止连接的远程地址，域名为解析得到的地址
+	Addresses []string
+	// 规则是否启用
+	Enabled bool
+}
+
+// Resolve 将要屏蔽的目标转换为规则，域名解析为当前的IP地址
+// 目标可以是域名、IP地址、CIDR网段或以-连接的地址范围；
+// 域名的地址可能变化，解析到的地址只反映创建规则时的结果
+func Resolve(ctx context.Context, targets []string) ([]Rule, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no telemetry hosts to block")
+	}
+
+	rules := make([]Rule, 0, len(targets))
+	for _, target := range targets {
+		target = strings.TrimSpace(target)
+		if isAddress(target) {
+			rules = append(rules, Rule{Name: rulePrefix + target, Target: target, Addresses: []string{target}, Enabled: true})
+			continue
+		}
+		if !isDomain(target) {
+			return nil, fmt.Errorf("invalid telemetry host: %q", target)
+		}
+
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", target, err)
+		}
+		addresses := make([]string, 0, len(addrs))
+		for _, addr := range addrs {
+			addresses = append(addresses, addr.IP.String())
+		}
+		sort.Strings(addresses)
+		rules = append(rules, Rule{Name: rulePrefix + target, Target: target, Addresses: addresses, Enabled: true})
+	}
+	return rules, nil
+}
+
+// isAddress 判断目标是否为IP地址、CIDR网段或地址范围
+func isAddress(target string) bool {
+	if net.ParseIP(target) != nil {
+		return true
+	}
+	if _, _, err := net.ParseCIDR(target); err == nil {
+		return true
+	}
+	first, last, ok := strings.Cut(target, "-")
+	return ok && net.ParseIP(first) != nil && net.ParseIP(last) != nil
+}
+
+// isDomain 判断目标是否为域名，只允许字母、数字、-和.
+// 目标会拼接到PowerShell命令中，限制字符避免注入
+func isDomain(target string) bool {
+	if target == "" || strings.HasPrefix(target, ".") || strings.HasPrefix(target, "-") {
+		return false
+	}
+	for _, r := range target {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '.') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+
+package firewall
+
+import "context"
+
+// Block 非Windows系统上不支持，返回ErrUnsupported
+func Block(ctx context.Context, targets []string) ([]Rule, error) {
+	return nil, ErrUnsupported
+}
+
+// Unblock 非Windows系统上不支持，返回ErrUnsupported
+func Unblock(ctx context.Context) (int, error) {
+	return 0, ErrUnsupported
+}
+
+// Status 非Windows系统上不支持，返回ErrUnsupported
+func Status(ctx context.Context) ([]Rule, error) {
+	return nil, ErrUnsupported
+}
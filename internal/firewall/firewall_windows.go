@@ -0,0 +1,102 @@
+package firewall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// 规则的说明，在Windows防火墙的高级设置中显示
+const ruleDescription = "Blocks Cursor telemetry. Created by cursor-id-modifier, remove with: cursor-id-modifier firewall unblock"
+
+// Block 为每个目标创建一条阻止出站连接的规则，先移除本工具以前创建的所有规则
+// 返回创建的规则；需要管理员权限
+func Block(ctx context.Context, targets []string) ([]Rule, error) {
+	rules, err := Resolve(ctx, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []string{
+		"$ErrorActionPreference = 'Stop'",
+		fmt.Sprintf("Get-NetFirewallRule -Group %s -ErrorAction SilentlyContinue | Remove-NetFirewallRule", quote(RuleGroup)),
+	}
+	for _, rule := range rules {
+		addresses := make([]string, len(rule.Addresses))
+		for i, address := range rule.Addresses {
+			addresses[i] = quote(address)
+		}
+		lines = append(lines, fmt.Sprintf("New-NetFirewallRule -DisplayName %s -Group %s -Description %s -Direction Outbound -Action Block -RemoteAddress @(%s) | Out-Null",
+			quote(rule.Name), quote(RuleGroup), quote(ruleDescription), strings.Join(addresses, ",")))
+	}
+	if _, err := powershell(ctx, strings.Join(lines, "\n")); err != nil {
+		return nil, fmt.Errorf("failed to create firewall rules: %w", err)
+	}
+	return rules, nil
+}
+
+// Unblock 移除本工具创建的所有规则，返回移除的规则数量；需要管理员权限
+func Unblock(ctx context.Context) (int, error) {
+	script := fmt.Sprintf(`$ErrorActionPreference = 'Stop'
+$rules = @(Get-NetFirewallRule -Group %s -ErrorAction SilentlyContinue)
+$rules | Remove-NetFirewallRule
+$rules.Count`, quote(RuleGroup))
+	output, err := powershell(ctx, script)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove firewall rules: %w", err)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected output from PowerShell: %q", output)
+	}
+	return count, nil
+}
+
+// Status 列出本工具创建的规则，按名称排序
+func Status(ctx context.Context) ([]Rule, error) {
+	script := fmt.Sprintf(`$ErrorActionPreference = 'Stop'
+$rules = @(Get-NetFirewallRule -Group %s -ErrorAction SilentlyContinue | Sort-Object DisplayName | ForEach-Object {
+	[pscustomobject]@{
+		Name = $_.DisplayName
+		Enabled = ($_.Enabled.ToString() -eq 'True')
+		Addresses = @(($_ | Get-NetFirewallAddressFilter).RemoteAddress)
+	}
+})
+ConvertTo-Json -InputObject $rules -Compress`, quote(RuleGroup))
+	output, err := powershell(ctx, script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewall rules: %w", err)
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal(output, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse firewall rules: %w", err)
+	}
+	for i := range rules {
+		rules[i].Target = strings.TrimPrefix(rules[i].Name, rulePrefix)
+	}
+	return rules, nil
+}
+
+// powershell 运行PowerShell脚本并返回标准输出，失败时错误中包含标准错误的内容
+// 使用NetSecurity模块而不是netsh，规则可以按组列出，输出也不随系统语言变化
+func powershell(ctx context.Context, script string) ([]byte, error) {
+	output, err := exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", script).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+	return output, nil
+}
+
+// quote 将字符串转为PowerShell的单引号字符串
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,134 @@
+package fleet
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 默认同时处理的主机数
+const defaultParallel = 8
+
+// 处理一台主机的最长时间，包括连接、关闭Cursor和写入
+const hostTimeout = 2 * time.Minute
+
+// 远程备份文件名中的时间格式，与本机备份一致
+const backupTimeFormat = "20060102_150405"
+
+// Options 批量处理的设置
+type Options struct {
+	// 产品名称，决定远程配置目录的默认位置和要关闭的进程名
+	AppName string
+	// 同时处理的主机数，为0时使用默认值
+	Parallel int
+	// 根据远程storage.json的现有内容（文件不存在时为nil）生成新内容，每台主机调用一次
+	// 不会被并发调用
+	Update func(host Host, existing []byte) ([]byte, error)
+	// 每台主机处理完成后调用，不会被并发调用
+	Done func(result Result)
+}
+
+// Result 一台主机的处理结果
+type Result struct {
+	// 主机
+	Host Host
+	// 远程storage.json的路径
+	Storage string
+	// 写入前在远程创建的备份，文件原本不存在时为空
+	BackupPath string
+	// 失败原因，成功时为nil
+	Err error
+}
+
+// Apply 依次连接各主机，关闭Cursor，生成新的标识符并写入远程storage.json
+// 一台主机失败不影响其他主机；ctx被取消时尚未开始的主机以ctx.Err()失败
+// 返回值与hosts的顺序一致
+func Apply(ctx context.Context, hosts []Host, opts Options) []Result {
+	parallel := opts.Parallel
+	if parallel <= 0 {
+		parallel = defaultParallel
+	}
+
+	results := make([]Result, len(hosts))
+	var (
+		wg       sync.WaitGroup
+		updateMu sync.Mutex
+		doneMu   sync.Mutex
+	)
+	update := func(host Host, existing []byte) ([]byte, error) {
+		updateMu.Lock()
+		defer updateMu.Unlock()
+		return opts.Update(host, existing)
+	}
+	slots := make(chan struct{}, parallel)
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host Host) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			results[i] = applyHost(ctx, host, opts.AppName, update)
+			if opts.Done != nil {
+				doneMu.Lock()
+				opts.Done(results[i])
+				doneMu.Unlock()
+			}
+		}(i, host)
+	}
+	wg.Wait()
+	return results
+}
+
+// applyHost 处理一台主机
+func applyHost(ctx context.Context, host Host, appName string, update func(Host, []byte) ([]byte, error)) Result {
+	result := Result{Host: host, Storage: host.Storage}
+	if result.Storage == "" {
+		result.Storage = defaultStorage(host.OS, appName)
+	}
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+		return result
+	}
+	ctx, cancel := context.WithTimeout(ctx, hostTimeout)
+	defer cancel()
+
+	client, err := dial(ctx, host)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer client.Close()
+
+	scripts := scriptsFor(host.OS)
+	if _, err := runCommand(ctx, client, scripts.closeApp(appName), nil); err != nil {
+		result.Err = fmt.Errorf("failed to close %s: %w", appName, err)
+		return result
+	}
+	output, err := runCommand(ctx, client, scripts.read(result.Storage), nil)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to read %s: %w", result.Storage, err)
+		return result
+	}
+	existing, err := scripts.decode(output)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	content, err := update(host, existing)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	stamp := time.Now().Format(backupTimeFormat)
+	output, err = runCommand(ctx, client, scripts.write(result.Storage, stamp), scripts.encode(content))
+	if err != nil {
+		result.Err = fmt.Errorf("failed to write %s: %w", result.Storage, err)
+		return result
+	}
+	result.BackupPath = strings.TrimSpace(string(output))
+	return result
+}
@@ -0,0 +1,165 @@
+// fleet包，通过SSH在清单中列出的多台机器上重置标识符，用于机房、教室等需要批量处理的场景
+package fleet
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 默认的SSH端口
+const defaultPort = 22
+
+// 支持的远程操作系统
+var supportedOS = map[string]bool{"linux": true, "darwin": true, "windows": true}
+
+// Inventory 清单文件的内容
+//
+//	defaults:
+//	  user: student
+//	  identity_file: ~/.ssh/lab_ed25519
+//	hosts:
+//	  - address: 10.0.0.11
+//	  - name: teacher
+//	    address: 10.0.0.10:2222
+//	    os: windows
+type Inventory struct {
+	// 所有主机共用的设置，主机中未填写的字段取这里的值
+	Defaults Host `yaml:"defaults"`
+	// 要处理的主机
+	Hosts []Host `yaml:"hosts"`
+}
+
+// Host 清单中的一台主机
+type Host struct {
+	// 显示名称，默认为地址
+	Name string `yaml:"name"`
+	// 主机名或IP地址，可以带端口
+	Address string `yaml:"address"`
+	// SSH端口，默认为22
+	Port int `yaml:"port"`
+	// 登录的用户名，默认为本机当前用户
+	User string `yaml:"user"`
+	// 私钥文件，为空时使用ssh-agent和~/.ssh下的默认私钥
+	IdentityFile string `yaml:"identity_file"`
+	// 远程操作系统：linux（默认）、darwin或windows
+	OS string `yaml:"os"`
+	// 远程storage.json的路径，为空时使用该系统的默认位置
+	// Linux和macOS上可以用~/表示登录用户的主目录，Windows上可以使用%APPDATA%等环境变量
+	Storage string `yaml:"storage"`
+	// known_hosts文件，默认为~/.ssh/known_hosts
+	KnownHosts string `yaml:"known_hosts"`
+	// 是否跳过主机密钥校验，只应在隔离的实验网络中使用
+	InsecureIgnoreHostKey bool `yaml:"insecure_ignore_host_key"`
+}
+
+// LoadInventory 读取清单文件，返回合并了默认设置的主机列表
+// 参数:
+//   - path: 清单文件路径（YAML）
+//   - defaultUser: 主机和defaults都未指定用户名时使用的用户名
+//
+// 返回值:
+//   - []Host: 主机列表，顺序与清单一致
+//   - error: 文件无法读取、包含未知字段或主机设置无效时返回错误
+func LoadInventory(path string, defaultUser string) ([]Host, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inventory: %w", err)
+	}
+
+	var inventory Inventory
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&inventory); err != nil {
+		return nil, fmt.Errorf("failed to parse inventory %s: %w", path, err)
+	}
+	if len(inventory.Hosts) == 0 {
+		return nil, fmt.Errorf("inventory %s lists no hosts", path)
+	}
+
+	hosts := make([]Host, 0, len(inventory.Hosts))
+	names := make(map[string]bool, len(inventory.Hosts))
+	for i, host := range inventory.Hosts {
+		host = host.withDefaults(inventory.Defaults, defaultUser)
+		if err := host.validate(); err != nil {
+			return nil, fmt.Errorf("inventory %s, host %d: %w", path, i+1, err)
+		}
+		if names[host.Name] {
+			return nil, fmt.Errorf("inventory %s lists host %s more than once", path, host.Name)
+		}
+		names[host.Name] = true
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+// withDefaults 用defaults填充未指定的字段
+func (h Host) withDefaults(defaults Host, defaultUser string) Host {
+	if h.Port == 0 {
+		h.Port = defaults.Port
+	}
+	if h.Port == 0 {
+		h.Port = defaultPort
+	}
+	if h.User == "" {
+		h.User = defaults.User
+	}
+	if h.User == "" {
+		h.User = defaultUser
+	}
+	if h.IdentityFile == "" {
+		h.IdentityFile = defaults.IdentityFile
+	}
+	if h.OS == "" {
+		h.OS = defaults.OS
+	}
+	if h.OS == "" {
+		h.OS = "linux"
+	}
+	h.OS = strings.ToLower(h.OS)
+	if h.Storage == "" {
+		h.Storage = defaults.Storage
+	}
+	if h.KnownHosts == "" {
+		h.KnownHosts = defaults.KnownHosts
+	}
+	h.InsecureIgnoreHostKey = h.InsecureIgnoreHostKey || defaults.InsecureIgnoreHostKey
+	if h.Name == "" {
+		h.Name = h.Address
+	}
+	return h
+}
+
+// validate 检查主机设置是否完整
+func (h Host) validate() error {
+	if h.Address == "" {
+		return fmt.Errorf("address is required")
+	}
+	if h.User == "" {
+		return fmt.Errorf("%s: user is required", h.Name)
+	}
+	if h.Port < 1 || h.Port > 65535 {
+		return fmt.Errorf("%s: invalid port %d", h.Name, h.Port)
+	}
+	if !supportedOS[h.OS] {
+		return fmt.Errorf("%s: unsupported os %q, supported: linux, darwin, windows", h.Name, h.OS)
+	}
+	return nil
+}
+
+// expandHome 将本机路径开头的~/替换为当前用户的主目录
+func expandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, rest), nil
+}
@@ -0,0 +1,223 @@
+package fleet
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// 远程备份的目录名和文件名前缀，与本机备份（config包）一致，恢复时可以直接使用
+const (
+	backupDirName    = "backups"
+	backupFilePrefix = "storage.json.backup_"
+)
+
+// 等待Cursor退出的最长秒数，超时后强制结束
+const closeWaitSeconds = 10
+
+// remoteScripts 在一种操作系统上读写storage.json和关闭Cursor的命令
+type remoteScripts interface {
+	// closeApp 关闭登录用户的appName进程，超时后强制结束，仍在运行时失败
+	closeApp(appName string) string
+	// read 将storage.json的内容写到标准输出，文件不存在时不输出
+	read(path string) string
+	// decode 将read的输出转换为文件内容，文件不存在时返回nil
+	decode(output []byte) ([]byte, error)
+	// write 备份storage.json后以原子方式写入新内容，标准输出为备份文件路径（文件原本不存在时为空）
+	write(path string, stamp string) string
+	// encode 将新内容转换为write从标准输入读取的格式
+	encode(content []byte) []byte
+}
+
+// scriptsFor 返回指定操作系统的命令
+func scriptsFor(os string) remoteScripts {
+	if os == "windows" {
+		return powershellScripts{}
+	}
+	return shellScripts{}
+}
+
+// defaultStorage 返回远程storage.json的默认路径
+func defaultStorage(os string, appName string) string {
+	switch os {
+	case "windows":
+		return `%APPDATA%\` + appName + `\User\globalStorage\storage.json`
+	case "darwin":
+		return "~/Library/Application Support/" + appName + "/User/globalStorage/storage.json"
+	}
+	return "~/.config/" + appName + "/User/globalStorage/storage.json"
+}
+
+// shellScripts Linux和macOS上的POSIX shell命令
+// 通过sh -c执行，不依赖登录用户的默认shell
+type shellScripts struct{}
+
+// closeApp 实现remoteScripts
+func (shellScripts) closeApp(appName string) string {
+	names := []string{appName}
+	if lower := strings.ToLower(appName); lower != appName {
+		names = append(names, lower)
+	}
+	var running, kill, forceKill []string
+	for _, name := range names {
+		running = append(running, fmt.Sprintf(`pgrep -x -u "$(id -u)" %s >/dev/null`, shellQuote(name)))
+		kill = append(kill, fmt.Sprintf(`pkill -x -u "$(id -u)" %s`, shellQuote(name)))
+		forceKill = append(forceKill, fmt.Sprintf(`pkill -9 -x -u "$(id -u)" %s`, shellQuote(name)))
+	}
+	lines := []string{
+		"running() { " + strings.Join(running, " || ") + "; }",
+		"running || exit 0",
+		strings.Join(kill, "; "),
+		"i=0",
+		fmt.Sprintf("while running && [ $i -lt %d ]; do sleep 1; i=$((i+1)); done", closeWaitSeconds),
+		"running || exit 0",
+		strings.Join(forceKill, "; "),
+		"sleep 1",
+		fmt.Sprintf("if running; then echo %s >&2; exit 1; fi", shellQuote(appName+" is still running")),
+	}
+	return shellCommand(lines)
+}
+
+// read 实现remoteScripts
+func (shellScripts) read(path string) string {
+	return shellCommand([]string{
+		"p=" + shellPath(path),
+		`if [ -f "$p" ]; then cat "$p"; fi`,
+	})
+}
+
+// decode 实现remoteScripts
+func (shellScripts) decode(output []byte) ([]byte, error) {
+	if len(output) == 0 {
+		return nil, nil
+	}
+	return output, nil
+}
+
+// write 实现remoteScripts
+func (shellScripts) write(path string, stamp string) string {
+	return shellCommand([]string{
+		"set -e",
+		"p=" + shellPath(path),
+		`d=$(dirname "$p")`,
+		`mkdir -p "$d"`,
+		`if [ -f "$p" ]; then`,
+		fmt.Sprintf(`  b="$d/%s/%s%s"`, backupDirName, backupFilePrefix, stamp),
+		fmt.Sprintf(`  mkdir -p "$d/%s"`, backupDirName),
+		`  cp "$p" "$b"`,
+		`  chmod 600 "$b"`,
+		`  echo "$b"`,
+		`fi`,
+		`cat > "$p.tmp"`,
+		`mv -f "$p.tmp" "$p"`,
+	})
+}
+
+// encode 实现remoteScripts
+func (shellScripts) encode(content []byte) []byte {
+	return content
+}
+
+// shellCommand 将多行脚本包装为sh -c命令
+func shellCommand(lines []string) string {
+	return "sh -c " + shellQuote(strings.Join(lines, "\n"))
+}
+
+// shellQuote 用单引号包裹字符串，内容不会被shell解释
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// shellPath 引用远程路径，开头的~/展开为登录用户的主目录
+func shellPath(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		return `"$HOME"/` + shellQuote(rest)
+	}
+	return shellQuote(path)
+}
+
+// powershellScripts Windows上的PowerShell命令
+// 脚本以-EncodedCommand传递，避免cmd.exe和PowerShell两层转义；文件内容以base64传输，避免换行和编码被转换
+type powershellScripts struct{}
+
+// closeApp 实现remoteScripts
+func (powershellScripts) closeApp(appName string) string {
+	name := powershellQuote(appName)
+	return powershellCommand([]string{
+		fmt.Sprintf("$running = { Get-Process -Name %s -ErrorAction SilentlyContinue }", name),
+		"if (-not (& $running)) { exit 0 }",
+		"& $running | ForEach-Object { $_.CloseMainWindow() | Out-Null }",
+		fmt.Sprintf("for ($i = 0; $i -lt %d -and (& $running); $i++) { Start-Sleep -Seconds 1 }", closeWaitSeconds),
+		"& $running | Stop-Process -Force -ErrorAction SilentlyContinue",
+		"Start-Sleep -Seconds 1",
+		fmt.Sprintf("if (& $running) { [Console]::Error.WriteLine(%s); exit 1 }", powershellQuote(appName+" is still running")),
+	})
+}
+
+// read 实现remoteScripts
+func (powershellScripts) read(path string) string {
+	return powershellCommand([]string{
+		"$p = " + powershellPath(path),
+		"if (Test-Path -LiteralPath $p -PathType Leaf) { [Convert]::ToBase64String([IO.File]::ReadAllBytes($p)) }",
+	})
+}
+
+// decode 实现remoteScripts
+func (powershellScripts) decode(output []byte) ([]byte, error) {
+	text := strings.TrimSpace(string(output))
+	if text == "" {
+		return nil, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode remote storage.json: %w", err)
+	}
+	return data, nil
+}
+
+// write 实现remoteScripts
+func (powershellScripts) write(path string, stamp string) string {
+	return powershellCommand([]string{
+		"$ErrorActionPreference = 'Stop'",
+		"$p = " + powershellPath(path),
+		"$d = Split-Path -Parent $p",
+		"New-Item -ItemType Directory -Force -Path $d | Out-Null",
+		"if (Test-Path -LiteralPath $p -PathType Leaf) {",
+		fmt.Sprintf("  $b = Join-Path $d %s", powershellQuote(backupDirName)),
+		"  New-Item -ItemType Directory -Force -Path $b | Out-Null",
+		fmt.Sprintf("  $b = Join-Path $b %s", powershellQuote(backupFilePrefix+stamp)),
+		"  Copy-Item -LiteralPath $p -Destination $b -Force",
+		"  Write-Output $b",
+		// 之前的运行可能将文件设为只读
+		"  Set-ItemProperty -LiteralPath $p -Name IsReadOnly -Value $false",
+		"}",
+		"[IO.File]::WriteAllBytes(\"$p.tmp\", [Convert]::FromBase64String([Console]::In.ReadToEnd()))",
+		"Move-Item -LiteralPath \"$p.tmp\" -Destination $p -Force",
+	})
+}
+
+// encode 实现remoteScripts
+func (powershellScripts) encode(content []byte) []byte {
+	return []byte(base64.StdEncoding.EncodeToString(content))
+}
+
+// powershellCommand 将多行脚本编码为powershell -EncodedCommand命令
+func powershellCommand(lines []string) string {
+	script := utf16.Encode([]rune(strings.Join(lines, "\n")))
+	encoded := make([]byte, 0, len(script)*2)
+	for _, unit := range script {
+		encoded = append(encoded, byte(unit), byte(unit>>8))
+	}
+	return "powershell -NoProfile -NonInteractive -EncodedCommand " + base64.StdEncoding.EncodeToString(encoded)
+}
+
+// powershellQuote 将字符串转换为PowerShell的单引号字符串
+func powershellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// powershellPath 引用远程路径，展开其中的%APPDATA%等环境变量
+func powershellPath(path string) string {
+	return "[Environment]::ExpandEnvironmentVariables(" + powershellQuote(path) + ")"
+}
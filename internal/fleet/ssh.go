@@ -0,0 +1,168 @@
+package fleet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// 建立SSH连接（含握手）的最长时间
+const dialTimeout = 15 * time.Second
+
+// 未指定私钥时依次尝试的默认私钥
+var defaultIdentityFiles = []string{"~/.ssh/id_ed25519", "~/.ssh/id_ecdsa", "~/.ssh/id_rsa"}
+
+// dial 连接主机并完成认证
+// ctx被取消时放弃连接
+func dial(ctx context.Context, host Host) (*ssh.Client, error) {
+	auth, closeAgent, err := authMethods(host)
+	if err != nil {
+		return nil, err
+	}
+	defer closeAgent()
+	hostKeyCallback, err := hostKeyCallback(host)
+	if err != nil {
+		return nil, err
+	}
+
+	address := host.Address
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, strconv.Itoa(host.Port))
+	}
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	// 握手本身不支持ctx，以连接的截止时间代替
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, address, &ssh.ClientConfig{
+		User:            host.User,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to log in to %s as %s: %w", address, host.User, err)
+	}
+	conn.SetDeadline(time.Time{})
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+// authMethods 返回可用的认证方式：指定的私钥，或ssh-agent和~/.ssh下的默认私钥
+// 返回的函数在认证完成后关闭与ssh-agent的连接
+func authMethods(host Host) ([]ssh.AuthMethod, func(), error) {
+	if host.IdentityFile != "" {
+		signer, err := loadSigner(host.IdentityFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []ssh.AuthMethod{ssh.PublicKeys(signer)}, func() {}, nil
+	}
+
+	var signers []ssh.Signer
+	closeAgent := func() {}
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		if conn, err := net.Dial("unix", socket); err == nil {
+			closeAgent = func() { conn.Close() }
+			if agentSigners, err := agent.NewClient(conn).Signers(); err == nil {
+				signers = append(signers, agentSigners...)
+			}
+		}
+	}
+	// 默认私钥不存在或需要口令时跳过，需要口令的私钥应先加入ssh-agent
+	for _, path := range defaultIdentityFiles {
+		if signer, err := loadSigner(path); err == nil {
+			signers = append(signers, signer)
+		}
+	}
+	if len(signers) == 0 {
+		closeAgent()
+		return nil, nil, fmt.Errorf("no SSH keys found, set identity_file in the inventory or start ssh-agent")
+	}
+	return []ssh.AuthMethod{ssh.PublicKeys(signers...)}, closeAgent, nil
+}
+
+// loadSigner 读取私钥文件
+func loadSigner(path string) (ssh.Signer, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SSH key: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey(data)
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) {
+		return nil, fmt.Errorf("SSH key %s is protected by a passphrase, add it to ssh-agent instead", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH key %s: %w", path, err)
+	}
+	return signer, nil
+}
+
+// hostKeyCallback 返回按known_hosts校验主机密钥的回调
+func hostKeyCallback(host Host) (ssh.HostKeyCallback, error) {
+	if host.InsecureIgnoreHostKey {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+	path := host.KnownHosts
+	if path == "" {
+		path = "~/.ssh/known_hosts"
+	}
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+	callback, err := knownhosts.New(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load known hosts: %w", err)
+	}
+	return callback, nil
+}
+
+// runCommand 在远程执行命令，返回标准输出
+// 命令失败时错误中包含标准错误的内容；ctx被取消时关闭会话
+func runCommand(ctx context.Context, client *ssh.Client, command string, stdin []byte) ([]byte, error) {
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SSH session: %w", err)
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+	if stdin != nil {
+		session.Stdin = bytes.NewReader(stdin)
+	}
+	stop := context.AfterFunc(ctx, func() { session.Close() })
+	defer stop()
+
+	if err := session.Run(command); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%w: %s", err, message)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,229 @@
+// hosts包，在系统hosts文件中添加和移除屏蔽Cursor遥测域名的条目
+package hosts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/yuaotian/go-cursor-help/internal/settings"
+)
+
+// 标记本工具添加的条目的首尾注释，移除时只删除两行之间的内容，不影响用户自己的条目
+const (
+	beginMarker = "# BEGIN cursor-id-modifier telemetry block"
+	endMarker   = "# END cursor-id-modifier telemetry block"
+)
+
+// 备份文件名中时间戳的格式，与storage.json的备份一致
+const backupTimeFormat = "20060102_150405.000000000"
+
+// 屏蔽的域名解析到的地址，同时添加IPv4和IPv6条目，否则遥测仍可通过IPv6发送
+var blockAddresses = []string{"0.0.0.0", "::"}
+
+// DefaultDomains 默认屏蔽的Cursor遥测域名，可以在工具配置文件中替换
+var DefaultDomains = []string{
+	"metrics.cursor.sh",
+}
+
+// Result 表示修改hosts文件的结果
+type Result struct {
+	// hosts文件的路径
+	Path string
+	// 修改前创建的备份，没有修改时为空
+	BackupPath string
+	// 屏蔽的域名数量
+	Domains int
+	// 是否修改了hosts文件；条目已经是最新或不存在时为false
+	Changed bool
+}
+
+// DefaultPath 返回当前系统的hosts文件路径
+func DefaultPath() string {
+	if runtime.GOOS == "windows" {
+		root := os.Getenv("SystemRoot")
+		if root == "" {
+			root = `C:\Windows`
+		}
+		return filepath.Join(root, "System32", "drivers", "etc", "hosts")
+	}
+	return "/etc/hosts"
+}
+
+// Block 在hosts文件末尾添加屏蔽遥测域名的条目，已有条目时替换为新的域名列表
+// 修改前在backupDir中创建带时间戳的备份；条目已经是最新时不修改文件
+func Block(path string, domains []string, backupDir string) (*Result, error) {
+	for _, domain := range domains {
+		if domain == "" || strings.ContainsAny(domain, " \t#\r\n") {
+			return nil, fmt.Errorf("invalid domain: %q", domain)
+		}
+	}
+	if len(domains) == 0 {
+		return nil, fmt.Errorf("no domains to block")
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hosts file: %w", err)
+	}
+	text := string(content)
+	newline := lineEnding(text)
+
+	lines := []string{beginMarker, "# Added by cursor-id-modifier, remove with -unblock-telemetry"}
+	for _, domain := range domains {
+		for _, address := range blockAddresses {
+			lines = append(lines, address+" "+domain)
+		}
+	}
+	lines = append(lines, endMarker)
+	block := strings.Join(lines, newline) + newline
+
+	result := &Result{Path: path, Domains: len(domains)}
+	rest, existing, _ := removeBlock(text)
+	if existing == block {
+		return result, nil
+	}
+	if rest != "" && !strings.HasSuffix(rest, "\n") {
+		rest += newline
+	}
+	if err := write(path, backupDir, content, rest+block, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Unblock 移除本工具添加的条目，hosts文件中没有条目时不修改文件
+// 修改前在backupDir中创建带时间戳的备份
+func Unblock(path string, backupDir string) (*Result, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hosts file: %w", err)
+	}
+
+	result := &Result{Path: path}
+	rest, _, found := removeBlock(string(content))
+	if !found {
+		return result, nil
+	}
+	if err := write(path, backupDir, content, rest, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FlushDNS 清除系统的DNS缓存，使hosts文件的修改立即生效
+// 各系统使用的命令不同，命令不存在（例如未使用systemd-resolved的Linux）时返回错误，调用方可以忽略
+func FlushDNS(ctx context.Context) error {
+	var commands [][]string
+	switch runtime.GOOS {
+	case "windows":
+		commands = [][]string{{"ipconfig", "/flushdns"}}
+	case "darwin":
+		commands = [][]string{{"dscacheutil", "-flushcache"}, {"killall", "-HUP", "mDNSResponder"}}
+	default:
+		commands = [][]string{{"resolvectl", "flush-caches"}}
+	}
+	for _, command := range commands {
+		if output, err := exec.CommandContext(ctx, command[0], command[1:]...).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to flush DNS cache with %s: %w: %s", command[0], err, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+// removeBlock 从hosts文件内容中去掉本工具添加的条目
+// 返回去掉后的内容、去掉的条目（含首尾标记和结尾的换行）以及是否找到条目
+// 只有开始标记没有结束标记时视为没有条目，避免误删用户的内容
+func removeBlock(text string) (string, string, bool) {
+	start := strings.Index(text, beginMarker)
+	if start < 0 || (start > 0 && text[start-1] != '\n') {
+		return text, "", false
+	}
+	end := strings.Index(text[start:], endMarker)
+	if end < 0 {
+		return text, "", false
+	}
+	end += start + len(endMarker)
+	if strings.HasPrefix(text[end:], "\r\n") {
+		end += 2
+	} else if strings.HasPrefix(text[end:], "\n") {
+		end++
+	}
+	return text[:start] + text[end:], text[start:end], true
+}
+
+// lineEnding 返回hosts文件使用的换行符，保持与原文件一致
+func lineEnding(text string) string {
+	if strings.Contains(text, "\r\n") || (text == "" && runtime.GOOS == "windows") {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// write 备份原内容后写入新内容，保留hosts文件原有的权限
+// 新内容先写入同一目录中的临时文件再重命名，中途崩溃或磁盘已满时原文件保持完整
+func write(path, backupDir string, original []byte, content string, result *Result) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat hosts file: %w", err)
+	}
+
+	// 备份放在工具的备份目录中，不在系统目录中留下文件
+	if err := settings.MkdirAllOwned(backupDir, 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	backupPath := filepath.Join(backupDir, "hosts.backup_"+time.Now().Format(backupTimeFormat))
+	if err := os.WriteFile(backupPath, original, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to back up hosts file: %w", err)
+	}
+	if err := settings.RestoreOwnership(backupPath); err != nil {
+		return err
+	}
+	result.BackupPath = backupPath
+
+	if err := replaceFile(path, []byte(content), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write hosts file: %w", err)
+	}
+	result.Changed = true
+	return nil
+}
+
+// replaceFile 通过临时文件和重命名替换文件内容
+// 容器中以绑定挂载提供的hosts文件无法被重命名替换，此时退回到直接写入
+func replaceFile(path string, content []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	_, err = tmp.Write(content)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, perm)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		if errors.Is(err, syscall.EBUSY) || errors.Is(err, syscall.EXDEV) {
+			return os.WriteFile(path, content, perm)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package hosts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBlockUnblockRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		// Unblock后的内容；原文件末尾没有换行时，添加条目前补上的换行会保留
+		restored string
+	}{
+		{name: "empty", original: "", restored: ""},
+		{name: "lf", original: "127.0.0.1 localhost\n::1 localhost\n", restored: "127.0.0.1 localhost\n::1 localhost\n"},
+		{name: "crlf", original: "127.0.0.1 localhost\r\n", restored: "127.0.0.1 localhost\r\n"},
+		{name: "no trailing newline", original: "127.0.0.1 localhost", restored: "127.0.0.1 localhost\n"},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "hosts")
+		backupDir := filepath.Join(dir, "backups")
+		if err := os.WriteFile(path, []byte(tt.original), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := Block(path, []string{"metrics.cursor.sh", "api.example.com"}, backupDir)
+		if err != nil {
+			t.Fatalf("%s: Block returned error: %v", tt.name, err)
+		}
+		if !result.Changed || result.Domains != 2 || result.BackupPath == "" {
+			t.Errorf("%s: Block result = %+v, want a change with a backup", tt.name, result)
+		}
+		if backup, err := os.ReadFile(result.BackupPath); err != nil || string(backup) != tt.original {
+			t.Errorf("%s: backup = %q (%v), want the original content", tt.name, backup, err)
+		}
+		blocked := readFile(t, path)
+		if !strings.HasPrefix(blocked, strings.TrimRight(tt.original, "\r\n")) {
+			t.Errorf("%s: Block changed the existing entries:\n%s", tt.name, blocked)
+		}
+		for _, line := range []string{"0.0.0.0 metrics.cursor.sh", ":: metrics.cursor.sh", "0.0.0.0 api.example.com", ":: api.example.com"} {
+			if !strings.Contains(blocked, line) {
+				t.Errorf("%s: blocked hosts file does not contain %q", tt.name, line)
+			}
+		}
+		if strings.Contains(tt.original, "\r\n") && strings.Count(blocked, "\n") != strings.Count(blocked, "\r\n") {
+			t.Errorf("%s: Block mixed line endings:\n%q", tt.name, blocked)
+		}
+
+		// 条目已是最新时不修改文件
+		result, err = Block(path, []string{"metrics.cursor.sh", "api.example.com"}, backupDir)
+		if err != nil {
+			t.Fatalf("%s: second Block returned error: %v", tt.name, err)
+		}
+		if result.Changed || readFile(t, path) != blocked {
+			t.Errorf("%s: second Block changed the hosts file", tt.name)
+		}
+
+		// 新的域名列表替换原有条目，而不是追加第二个条目
+		if _, err := Block(path, []string{"metrics.cursor.sh"}, backupDir); err != nil {
+			t.Fatalf("%s: Block with new domains returned error: %v", tt.name, err)
+		}
+		replaced := readFile(t, path)
+		if strings.Count(replaced, beginMarker) != 1 || strings.Contains(replaced, "api.example.com") {
+			t.Errorf("%s: Block did not replace the existing entries:\n%s", tt.name, replaced)
+		}
+
+		result, err = Unblock(path, backupDir)
+		if err != nil {
+			t.Fatalf("%s: Unblock returned error: %v", tt.name, err)
+		}
+		if !result.Changed {
+			t.Errorf("%s: Unblock reported no change", tt.name)
+		}
+		if got := readFile(t, path); got != tt.restored {
+			t.Errorf("%s: hosts file after Unblock = %q, want %q", tt.name, got, tt.restored)
+		}
+
+		result, err = Unblock(path, backupDir)
+		if err != nil {
+			t.Fatalf("%s: second Unblock returned error: %v", tt.name, err)
+		}
+		if result.Changed {
+			t.Errorf("%s: second Unblock changed the hosts file", tt.name)
+		}
+	}
+}
+
+func TestBlockRejectsInvalidDomains(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts")
+	if err := os.WriteFile(path, []byte("127.0.0.1 localhost\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, domains := range [][]string{nil, {""}, {"a.com b.com"}, {"a.com#"}, {"a.com\n0.0.0.0 b.com"}} {
+		if _, err := Block(path, domains, t.TempDir()); err == nil {
+			t.Errorf("Block(%q) succeeded, want an error", domains)
+		}
+	}
+	if got := readFile(t, path); got != "127.0.0.1 localhost\n" {
+		t.Errorf("hosts file changed after invalid Block: %q", got)
+	}
+}
+
+func TestUnblockKeepsIncompleteBlock(t *testing.T) {
+	// 只有开始标记时不删除任何内容
+	original := "127.0.0.1 localhost\n" + beginMarker + "\n0.0.0.0 metrics.cursor.sh\n"
+	path := filepath.Join(t.TempDir(), "hosts")
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, err := Unblock(path, t.TempDir())
+	if err != nil {
+		t.Fatalf("Unblock returned error: %v", err)
+	}
+	if result.Changed || readFile(t, path) != original {
+		t.Errorf("Unblock changed a hosts file without an end marker")
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
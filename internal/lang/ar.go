@@ -0,0 +1,248 @@
+// 语言包，提供多语言支持功能
+package lang
+
+// arText 阿拉伯语文本，未翻译的文本使用英文
+var arText = TextResource{
+	// 成功消息
+	SuccessMessage:     "[√] تم تحديث ملف الإعدادات بنجاح!",
+	RestartMessage:     "[!] أعد تشغيل Cursor يدويًا لتطبيق التغييرات",
+	OperationCompleted: "اكتملت العملية!",
+
+	// 版本信息
+	VersionInfo: "Cursor ID Modifier الإصدار {{.Version}}",
+
+	// 标识符表格列标题
+	TableField: "الحقل",
+	TableOld:   "القيمة القديمة",
+	TableNew:   "القيمة الجديدة",
+	TableFile:  "الملف",
+
+	// 提问消息
+	PromptYesHint:       "(Y/n)",
+	PromptNoHint:        "(y/N)",
+	PromptYes:           "نعم",
+	PromptNo:            "لا",
+	PromptInvalidChoice: "أدخل رقمًا من 1 إلى {{.Count}}.",
+	PromptTimedOut:      "لم يتم إدخال أي شيء خلال {{.Timeout}}، سيتم استخدام القيمة الافتراضية.",
+
+	// 运行摘要消息
+	SummaryTitle:      "الملخص",
+	SummaryFiles:      "الملفات المعدلة",
+	SummaryBackup:     "النسخة الاحتياطية",
+	SummaryIDs:        "المعرّفات المتغيرة",
+	SummaryIDsValue:   "{{.Changed}} من {{.Total}} ({{.Fields}})",
+	SummaryProtection: "الحماية من الكتابة",
+	SummaryProcesses:  "العمليات المغلقة",
+	SummaryTime:       "الوقت الإجمالي",
+	SummaryNone:       "لا شيء",
+
+	// 启动菜单消息
+	MenuTitle:        "ماذا تريد أن تفعل؟",
+	MenuModify:       "تعديل المعرّفات",
+	MenuStatus:       "عرض الحالة",
+	MenuRestore:      "الاستعادة من نسخة احتياطية",
+	MenuLock:         "حماية storage.json من الكتابة",
+	MenuUnlock:       "إزالة الحماية من الكتابة عن storage.json",
+	MenuLanguage:     "تغيير اللغة (Language)",
+	MenuExit:         "خروج",
+	MenuPrompt:       "أدخل رقمًا",
+	MenuBackupPrompt: "اختر النسخة الاحتياطية المراد استعادتها",
+	MenuNoBackups:    "لم يتم العثور على نسخ احتياطية في {{.Dir}}",
+	LanguagePrompt:   "اختر لغة الواجهة",
+	LanguageSaved:    "تم حفظ اللغة في {{.Path}}",
+	StatusTitle:      "الحالة الحالية",
+	StatusConfig:     "ملف الإعدادات",
+	StatusMissing:    "(غير موجود)",
+	StatusProcesses:  "العمليات قيد التشغيل",
+	LockSuccess:      "[√] أصبح storage.json محميًا من الكتابة ({{.Level}})",
+
+	// 进度消息
+	ReadingConfig:     "جارٍ قراءة ملف الإعدادات...",
+	GeneratingIds:     "جارٍ إنشاء معرّفات جديدة...",
+	SavingConfig:      "جارٍ إنشاء نسخة احتياطية وحفظ الإعدادات...",
+	VerifyingConfig:   "جارٍ التحقق من الملف المكتوب...",
+	CheckingProcesses: "جارٍ البحث عن نسخ Cursor قيد التشغيل...",
+	ClosingProcesses:  "جارٍ إغلاق Cursor...",
+	ClosingRemaining:  "جارٍ إغلاق العمليات ({{.Total}})... المتبقي {{.Remaining}}",
+	ProcessesClosed:   "تم إغلاق جميع نسخ Cursor",
+	PleaseWait:        "يرجى الانتظار...",
+
+	// 错误消息
+	ErrorPrefix:     "حدث خطأ فادح: {{.Error}}",
+	PrivilegeError:  "\n[!] خطأ: يلزم الحصول على صلاحيات المسؤول",
+	CloseFailed:     "تعذّر إغلاق {{.App}}. يُرجى إغلاقه يدويًا والمحاولة مرة أخرى.",
+	CloseIncomplete: "تعذّر إغلاق {{.App}} بالكامل. يُرجى إغلاقه يدويًا والمحاولة مرة أخرى.",
+
+	// 指令提示
+	RunAsAdmin:           "انقر بزر الماوس الأيمن واختر \"تشغيل كمسؤول\"",
+	RunWithSudo:          "شغّل هذا البرنامج باستخدام sudo",
+	SudoExample:          "مثال: sudo {{.Executable}}",
+	PressEnterToExit:     "\nاضغط Enter للخروج...",
+	RequestingPrivileges: "\nجارٍ طلب صلاحيات المسؤول...",
+	SetReadOnlyMessage:   "تم تعيين storage.json للقراءة فقط، وقد يؤدي ذلك إلى فقدان سجل مساحات العمل",
+
+	// 信息消息
+	ConfigLocation: "موقع ملف الإعدادات:",
+
+	// 写保护消息
+	StrongProtectionWarning: "[!] تم تفعيل الحماية القوية من الكتابة، ولن يتمكن Cursor من تحديث storage.json. شغّل البرنامج مع -unlock للتراجع",
+	UnlockSuccess:           "[√] تمت إزالة الحماية من الكتابة عن storage.json",
+
+	// 备份消息
+	BackupCreated:     "تم حفظ نسخة احتياطية من الإعدادات في: {{.Path}}",
+	RestoreSuccess:    "[√] تمت استعادة الإعدادات من النسخة الاحتياطية: {{.Path}}",
+	RestoreInvalid:    "[!] لم تجتز النسخة الاحتياطية التحقق:",
+	RestoreDiffHeader: "ستؤدي الاستعادة إلى التغييرات التالية في storage.json:",
+	RestoreNoChanges:  "النسخة الاحتياطية مطابقة للإعدادات الحالية، لا يوجد ما يُستعاد",
+	RestorePrompt:     "هل تريد الاستعادة الآن؟",
+
+	// hosts文件遥测屏蔽消息
+	TelemetryBlocked:        "[√] تم حظر نطاقات القياس عن بُعد ({{.Count}}) في {{.Path}}",
+	TelemetryAlreadyBlocked: "نطاقات القياس عن بُعد محظورة بالفعل في {{.Path}}",
+	TelemetryUnblocked:      "[√] تمت إزالة حظر القياس عن بُعد من {{.Path}}",
+	TelemetryNotBlocked:     "لا يحتوي {{.Path}} على حظر للقياس عن بُعد",
+	HostsBackupCreated:      "تم حفظ نسخة احتياطية من ملف hosts في: {{.Path}}",
+
+	// Windows防火墙规则消息
+	FirewallRulesCreated: "[√] تم إنشاء قواعد جدار الحماية الصادرة لحظر القياس عن بُعد ({{.Count}})",
+	FirewallRulesRemoved: "[√] تمت إزالة قواعد جدار الحماية ({{.Count}})",
+	FirewallNoRules:      "لم يتم العثور على قواعد جدار حماية أنشأتها هذه الأداة",
+	FirewallStatusTitle:  "قواعد جدار الحماية للقياس عن بُعد",
+	FirewallRuleDisabled: "(معطلة)",
+
+	// HTTP API消息
+	ServeListening:     "واجهة HTTP API تستمع على http://{{.Address}} (اضغط Ctrl+C للإيقاف)",
+	ServeGRPCListening: "خدمة gRPC تستمع على {{.Address}}",
+	ServeToken:         "رمز الوصول: {{.Token}}",
+	ServeNotLoopback:   "[!] يمكن الوصول إلى الواجهة من أجهزة أخرى؛ يمكن لأي شخص يملك الرمز إعادة تعيين المعرفات على هذا الكمبيوتر",
+
+	// SSH批量处理消息
+	FleetStarting:    "جارٍ تحديث {{.Count}} من الأجهزة المضيفة من {{.Inventory}} عبر SSH...",
+	FleetHostUpdated: "[√] {{.Host}}: تم تحديث {{.Path}}",
+	FleetHostFailed:  "[×] {{.Host}}: {{.Error}}",
+	FleetSummary:     "تم تحديث {{.Succeeded}} من أصل {{.Total}} من الأجهزة المضيفة",
+
+	// 审计日志消息
+	AuditVerified:      "[√] سجل التدقيق سليم: تم التحقق من جميع الإدخالات ({{.Count}}) في {{.Path}}",
+	AuditHead:          "آخر إدخال: {{.Time}}، التجزئة {{.Hash}}",
+	AuditEmpty:         "لا يحتوي سجل التدقيق {{.Path}} على إدخالات بعد",
+	AuditIntactEntries: "[!] الإدخالات ({{.Count}}) التي تسبق الخطأ سليمة",
+
+	// 全屏界面消息
+	TUITitle:           "Cursor ID Modifier",
+	TUIMenuReset:       "إعادة تعيين معرّفات الجهاز",
+	TUIMenuProcesses:   "عرض عمليات Cursor قيد التشغيل",
+	TUIMenuQuit:        "خروج",
+	TUIProcessesHeader: "عمليات Cursor قيد التشغيل (تُحدَّث كل ثانية):",
+	TUINoProcesses:     "لا توجد عمليات Cursor قيد التشغيل",
+	TUIChangesHeader:   "تغييرات المعرّفات:",
+	TUIOldValue:        "قديم",
+	TUINewValue:        "جديد",
+	TUISucceeded:       "[√] تم. أعد تشغيل Cursor لاستخدام المعرّفات الجديدة",
+	TUIFailed:          "[×] لم يكتمل، راجع الخطوة الفاشلة أعلاه",
+	TUIMenuHelp:        "↑/↓ تحديد · Enter تأكيد · l اللغة · q خروج",
+	TUIBackHelp:        "Enter/Esc العودة إلى القائمة · Ctrl+C خروج",
+	TUIRunningHelp:     "قيد التنفيذ… · Ctrl+C إلغاء",
+	TUIStepClose:       "إغلاق Cursor",
+	TUIStepCheckFile:   "التحقق من أن storage.json غير مستخدم",
+	TUIStepRead:        "قراءة الإعدادات الحالية",
+	TUIStepGenerate:    "إنشاء معرّفات جديدة",
+	TUIStepSave:        "إنشاء نسخة احتياطية وحفظ الإعدادات",
+	TUIStepVerify:      "التحقق من الملف المكتوب",
+
+	// 无障碍模式消息
+	AccessibleStepStarted:  "الخطوة {{.Step}} من {{.Total}}: {{.Title}}",
+	AccessibleStepFinished: "اكتملت الخطوة {{.Step}} من {{.Total}} خلال {{.Elapsed}}.",
+	AccessibleStepFailed:   "فشلت الخطوة {{.Step}} من {{.Total}}.",
+	AccessibleFinished:     "تم خلال {{.Elapsed}}.",
+	AccessibleFailed:       "فشل.",
+	AccessibleSuccess:      "نجاح: ",
+	AccessibleWarning:      "تحذير: ",
+	AccessibleError:        "خطأ: ",
+
+	// 守护模式消息
+	GuardReapplied: "[!] أعاد Cursor كتابة المعرّفات، وتمت إعادة تطبيقها: {{.Keys}}",
+	GuardUnchanged: "[√] لم تتغير المعرّفات",
+	GuardWatching:  "جارٍ مراقبة storage.json، اضغط Ctrl+C للإيقاف...",
+
+	// 快照消息
+	SnapshotCreated:  "[√] تم حفظ لقطة globalStorage في: {{.Path}}",
+	SnapshotRestored: "[√] تمت استعادة globalStorage من اللقطة: {{.Path}}",
+
+	// workspaceStorage清理消息
+	WorkspaceStorageHeader:  "دليل تخزين مساحات العمل: {{.Dir}}",
+	WorkspaceStorageTotal:   "مساحات العمل: {{.Count}}، الحجم الإجمالي {{.Size}}",
+	WorkspaceStoragePrompt:  "أدخل الأرقام المراد حذفها (مثل 1,3,5-7)، أو اكتب all لحذف الكل، أو اضغط Enter للتخطي",
+	WorkspaceStorageCleared: "[√] تمت إزالة مساحات العمل ({{.Count}}) وتحرير {{.Size}}",
+	WorkspaceStorageEmpty:   "لا يوجد تخزين لمساحات العمل لتنظيفه",
+
+	// 登录状态清理消息
+	SignOutSuccess: "[√] تم مسح حالة تسجيل الدخول إلى Cursor (مفاتيح المصادقة التي تمت إزالتها: {{.Count}})",
+	SignOutBackup:  "تم نسخ بيانات تسجيل الدخول احتياطيًا إلى: {{.Path}}",
+
+	// 并发修改消息
+	ConcurrentModification: "[!] عدّلت عملية أخرى (ربما Cursor أو أداة التحديث الخاصة به) الملف storage.json أثناء التشغيل، فتم إلغاء الكتابة لتجنب الكتابة فوق البيانات الجديدة",
+	RetryPrompt:            "إعادة قراءة الملف والمحاولة مرة أخرى؟",
+
+	// 已有写保护消息
+	WriteProtectedDetected: "[!] الملف storage.json محمي من الكتابة بواسطة تشغيل سابق ({{.Level}})",
+	LiftProtectionPrompt:   "إزالة الحماية مؤقتًا لكتابة المعرّفات الجديدة؟ ستتم إعادة تطبيق الحماية المطلوبة بعد ذلك.",
+
+	// OneDrive重定向消息
+	OneDriveDetected:       "[!] مجلد بيانات Cursor موجود داخل مجلد تتم مزامنته عبر OneDrive ({{.Dir}})؛ قد تؤدي المزامنة إلى الكتابة فوق المعرّفات الجديدة",
+	OneDriveAdvice:         "يُنصح بإيقاف مزامنة OneDrive مؤقتًا أثناء التعديل، أو استخدم -pause-onedrive لإيقافها تلقائيًا",
+	OneDrivePaused:         "تم إغلاق OneDrive مؤقتًا وستتم إعادة تشغيله بعد ذلك",
+	OneDriveConflictCopies: "[!] تم العثور على نسخ تعارض مزامنة OneDrive، يرجى مراجعتها وحذفها: {{.Files}}",
+
+	// 数据目录扫描消息
+	DiscoverHeader:   "تم العثور على ملفات storage.json ({{.Count}})، الأحدث أولًا:",
+	DiscoverEmpty:    "لم يتم العثور على storage.json",
+	DiscoverPrompt:   "أدخل رقمًا لجعله الهدف الافتراضي، أو اضغط Enter للتخطي",
+	DiscoverSelected: "تم حفظ {{.Path}} كهدف افتراضي",
+
+	// 文件锁定消息
+	FileLocked: "[!] الملف storage.json مقفل بواسطة عملية أخرى، ربما أداة التحديث الخلفية لـ Cursor أو نسخة أخرى قيد التشغيل من هذه الأداة؛ أغلقها وحاول مرة أخرى",
+
+	// 备份集消息
+	BackupSetCreated: "[√] تم حزم الملفات ({{.Count}}) في مجموعة النسخ الاحتياطي: {{.Path}}",
+
+	// 标识符校验消息
+	VerifyHeader: "جارٍ فحص المعرّفات في {{.Path}}:",
+	VerifyPassed: "[√] جميع المعرّفات صالحة من حيث البنية",
+
+	// 自检消息
+	SelfTestRunning: "جارٍ إنشاء مجموعات المعرّفات ({{.Count}}) والتحقق من العشوائية...",
+	SelfTestPassed:  "[√] نجح الاختبار الذاتي، يمكن إنشاء المعرّفات بأمان",
+
+	// 其他用户的进程消息
+	OtherUserProcesses:    "[!] عمليات Cursor التالية تخص حساب مستخدم آخر:",
+	OtherUserRefused:      "قد تكون تستخدم إعدادات مستخدم آخر، لذلك لم يتم تغيير أي شيء. أعد التشغيل باستخدام -force لإغلاقها على أي حال",
+	OtherUserProcessEntry: "  PID {{.PID}}  {{.Name}}  (المستخدم {{.User}})",
+
+	// 工作区消息
+	WorkspacesOpen:           "كانت مساحات العمل هذه مفتوحة قبل إغلاق Cursor:",
+	RelaunchWorkspacesPrompt: "إعادة فتح مساحات العمل ({{.Count}})؟",
+	WorkspacesRelaunched:     "[√] تمت إعادة فتح مساحات العمل ({{.Count}})",
+	CursorRestarted:          "[√] تمت إعادة تشغيل {{.App}}",
+
+	// 未保存修改消息
+	UnsavedWorkWarning: "[!] يحتوي Cursor على محررات بها تغييرات غير محفوظة ({{.Count}})؛ قد يؤدي الإغلاق القسري إلى فقدانها، لذا احفظها أولًا",
+	UnsavedWorkPrompt:  "إغلاق Cursor والمتابعة على أي حال؟",
+
+	// 进程列表消息
+	ProcessListHeader: "سيؤدي إغلاق {{.App}} إلى إنهاء هذه العمليات ({{.Count}}):",
+	ProcessListEmpty:  "[√] لن يتم إغلاق أي عملية لـ {{.App}}",
+	ProcessListEntry:  "  PID {{.PID}} PPID {{.PPID}} {{.User}} {{.Started}}  {{.Name}}",
+	ProcessListDaemon: "{{.Name}} (خدمة خلفية)",
+
+	// 文件占用消息
+	FileInUse:     "[!] لا تزال هذه العمليات تفتح {{.File}} وقد تكتب فوق التغييرات:",
+	FileInUseHint: "أغلقها وحاول مرة أخرى",
+
+	// 提升权限的进程消息
+	ElevatedProcess: "[!] العملية {{.PID}} الخاصة بـ {{.App}} تعمل بصلاحيات أعلى ({{.Level}}) ولا يمكن إغلاقها من هنا. شغّل هذه الأداة كمسؤول (Windows) أو باستخدام sudo (macOS/Linux)، أو أغلقها يدويًا",
+
+	// 远程服务器消息
+	ServerMachineIDReset:   "[√] تمت إعادة تعيين machineid للخادم البعيد",
+	ServerMachineIDMissing: "[!] لا يحتوي الخادم البعيد على ملف machineid، تم التخطي",
+}
@@ -0,0 +1,68 @@
+// 语言包，提供多语言支持功能
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yuaotian/go-cursor-help/internal/settings"
+)
+
+// cacheMaxAge 缓存的检测结果的有效期，过期后重新检测，使系统语言的修改在一天内生效
+const cacheMaxAge = 24 * time.Hour
+
+// cachePath 缓存系统语言检测结果的文件，为空时不缓存，由languageMutex保护
+var cachePath string
+
+// SetDetectionCache 设置缓存系统语言检测结果的文件，之后的运行直接使用缓存，不再调用系统命令
+// 需要在第一次获取当前语言之前调用；环境变量中的语言设置总是优先于缓存
+func SetDetectionCache(path string) {
+	languageMutex.Lock()
+	defer languageMutex.Unlock()
+	cachePath = path
+}
+
+// cachedLanguage 读取缓存的检测结果，文件不存在、过期或内容无效时返回false
+func cachedLanguage() (Language, bool) {
+	languageMutex.RLock()
+	path := cachePath
+	languageMutex.RUnlock()
+	if path == "" {
+		return "", false
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > cacheMaxAge {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	language := Language(strings.TrimSpace(string(data)))
+	if _, ok := texts[language]; !ok {
+		return "", false
+	}
+	return language, true
+}
+
+// saveCachedLanguage 保存检测结果，写入失败时忽略，下次运行重新检测
+func saveCachedLanguage(language Language) {
+	languageMutex.RLock()
+	path := cachePath
+	languageMutex.RUnlock()
+	if path == "" {
+		return
+	}
+
+	// 通过sudo运行时文件归还给原始用户，之后以普通用户运行时仍可更新
+	if err := settings.MkdirAllOwned(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	if err := os.WriteFile(path, []byte(string(language)+"\n"), 0644); err != nil {
+		return
+	}
+	_ = settings.RestoreOwnership(path)
+}
@@ -0,0 +1,248 @@
+// 语言包，提供多语言支持功能
+package lang
+
+// deText 德语文本，未翻译的文本使用英文
+var deText = TextResource{
+	// 成功消息
+	SuccessMessage:     "[√] Konfigurationsdatei erfolgreich aktualisiert!",
+	RestartMessage:     "[!] Bitte starten Sie Cursor manuell neu, damit die Änderungen wirksam werden",
+	OperationCompleted: "Vorgang abgeschlossen!",
+
+	// 版本信息
+	VersionInfo: "Cursor ID Modifier Version {{.Version}}",
+
+	// 标识符表格列标题
+	TableField: "Feld",
+	TableOld:   "Alter Wert",
+	TableNew:   "Neuer Wert",
+	TableFile:  "Datei",
+
+	// 提问消息
+	PromptYesHint:       "(J/n)",
+	PromptNoHint:        "(j/N)",
+	PromptYes:           "ja",
+	PromptNo:            "nein",
+	PromptInvalidChoice: "Bitte geben Sie eine Zahl von 1 bis {{.Count}} ein.",
+	PromptTimedOut:      "Keine Eingabe innerhalb von {{.Timeout}}, der Standardwert wird verwendet.",
+
+	// 运行摘要消息
+	SummaryTitle:      "Zusammenfassung",
+	SummaryFiles:      "Geänderte Dateien",
+	SummaryBackup:     "Sicherung",
+	SummaryIDs:        "Geänderte IDs",
+	SummaryIDsValue:   "{{.Changed}} von {{.Total}} ({{.Fields}})",
+	SummaryProtection: "Schreibschutz",
+	SummaryProcesses:  "Beendete Prozesse",
+	SummaryTime:       "Gesamtdauer",
+	SummaryNone:       "keine",
+
+	// 启动菜单消息
+	MenuTitle:        "Was möchten Sie tun?",
+	MenuModify:       "IDs ändern",
+	MenuStatus:       "Status anzeigen",
+	MenuRestore:      "Sicherung wiederherstellen",
+	MenuLock:         "storage.json schreibschützen",
+	MenuUnlock:       "Schreibschutz von storage.json entfernen",
+	MenuLanguage:     "Sprache wechseln (Language)",
+	MenuExit:         "Beenden",
+	MenuPrompt:       "Nummer eingeben",
+	MenuBackupPrompt: "Wählen Sie die wiederherzustellende Sicherung",
+	MenuNoBackups:    "Keine Sicherungen in {{.Dir}} gefunden",
+	LanguagePrompt:   "Wählen Sie die Sprache der Oberfläche",
+	LanguageSaved:    "Sprache gespeichert in {{.Path}}",
+	StatusTitle:      "Aktueller Status",
+	StatusConfig:     "Konfigurationsdatei",
+	StatusMissing:    "(nicht vorhanden)",
+	StatusProcesses:  "Laufende Prozesse",
+	LockSuccess:      "[√] storage.json ist jetzt schreibgeschützt ({{.Level}})",
+
+	// 进度消息
+	ReadingConfig:     "Konfigurationsdatei wird gelesen...",
+	GeneratingIds:     "Neue IDs werden erzeugt...",
+	SavingConfig:      "Konfiguration wird gesichert und gespeichert...",
+	VerifyingConfig:   "Geschriebene Datei wird überprüft...",
+	CheckingProcesses: "Suche nach laufenden Cursor-Instanzen...",
+	ClosingProcesses:  "Cursor-Instanzen werden beendet...",
+	ClosingRemaining:  "{{.Total}} {{plural .Total one \"Prozess wird\" other \"Prozesse werden\"}} beendet... {{.Remaining}} verbleibend",
+	ProcessesClosed:   "Alle Cursor-Instanzen wurden beendet",
+	PleaseWait:        "Bitte warten...",
+
+	// 错误消息
+	ErrorPrefix:     "Das Programm hat einen schweren Fehler festgestellt: {{.Error}}",
+	PrivilegeError:  "\n[!] Fehler: Administratorrechte erforderlich",
+	CloseFailed:     "{{.App}} konnte nicht beendet werden. Bitte beenden Sie es manuell und versuchen Sie es erneut.",
+	CloseIncomplete: "{{.App}} konnte nicht vollständig beendet werden. Bitte beenden Sie es manuell und versuchen Sie es erneut.",
+
+	// 指令提示
+	RunAsAdmin:           "Bitte mit der rechten Maustaste klicken und „Als Administrator ausführen“ wählen",
+	RunWithSudo:          "Bitte führen Sie dieses Programm mit sudo aus",
+	SudoExample:          "Beispiel: sudo {{.Executable}}",
+	PressEnterToExit:     "\nZum Beenden Enter drücken...",
+	RequestingPrivileges: "\nAdministratorrechte werden angefordert...",
+	SetReadOnlyMessage:   "storage.json wurde schreibgeschützt, dadurch können z. B. Arbeitsbereichseinträge verloren gehen",
+
+	// 信息消息
+	ConfigLocation: "Speicherort der Konfigurationsdatei:",
+
+	// 写保护消息
+	StrongProtectionWarning: "[!] Starker Schreibschutz aktiviert, Cursor kann storage.json nicht mehr aktualisieren. Mit -unlock rückgängig machen",
+	UnlockSuccess:           "[√] Schreibschutz von storage.json entfernt",
+
+	// 备份消息
+	BackupCreated:     "Konfiguration gesichert nach: {{.Path}}",
+	RestoreSuccess:    "[√] Konfiguration aus Sicherung wiederhergestellt: {{.Path}}",
+	RestoreInvalid:    "[!] Die Sicherung hat die Prüfung nicht bestanden:",
+	RestoreDiffHeader: "Die Wiederherstellung nimmt folgende Änderungen an storage.json vor:",
+	RestoreNoChanges:  "Die Sicherung entspricht der aktuellen Konfiguration, nichts wiederherzustellen",
+	RestorePrompt:     "Jetzt wiederherstellen?",
+
+	// hosts文件遥测屏蔽消息
+	TelemetryBlocked:        "[√] {{.Count}} {{plural .Count one \"Telemetrie-Domain\" other \"Telemetrie-Domains\"}} in {{.Path}} blockiert",
+	TelemetryAlreadyBlocked: "Die Telemetrie-Domains sind in {{.Path}} bereits blockiert",
+	TelemetryUnblocked:      "[√] Telemetrie-Sperre aus {{.Path}} entfernt",
+	TelemetryNotBlocked:     "{{.Path}} enthält keine Telemetrie-Sperre",
+	HostsBackupCreated:      "Sicherung der hosts-Datei gespeichert: {{.Path}}",
+
+	// Windows防火墙规则消息
+	FirewallRulesCreated: "[√] {{.Count}} ausgehende {{plural .Count one \"Firewall-Regel\" other \"Firewall-Regeln\"}} gegen Telemetrie erstellt",
+	FirewallRulesRemoved: "[√] {{.Count}} {{plural .Count one \"Firewall-Regel\" other \"Firewall-Regeln\"}} entfernt",
+	FirewallNoRules:      "Keine von diesem Tool erstellten Firewall-Regeln gefunden",
+	FirewallStatusTitle:  "Firewall-Regeln gegen Telemetrie",
+	FirewallRuleDisabled: "(deaktiviert)",
+
+	// HTTP API消息
+	ServeListening:     "HTTP-API lauscht auf http://{{.Address}} (Strg+C zum Beenden)",
+	ServeGRPCListening: "gRPC-Dienst lauscht auf {{.Address}}",
+	ServeToken:         "Zugriffstoken: {{.Token}}",
+	ServeNotLoopback:   "[!] Die API ist von anderen Rechnern erreichbar; jeder mit dem Token kann die Kennungen auf diesem Computer zurücksetzen",
+
+	// SSH批量处理消息
+	FleetStarting:    "{{.Count}} Hosts aus {{.Inventory}} werden über SSH aktualisiert...",
+	FleetHostUpdated: "[√] {{.Host}}: {{.Path}} aktualisiert",
+	FleetHostFailed:  "[×] {{.Host}}: {{.Error}}",
+	FleetSummary:     "{{.Succeeded}} von {{.Total}} Hosts aktualisiert",
+
+	// 审计日志消息
+	AuditVerified:      "[√] Audit-Log intakt: alle {{.Count}} Einträge in {{.Path}} geprüft",
+	AuditHead:          "Letzter Eintrag: {{.Time}}, Hash {{.Hash}}",
+	AuditEmpty:         "Audit-Log {{.Path}} enthält noch keine Einträge",
+	AuditIntactEntries: "[!] Die {{.Count}} Einträge vor dem Fehler sind intakt",
+
+	// 全屏界面消息
+	TUITitle:           "Cursor ID Modifier",
+	TUIMenuReset:       "Gerätekennungen zurücksetzen",
+	TUIMenuProcesses:   "Laufende Cursor-Prozesse anzeigen",
+	TUIMenuQuit:        "Beenden",
+	TUIProcessesHeader: "Laufende Cursor-Prozesse (jede Sekunde aktualisiert):",
+	TUINoProcesses:     "Es laufen keine Cursor-Prozesse",
+	TUIChangesHeader:   "Geänderte IDs:",
+	TUIOldValue:        "alt",
+	TUINewValue:        "neu",
+	TUISucceeded:       "[√] Fertig. Starten Sie Cursor neu, um die neuen IDs zu verwenden",
+	TUIFailed:          "[×] Nicht abgeschlossen, siehe fehlgeschlagenen Schritt oben",
+	TUIMenuHelp:        "↑/↓ auswählen · Enter bestätigen · l Sprache · q beenden",
+	TUIBackHelp:        "Enter/Esc zurück zum Menü · Ctrl+C beenden",
+	TUIRunningHelp:     "Wird ausgeführt… · Ctrl+C abbrechen",
+	TUIStepClose:       "Cursor beenden",
+	TUIStepCheckFile:   "Prüfen, ob storage.json verwendet wird",
+	TUIStepRead:        "Vorhandene Konfiguration lesen",
+	TUIStepGenerate:    "Neue IDs erzeugen",
+	TUIStepSave:        "Konfiguration sichern und speichern",
+	TUIStepVerify:      "Geschriebene Datei überprüfen",
+
+	// 无障碍模式消息
+	AccessibleStepStarted:  "Schritt {{.Step}} von {{.Total}}: {{.Title}}",
+	AccessibleStepFinished: "Schritt {{.Step}} von {{.Total}} in {{.Elapsed}} abgeschlossen.",
+	AccessibleStepFailed:   "Schritt {{.Step}} von {{.Total}} fehlgeschlagen.",
+	AccessibleFinished:     "Fertig in {{.Elapsed}}.",
+	AccessibleFailed:       "Fehlgeschlagen.",
+	AccessibleSuccess:      "Erfolg: ",
+	AccessibleWarning:      "Warnung: ",
+	AccessibleError:        "Fehler: ",
+
+	// 守护模式消息
+	GuardReapplied: "[!] Cursor hat die Kennungen überschrieben, erneut angewendet: {{.Keys}}",
+	GuardUnchanged: "[√] Die Kennungen sind unverändert",
+	GuardWatching:  "storage.json wird überwacht, Strg+C zum Beenden...",
+
+	// 快照消息
+	SnapshotCreated:  "[√] globalStorage-Snapshot gespeichert unter: {{.Path}}",
+	SnapshotRestored: "[√] globalStorage aus Snapshot wiederhergestellt: {{.Path}}",
+
+	// workspaceStorage清理消息
+	WorkspaceStorageHeader:  "Verzeichnis des Arbeitsbereichsspeichers: {{.Dir}}",
+	WorkspaceStorageTotal:   "{{.Count}} {{plural .Count one \"Arbeitsbereich\" other \"Arbeitsbereiche\"}}, insgesamt {{.Size}}",
+	WorkspaceStoragePrompt:  "Geben Sie die zu löschenden Nummern ein (z. B. 1,3,5-7), all, um alle zu löschen, oder drücken Sie Enter, um zu überspringen",
+	WorkspaceStorageCleared: "[√] {{.Count}} {{plural .Count one \"Arbeitsbereich\" other \"Arbeitsbereiche\"}} entfernt, {{.Size}} freigegeben",
+	WorkspaceStorageEmpty:   "Kein Arbeitsbereichsspeicher zum Bereinigen vorhanden",
+
+	// 登录状态清理消息
+	SignOutSuccess: "[√] Cursor-Anmeldestatus gelöscht ({{.Count}} Authentifizierungsschlüssel entfernt)",
+	SignOutBackup:  "Anmeldedaten gesichert unter: {{.Path}}",
+
+	// 并发修改消息
+	ConcurrentModification: "[!] storage.json wurde während der Ausführung von einem anderen Prozess geändert (möglicherweise Cursor oder dessen Updater); der Schreibvorgang wurde abgebrochen, um die neuen Daten nicht zu überschreiben",
+	RetryPrompt:            "Datei erneut lesen und noch einmal versuchen?",
+
+	// 已有写保护消息
+	WriteProtectedDetected: "[!] storage.json ist durch eine frühere Ausführung schreibgeschützt ({{.Level}})",
+	LiftProtectionPrompt:   "Schutz vorübergehend aufheben, um die neuen Kennungen zu schreiben? Der gewünschte Schutz wird danach wieder angewendet.",
+
+	// OneDrive重定向消息
+	OneDriveDetected:       "[!] Der Datenordner von Cursor liegt in einem mit OneDrive synchronisierten Ordner ({{.Dir}}); die Synchronisierung kann die neuen Kennungen überschreiben",
+	OneDriveAdvice:         "Pausieren Sie die OneDrive-Synchronisierung während der Änderung oder verwenden Sie -pause-onedrive, um sie automatisch zu pausieren",
+	OneDrivePaused:         "OneDrive wurde vorübergehend beendet und wird danach neu gestartet",
+	OneDriveConflictCopies: "[!] OneDrive-Konfliktkopien gefunden, bitte prüfen und löschen: {{.Files}}",
+
+	// 数据目录扫描消息
+	DiscoverHeader:   "{{.Count}} storage.json-{{plural .Count one \"Datei\" other \"Dateien\"}} gefunden (neueste zuerst):",
+	DiscoverEmpty:    "Keine storage.json gefunden",
+	DiscoverPrompt:   "Geben Sie eine Nummer ein, um sie als Standardziel festzulegen, oder drücken Sie Enter, um zu überspringen",
+	DiscoverSelected: "{{.Path}} als Standardziel gespeichert",
+
+	// 文件锁定消息
+	FileLocked: "[!] storage.json ist durch einen anderen Prozess gesperrt, vermutlich den Hintergrund-Updater von Cursor oder eine weitere laufende Instanz dieses Tools; beenden Sie ihn und versuchen Sie es erneut",
+
+	// 备份集消息
+	BackupSetCreated: "[√] {{.Count}} {{plural .Count one \"Datei\" other \"Dateien\"}} in Sicherungssatz gepackt: {{.Path}}",
+
+	// 标识符校验消息
+	VerifyHeader: "Kennungen in {{.Path}} werden geprüft:",
+	VerifyPassed: "[√] Alle Kennungen sind strukturell gültig",
+
+	// 自检消息
+	SelfTestRunning: "{{.Count}} ID-Sätze werden erzeugt und auf Zufälligkeit geprüft...",
+	SelfTestPassed:  "[√] Selbsttest bestanden, IDs können sicher erzeugt werden",
+
+	// 其他用户的进程消息
+	OtherUserProcesses:    "[!] Die folgenden Cursor-Prozesse gehören zu einem anderen Benutzerkonto:",
+	OtherUserRefused:      "Sie verwenden möglicherweise die Konfiguration eines anderen Benutzers, daher wurde nichts geändert. Führen Sie das Tool mit -force erneut aus, um sie trotzdem zu beenden",
+	OtherUserProcessEntry: "  PID {{.PID}}  {{.Name}}  (Benutzer {{.User}})",
+
+	// 工作区消息
+	WorkspacesOpen:           "Diese Arbeitsbereiche waren geöffnet, bevor Cursor beendet wurde:",
+	RelaunchWorkspacesPrompt: "{{plural .Count one \"Diesen Arbeitsbereich\" other \"Diese {{.Count}} Arbeitsbereiche\"}} wieder öffnen?",
+	WorkspacesRelaunched:     "[√] {{.Count}} {{plural .Count one \"Arbeitsbereich\" other \"Arbeitsbereiche\"}} wieder geöffnet",
+	CursorRestarted:          "[√] {{.App}} wurde neu gestartet",
+
+	// 未保存修改消息
+	UnsavedWorkWarning: "[!] Cursor hat {{.Count}} {{plural .Count one \"Editor\" other \"Editoren\"}} mit ungespeicherten Änderungen; beim erzwungenen Beenden können sie verloren gehen, speichern Sie sie daher zuerst",
+	UnsavedWorkPrompt:  "Cursor trotzdem beenden und fortfahren?",
+
+	// 进程列表消息
+	ProcessListHeader: "Das Beenden von {{.App}} würde {{plural .Count one \"diesen Prozess\" other \"diese {{.Count}} Prozesse\"}} beenden:",
+	ProcessListEmpty:  "[√] Es würden keine {{.App}}-Prozesse beendet",
+	ProcessListEntry:  "  PID {{.PID}} PPID {{.PPID}} {{.User}} {{.Started}}  {{.Name}}",
+	ProcessListDaemon: "{{.Name}} (Daemon)",
+
+	// 文件占用消息
+	FileInUse:     "[!] Diese Prozesse haben {{.File}} noch geöffnet und könnten die Änderungen überschreiben:",
+	FileInUseHint: "Beenden Sie sie und versuchen Sie es erneut",
+
+	// 提升权限的进程消息
+	ElevatedProcess: "[!] Der {{.App}}-Prozess {{.PID}} läuft mit höheren Rechten ({{.Level}}) und kann von hier aus nicht beendet werden. Führen Sie dieses Tool als Administrator (Windows) oder mit sudo (macOS/Linux) aus oder beenden Sie den Prozess manuell",
+
+	// 远程服务器消息
+	ServerMachineIDReset:   "[√] Die machineid des Remote-Servers wurde zurückgesetzt",
+	ServerMachineIDMissing: "[!] Der Remote-Server hat keine machineid-Datei, übersprungen",
+}
@@ -0,0 +1,248 @@
+// 语言包，提供多语言支持功能
+package lang
+
+// esText 西班牙语文本，未翻译的文本使用英文
+var esText = TextResource{
+	// 成功消息
+	SuccessMessage:     "[√] ¡Archivo de configuración actualizado correctamente!",
+	RestartMessage:     "[!] Reinicie Cursor manualmente para aplicar los cambios",
+	OperationCompleted: "¡Operación completada!",
+
+	// 版本信息
+	VersionInfo: "Cursor ID Modifier versión {{.Version}}",
+
+	// 标识符表格列标题
+	TableField: "Campo",
+	TableOld:   "Valor anterior",
+	TableNew:   "Valor nuevo",
+	TableFile:  "Archivo",
+
+	// 提问消息
+	PromptYesHint:       "(S/n)",
+	PromptNoHint:        "(s/N)",
+	PromptYes:           "sí",
+	PromptNo:            "no",
+	PromptInvalidChoice: "Introduzca un número del 1 al {{.Count}}.",
+	PromptTimedOut:      "Sin respuesta en {{.Timeout}}, se usa el valor predeterminado.",
+
+	// 运行摘要消息
+	SummaryTitle:      "Resumen",
+	SummaryFiles:      "Archivos modificados",
+	SummaryBackup:     "Copia de seguridad",
+	SummaryIDs:        "Identificadores cambiados",
+	SummaryIDsValue:   "{{.Changed}} de {{.Total}} ({{.Fields}})",
+	SummaryProtection: "Protección contra escritura",
+	SummaryProcesses:  "Procesos cerrados",
+	SummaryTime:       "Tiempo total",
+	SummaryNone:       "ninguno",
+
+	// 启动菜单消息
+	MenuTitle:        "¿Qué desea hacer?",
+	MenuModify:       "Modificar identificadores",
+	MenuStatus:       "Mostrar estado",
+	MenuRestore:      "Restaurar una copia de seguridad",
+	MenuLock:         "Proteger storage.json contra escritura",
+	MenuUnlock:       "Quitar la protección contra escritura de storage.json",
+	MenuLanguage:     "Cambiar idioma (Language)",
+	MenuExit:         "Salir",
+	MenuPrompt:       "Introduzca un número",
+	MenuBackupPrompt: "Elija la copia de seguridad que desea restaurar",
+	MenuNoBackups:    "No se encontraron copias de seguridad en {{.Dir}}",
+	LanguagePrompt:   "Elija el idioma de la interfaz",
+	LanguageSaved:    "Idioma guardado en {{.Path}}",
+	StatusTitle:      "Estado actual",
+	StatusConfig:     "Archivo de configuración",
+	StatusMissing:    "(no existe)",
+	StatusProcesses:  "Procesos en ejecución",
+	LockSuccess:      "[√] storage.json ahora está protegido contra escritura ({{.Level}})",
+
+	// 进度消息
+	ReadingConfig:     "Leyendo el archivo de configuración...",
+	GeneratingIds:     "Generando nuevos identificadores...",
+	SavingConfig:      "Creando copia de seguridad y guardando la configuración...",
+	VerifyingConfig:   "Verificando el archivo escrito...",
+	CheckingProcesses: "Buscando instancias de Cursor en ejecución...",
+	ClosingProcesses:  "Cerrando instancias de Cursor...",
+	ClosingRemaining:  "Cerrando {{.Total}} {{plural .Total one \"proceso\" other \"procesos\"}}... {{plural .Remaining one \"queda\" other \"quedan\"}} {{.Remaining}}",
+	ProcessesClosed:   "Se han cerrado todas las instancias de Cursor",
+	PleaseWait:        "Espere, por favor...",
+
+	// 错误消息
+	ErrorPrefix:     "El programa encontró un error grave: {{.Error}}",
+	PrivilegeError:  "\n[!] Error: se requieren privilegios de administrador",
+	CloseFailed:     "No se pudo cerrar {{.App}}. Ciérrelo manualmente e inténtelo de nuevo.",
+	CloseIncomplete: "No se pudo cerrar {{.App}} por completo. Ciérrelo manualmente e inténtelo de nuevo.",
+
+	// 指令提示
+	RunAsAdmin:           "Haga clic derecho y seleccione «Ejecutar como administrador»",
+	RunWithSudo:          "Ejecute este programa con sudo",
+	SudoExample:          "Ejemplo: sudo {{.Executable}}",
+	PressEnterToExit:     "\nPulse Enter para salir...",
+	RequestingPrivileges: "\nSolicitando privilegios de administrador...",
+	SetReadOnlyMessage:   "storage.json se ha puesto en modo de solo lectura, lo que puede provocar problemas como la pérdida del historial de espacios de trabajo",
+
+	// 信息消息
+	ConfigLocation: "Ubicación del archivo de configuración:",
+
+	// 写保护消息
+	StrongProtectionWarning: "[!] Protección reforzada contra escritura activada, Cursor ya no puede actualizar storage.json. Ejecute con -unlock para revertirla",
+	UnlockSuccess:           "[√] Se quitó la protección contra escritura de storage.json",
+
+	// 备份消息
+	BackupCreated:     "Copia de seguridad de la configuración guardada en: {{.Path}}",
+	RestoreSuccess:    "[√] Configuración restaurada desde la copia de seguridad: {{.Path}}",
+	RestoreInvalid:    "[!] La copia de seguridad no superó la validación:",
+	RestoreDiffHeader: "La restauración hará los siguientes cambios en storage.json:",
+	RestoreNoChanges:  "La copia de seguridad coincide con la configuración actual, no hay nada que restaurar",
+	RestorePrompt:     "¿Restaurar ahora?",
+
+	// hosts文件遥测屏蔽消息
+	TelemetryBlocked:        "[√] {{plural .Count one \"Bloqueado\" other \"Bloqueados\"}} {{.Count}} {{plural .Count one \"dominio\" other \"dominios\"}} de telemetría en {{.Path}}",
+	TelemetryAlreadyBlocked: "Los dominios de telemetría ya están bloqueados en {{.Path}}",
+	TelemetryUnblocked:      "[√] Bloqueo de telemetría eliminado de {{.Path}}",
+	TelemetryNotBlocked:     "{{.Path}} no contiene ningún bloqueo de telemetría",
+	HostsBackupCreated:      "Copia de seguridad del archivo hosts guardada en: {{.Path}}",
+
+	// Windows防火墙规则消息
+	FirewallRulesCreated: "[√] Reglas de firewall de salida creadas para bloquear la telemetría: {{.Count}}",
+	FirewallRulesRemoved: "[√] Reglas de firewall eliminadas: {{.Count}}",
+	FirewallNoRules:      "No se encontraron reglas de firewall creadas por esta herramienta",
+	FirewallStatusTitle:  "Reglas de firewall de telemetría",
+	FirewallRuleDisabled: "(deshabilitada)",
+
+	// HTTP API消息
+	ServeListening:     "API HTTP escuchando en http://{{.Address}} (pulse Ctrl+C para detener)",
+	ServeGRPCListening: "Servicio gRPC escuchando en {{.Address}}",
+	ServeToken:         "Token de acceso: {{.Token}}",
+	ServeNotLoopback:   "[!] La API es accesible desde otros equipos; cualquiera con el token puede restablecer los identificadores de este equipo",
+
+	// SSH批量处理消息
+	FleetStarting:    "Actualizando {{.Count}} hosts de {{.Inventory}} por SSH...",
+	FleetHostUpdated: "[√] {{.Host}}: {{.Path}} actualizado",
+	FleetHostFailed:  "[×] {{.Host}}: {{.Error}}",
+	FleetSummary:     "{{.Succeeded}} de {{.Total}} hosts actualizados",
+
+	// 审计日志消息
+	AuditVerified:      "[√] Registro de auditoría íntegro: las {{.Count}} entradas de {{.Path}} se verificaron",
+	AuditHead:          "Última entrada: {{.Time}}, hash {{.Hash}}",
+	AuditEmpty:         "El registro de auditoría {{.Path}} aún no tiene entradas",
+	AuditIntactEntries: "[!] Las {{.Count}} entradas anteriores al error están íntegras",
+
+	// 全屏界面消息
+	TUITitle:           "Cursor ID Modifier",
+	TUIMenuReset:       "Restablecer los identificadores del dispositivo",
+	TUIMenuProcesses:   "Mostrar los procesos de Cursor en ejecución",
+	TUIMenuQuit:        "Salir",
+	TUIProcessesHeader: "Procesos de Cursor en ejecución (se actualiza cada segundo):",
+	TUINoProcesses:     "No hay procesos de Cursor en ejecución",
+	TUIChangesHeader:   "Cambios de identificadores:",
+	TUIOldValue:        "antes",
+	TUINewValue:        "ahora",
+	TUISucceeded:       "[√] Listo. Reinicie Cursor para usar los nuevos identificadores",
+	TUIFailed:          "[×] No se completó, consulte el paso fallido arriba",
+	TUIMenuHelp:        "↑/↓ elegir · Enter confirmar · l idioma · q salir",
+	TUIBackHelp:        "Enter/Esc volver al menú · Ctrl+C salir",
+	TUIRunningHelp:     "En curso… · Ctrl+C cancelar",
+	TUIStepClose:       "Cerrar Cursor",
+	TUIStepCheckFile:   "Comprobar que storage.json no está en uso",
+	TUIStepRead:        "Leer la configuración existente",
+	TUIStepGenerate:    "Generar nuevos identificadores",
+	TUIStepSave:        "Copiar y guardar la configuración",
+	TUIStepVerify:      "Verificar el archivo escrito",
+
+	// 无障碍模式消息
+	AccessibleStepStarted:  "Paso {{.Step}} de {{.Total}}: {{.Title}}",
+	AccessibleStepFinished: "Paso {{.Step}} de {{.Total}} completado en {{.Elapsed}}.",
+	AccessibleStepFailed:   "Paso {{.Step}} de {{.Total}} fallido.",
+	AccessibleFinished:     "Terminado en {{.Elapsed}}.",
+	AccessibleFailed:       "Falló.",
+	AccessibleSuccess:      "Éxito: ",
+	AccessibleWarning:      "Advertencia: ",
+	AccessibleError:        "Error: ",
+
+	// 守护模式消息
+	GuardReapplied: "[!] Cursor reescribió los identificadores, se volvieron a aplicar: {{.Keys}}",
+	GuardUnchanged: "[√] Los identificadores no han cambiado",
+	GuardWatching:  "Vigilando storage.json, pulse Ctrl+C para detener...",
+
+	// 快照消息
+	SnapshotCreated:  "[√] Instantánea de globalStorage guardada en: {{.Path}}",
+	SnapshotRestored: "[√] globalStorage restaurado desde la instantánea: {{.Path}}",
+
+	// workspaceStorage清理消息
+	WorkspaceStorageHeader:  "Directorio de almacenamiento de espacios de trabajo: {{.Dir}}",
+	WorkspaceStorageTotal:   "{{.Count}} {{plural .Count one \"espacio de trabajo\" other \"espacios de trabajo\"}}, {{.Size}} en total",
+	WorkspaceStoragePrompt:  "Introduzca los números que desea eliminar (p. ej. 1,3,5-7), escriba all para eliminarlos todos o pulse Enter para omitir",
+	WorkspaceStorageCleared: "[√] {{plural .Count one \"Eliminado\" other \"Eliminados\"}} {{.Count}} {{plural .Count one \"espacio de trabajo\" other \"espacios de trabajo\"}}, {{.Size}} liberados",
+	WorkspaceStorageEmpty:   "No hay almacenamiento de espacios de trabajo que limpiar",
+
+	// 登录状态清理消息
+	SignOutSuccess: "[√] Estado de inicio de sesión de Cursor borrado ({{.Count}} {{plural .Count one \"clave de autenticación eliminada\" other \"claves de autenticación eliminadas\"}})",
+	SignOutBackup:  "Datos de inicio de sesión respaldados en: {{.Path}}",
+
+	// 并发修改消息
+	ConcurrentModification: "[!] Otro proceso (posiblemente Cursor o su actualizador) modificó storage.json durante la ejecución; se canceló la escritura para no sobrescribir datos recientes",
+	RetryPrompt:            "¿Volver a leer el archivo y reintentar?",
+
+	// 已有写保护消息
+	WriteProtectedDetected: "[!] storage.json está protegido contra escritura por una ejecución anterior ({{.Level}})",
+	LiftProtectionPrompt:   "¿Quitar temporalmente la protección para escribir los nuevos identificadores? La protección solicitada se vuelve a aplicar después.",
+
+	// OneDrive重定向消息
+	OneDriveDetected:       "[!] La carpeta de datos de Cursor está dentro de una carpeta sincronizada con OneDrive ({{.Dir}}); la sincronización puede sobrescribir los nuevos identificadores",
+	OneDriveAdvice:         "Considere pausar la sincronización de OneDrive durante la modificación, o use -pause-onedrive para pausarla automáticamente",
+	OneDrivePaused:         "OneDrive se cerró temporalmente y se reiniciará después",
+	OneDriveConflictCopies: "[!] Se encontraron copias de conflicto de sincronización de OneDrive, revíselas y elimínelas: {{.Files}}",
+
+	// 数据目录扫描消息
+	DiscoverHeader:   "{{plural .Count one \"Se encontró\" other \"Se encontraron\"}} {{.Count}} {{plural .Count one \"archivo\" other \"archivos\"}} storage.json (el más reciente primero):",
+	DiscoverEmpty:    "No se encontró ningún storage.json",
+	DiscoverPrompt:   "Introduzca un número para convertirlo en el destino predeterminado o pulse Enter para omitir",
+	DiscoverSelected: "{{.Path}} guardado como destino predeterminado",
+
+	// 文件锁定消息
+	FileLocked: "[!] storage.json está bloqueado por otro proceso, probablemente el actualizador en segundo plano de Cursor u otra instancia de esta herramienta; ciérrelo e inténtelo de nuevo",
+
+	// 备份集消息
+	BackupSetCreated: "[√] {{.Count}} {{plural .Count one \"archivo empaquetado\" other \"archivos empaquetados\"}} en el conjunto de copias de seguridad: {{.Path}}",
+
+	// 标识符校验消息
+	VerifyHeader: "Comprobando los identificadores en {{.Path}}:",
+	VerifyPassed: "[√] Todos los identificadores son estructuralmente válidos",
+
+	// 自检消息
+	SelfTestRunning: "Generando {{.Count}} conjuntos de identificadores y comprobando la aleatoriedad...",
+	SelfTestPassed:  "[√] Autoprueba superada, los identificadores se pueden generar de forma segura",
+
+	// 其他用户的进程消息
+	OtherUserProcesses:    "[!] Los siguientes procesos de Cursor pertenecen a otra cuenta de usuario:",
+	OtherUserRefused:      "Es posible que usen la configuración de otro usuario, así que no se cambió nada. Vuelva a ejecutar con -force para cerrarlos de todos modos",
+	OtherUserProcessEntry: "  PID {{.PID}}  {{.Name}}  (usuario {{.User}})",
+
+	// 工作区消息
+	WorkspacesOpen:           "Estos espacios de trabajo estaban abiertos antes de cerrar Cursor:",
+	RelaunchWorkspacesPrompt: "¿Volver a abrir {{plural .Count one \"este espacio de trabajo\" other \"estos {{.Count}} espacios de trabajo\"}}?",
+	WorkspacesRelaunched:     "[√] {{plural .Count one \"Reabierto\" other \"Reabiertos\"}} {{.Count}} {{plural .Count one \"espacio de trabajo\" other \"espacios de trabajo\"}}",
+	CursorRestarted:          "[√] {{.App}} se ha reiniciado",
+
+	// 未保存修改消息
+	UnsavedWorkWarning: "[!] Cursor tiene {{.Count}} {{plural .Count one \"editor\" other \"editores\"}} con cambios sin guardar; forzar el cierre puede hacer que se pierdan, así que guárdelos primero",
+	UnsavedWorkPrompt:  "¿Cerrar Cursor y continuar de todos modos?",
+
+	// 进程列表消息
+	ProcessListHeader: "Cerrar {{.App}} terminaría {{plural .Count one \"este proceso\" other \"estos {{.Count}} procesos\"}}:",
+	ProcessListEmpty:  "[√] No se cerraría ningún proceso de {{.App}}",
+	ProcessListEntry:  "  PID {{.PID}} PPID {{.PPID}} {{.User}} {{.Started}}  {{.Name}}",
+	ProcessListDaemon: "{{.Name}} (demonio)",
+
+	// 文件占用消息
+	FileInUse:     "[!] Estos procesos todavía tienen {{.File}} abierto y pueden sobrescribir los cambios:",
+	FileInUseHint: "Ciérrelos e inténtelo de nuevo",
+
+	// 提升权限的进程消息
+	ElevatedProcess: "[!] El proceso {{.PID}} de {{.App}} se ejecuta con privilegios superiores ({{.Level}}) y no se puede cerrar desde aquí. Ejecute esta herramienta como administrador (Windows) o con sudo (macOS/Linux), o ciérrelo manualmente",
+
+	// 远程服务器消息
+	ServerMachineIDReset:   "[√] Se restableció el machineid del servidor remoto",
+	ServerMachineIDMissing: "[!] El servidor remoto no tiene archivo machineid, se omitió",
+}
@@ -0,0 +1,55 @@
+// 语言包，提供多语言支持功能
+package lang
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Values 文本模板中命名占位符的值，键为占位符名称
+type Values map[string]any
+
+// placeholderPattern 匹配{{.Name}}形式的命名占位符，允许花括号内有空格
+var placeholderPattern = regexp.MustCompile(`\{\{\s*\.(\w+)\s*\}\}`)
+
+// tokenPattern 匹配复数占位符或命名占位符，第一个分组为复数占位符的数量名称，第三个分组为命名占位符的名称
+// 复数占位符的形式中可能包含命名占位符，因此先尝试匹配复数占位符
+var tokenPattern = regexp.MustCompile(pluralPattern.String() + `|` + placeholderPattern.String())
+
+// Format 将文本中的命名占位符（例如{{.Path}}、{{.Count}}）替换为对应的值
+// 翻译可以按语序自由调整占位符的位置；values中没有的占位符原样保留，便于发现遗漏
+// 复数占位符（例如{{plural .Count one "file" other "files"}}）按当前语言的复数规则先行展开
+// 从右到左书写的语言中，替换的值用Unicode方向隔离符包裹，避免路径等从左到右的值打乱句子的顺序
+func Format(message string, values Values) string {
+	language := GetCurrentLanguage()
+	message = expandPlurals(message, values, language)
+	return placeholderPattern.ReplaceAllStringFunc(message, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		value, ok := values[name]
+		if !ok {
+			return placeholder
+		}
+		text := fmt.Sprint(value)
+		if language.IsRTL() && text != "" {
+			// 第一强方向隔离（FSI）和结束隔离（PDI）
+			text = "\u2068" + text + "\u2069"
+		}
+		return text
+	})
+}
+
+// Placeholders 按出现顺序返回文本中的占位符名称（包括复数占位符使用的数量），重复的名称只返回一次
+func Placeholders(message string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range tokenPattern.FindAllStringSubmatch(message, -1) {
+		if match[1] == "" {
+			match[1] = match[3]
+		}
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
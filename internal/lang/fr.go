@@ -0,0 +1,248 @@
+// 语言包，提供多语言支持功能
+package lang
+
+// frText 法语文本，未翻译的文本使用英文
+var frText = TextResource{
+	// 成功消息
+	SuccessMessage:     "[√] Fichier de configuration mis à jour avec succès !",
+	RestartMessage:     "[!] Redémarrez Cursor manuellement pour appliquer les modifications",
+	OperationCompleted: "Opération terminée !",
+
+	// 版本信息
+	VersionInfo: "Cursor ID Modifier version {{.Version}}",
+
+	// 标识符表格列标题
+	TableField: "Champ",
+	TableOld:   "Ancienne valeur",
+	TableNew:   "Nouvelle valeur",
+	TableFile:  "Fichier",
+
+	// 提问消息
+	PromptYesHint:       "(O/n)",
+	PromptNoHint:        "(o/N)",
+	PromptYes:           "oui",
+	PromptNo:            "non",
+	PromptInvalidChoice: "Veuillez saisir un nombre entre 1 et {{.Count}}.",
+	PromptTimedOut:      "Aucune réponse en {{.Timeout}}, la valeur par défaut est utilisée.",
+
+	// 运行摘要消息
+	SummaryTitle:      "Résumé",
+	SummaryFiles:      "Fichiers modifiés",
+	SummaryBackup:     "Sauvegarde",
+	SummaryIDs:        "Identifiants modifiés",
+	SummaryIDsValue:   "{{.Changed}} sur {{.Total}} ({{.Fields}})",
+	SummaryProtection: "Protection en écriture",
+	SummaryProcesses:  "Processus fermés",
+	SummaryTime:       "Durée totale",
+	SummaryNone:       "aucun",
+
+	// 启动菜单消息
+	MenuTitle:        "Que voulez-vous faire ?",
+	MenuModify:       "Modifier les identifiants",
+	MenuStatus:       "Afficher l'état",
+	MenuRestore:      "Restaurer une sauvegarde",
+	MenuLock:         "Protéger storage.json en écriture",
+	MenuUnlock:       "Retirer la protection en écriture de storage.json",
+	MenuLanguage:     "Changer de langue (Language)",
+	MenuExit:         "Quitter",
+	MenuPrompt:       "Saisissez un numéro",
+	MenuBackupPrompt: "Choisissez la sauvegarde à restaurer",
+	MenuNoBackups:    "Aucune sauvegarde trouvée dans {{.Dir}}",
+	LanguagePrompt:   "Choisissez la langue de l'interface",
+	LanguageSaved:    "Langue enregistrée dans {{.Path}}",
+	StatusTitle:      "État actuel",
+	StatusConfig:     "Fichier de configuration",
+	StatusMissing:    "(absent)",
+	StatusProcesses:  "Processus en cours",
+	LockSuccess:      "[√] storage.json est désormais protégé en écriture ({{.Level}})",
+
+	// 进度消息
+	ReadingConfig:     "Lecture du fichier de configuration...",
+	GeneratingIds:     "Génération de nouveaux identifiants...",
+	SavingConfig:      "Sauvegarde et enregistrement de la configuration...",
+	VerifyingConfig:   "Vérification du fichier écrit...",
+	CheckingProcesses: "Recherche d'instances de Cursor en cours...",
+	ClosingProcesses:  "Fermeture des instances de Cursor...",
+	ClosingRemaining:  "Fermeture de {{.Total}} processus... {{.Remaining}} {{plural .Remaining one \"restant\" other \"restants\"}}",
+	ProcessesClosed:   "Toutes les instances de Cursor ont été fermées",
+	PleaseWait:        "Veuillez patienter...",
+
+	// 错误消息
+	ErrorPrefix:     "Le programme a rencontré une erreur grave : {{.Error}}",
+	PrivilegeError:  "\n[!] Erreur : droits administrateur requis",
+	CloseFailed:     "Impossible de fermer {{.App}}. Fermez-le manuellement puis réessayez.",
+	CloseIncomplete: "Impossible de fermer complètement {{.App}}. Fermez-le manuellement puis réessayez.",
+
+	// 指令提示
+	RunAsAdmin:           "Faites un clic droit et choisissez « Exécuter en tant qu'administrateur »",
+	RunWithSudo:          "Veuillez exécuter ce programme avec sudo",
+	SudoExample:          "Exemple : sudo {{.Executable}}",
+	PressEnterToExit:     "\nAppuyez sur Entrée pour quitter...",
+	RequestingPrivileges: "\nDemande des droits d'administrateur...",
+	SetReadOnlyMessage:   "storage.json est passé en lecture seule, ce qui peut entraîner la perte de l'historique des espaces de travail",
+
+	// 信息消息
+	ConfigLocation: "Emplacement du fichier de configuration :",
+
+	// 写保护消息
+	StrongProtectionWarning: "[!] Protection en écriture renforcée activée, Cursor ne peut plus mettre à jour storage.json. Relancez avec -unlock pour l'annuler",
+	UnlockSuccess:           "[√] Protection en écriture retirée de storage.json",
+
+	// 备份消息
+	BackupCreated:     "Configuration sauvegardée dans : {{.Path}}",
+	RestoreSuccess:    "[√] Configuration restaurée depuis la sauvegarde : {{.Path}}",
+	RestoreInvalid:    "[!] La sauvegarde n'a pas passé la validation :",
+	RestoreDiffHeader: "La restauration apportera les modifications suivantes à storage.json :",
+	RestoreNoChanges:  "La sauvegarde correspond à la configuration actuelle, rien à restaurer",
+	RestorePrompt:     "Restaurer maintenant ?",
+
+	// hosts文件遥测屏蔽消息
+	TelemetryBlocked:        "[√] {{.Count}} {{plural .Count one \"domaine\" other \"domaines\"}} de télémétrie {{plural .Count one \"bloqué\" other \"bloqués\"}} dans {{.Path}}",
+	TelemetryAlreadyBlocked: "Les domaines de télémétrie sont déjà bloqués dans {{.Path}}",
+	TelemetryUnblocked:      "[√] Blocage de la télémétrie supprimé de {{.Path}}",
+	TelemetryNotBlocked:     "{{.Path}} ne contient aucun blocage de télémétrie",
+	HostsBackupCreated:      "Sauvegarde du fichier hosts enregistrée : {{.Path}}",
+
+	// Windows防火墙规则消息
+	FirewallRulesCreated: "[√] {{.Count}} {{plural .Count one \"règle\" other \"règles\"}} de pare-feu {{plural .Count one \"sortante créée\" other \"sortantes créées\"}} pour bloquer la télémétrie",
+	FirewallRulesRemoved: "[√] {{.Count}} {{plural .Count one \"règle\" other \"règles\"}} de pare-feu {{plural .Count one \"supprimée\" other \"supprimées\"}}",
+	FirewallNoRules:      "Aucune règle de pare-feu créée par cet outil n'a été trouvée",
+	FirewallStatusTitle:  "Règles de pare-feu de télémétrie",
+	FirewallRuleDisabled: "(désactivée)",
+
+	// HTTP API消息
+	ServeListening:     "API HTTP à l'écoute sur http://{{.Address}} (Ctrl+C pour arrêter)",
+	ServeGRPCListening: "Service gRPC à l'écoute sur {{.Address}}",
+	ServeToken:         "Jeton d'accès : {{.Token}}",
+	ServeNotLoopback:   "[!] L'API est accessible depuis d'autres machines ; toute personne disposant du jeton peut réinitialiser les identifiants de cet ordinateur",
+
+	// SSH批量处理消息
+	FleetStarting:    "Mise à jour de {{.Count}} hôtes de {{.Inventory}} via SSH...",
+	FleetHostUpdated: "[√] {{.Host}} : {{.Path}} mis à jour",
+	FleetHostFailed:  "[×] {{.Host}} : {{.Error}}",
+	FleetSummary:     "{{.Succeeded}} hôtes sur {{.Total}} mis à jour",
+
+	// 审计日志消息
+	AuditVerified:      "[√] Journal d'audit intact : les {{.Count}} entrées de {{.Path}} ont été vérifiées",
+	AuditHead:          "Dernière entrée : {{.Time}}, hachage {{.Hash}}",
+	AuditEmpty:         "Le journal d'audit {{.Path}} ne contient encore aucune entrée",
+	AuditIntactEntries: "[!] Les {{.Count}} entrées précédant l'erreur sont intactes",
+
+	// 全屏界面消息
+	TUITitle:           "Cursor ID Modifier",
+	TUIMenuReset:       "Réinitialiser les identifiants de l'appareil",
+	TUIMenuProcesses:   "Afficher les processus Cursor en cours",
+	TUIMenuQuit:        "Quitter",
+	TUIProcessesHeader: "Processus Cursor en cours (actualisés chaque seconde) :",
+	TUINoProcesses:     "Aucun processus Cursor en cours",
+	TUIChangesHeader:   "Modifications des identifiants :",
+	TUIOldValue:        "ancien",
+	TUINewValue:        "nouveau",
+	TUISucceeded:       "[√] Terminé. Redémarrez Cursor pour utiliser les nouveaux identifiants",
+	TUIFailed:          "[×] Non terminé, voir l'étape en échec ci-dessus",
+	TUIMenuHelp:        "↑/↓ choisir · Entrée valider · l langue · q quitter",
+	TUIBackHelp:        "Entrée/Échap retour au menu · Ctrl+C quitter",
+	TUIRunningHelp:     "En cours… · Ctrl+C annuler",
+	TUIStepClose:       "Fermer Cursor",
+	TUIStepCheckFile:   "Vérifier que storage.json n'est pas utilisé",
+	TUIStepRead:        "Lire la configuration existante",
+	TUIStepGenerate:    "Générer de nouveaux identifiants",
+	TUIStepSave:        "Sauvegarder et enregistrer la configuration",
+	TUIStepVerify:      "Vérifier le fichier écrit",
+
+	// 无障碍模式消息
+	AccessibleStepStarted:  "Étape {{.Step}} sur {{.Total}} : {{.Title}}",
+	AccessibleStepFinished: "Étape {{.Step}} sur {{.Total}} terminée en {{.Elapsed}}.",
+	AccessibleStepFailed:   "Étape {{.Step}} sur {{.Total}} en échec.",
+	AccessibleFinished:     "Terminé en {{.Elapsed}}.",
+	AccessibleFailed:       "Échec.",
+	AccessibleSuccess:      "Succès : ",
+	AccessibleWarning:      "Avertissement : ",
+	AccessibleError:        "Erreur : ",
+
+	// 守护模式消息
+	GuardReapplied: "[!] Cursor a réécrit les identifiants, ils ont été réappliqués : {{.Keys}}",
+	GuardUnchanged: "[√] Les identifiants n'ont pas changé",
+	GuardWatching:  "Surveillance de storage.json, appuyez sur Ctrl+C pour arrêter...",
+
+	// 快照消息
+	SnapshotCreated:  "[√] Instantané de globalStorage enregistré dans : {{.Path}}",
+	SnapshotRestored: "[√] globalStorage restauré depuis l'instantané : {{.Path}}",
+
+	// workspaceStorage清理消息
+	WorkspaceStorageHeader:  "Répertoire de stockage des espaces de travail : {{.Dir}}",
+	WorkspaceStorageTotal:   "{{.Count}} {{plural .Count one \"espace de travail\" other \"espaces de travail\"}}, {{.Size}} au total",
+	WorkspaceStoragePrompt:  "Saisissez les numéros à supprimer (ex. 1,3,5-7), tapez all pour tout supprimer ou appuyez sur Entrée pour ignorer",
+	WorkspaceStorageCleared: "[√] {{.Count}} {{plural .Count one \"espace de travail supprimé\" other \"espaces de travail supprimés\"}}, {{.Size}} libérés",
+	WorkspaceStorageEmpty:   "Aucun stockage d'espace de travail à nettoyer",
+
+	// 登录状态清理消息
+	SignOutSuccess: "[√] État de connexion de Cursor effacé ({{.Count}} {{plural .Count one \"clé d'authentification supprimée\" other \"clés d'authentification supprimées\"}})",
+	SignOutBackup:  "Données de connexion sauvegardées dans : {{.Path}}",
+
+	// 并发修改消息
+	ConcurrentModification: "[!] storage.json a été modifié par un autre processus (peut-être Cursor ou son outil de mise à jour) pendant l'exécution ; l'écriture a été annulée pour ne pas écraser les nouvelles données",
+	RetryPrompt:            "Relire le fichier et réessayer ?",
+
+	// 已有写保护消息
+	WriteProtectedDetected: "[!] storage.json est protégé en écriture par une exécution précédente ({{.Level}})",
+	LiftProtectionPrompt:   "Retirer temporairement la protection pour écrire les nouveaux identifiants ? La protection demandée est réappliquée ensuite.",
+
+	// OneDrive重定向消息
+	OneDriveDetected:       "[!] Le dossier de données de Cursor se trouve dans un dossier synchronisé par OneDrive ({{.Dir}}) ; la synchronisation peut écraser les nouveaux identifiants",
+	OneDriveAdvice:         "Suspendez la synchronisation OneDrive pendant la modification, ou utilisez -pause-onedrive pour la suspendre automatiquement",
+	OneDrivePaused:         "OneDrive a été fermé temporairement et sera redémarré ensuite",
+	OneDriveConflictCopies: "[!] Copies de conflit de synchronisation OneDrive trouvées, vérifiez-les et supprimez-les : {{.Files}}",
+
+	// 数据目录扫描消息
+	DiscoverHeader:   "{{.Count}} {{plural .Count one \"fichier storage.json trouvé\" other \"fichiers storage.json trouvés\"}} (du plus récent au plus ancien) :",
+	DiscoverEmpty:    "Aucun storage.json trouvé",
+	DiscoverPrompt:   "Saisissez un numéro pour en faire la cible par défaut, ou appuyez sur Entrée pour ignorer",
+	DiscoverSelected: "{{.Path}} enregistré comme cible par défaut",
+
+	// 文件锁定消息
+	FileLocked: "[!] storage.json est verrouillé par un autre processus, probablement l'outil de mise à jour de Cursor en arrière-plan ou une autre instance de cet outil ; fermez-le et réessayez",
+
+	// 备份集消息
+	BackupSetCreated: "[√] {{.Count}} {{plural .Count one \"fichier regroupé\" other \"fichiers regroupés\"}} dans le jeu de sauvegarde : {{.Path}}",
+
+	// 标识符校验消息
+	VerifyHeader: "Vérification des identifiants dans {{.Path}} :",
+	VerifyPassed: "[√] Tous les identifiants sont structurellement valides",
+
+	// 自检消息
+	SelfTestRunning: "Génération de {{.Count}} jeux d'identifiants et vérification de l'aléatoire...",
+	SelfTestPassed:  "[√] Autotest réussi, les identifiants peuvent être générés en toute sécurité",
+
+	// 其他用户的进程消息
+	OtherUserProcesses:    "[!] Les processus Cursor suivants appartiennent à un autre compte utilisateur :",
+	OtherUserRefused:      "Ils utilisent peut-être la configuration d'un autre utilisateur, rien n'a donc été modifié. Relancez avec -force pour les fermer quand même",
+	OtherUserProcessEntry: "  PID {{.PID}}  {{.Name}}  (utilisateur {{.User}})",
+
+	// 工作区消息
+	WorkspacesOpen:           "Ces espaces de travail étaient ouverts avant la fermeture de Cursor :",
+	RelaunchWorkspacesPrompt: "Rouvrir {{plural .Count one \"cet espace de travail\" other \"ces {{.Count}} espaces de travail\"}} ?",
+	WorkspacesRelaunched:     "[√] {{.Count}} {{plural .Count one \"espace de travail rouvert\" other \"espaces de travail rouverts\"}}",
+	CursorRestarted:          "[√] {{.App}} a été redémarré",
+
+	// 未保存修改消息
+	UnsavedWorkWarning: "[!] Cursor a {{.Count}} {{plural .Count one \"éditeur\" other \"éditeurs\"}} avec des modifications non enregistrées ; une fermeture forcée peut les faire perdre, enregistrez-les d'abord",
+	UnsavedWorkPrompt:  "Fermer Cursor et continuer quand même ?",
+
+	// 进程列表消息
+	ProcessListHeader: "Fermer {{.App}} arrêterait {{plural .Count one \"ce processus\" other \"ces {{.Count}} processus\"}} :",
+	ProcessListEmpty:  "[√] Aucun processus {{.App}} ne serait fermé",
+	ProcessListEntry:  "  PID {{.PID}} PPID {{.PPID}} {{.User}} {{.Started}}  {{.Name}}",
+	ProcessListDaemon: "{{.Name}} (démon)",
+
+	// 文件占用消息
+	FileInUse:     "[!] Ces processus ont encore {{.File}} ouvert et peuvent écraser les modifications :",
+	FileInUseHint: "Fermez-les et réessayez",
+
+	// 提升权限的进程消息
+	ElevatedProcess: "[!] Le processus {{.App}} {{.PID}} s'exécute avec des privilèges plus élevés ({{.Level}}) et ne peut pas être fermé d'ici. Exécutez cet outil en tant qu'administrateur (Windows) ou avec sudo (macOS/Linux), ou fermez-le manuellement",
+
+	// 远程服务器消息
+	ServerMachineIDReset:   "[√] Le machineid du serveur distant a été réinitialisé",
+	ServerMachineIDMissing: "[!] Le serveur distant n'a pas de fichier machineid, ignoré",
+}
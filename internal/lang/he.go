@@ -0,0 +1,248 @@
+// 语言包，提供多语言支持功能
+package lang
+
+// heText 希伯来语文本，未翻译的文本使用英文
+var heText = TextResource{
+	// 成功消息
+	SuccessMessage:     "[√] קובץ ההגדרות עודכן בהצלחה!",
+	RestartMessage:     "[!] יש להפעיל מחדש את Cursor באופן ידני כדי שהשינויים ייכנסו לתוקף",
+	OperationCompleted: "הפעולה הושלמה!",
+
+	// 版本信息
+	VersionInfo: "Cursor ID Modifier גרסה {{.Version}}",
+
+	// 标识符表格列标题
+	TableField: "שדה",
+	TableOld:   "ערך ישן",
+	TableNew:   "ערך חדש",
+	TableFile:  "קובץ",
+
+	// 提问消息
+	PromptYesHint:       "(Y/n)",
+	PromptNoHint:        "(y/N)",
+	PromptYes:           "כן",
+	PromptNo:            "לא",
+	PromptInvalidChoice: "יש להזין מספר בין 1 ל-{{.Count}}.",
+	PromptTimedOut:      "לא התקבלה תשובה תוך {{.Timeout}}, נעשה שימוש בערך ברירת המחדל.",
+
+	// 运行摘要消息
+	SummaryTitle:      "סיכום",
+	SummaryFiles:      "קבצים ששונו",
+	SummaryBackup:     "גיבוי",
+	SummaryIDs:        "מזהים ששונו",
+	SummaryIDsValue:   "{{.Changed}} מתוך {{.Total}} ({{.Fields}})",
+	SummaryProtection: "הגנה מפני כתיבה",
+	SummaryProcesses:  "תהליכים שנסגרו",
+	SummaryTime:       "זמן כולל",
+	SummaryNone:       "אין",
+
+	// 启动菜单消息
+	MenuTitle:        "מה ברצונך לעשות?",
+	MenuModify:       "שינוי המזהים",
+	MenuStatus:       "הצגת המצב",
+	MenuRestore:      "שחזור מגיבוי",
+	MenuLock:         "הגנה על storage.json מפני כתיבה",
+	MenuUnlock:       "הסרת ההגנה מפני כתיבה מ-storage.json",
+	MenuLanguage:     "החלפת שפה (Language)",
+	MenuExit:         "יציאה",
+	MenuPrompt:       "יש להזין מספר",
+	MenuBackupPrompt: "יש לבחור גיבוי לשחזור",
+	MenuNoBackups:    "לא נמצאו גיבויים ב-{{.Dir}}",
+	LanguagePrompt:   "יש לבחור את שפת הממשק",
+	LanguageSaved:    "השפה נשמרה ב-{{.Path}}",
+	StatusTitle:      "המצב הנוכחי",
+	StatusConfig:     "קובץ ההגדרות",
+	StatusMissing:    "(לא קיים)",
+	StatusProcesses:  "תהליכים פעילים",
+	LockSuccess:      "[√] storage.json מוגן כעת מפני כתיבה ({{.Level}})",
+
+	// 进度消息
+	ReadingConfig:     "קורא את קובץ ההגדרות...",
+	GeneratingIds:     "יוצר מזהים חדשים...",
+	SavingConfig:      "מגבה ושומר את ההגדרות...",
+	VerifyingConfig:   "מאמת את הקובץ שנכתב...",
+	CheckingProcesses: "מחפש מופעים פעילים של Cursor...",
+	ClosingProcesses:  "סוגר את Cursor...",
+	ClosingRemaining:  "סוגר {{.Total}} {{plural .Total one \"תהליך\" other \"תהליכים\"}}... {{plural .Remaining one \"נותר\" other \"נותרו\"}} {{.Remaining}}",
+	ProcessesClosed:   "כל המופעים של Cursor נסגרו",
+	PleaseWait:        "נא להמתין...",
+
+	// 错误消息
+	ErrorPrefix:     "אירעה שגיאה חמורה: {{.Error}}",
+	PrivilegeError:  "\n[!] שגיאה: נדרשות הרשאות מנהל",
+	CloseFailed:     "לא ניתן לסגור את {{.App}}. סגור אותו ידנית ונסה שוב.",
+	CloseIncomplete: "לא ניתן לסגור את {{.App}} לחלוטין. סגור אותו ידנית ונסה שוב.",
+
+	// 指令提示
+	RunAsAdmin:           "יש ללחוץ לחיצה ימנית ולבחור \"הפעל כמנהל\"",
+	RunWithSudo:          "יש להריץ את התוכנית באמצעות sudo",
+	SudoExample:          "דוגמה: sudo {{.Executable}}",
+	PressEnterToExit:     "\nיש להקיש Enter כדי לצאת...",
+	RequestingPrivileges: "\nמבקש הרשאות מנהל...",
+	SetReadOnlyMessage:   "storage.json הוגדר לקריאה בלבד, דבר שעלול לגרום לאובדן היסטוריית סביבות העבודה",
+
+	// 信息消息
+	ConfigLocation: "מיקום קובץ ההגדרות:",
+
+	// 写保护消息
+	StrongProtectionWarning: "[!] הופעלה הגנה חזקה מפני כתיבה, ו-Cursor לא יוכל עוד לעדכן את storage.json. כדי לבטל, יש להריץ עם -unlock",
+	UnlockSuccess:           "[√] ההגנה מפני כתיבה הוסרה מ-storage.json",
+
+	// 备份消息
+	BackupCreated:     "גיבוי ההגדרות נשמר ב-{{.Path}}",
+	RestoreSuccess:    "[√] ההגדרות שוחזרו מהגיבוי: {{.Path}}",
+	RestoreInvalid:    "[!] הגיבוי לא עבר את האימות:",
+	RestoreDiffHeader: "השחזור יבצע את השינויים הבאים ב-storage.json:",
+	RestoreNoChanges:  "הגיבוי זהה להגדרות הנוכחיות, אין מה לשחזר",
+	RestorePrompt:     "לשחזר עכשיו?",
+
+	// hosts文件遥测屏蔽消息
+	TelemetryBlocked:        "[√] {{plural .Count one \"נחסם דומיין טלמטריה אחד\" other \"נחסמו {{.Count}} דומיינים של טלמטריה\"}} ב-{{.Path}}",
+	TelemetryAlreadyBlocked: "דומייני הטלמטריה כבר חסומים ב-{{.Path}}",
+	TelemetryUnblocked:      "[√] חסימת הטלמטריה הוסרה מ-{{.Path}}",
+	TelemetryNotBlocked:     "אין ב-{{.Path}} חסימת טלמטריה להסרה",
+	HostsBackupCreated:      "גיבוי קובץ hosts נשמר ב-{{.Path}}",
+
+	// Windows防火墙规则消息
+	FirewallRulesCreated: "[√] נוצרו כללי חומת אש יוצאים לחסימת טלמטריה ({{.Count}})",
+	FirewallRulesRemoved: "[√] הוסרו כללי חומת אש ({{.Count}})",
+	FirewallNoRules:      "לא נמצאו כללי חומת אש שנוצרו על ידי הכלי",
+	FirewallStatusTitle:  "כללי חומת אש לטלמטריה",
+	FirewallRuleDisabled: "(מושבת)",
+
+	// HTTP API消息
+	ServeListening:     "ממשק HTTP API מאזין ב-http://{{.Address}} (הקש Ctrl+C לעצירה)",
+	ServeGRPCListening: "שירות gRPC מאזין ב-{{.Address}}",
+	ServeToken:         "אסימון גישה: {{.Token}}",
+	ServeNotLoopback:   "[!] ה-API נגיש ממחשבים אחרים; כל מי שמחזיק באסימון יכול לאפס את המזהים במחשב זה",
+
+	// SSH批量处理消息
+	FleetStarting:    "מעדכן {{.Count}} מארחים מתוך {{.Inventory}} דרך SSH...",
+	FleetHostUpdated: "[√] {{.Host}}: {{.Path}} עודכן",
+	FleetHostFailed:  "[×] {{.Host}}: {{.Error}}",
+	FleetSummary:     "עודכנו {{.Succeeded}} מתוך {{.Total}} מארחים",
+
+	// 审计日志消息
+	AuditVerified:      "[√] יומן הביקורת תקין: כל {{.Count}} הרשומות ב-{{.Path}} אומתו",
+	AuditHead:          "רשומה אחרונה: {{.Time}}, גיבוב {{.Hash}}",
+	AuditEmpty:         "ביומן הביקורת {{.Path}} אין עדיין רשומות",
+	AuditIntactEntries: "[!] {{.Count}} הרשומות שלפני השגיאה תקינות",
+
+	// 全屏界面消息
+	TUITitle:           "Cursor ID Modifier",
+	TUIMenuReset:       "איפוס מזהי המכשיר",
+	TUIMenuProcesses:   "הצגת תהליכי Cursor פעילים",
+	TUIMenuQuit:        "יציאה",
+	TUIProcessesHeader: "תהליכי Cursor פעילים (מתעדכן בכל שנייה):",
+	TUINoProcesses:     "אין תהליכי Cursor פעילים",
+	TUIChangesHeader:   "שינויי מזהים:",
+	TUIOldValue:        "ישן",
+	TUINewValue:        "חדש",
+	TUISucceeded:       "[√] הסתיים. יש להפעיל מחדש את Cursor כדי להשתמש במזהים החדשים",
+	TUIFailed:          "[×] לא הושלם, יש לעיין בשלב שנכשל למעלה",
+	TUIMenuHelp:        "↑/↓ בחירה · Enter אישור · l שפה · q יציאה",
+	TUIBackHelp:        "Enter/Esc חזרה לתפריט · Ctrl+C יציאה",
+	TUIRunningHelp:     "פועל… · Ctrl+C ביטול",
+	TUIStepClose:       "סגירת Cursor",
+	TUIStepCheckFile:   "בדיקה ש-storage.json אינו בשימוש",
+	TUIStepRead:        "קריאת ההגדרות הקיימות",
+	TUIStepGenerate:    "יצירת מזהים חדשים",
+	TUIStepSave:        "גיבוי ושמירת ההגדרות",
+	TUIStepVerify:      "אימות הקובץ שנכתב",
+
+	// 无障碍模式消息
+	AccessibleStepStarted:  "שלב {{.Step}} מתוך {{.Total}}: {{.Title}}",
+	AccessibleStepFinished: "שלב {{.Step}} מתוך {{.Total}} הסתיים תוך {{.Elapsed}}.",
+	AccessibleStepFailed:   "שלב {{.Step}} מתוך {{.Total}} נכשל.",
+	AccessibleFinished:     "הסתיים תוך {{.Elapsed}}.",
+	AccessibleFailed:       "נכשל.",
+	AccessibleSuccess:      "הצלחה: ",
+	AccessibleWarning:      "אזהרה: ",
+	AccessibleError:        "שגיאה: ",
+
+	// 守护模式消息
+	GuardReapplied: "[!] Cursor שכתב את המזהים, והם הוחלו מחדש: {{.Keys}}",
+	GuardUnchanged: "[√] המזהים לא השתנו",
+	GuardWatching:  "מנטר את storage.json, הקש Ctrl+C לעצירה...",
+
+	// 快照消息
+	SnapshotCreated:  "[√] תמונת מצב של globalStorage נשמרה אל: {{.Path}}",
+	SnapshotRestored: "[√] globalStorage שוחזר מתמונת המצב: {{.Path}}",
+
+	// workspaceStorage清理消息
+	WorkspaceStorageHeader:  "תיקיית האחסון של סביבות העבודה: {{.Dir}}",
+	WorkspaceStorageTotal:   "{{plural .Count one \"סביבת עבודה אחת\" other \"{{.Count}} סביבות עבודה\"}}, {{.Size}} בסך הכול",
+	WorkspaceStoragePrompt:  "הזן את המספרים למחיקה (לדוגמה 1,3,5-7), הקלד all כדי למחוק הכול, או הקש Enter כדי לדלג",
+	WorkspaceStorageCleared: "[√] {{plural .Count one \"הוסרה סביבת עבודה אחת\" other \"הוסרו {{.Count}} סביבות עבודה\"}}, שוחררו {{.Size}}",
+	WorkspaceStorageEmpty:   "אין אחסון של סביבות עבודה לניקוי",
+
+	// 登录状态清理消息
+	SignOutSuccess: "[√] מצב ההתחברות של Cursor נוקה ({{plural .Count one \"הוסר מפתח אימות אחד\" other \"הוסרו {{.Count}} מפתחות אימות\"}})",
+	SignOutBackup:  "נתוני ההתחברות גובו אל: {{.Path}}",
+
+	// 并发修改消息
+	ConcurrentModification: "[!] storage.json שונה על ידי תהליך אחר (ייתכן ש-Cursor או המעדכן שלו) במהלך הריצה, והכתיבה בוטלה כדי לא לדרוס נתונים חדשים",
+	RetryPrompt:            "לקרוא את הקובץ מחדש ולנסות שוב?",
+
+	// 已有写保护消息
+	WriteProtectedDetected: "[!] storage.json מוגן מפני כתיבה על ידי ריצה קודמת ({{.Level}})",
+	LiftProtectionPrompt:   "להסיר את ההגנה זמנית כדי לכתוב את המזהים החדשים? ההגנה המבוקשת תוחל מחדש לאחר מכן.",
+
+	// OneDrive重定向消息
+	OneDriveDetected:       "[!] תיקיית הנתונים של Cursor נמצאת בתוך תיקייה שמסונכרנת עם OneDrive ({{.Dir}}); הסנכרון עלול לדרוס את המזהים החדשים",
+	OneDriveAdvice:         "מומלץ להשהות את סנכרון OneDrive במהלך השינוי, או להשתמש באפשרות -pause-onedrive כדי להשהות אותו אוטומטית",
+	OneDrivePaused:         "OneDrive נסגר זמנית ויופעל מחדש לאחר מכן",
+	OneDriveConflictCopies: "[!] נמצאו עותקי התנגשות של סנכרון OneDrive, יש לבדוק ולמחוק אותם: {{.Files}}",
+
+	// 数据目录扫描消息
+	DiscoverHeader:   "{{plural .Count one \"נמצא קובץ storage.json אחד\" other \"נמצאו {{.Count}} קובצי storage.json\"}} (החדש ביותר ראשון):",
+	DiscoverEmpty:    "לא נמצא storage.json",
+	DiscoverPrompt:   "הזן מספר כדי להגדיר אותו כיעד ברירת המחדל, או הקש Enter כדי לדלג",
+	DiscoverSelected: "{{.Path}} נשמר כיעד ברירת המחדל",
+
+	// 文件锁定消息
+	FileLocked: "[!] storage.json נעול על ידי תהליך אחר, כנראה המעדכן של Cursor שפועל ברקע או מופע נוסף של הכלי הזה; סגור אותו ונסה שוב",
+
+	// 备份集消息
+	BackupSetCreated: "[√] {{plural .Count one \"קובץ אחד נארז\" other \"{{.Count}} קבצים נארזו\"}} בערכת הגיבוי: {{.Path}}",
+
+	// 标识符校验消息
+	VerifyHeader: "בודק את המזהים ב-{{.Path}}:",
+	VerifyPassed: "[√] כל המזהים תקינים מבחינה מבנית",
+
+	// 自检消息
+	SelfTestRunning: "יוצר {{.Count}} ערכות מזהים ובודק אקראיות...",
+	SelfTestPassed:  "[√] הבדיקה העצמית עברה, ניתן ליצור מזהים בבטחה",
+
+	// 其他用户的进程消息
+	OtherUserProcesses:    "[!] תהליכי Cursor הבאים שייכים לחשבון משתמש אחר:",
+	OtherUserRefused:      "ייתכן שהם משתמשים בהגדרות של משתמש אחר, ולכן לא בוצע שום שינוי. הרץ שוב עם -force כדי לסגור אותם בכל זאת",
+	OtherUserProcessEntry: "  PID {{.PID}}  {{.Name}}  (משתמש {{.User}})",
+
+	// 工作区消息
+	WorkspacesOpen:           "סביבות העבודה האלה היו פתוחות לפני ש-Cursor נסגר:",
+	RelaunchWorkspacesPrompt: "לפתוח מחדש את {{plural .Count one \"סביבת העבודה הזו\" other \"{{.Count}} סביבות העבודה האלה\"}}?",
+	WorkspacesRelaunched:     "[√] {{plural .Count one \"סביבת עבודה אחת נפתחה מחדש\" other \"{{.Count}} סביבות עבודה נפתחו מחדש\"}}",
+	CursorRestarted:          "[√] {{.App}} הופעל מחדש",
+
+	// 未保存修改消息
+	UnsavedWorkWarning: "[!] ב-Cursor יש {{plural .Count one \"עורך אחד\" other \"{{.Count}} עורכים\"}} עם שינויים שלא נשמרו; סגירה בכוח עלולה לגרום לאובדנם, לכן שמור אותם קודם",
+	UnsavedWorkPrompt:  "לסגור את Cursor ולהמשיך בכל זאת?",
+
+	// 进程列表消息
+	ProcessListHeader: "סגירת {{.App}} תסיים את {{plural .Count one \"התהליך הזה\" other \"{{.Count}} התהליכים האלה\"}}:",
+	ProcessListEmpty:  "[√] אף תהליך של {{.App}} לא ייסגר",
+	ProcessListEntry:  "  PID {{.PID}} PPID {{.PPID}} {{.User}} {{.Started}}  {{.Name}}",
+	ProcessListDaemon: "{{.Name}} (תהליך רקע)",
+
+	// 文件占用消息
+	FileInUse:     "[!] התהליכים האלה עדיין מחזיקים את {{.File}} פתוח ועלולים לדרוס את השינויים:",
+	FileInUseHint: "סגור אותם ונסה שוב",
+
+	// 提升权限的进程消息
+	ElevatedProcess: "[!] תהליך {{.PID}} של {{.App}} פועל עם הרשאות גבוהות יותר ({{.Level}}) ולא ניתן לסגור אותו מכאן. הרץ את הכלי כמנהל מערכת (Windows) או עם sudo (macOS/Linux), או סגור אותו ידנית",
+
+	// 远程服务器消息
+	ServerMachineIDReset:   "[√] ה-machineid של השרת המרוחק אופס",
+	ServerMachineIDMissing: "[!] בשרת המרוחק אין קובץ machineid, דולג",
+}
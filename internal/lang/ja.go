@@ -0,0 +1,248 @@
+// 语言包，提供多语言支持功能
+package lang
+
+// jaText 日语文本，未翻译的文本使用英文
+var jaText = TextResource{
+	// 成功消息
+	SuccessMessage:     "[√] 設定ファイルを更新しました！",
+	RestartMessage:     "[!] 変更を反映するには Cursor を手動で再起動してください",
+	OperationCompleted: "操作が完了しました！",
+
+	// 版本信息
+	VersionInfo: "Cursor ID Modifier バージョン {{.Version}}",
+
+	// 标识符表格列标题
+	TableField: "項目",
+	TableOld:   "変更前",
+	TableNew:   "変更後",
+	TableFile:  "ファイル",
+
+	// 提问消息
+	PromptYesHint:       "(Y/n)",
+	PromptNoHint:        "(y/N)",
+	PromptYes:           "はい",
+	PromptNo:            "いいえ",
+	PromptInvalidChoice: "1 から {{.Count}} までの番号を入力してください。",
+	PromptTimedOut:      "{{.Timeout}} 以内に入力がなかったため、既定値を使用します。",
+
+	// 运行摘要消息
+	SummaryTitle:      "実行結果",
+	SummaryFiles:      "変更したファイル",
+	SummaryBackup:     "バックアップ",
+	SummaryIDs:        "変更した識別子",
+	SummaryIDsValue:   "{{.Changed}}/{{.Total}} 件（{{.Fields}}）",
+	SummaryProtection: "書き込み保護",
+	SummaryProcesses:  "終了したプロセス",
+	SummaryTime:       "所要時間",
+	SummaryNone:       "なし",
+
+	// 启动菜单消息
+	MenuTitle:        "実行する操作を選んでください",
+	MenuModify:       "識別子を変更する",
+	MenuStatus:       "現在の状態を表示する",
+	MenuRestore:      "バックアップから復元する",
+	MenuLock:         "storage.json を書き込み保護する",
+	MenuUnlock:       "storage.json の書き込み保護を解除する",
+	MenuLanguage:     "言語を切り替える (Language)",
+	MenuExit:         "終了",
+	MenuPrompt:       "番号を入力してください",
+	MenuBackupPrompt: "復元するバックアップを選んでください",
+	MenuNoBackups:    "バックアップが見つかりません：{{.Dir}}",
+	LanguagePrompt:   "表示言語を選んでください",
+	LanguageSaved:    "表示言語を {{.Path}} に保存しました",
+	StatusTitle:      "現在の状態",
+	StatusConfig:     "設定ファイル",
+	StatusMissing:    "（存在しません）",
+	StatusProcesses:  "実行中のプロセス",
+	LockSuccess:      "[√] storage.json を書き込み保護しました（{{.Level}}）",
+
+	// 进度消息
+	ReadingConfig:     "設定ファイルを読み込んでいます...",
+	GeneratingIds:     "新しい識別子を生成しています...",
+	SavingConfig:      "設定をバックアップして保存しています...",
+	VerifyingConfig:   "書き込んだファイルを検証しています...",
+	CheckingProcesses: "実行中の Cursor を確認しています...",
+	ClosingProcesses:  "Cursor を終了しています...",
+	ClosingRemaining:  "{{.Total}} 個のプロセスを終了しています... 残り {{.Remaining}} 個",
+	ProcessesClosed:   "すべての Cursor を終了しました",
+	PleaseWait:        "しばらくお待ちください...",
+
+	// 错误消息
+	ErrorPrefix:     "重大なエラーが発生しました: {{.Error}}",
+	PrivilegeError:  "\n[!] エラー：管理者権限が必要です",
+	CloseFailed:     "{{.App}} を終了できませんでした。手動で終了してから再試行してください。",
+	CloseIncomplete: "{{.App}} を完全に終了できませんでした。手動で終了してから再試行してください。",
+
+	// 指令提示
+	RunAsAdmin:           "右クリックして「管理者として実行」を選んでください",
+	RunWithSudo:          "sudo を付けてこのプログラムを実行してください",
+	SudoExample:          "例: sudo {{.Executable}}",
+	PressEnterToExit:     "\nEnter キーを押すと終了します...",
+	RequestingPrivileges: "\n管理者権限を要求しています...",
+	SetReadOnlyMessage:   "storage.json を読み取り専用にしました。ワークスペースの履歴が失われるなどの問題が起きる場合があります",
+
+	// 信息消息
+	ConfigLocation: "設定ファイルの場所:",
+
+	// 写保护消息
+	StrongProtectionWarning: "[!] 強力な書き込み保護を有効にしたため、Cursor は storage.json を更新できなくなりました。元に戻すには -unlock を付けて実行してください",
+	UnlockSuccess:           "[√] storage.json の書き込み保護を解除しました",
+
+	// 备份消息
+	BackupCreated:     "設定をバックアップしました: {{.Path}}",
+	RestoreSuccess:    "[√] バックアップから設定を復元しました: {{.Path}}",
+	RestoreInvalid:    "[!] バックアップの検証に失敗しました:",
+	RestoreDiffHeader: "復元すると storage.json が次のように変更されます:",
+	RestoreNoChanges:  "バックアップは現在の設定と同じため、復元する必要はありません",
+	RestorePrompt:     "復元しますか？",
+
+	// hosts文件遥测屏蔽消息
+	TelemetryBlocked:        "[√] {{.Path}} で {{.Count}} 個のテレメトリドメインをブロックしました",
+	TelemetryAlreadyBlocked: "テレメトリドメインは {{.Path}} で既にブロックされています",
+	TelemetryUnblocked:      "[√] {{.Path}} からテレメトリのブロックを削除しました",
+	TelemetryNotBlocked:     "{{.Path}} に削除するテレメトリのブロックはありません",
+	HostsBackupCreated:      "hosts ファイルをバックアップしました: {{.Path}}",
+
+	// Windows防火墙规则消息
+	FirewallRulesCreated: "[√] テレメトリをブロックする送信ファイアウォール規則を {{.Count}} 件作成しました",
+	FirewallRulesRemoved: "[√] ファイアウォール規則を {{.Count}} 件削除しました",
+	FirewallNoRules:      "このツールが作成したファイアウォール規則はありません",
+	FirewallStatusTitle:  "テレメトリのファイアウォール規則",
+	FirewallRuleDisabled: "(無効)",
+
+	// HTTP API消息
+	ServeListening:     "HTTP API は http://{{.Address}} で待機しています（Ctrl+C で停止）",
+	ServeGRPCListening: "gRPC サービスは {{.Address}} で待機しています",
+	ServeToken:         "アクセストークン: {{.Token}}",
+	ServeNotLoopback:   "[!] API は他のコンピューターからアクセスできます。トークンを持つ人は誰でもこのコンピューターの識別子をリセットできます",
+
+	// SSH批量处理消息
+	FleetStarting:    "SSH 経由で {{.Inventory}} の {{.Count}} 台のホストを更新しています...",
+	FleetHostUpdated: "[√] {{.Host}}: {{.Path}} を更新しました",
+	FleetHostFailed:  "[×] {{.Host}}: {{.Error}}",
+	FleetSummary:     "{{.Total}} 台中 {{.Succeeded}} 台のホストを更新しました",
+
+	// 审计日志消息
+	AuditVerified:      "[√] 監査ログは正常です: {{.Path}} の {{.Count}} 件のエントリをすべて検証しました",
+	AuditHead:          "最後のエントリ: {{.Time}}、ハッシュ {{.Hash}}",
+	AuditEmpty:         "監査ログ {{.Path}} にはまだエントリがありません",
+	AuditIntactEntries: "[!] エラー箇所より前の {{.Count}} 件のエントリは正常です",
+
+	// 全屏界面消息
+	TUITitle:           "Cursor ID Modifier",
+	TUIMenuReset:       "デバイス識別子をリセットする",
+	TUIMenuProcesses:   "実行中の Cursor プロセスを表示する",
+	TUIMenuQuit:        "終了",
+	TUIProcessesHeader: "実行中の Cursor プロセス（毎秒更新）:",
+	TUINoProcesses:     "実行中の Cursor プロセスはありません",
+	TUIChangesHeader:   "識別子の変更:",
+	TUIOldValue:        "旧",
+	TUINewValue:        "新",
+	TUISucceeded:       "[√] 完了しました。新しい識別子を使うには Cursor を再起動してください",
+	TUIFailed:          "[×] 完了しませんでした。上の失敗した手順を確認してください",
+	TUIMenuHelp:        "↑/↓ 選択 · Enter 決定 · l 言語 · q 終了",
+	TUIBackHelp:        "Enter/Esc メニューに戻る · Ctrl+C 終了",
+	TUIRunningHelp:     "実行中… · Ctrl+C 中止",
+	TUIStepClose:       "Cursor を終了する",
+	TUIStepCheckFile:   "storage.json が使用中でないか確認する",
+	TUIStepRead:        "既存の設定を読み込む",
+	TUIStepGenerate:    "新しい識別子を生成する",
+	TUIStepSave:        "設定をバックアップして保存する",
+	TUIStepVerify:      "書き込んだファイルを検証する",
+
+	// 无障碍模式消息
+	AccessibleStepStarted:  "手順 {{.Step}}/{{.Total}}: {{.Title}}",
+	AccessibleStepFinished: "手順 {{.Step}}/{{.Total}} が {{.Elapsed}} で完了しました。",
+	AccessibleStepFailed:   "手順 {{.Step}}/{{.Total}} が失敗しました。",
+	AccessibleFinished:     "{{.Elapsed}} で完了しました。",
+	AccessibleFailed:       "失敗しました。",
+	AccessibleSuccess:      "成功: ",
+	AccessibleWarning:      "警告: ",
+	AccessibleError:        "エラー: ",
+
+	// 守护模式消息
+	GuardReapplied: "[!] Cursor が識別子を書き換えたため、再適用しました: {{.Keys}}",
+	GuardUnchanged: "[√] 識別子は変更されていません",
+	GuardWatching:  "storage.json を監視しています。Ctrl+C で停止します...",
+
+	// 快照消息
+	SnapshotCreated:  "[√] globalStorage のスナップショットを保存しました: {{.Path}}",
+	SnapshotRestored: "[√] スナップショットから globalStorage を復元しました: {{.Path}}",
+
+	// workspaceStorage清理消息
+	WorkspaceStorageHeader:  "ワークスペースストレージのディレクトリ: {{.Dir}}",
+	WorkspaceStorageTotal:   "ワークスペース {{.Count}} 件、合計 {{.Size}}",
+	WorkspaceStoragePrompt:  "削除する番号を入力してください（例: 1,3,5-7）。all と入力するとすべて削除し、Enter でスキップします",
+	WorkspaceStorageCleared: "[√] ワークスペース {{.Count}} 件を削除し、{{.Size}} を解放しました",
+	WorkspaceStorageEmpty:   "整理するワークスペースストレージはありません",
+
+	// 登录状态清理消息
+	SignOutSuccess: "[√] Cursor のログイン状態を消去しました（認証キー {{.Count}} 件を削除）",
+	SignOutBackup:  "ログインデータのバックアップ先: {{.Path}}",
+
+	// 并发修改消息
+	ConcurrentModification: "[!] 実行中に storage.json が別のプロセス（Cursor またはそのアップデーターの可能性があります）によって変更されたため、新しいデータを上書きしないよう書き込みを中止しました",
+	RetryPrompt:            "ファイルを読み込み直して再試行しますか？",
+
+	// 已有写保护消息
+	WriteProtectedDetected: "[!] storage.json は以前の実行によって書き込み保護されています（{{.Level}}）",
+	LiftProtectionPrompt:   "新しい識別子を書き込むために一時的に保護を解除しますか？指定された保護は書き込み後に再適用されます。",
+
+	// OneDrive重定向消息
+	OneDriveDetected:       "[!] Cursor のデータフォルダーが OneDrive の同期フォルダー内にあります（{{.Dir}}）。同期によって新しい識別子が上書きされる可能性があります",
+	OneDriveAdvice:         "変更中は OneDrive の同期を一時停止するか、-pause-onedrive を指定して自動的に一時停止してください",
+	OneDrivePaused:         "OneDrive を一時的に終了しました。処理後に再起動します",
+	OneDriveConflictCopies: "[!] OneDrive の同期競合によるコピーが見つかりました。確認して削除してください: {{.Files}}",
+
+	// 数据目录扫描消息
+	DiscoverHeader:   "storage.json が {{.Count}} 件見つかりました（新しい順）:",
+	DiscoverEmpty:    "storage.json が見つかりません",
+	DiscoverPrompt:   "既定の対象にする番号を入力してください。Enter でスキップします",
+	DiscoverSelected: "{{.Path}} を既定の対象として保存しました",
+
+	// 文件锁定消息
+	FileLocked: "[!] storage.json が別のプロセスによってロックされています。Cursor のバックグラウンドアップデーターか、このツールの別のインスタンスが実行中の可能性があります。終了してから再試行してください",
+
+	// 备份集消息
+	BackupSetCreated: "[√] {{.Count}} 個のファイルをバックアップセットにまとめました: {{.Path}}",
+
+	// 标识符校验消息
+	VerifyHeader: "{{.Path}} の識別子を確認しています:",
+	VerifyPassed: "[√] すべての識別子の構造は正常です",
+
+	// 自检消息
+	SelfTestRunning: "識別子を {{.Count}} セット生成し、ランダム性を確認しています...",
+	SelfTestPassed:  "[√] 自己テストに合格しました。識別子を安全に生成できます",
+
+	// 其他用户的进程消息
+	OtherUserProcesses:    "[!] 次の Cursor プロセスは別のユーザーアカウントのものです:",
+	OtherUserRefused:      "別のユーザーの設定を使用している可能性があるため、何も変更していません。それでも終了するには -force を付けて再実行してください",
+	OtherUserProcessEntry: "  PID {{.PID}}  {{.Name}}（ユーザー {{.User}}）",
+
+	// 工作区消息
+	WorkspacesOpen:           "Cursor を終了する前に開いていたワークスペース:",
+	RelaunchWorkspacesPrompt: "ワークスペース {{.Count}} 件を再び開きますか？",
+	WorkspacesRelaunched:     "[√] ワークスペース {{.Count}} 件を再び開きました",
+	CursorRestarted:          "[√] {{.App}} を再起動しました",
+
+	// 未保存修改消息
+	UnsavedWorkWarning: "[!] Cursor には未保存の変更があるエディターが {{.Count}} 件あります。強制終了すると失われる可能性があるため、先に保存してください",
+	UnsavedWorkPrompt:  "それでも Cursor を終了して続行しますか？",
+
+	// 进程列表消息
+	ProcessListHeader: "{{.App}} を終了すると、次の {{.Count}} 個のプロセスが終了します:",
+	ProcessListEmpty:  "[√] 終了する {{.App}} プロセスはありません",
+	ProcessListEntry:  "  PID {{.PID}} PPID {{.PPID}} {{.User}} {{.Started}}  {{.Name}}",
+	ProcessListDaemon: "{{.Name}}（デーモン）",
+
+	// 文件占用消息
+	FileInUse:     "[!] 次のプロセスがまだ {{.File}} を開いており、変更を上書きする可能性があります:",
+	FileInUseHint: "それらを終了してから再試行してください",
+
+	// 提升权限的进程消息
+	ElevatedProcess: "[!] {{.App}} のプロセス {{.PID}} は高い権限（{{.Level}}）で実行されているため、ここからは終了できません。このツールを管理者として（Windows）または sudo で（macOS/Linux）実行するか、手動で終了してください",
+
+	// 远程服务器消息
+	ServerMachineIDReset:   "[√] リモートサーバーの machineid をリセットしました",
+	ServerMachineIDMissing: "[!] リモートサーバーに machineid ファイルがないため、スキップしました",
+}
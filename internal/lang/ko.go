@@ -0,0 +1,248 @@
+// 语言包，提供多语言支持功能
+package lang
+
+// koText 韩语文本，未翻译的文本使用英文
+var koText = TextResource{
+	// 成功消息
+	SuccessMessage:     "[√] 설정 파일을 업데이트했습니다!",
+	RestartMessage:     "[!] 변경 사항을 적용하려면 Cursor를 직접 다시 시작하세요",
+	OperationCompleted: "작업을 완료했습니다!",
+
+	// 版本信息
+	VersionInfo: "Cursor ID Modifier 버전 {{.Version}}",
+
+	// 标识符表格列标题
+	TableField: "항목",
+	TableOld:   "이전 값",
+	TableNew:   "새 값",
+	TableFile:  "파일",
+
+	// 提问消息
+	PromptYesHint:       "(Y/n)",
+	PromptNoHint:        "(y/N)",
+	PromptYes:           "예",
+	PromptNo:            "아니요",
+	PromptInvalidChoice: "1부터 {{.Count}} 사이의 번호를 입력하세요.",
+	PromptTimedOut:      "{{.Timeout}} 동안 입력이 없어 기본값을 사용합니다.",
+
+	// 运行摘要消息
+	SummaryTitle:      "실행 요약",
+	SummaryFiles:      "수정한 파일",
+	SummaryBackup:     "백업",
+	SummaryIDs:        "변경한 식별자",
+	SummaryIDsValue:   "{{.Changed}}/{{.Total}}개 ({{.Fields}})",
+	SummaryProtection: "쓰기 보호",
+	SummaryProcesses:  "종료한 프로세스",
+	SummaryTime:       "총 소요 시간",
+	SummaryNone:       "없음",
+
+	// 启动菜单消息
+	MenuTitle:        "실행할 작업을 선택하세요",
+	MenuModify:       "식별자 변경",
+	MenuStatus:       "현재 상태 보기",
+	MenuRestore:      "백업에서 복원",
+	MenuLock:         "storage.json 쓰기 보호",
+	MenuUnlock:       "storage.json 쓰기 보호 해제",
+	MenuLanguage:     "언어 변경 (Language)",
+	MenuExit:         "종료",
+	MenuPrompt:       "번호를 입력하세요",
+	MenuBackupPrompt: "복원할 백업을 선택하세요",
+	MenuNoBackups:    "백업을 찾을 수 없습니다: {{.Dir}}",
+	LanguagePrompt:   "표시 언어를 선택하세요",
+	LanguageSaved:    "표시 언어를 {{.Path}}에 저장했습니다",
+	StatusTitle:      "현재 상태",
+	StatusConfig:     "설정 파일",
+	StatusMissing:    "(없음)",
+	StatusProcesses:  "실행 중인 프로세스",
+	LockSuccess:      "[√] storage.json에 쓰기 보호를 적용했습니다 ({{.Level}})",
+
+	// 进度消息
+	ReadingConfig:     "설정 파일을 읽는 중...",
+	GeneratingIds:     "새 식별자를 생성하는 중...",
+	SavingConfig:      "설정을 백업하고 저장하는 중...",
+	VerifyingConfig:   "기록한 파일을 확인하는 중...",
+	CheckingProcesses: "실행 중인 Cursor를 확인하는 중...",
+	ClosingProcesses:  "Cursor를 종료하는 중...",
+	ClosingRemaining:  "프로세스 {{.Total}}개를 종료하는 중... {{.Remaining}}개 남음",
+	ProcessesClosed:   "모든 Cursor를 종료했습니다",
+	PleaseWait:        "잠시 기다려 주세요...",
+
+	// 错误消息
+	ErrorPrefix:     "심각한 오류가 발생했습니다: {{.Error}}",
+	PrivilegeError:  "\n[!] 오류: 관리자 권한이 필요합니다",
+	CloseFailed:     "{{.App}}를 종료하지 못했습니다. 직접 종료한 후 다시 시도하세요.",
+	CloseIncomplete: "{{.App}}를 완전히 종료하지 못했습니다. 직접 종료한 후 다시 시도하세요.",
+
+	// 指令提示
+	RunAsAdmin:           "마우스 오른쪽 버튼을 클릭하고 '관리자 권한으로 실행'을 선택하세요",
+	RunWithSudo:          "sudo로 이 프로그램을 실행하세요",
+	SudoExample:          "예: sudo {{.Executable}}",
+	PressEnterToExit:     "\nEnter 키를 누르면 종료합니다...",
+	RequestingPrivileges: "\n관리자 권한을 요청하는 중...",
+	SetReadOnlyMessage:   "storage.json을 읽기 전용으로 설정했습니다. 작업 영역 기록이 사라지는 등의 문제가 생길 수 있습니다",
+
+	// 信息消息
+	ConfigLocation: "설정 파일 위치:",
+
+	// 写保护消息
+	StrongProtectionWarning: "[!] 강력한 쓰기 보호를 적용하여 Cursor가 더 이상 storage.json을 업데이트할 수 없습니다. 되돌리려면 -unlock 옵션으로 실행하세요",
+	UnlockSuccess:           "[√] storage.json의 쓰기 보호를 해제했습니다",
+
+	// 备份消息
+	BackupCreated:     "설정을 백업했습니다: {{.Path}}",
+	RestoreSuccess:    "[√] 백업에서 설정을 복원했습니다: {{.Path}}",
+	RestoreInvalid:    "[!] 백업 검증에 실패했습니다:",
+	RestoreDiffHeader: "복원하면 storage.json이 다음과 같이 변경됩니다:",
+	RestoreNoChanges:  "백업이 현재 설정과 같아 복원할 내용이 없습니다",
+	RestorePrompt:     "지금 복원할까요?",
+
+	// hosts文件遥测屏蔽消息
+	TelemetryBlocked:        "[√] {{.Path}}에서 텔레메트리 도메인 {{.Count}}개를 차단했습니다",
+	TelemetryAlreadyBlocked: "{{.Path}}에서 텔레메트리 도메인이 이미 차단되어 있습니다",
+	TelemetryUnblocked:      "[√] {{.Path}}에서 텔레메트리 차단 항목을 제거했습니다",
+	TelemetryNotBlocked:     "{{.Path}}에 제거할 텔레메트리 차단 항목이 없습니다",
+	HostsBackupCreated:      "hosts 파일을 백업했습니다: {{.Path}}",
+
+	// Windows防火墙规则消息
+	FirewallRulesCreated: "[√] 텔레메트리를 차단하는 아웃바운드 방화벽 규칙 {{.Count}}개를 만들었습니다",
+	FirewallRulesRemoved: "[√] 방화벽 규칙 {{.Count}}개를 제거했습니다",
+	FirewallNoRules:      "이 도구가 만든 방화벽 규칙이 없습니다",
+	FirewallStatusTitle:  "텔레메트리 방화벽 규칙",
+	FirewallRuleDisabled: "(사용 안 함)",
+
+	// HTTP API消息
+	ServeListening:     "HTTP API가 http://{{.Address}}에서 대기 중입니다 (Ctrl+C로 중지)",
+	ServeGRPCListening: "gRPC 서비스가 {{.Address}}에서 대기 중입니다",
+	ServeToken:         "액세스 토큰: {{.Token}}",
+	ServeNotLoopback:   "[!] 다른 컴퓨터에서 API에 접근할 수 있습니다. 토큰을 가진 사람은 누구나 이 컴퓨터의 식별자를 재설정할 수 있습니다",
+
+	// SSH批量处理消息
+	FleetStarting:    "SSH를 통해 {{.Inventory}}의 호스트 {{.Count}}대를 업데이트하는 중...",
+	FleetHostUpdated: "[√] {{.Host}}: {{.Path}} 업데이트됨",
+	FleetHostFailed:  "[×] {{.Host}}: {{.Error}}",
+	FleetSummary:     "호스트 {{.Total}}대 중 {{.Succeeded}}대 업데이트됨",
+
+	// 审计日志消息
+	AuditVerified:      "[√] 감사 로그 정상: {{.Path}}의 항목 {{.Count}}개를 모두 검증했습니다",
+	AuditHead:          "마지막 항목: {{.Time}}, 해시 {{.Hash}}",
+	AuditEmpty:         "감사 로그 {{.Path}}에 아직 항목이 없습니다",
+	AuditIntactEntries: "[!] 오류 위치 이전의 항목 {{.Count}}개는 정상입니다",
+
+	// 全屏界面消息
+	TUITitle:           "Cursor ID Modifier",
+	TUIMenuReset:       "장치 식별자 재설정",
+	TUIMenuProcesses:   "실행 중인 Cursor 프로세스 보기",
+	TUIMenuQuit:        "종료",
+	TUIProcessesHeader: "실행 중인 Cursor 프로세스 (1초마다 갱신):",
+	TUINoProcesses:     "실행 중인 Cursor 프로세스가 없습니다",
+	TUIChangesHeader:   "식별자 변경 내역:",
+	TUIOldValue:        "이전",
+	TUINewValue:        "새 값",
+	TUISucceeded:       "[√] 완료했습니다. 새 식별자를 사용하려면 Cursor를 다시 시작하세요",
+	TUIFailed:          "[×] 완료하지 못했습니다. 위에서 실패한 단계를 확인하세요",
+	TUIMenuHelp:        "↑/↓ 선택 · Enter 확인 · l 언어 · q 종료",
+	TUIBackHelp:        "Enter/Esc 메뉴로 돌아가기 · Ctrl+C 종료",
+	TUIRunningHelp:     "실행 중… · Ctrl+C 중단",
+	TUIStepClose:       "Cursor 종료",
+	TUIStepCheckFile:   "storage.json이 사용 중인지 확인",
+	TUIStepRead:        "기존 설정 읽기",
+	TUIStepGenerate:    "새 식별자 생성",
+	TUIStepSave:        "설정 백업 및 저장",
+	TUIStepVerify:      "기록한 파일 확인",
+
+	// 无障碍模式消息
+	AccessibleStepStarted:  "{{.Total}}단계 중 {{.Step}}단계: {{.Title}}",
+	AccessibleStepFinished: "{{.Total}}단계 중 {{.Step}}단계가 {{.Elapsed}} 만에 완료되었습니다.",
+	AccessibleStepFailed:   "{{.Total}}단계 중 {{.Step}}단계가 실패했습니다.",
+	AccessibleFinished:     "{{.Elapsed}} 만에 완료되었습니다.",
+	AccessibleFailed:       "실패했습니다.",
+	AccessibleSuccess:      "성공: ",
+	AccessibleWarning:      "경고: ",
+	AccessibleError:        "오류: ",
+
+	// 守护模式消息
+	GuardReapplied: "[!] Cursor가 식별자를 다시 썼으므로 다시 적용했습니다: {{.Keys}}",
+	GuardUnchanged: "[√] 식별자가 변경되지 않았습니다",
+	GuardWatching:  "storage.json을 감시하는 중입니다. 중지하려면 Ctrl+C를 누르세요...",
+
+	// 快照消息
+	SnapshotCreated:  "[√] globalStorage 스냅샷을 저장했습니다: {{.Path}}",
+	SnapshotRestored: "[√] 스냅샷에서 globalStorage를 복원했습니다: {{.Path}}",
+
+	// workspaceStorage清理消息
+	WorkspaceStorageHeader:  "작업 영역 저장소 디렉터리: {{.Dir}}",
+	WorkspaceStorageTotal:   "작업 영역 {{.Count}}개, 총 {{.Size}}",
+	WorkspaceStoragePrompt:  "삭제할 번호를 입력하세요(예: 1,3,5-7). 모두 삭제하려면 all을 입력하고, 건너뛰려면 Enter를 누르세요",
+	WorkspaceStorageCleared: "[√] 작업 영역 {{.Count}}개를 삭제하고 {{.Size}}를 확보했습니다",
+	WorkspaceStorageEmpty:   "정리할 작업 영역 저장소가 없습니다",
+
+	// 登录状态清理消息
+	SignOutSuccess: "[√] Cursor 로그인 상태를 지웠습니다(인증 키 {{.Count}}개 삭제)",
+	SignOutBackup:  "로그인 데이터 백업 위치: {{.Path}}",
+
+	// 并发修改消息
+	ConcurrentModification: "[!] 실행 중에 다른 프로세스(Cursor 또는 업데이트 프로그램일 수 있음)가 storage.json을 수정했습니다. 새 데이터를 덮어쓰지 않도록 쓰기를 중단했습니다",
+	RetryPrompt:            "파일을 다시 읽고 재시도할까요?",
+
+	// 已有写保护消息
+	WriteProtectedDetected: "[!] 이전 실행에서 storage.json에 쓰기 보호가 적용되었습니다({{.Level}})",
+	LiftProtectionPrompt:   "새 식별자를 쓰기 위해 보호를 일시적으로 해제할까요? 요청한 보호는 이후에 다시 적용됩니다.",
+
+	// OneDrive重定向消息
+	OneDriveDetected:       "[!] Cursor 데이터 폴더가 OneDrive 동기화 폴더 안에 있습니다({{.Dir}}). 동기화로 인해 새 식별자가 덮어써질 수 있습니다",
+	OneDriveAdvice:         "수정하는 동안 OneDrive 동기화를 일시 중지하거나 -pause-onedrive 옵션으로 자동으로 일시 중지하세요",
+	OneDrivePaused:         "OneDrive를 일시적으로 종료했으며 작업 후 다시 시작합니다",
+	OneDriveConflictCopies: "[!] OneDrive 동기화 충돌 사본이 발견되었습니다. 확인 후 삭제하세요: {{.Files}}",
+
+	// 数据目录扫描消息
+	DiscoverHeader:   "storage.json 파일 {{.Count}}개를 찾았습니다(최신순):",
+	DiscoverEmpty:    "storage.json을 찾을 수 없습니다",
+	DiscoverPrompt:   "기본 대상으로 지정할 번호를 입력하거나 Enter를 눌러 건너뛰세요",
+	DiscoverSelected: "{{.Path}}을(를) 기본 대상으로 저장했습니다",
+
+	// 文件锁定消息
+	FileLocked: "[!] 다른 프로세스가 storage.json을 잠갔습니다. Cursor의 백그라운드 업데이트 프로그램이나 이 도구의 다른 인스턴스일 수 있습니다. 종료한 후 다시 시도하세요",
+
+	// 备份集消息
+	BackupSetCreated: "[√] 파일 {{.Count}}개를 백업 세트로 묶었습니다: {{.Path}}",
+
+	// 标识符校验消息
+	VerifyHeader: "{{.Path}}의 식별자를 확인하는 중:",
+	VerifyPassed: "[√] 모든 식별자의 구조가 올바릅니다",
+
+	// 自检消息
+	SelfTestRunning: "식별자 세트 {{.Count}}개를 생성하고 무작위성을 확인하는 중...",
+	SelfTestPassed:  "[√] 자체 테스트를 통과했습니다. 식별자를 안전하게 생성할 수 있습니다",
+
+	// 其他用户的进程消息
+	OtherUserProcesses:    "[!] 다음 Cursor 프로세스는 다른 사용자 계정에 속합니다:",
+	OtherUserRefused:      "다른 사용자의 구성을 사용 중일 수 있으므로 아무것도 변경하지 않았습니다. 그래도 종료하려면 -force 옵션으로 다시 실행하세요",
+	OtherUserProcessEntry: "  PID {{.PID}}  {{.Name}}  (사용자 {{.User}})",
+
+	// 工作区消息
+	WorkspacesOpen:           "Cursor를 종료하기 전에 열려 있던 작업 영역:",
+	RelaunchWorkspacesPrompt: "작업 영역 {{.Count}}개를 다시 열까요?",
+	WorkspacesRelaunched:     "[√] 작업 영역 {{.Count}}개를 다시 열었습니다",
+	CursorRestarted:          "[√] {{.App}}을(를) 다시 시작했습니다",
+
+	// 未保存修改消息
+	UnsavedWorkWarning: "[!] Cursor에 저장하지 않은 변경 사항이 있는 편집기가 {{.Count}}개 있습니다. 강제로 종료하면 잃을 수 있으니 먼저 저장하세요",
+	UnsavedWorkPrompt:  "그래도 Cursor를 종료하고 계속할까요?",
+
+	// 进程列表消息
+	ProcessListHeader: "{{.App}}을(를) 종료하면 다음 프로세스 {{.Count}}개가 종료됩니다:",
+	ProcessListEmpty:  "[√] 종료될 {{.App}} 프로세스가 없습니다",
+	ProcessListEntry:  "  PID {{.PID}} PPID {{.PPID}} {{.User}} {{.Started}}  {{.Name}}",
+	ProcessListDaemon: "{{.Name}} (데몬)",
+
+	// 文件占用消息
+	FileInUse:     "[!] 다음 프로세스가 아직 {{.File}}을(를) 열고 있어 변경 사항을 덮어쓸 수 있습니다:",
+	FileInUseHint: "해당 프로세스를 종료한 후 다시 시도하세요",
+
+	// 提升权限的进程消息
+	ElevatedProcess: "[!] {{.App}} 프로세스 {{.PID}}는 더 높은 권한({{.Level}})으로 실행 중이므로 여기에서 종료할 수 없습니다. 이 도구를 관리자 권한(Windows) 또는 sudo(macOS/Linux)로 실행하거나 직접 종료하세요",
+
+	// 远程服务器消息
+	ServerMachineIDReset:   "[√] 원격 서버의 machineid를 재설정했습니다",
+	ServerMachineIDMissing: "[!] 원격 서버에 machineid 파일이 없어 건너뛰었습니다",
+}
@@ -2,10 +2,15 @@
 package lang
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"os/exec"
+	"reflect"
+	"runtime"
 	"strings"
 	"sync"
+	"time"
 )
 
 // Language 表示支持的语言代码类型
@@ -16,35 +21,324 @@ const (
 	CN Language = "cn"
 	// EN 表示英文语言
 	EN Language = "en"
+	// JA 表示日语
+	JA Language = "ja"
+	// KO 表示韩语
+	KO Language = "ko"
+	// RU 表示俄语
+	RU Language = "ru"
+	// DE 表示德语
+	DE Language = "de"
+	// FR 表示法语
+	FR Language = "fr"
+	// ES 表示西班牙语
+	ES Language = "es"
+	// PTBR 表示巴西葡萄牙语
+	PTBR Language = "pt-br"
+	// ZHTW 表示繁体中文，未翻译的文本依次使用简体中文和英文
+	ZHTW Language = "zh-tw"
+	// PTPT 表示欧洲葡萄牙语，未翻译的文本依次使用巴西葡萄牙语和英文
+	PTPT Language = "pt-pt"
+	// AR 表示阿拉伯语，从右到左书写
+	AR Language = "ar"
+	// HE 表示希伯来语，从右到左书写
+	HE Language = "he"
 )
 
+// fallbacks 地区语言的回退链，缺少的文本按顺序从链中的语言查找，最后使用英文
+var fallbacks = map[Language][]Language{
+	ZHTW: {CN},
+	PTPT: {PTBR},
+}
+
+// languageNames 各语言以其自身书写的名称，用于语言选择菜单，顺序即菜单中的顺序
+var languageNames = []struct {
+	language Language
+	name     string
+}{
+	{CN, "简体中文"},
+	{ZHTW, "繁體中文"},
+	{EN, "English"},
+	{JA, "日本語"},
+	{KO, "한국어"},
+	{RU, "Русский"},
+	{DE, "Deutsch"},
+	{FR, "Français"},
+	{ES, "Español"},
+	{PTBR, "Português (Brasil)"},
+	{PTPT, "Português (Portugal)"},
+	{AR, "العربية"},
+	{HE, "עברית"},
+}
+
+// Languages 返回所有支持的语言，顺序固定
+func Languages() []Language {
+	languages := make([]Language, len(languageNames))
+	for i, entry := range languageNames {
+		languages[i] = entry.language
+	}
+	return languages
+}
+
+// IsRTL 返回语言是否从右到左书写
+func (l Language) IsRTL() bool {
+	return l == AR || l == HE
+}
+
+// Name 返回语言以其自身书写的名称，例如"日本語"
+func (l Language) Name() string {
+	for _, entry := range languageNames {
+		if entry.language == l {
+			return entry.name
+		}
+	}
+	return string(l)
+}
+
 // TextResource 包含所有可翻译的文本资源
 type TextResource struct {
 	// 成功消息
-	SuccessMessage string
-	RestartMessage string
+	SuccessMessage     string
+	RestartMessage     string
+	OperationCompleted string
+
+	// 版本信息
+	VersionInfo string
+
+	// 无障碍模式消息
+	AccessibleStepStarted  string
+	AccessibleStepFinished string
+	AccessibleStepFailed   string
+	AccessibleFinished     string
+	AccessibleFailed       string
+	AccessibleSuccess      string
+	AccessibleWarning      string
+	AccessibleError        string
+
+	// 标识符表格列标题
+	TableField string
+	TableOld   string
+	TableNew   string
+	TableFile  string
+
+	// 提问消息
+	PromptYesHint       string
+	PromptNoHint        string
+	PromptYes           string
+	PromptNo            string
+	PromptInvalidChoice string
+	PromptTimedOut      string
+
+	// 运行摘要消息
+	SummaryTitle      string
+	SummaryFiles      string
+	SummaryBackup     string
+	SummaryIDs        string
+	SummaryIDsValue   string
+	SummaryProtection string
+	SummaryProcesses  string
+	SummaryTime       string
+	SummaryNone       string
+
+	// 启动菜单消息
+	MenuTitle        string
+	MenuModify       string
+	MenuStatus       string
+	MenuRestore      string
+	MenuLock         string
+	MenuUnlock       string
+	MenuLanguage     string
+	MenuExit         string
+	MenuPrompt       string
+	MenuBackupPrompt string
+	MenuNoBackups    string
+	LanguagePrompt   string
+	LanguageSaved    string
+	StatusTitle      string
+	StatusConfig     string
+	StatusMissing    string
+	StatusProcesses  string
+	LockSuccess      string
 
 	// 进度消息
 	ReadingConfig     string
 	GeneratingIds     string
+	SavingConfig      string
+	VerifyingConfig   string
 	CheckingProcesses string
 	ClosingProcesses  string
+	ClosingRemaining  string
 	ProcessesClosed   string
 	PleaseWait        string
 
 	// 错误消息
-	ErrorPrefix    string
-	PrivilegeError string
+	ErrorPrefix     string
+	PrivilegeError  string
+	CloseFailed     string
+	CloseIncomplete string
 
 	// 指令提示
-	RunAsAdmin         string
-	RunWithSudo        string
-	SudoExample        string
-	PressEnterToExit   string
-	SetReadOnlyMessage string
+	RunAsAdmin           string
+	RunWithSudo          string
+	SudoExample          string
+	PressEnterToExit     string
+	RequestingPrivileges string
+	SetReadOnlyMessage   string
 
 	// 信息消息
 	ConfigLocation string
+
+	// 写保护消息
+	StrongProtectionWarning string
+	UnlockSuccess           string
+
+	// 守护模式消息
+	GuardReapplied string
+	GuardUnchanged string
+	GuardWatching  string
+
+	// 备份消息
+	BackupCreated     string
+	RestoreSuccess    string
+	RestoreInvalid    string
+	RestoreDiffHeader string
+	RestoreNoChanges  string
+	RestorePrompt     string
+
+	// 快照消息
+	SnapshotCreated  string
+	SnapshotRestored string
+
+	// workspaceStorage清理消息
+	WorkspaceStorageHeader  string
+	WorkspaceStorageTotal   string
+	WorkspaceStoragePrompt  string
+	WorkspaceStorageCleared string
+	WorkspaceStorageEmpty   string
+
+	// 登录状态清理消息
+	SignOutSuccess string
+	SignOutBackup  string
+
+	// hosts文件遥测屏蔽消息
+	TelemetryBlocked        string
+	TelemetryAlreadyBlocked string
+	TelemetryUnblocked      string
+	TelemetryNotBlocked     string
+	HostsBackupCreated      string
+
+	// Windows防火墙规则消息
+	FirewallRulesCreated string
+	FirewallRulesRemoved string
+	FirewallNoRules      string
+	FirewallStatusTitle  string
+	FirewallRuleDisabled string
+
+	// HTTP API消息
+	ServeListening     string
+	ServeGRPCListening string
+	ServeToken         string
+	ServeNotLoopback   string
+
+	// SSH批量处理消息
+	FleetStarting    string
+	FleetHostUpdated string
+	FleetHostFailed  string
+	FleetSummary     string
+
+	// 审计日志消息
+	AuditVerified      string
+	AuditHead          string
+	AuditEmpty         string
+	AuditIntactEntries string
+
+	// 并发修改消息
+	ConcurrentModification string
+	RetryPrompt            string
+
+	// 已有写保护消息
+	WriteProtectedDetected string
+	LiftProtectionPrompt   string
+
+	// OneDrive重定向消息
+	OneDriveDetected       string
+	OneDriveAdvice         string
+	OneDrivePaused         string
+	OneDriveConflictCopies string
+
+	// 数据目录扫描消息
+	DiscoverHeader   string
+	DiscoverEmpty    string
+	DiscoverPrompt   string
+	DiscoverSelected string
+
+	// 文件锁定消息
+	FileLocked string
+
+	// 备份集消息
+	BackupSetCreated string
+
+	// 标识符校验消息
+	VerifyHeader string
+	VerifyPassed string
+
+	// 自检消息
+	SelfTestRunning string
+	SelfTestPassed  string
+
+	// 其他用户的进程消息
+	OtherUserProcesses    string
+	OtherUserRefused      string
+	OtherUserProcessEntry string
+
+	// 工作区消息
+	WorkspacesOpen           string
+	RelaunchWorkspacesPrompt string
+	WorkspacesRelaunched     string
+	CursorRestarted          string
+
+	// 未保存修改消息
+	UnsavedWorkWarning string
+	UnsavedWorkPrompt  string
+
+	// 进程列表消息
+	ProcessListHeader string
+	ProcessListEmpty  string
+	ProcessListEntry  string
+	ProcessListDaemon string
+
+	// 提升权限的进程消息
+	ElevatedProcess string
+
+	// 文件占用消息
+	FileInUse     string
+	FileInUseHint string
+
+	// 远程服务器消息
+	ServerMachineIDReset   string
+	ServerMachineIDMissing string
+
+	// 全屏界面消息
+	TUITitle           string
+	TUIMenuReset       string
+	TUIMenuProcesses   string
+	TUIMenuQuit        string
+	TUIProcessesHeader string
+	TUINoProcesses     string
+	TUIChangesHeader   string
+	TUIOldValue        string
+	TUINewValue        string
+	TUISucceeded       string
+	TUIFailed          string
+	TUIMenuHelp        string
+	TUIBackHelp        string
+	TUIRunningHelp     string
+	TUIStepClose       string
+	TUIStepCheckFile   string
+	TUIStepRead        string
+	TUIStepGenerate    string
+	TUIStepSave        string
+	TUIStepVerify      string
 }
 
 var (
@@ -54,8 +348,13 @@ var (
 	currentLanguageOnce sync.Once
 	// 保护语言变量的互斥锁
 	languageMutex       sync.RWMutex
+	// 目标应用程序名称，文本中的"Cursor"会被替换为该名称
+	appName = defaultAppName
 )
 
+// 文本资源中使用的默认应用程序名称
+const defaultAppName = "Cursor"
+
 // GetCurrentLanguage 返回当前语言，如果尚未设置则自动检测
 func GetCurrentLanguage() Language {
 	currentLanguageOnce.Do(func() {
@@ -69,127 +368,730 @@ func GetCurrentLanguage() Language {
 
 // SetLanguage 设置当前语言
 func SetLanguage(lang Language) {
+	// 显式设置的语言不应被之后的自动检测覆盖
+	currentLanguageOnce.Do(func() {})
+
 	languageMutex.Lock()
 	defer languageMutex.Unlock()
 	currentLanguage = lang
 }
 
+// ParseLanguage 解析语言代码，不支持的语言返回错误
+// 同时接受下划线形式和区域设置名称，例如pt_BR、zh、de-AT
+func ParseLanguage(code string) (Language, error) {
+	language := Language(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(code)), "_", "-"))
+	if _, ok := texts[language]; ok || language == PSEUDO {
+		return language, nil
+	}
+	if language, ok := localeLanguage(code); ok {
+		return language, nil
+	}
+	return "", fmt.Errorf("unsupported language: %s", code)
+}
+
+// SetAppName 设置目标应用程序名称，用于Cursor衍生编辑器
+func SetAppName(name string) {
+	languageMutex.Lock()
+	defer languageMutex.Unlock()
+	if name == "" {
+		name = defaultAppName
+	}
+	appName = name
+}
+
 // GetText 返回当前语言的文本资源
+// 尚未翻译的文本逐条按回退链查找，例如繁体中文依次使用简体中文和英文
+// 伪本地化语言由英文转换而来；用户在工具配置文件中覆盖的文本最后合并
 func GetText() TextResource {
-	return texts[GetCurrentLanguage()]
+	language := GetCurrentLanguage()
+	if language == PSEUDO {
+		return withOverrides(pseudoText(withAppName(texts[EN])))
+	}
+
+	chain := fallbackChain(language)
+	text := texts[chain[0]]
+	for _, language := range chain[1:] {
+		text = withFallback(text, texts[language])
+	}
+	return withOverrides(withAppName(text))
+}
+
+// Translation 返回语言自身包含的文本资源，不做回退和应用程序名称替换
+// 未翻译的文本为空字符串，供提取工具检查缺少的翻译
+func Translation(language Language) TextResource {
+	return texts[language]
 }
 
+// Fallbacks 返回地区语言的回退链（不含英文），没有回退链的语言返回nil
+func (l Language) Fallbacks() []Language {
+	return fallbacks[l]
+}
+
+// fallbackChain 返回查找文本的语言顺序：语言本身、其回退链，最后是英文
+func fallbackChain(language Language) []Language {
+	chain := append([]Language{language}, fallbacks[language]...)
+	if language != EN {
+		chain = append(chain, EN)
+	}
+	return chain
+}
+
+// withFallback 用fallback中的文本补全text中为空的文本
+func withFallback(text, fallback TextResource) TextResource {
+	value := reflect.ValueOf(&text).Elem()
+	fallbackValue := reflect.ValueOf(fallback)
+	for i := 0; i < value.NumField(); i++ {
+		if field := value.Field(i); field.Kind() == reflect.String && field.String() == "" {
+			field.SetString(fallbackValue.Field(i).String())
+		}
+	}
+	return text
+}
+
+// withAppName 将文本资源中的"Cursor"替换为当前目标应用程序名称
+func withAppName(text TextResource) TextResource {
+	languageMutex.RLock()
+	name := appName
+	languageMutex.RUnlock()
+	if name == defaultAppName {
+		return text
+	}
+
+	value := reflect.ValueOf(&text).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if field := value.Field(i); field.Kind() == reflect.String {
+			field.SetString(strings.ReplaceAll(field.String(), defaultAppName, name))
+		}
+	}
+	return text
+}
+
+// detectTimeout 检测操作系统区域设置的最长时间，超时后使用英文
+const detectTimeout = time.Second
+
 // detectLanguage 检测系统语言
 func detectLanguage() Language {
-	// 首先检查环境变量
-	if isChineseEnvVar() {
-		return CN
+	// 首先检查环境变量，不需要调用系统命令
+	if language, ok := envLanguage(); ok {
+		return language
 	}
 
-	// 然后检查特定操作系统的区域设置
-	if isWindows() {
-		if isWindowsChineseLocale() {
-			return CN
-		}
-	} else if isUnixChineseLocale() {
-		return CN
+	// 然后使用缓存的上次检测结果
+	if language, ok := cachedLanguage(); ok {
+		return language
 	}
 
-	return EN
+	// 最后检查特定操作系统的区域设置，只缓存按时完成的检测结果
+	language, completed := systemLanguage()
+	if completed {
+		saveCachedLanguage(language)
+	}
+	return language
 }
 
-// isChineseEnvVar 检查环境变量是否表明系统使用中文
-func isChineseEnvVar() bool {
-	for _, envVar := range []string{"LANG", "LANGUAGE", "LC_ALL"} {
-		if lang := os.Getenv(envVar); lang != "" && strings.Contains(strings.ToLower(lang), "zh") {
-			return true
+// systemLanguage 在限定时间内检测操作系统的区域设置，系统命令卡住时不会阻塞启动
+// 返回检测到的语言（未检测到或超时时为英文）以及检测是否按时完成
+func systemLanguage() (Language, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), detectTimeout)
+	defer cancel()
+
+	result := make(chan Language, 1)
+	go func() {
+		var language Language
+		var ok bool
+		if runtime.GOOS == "windows" {
+			language, ok = windowsLanguage()
+		} else {
+			language, ok = unixLanguage(ctx)
+		}
+		if !ok {
+			language = EN
 		}
+		result <- language
+	}()
+
+	select {
+	case language := <-result:
+		return language, true
+	case <-ctx.Done():
+		return EN, false
 	}
-	return false
 }
 
-// isWindows 判断当前操作系统是否为Windows
-func isWindows() bool {
-	return os.Getenv("OS") == "Windows_NT"
+// localeLanguage 将区域设置名称（例如zh_CN.UTF-8、ja-JP、pt_BR）映射为支持的语言
+func localeLanguage(locale string) (Language, bool) {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	primary, region, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	switch primary {
+	case "zh":
+		// 台湾、香港、澳门以及明确使用繁体字（zh-Hant）的区域使用繁体中文
+		switch region {
+		case "tw", "hk", "mo", "hant", "hant-tw", "hant-hk", "hant-mo":
+			return ZHTW, true
+		}
+		return CN, true
+	case "en":
+		return EN, true
+	case "ja":
+		return JA, true
+	case "ko":
+		return KO, true
+	case "ru":
+		return RU, true
+	case "de":
+		return DE, true
+	case "fr":
+		return FR, true
+	case "es":
+		return ES, true
+	case "pt":
+		if region == "pt" {
+			return PTPT, true
+		}
+		return PTBR, true
+	case "ar":
+		return AR, true
+	case "he", "iw":
+		// iw是希伯来语旧的语言代码，部分Java和Android环境仍在使用
+		return HE, true
+	}
+	return "", false
 }
 
-// isWindowsChineseLocale 检查Windows系统是否使用中文区域设置
-func isWindowsChineseLocale() bool {
-	// 检查Windows UI文化设置
-	cmd := exec.Command("powershell", "-Command",
-		"[System.Globalization.CultureInfo]::CurrentUICulture.Name")
-	output, err := cmd.Output()
-	if err == nil && strings.HasPrefix(strings.ToLower(strings.TrimSpace(string(output))), "zh") {
-		return true
+// envLanguage 根据环境变量判断系统语言
+func envLanguage() (Language, bool) {
+	for _, envVar := range []string{"LANG", "LANGUAGE", "LC_ALL"} {
+		// LANGUAGE可以是冒号分隔的列表，取第一个
+		value, _, _ := strings.Cut(os.Getenv(envVar), ":")
+		if language, ok := localeLanguage(value); ok {
+			return language, true
+		}
 	}
-
-	// 检查Windows区域设置
-	cmd = exec.Command("wmic", "os", "get", "locale")
-	output, err = cmd.Output()
-	return err == nil && strings.Contains(string(output), "2052")
+	return "", false
 }
 
-// isUnixChineseLocale 检查Unix系统是否使用中文区域设置
-func isUnixChineseLocale() bool {
-	cmd := exec.Command("locale")
-	output, err := cmd.Output()
-	return err == nil && strings.Contains(strings.ToLower(string(output)), "zh_cn")
+// unixLanguage 根据locale命令输出的LC_MESSAGES或LANG判断系统语言
+func unixLanguage(ctx context.Context) (Language, bool) {
+	output, err := exec.CommandContext(ctx, "locale").Output()
+	if err != nil {
+		return "", false
+	}
+	values := map[string]string{}
+	for _, line := range strings.Split(string(output), "\n") {
+		if name, value, ok := strings.Cut(line, "="); ok {
+			values[name] = strings.Trim(value, `"`)
+		}
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if language, ok := localeLanguage(values[name]); ok {
+			return language, true
+		}
+	}
+	return "", false
 }
 
 // texts 包含所有翻译文本
 var texts = map[Language]TextResource{
 	CN: {
 		// 成功消息
-		SuccessMessage: "[√] 配置文件已成功更新！",
-		RestartMessage: "[!] 请手动重启 Cursor 以使更新生效",
+		SuccessMessage:     "[√] 配置文件已成功更新！",
+		RestartMessage:     "[!] 请手动重启 Cursor 以使更新生效",
+		OperationCompleted: "操作完成！",
+
+		// 版本信息
+		VersionInfo: "Cursor ID Modifier 版本 {{.Version}}",
+
+		// 无障碍模式消息
+		AccessibleStepStarted:  "第 {{.Step}} 步，共 {{.Total}} 步：{{.Title}}",
+		AccessibleStepFinished: "第 {{.Step}} 步已完成，共 {{.Total}} 步，用时 {{.Elapsed}}。",
+		AccessibleStepFailed:   "第 {{.Step}} 步失败，共 {{.Total}} 步。",
+		AccessibleFinished:     "已完成，用时 {{.Elapsed}}。",
+		AccessibleFailed:       "失败。",
+		AccessibleSuccess:      "成功：",
+		AccessibleWarning:      "警告：",
+		AccessibleError:        "错误：",
+
+		// 标识符表格列标题
+		TableField: "字段",
+		TableOld:   "旧值",
+		TableNew:   "新值",
+		TableFile:  "文件",
+
+		// 提问消息
+		PromptYesHint:       "(Y/n)",
+		PromptNoHint:        "(y/N)",
+		PromptYes:           "是",
+		PromptNo:            "否",
+		PromptInvalidChoice: "请输入 1 到 {{.Count}} 之间的序号。",
+		PromptTimedOut:      "{{.Timeout}} 内没有输入，使用默认值。",
+
+		// 运行摘要消息
+		SummaryTitle:      "运行摘要",
+		SummaryFiles:      "修改的文件",
+		SummaryBackup:     "备份",
+		SummaryIDs:        "修改的标识符",
+		SummaryIDsValue:   "{{.Changed}}/{{.Total}} 个（{{.Fields}}）",
+		SummaryProtection: "写保护",
+		SummaryProcesses:  "关闭的进程",
+		SummaryTime:       "总耗时",
+		SummaryNone:       "无",
+
+		// 启动菜单消息
+		MenuTitle:        "请选择要执行的操作",
+		MenuModify:       "修改标识符",
+		MenuStatus:       "查看当前状态",
+		MenuRestore:      "从备份恢复",
+		MenuLock:         "为 storage.json 加写保护",
+		MenuUnlock:       "移除 storage.json 的写保护",
+		MenuLanguage:     "切换语言 (Language)",
+		MenuExit:         "退出",
+		MenuPrompt:       "请输入序号",
+		MenuBackupPrompt: "请选择要恢复的备份",
+		MenuNoBackups:    "备份目录中没有找到备份：{{.Dir}}",
+		LanguagePrompt:   "请选择界面语言",
+		LanguageSaved:    "界面语言已保存到 {{.Path}}",
+		StatusTitle:      "当前状态",
+		StatusConfig:     "配置文件",
+		StatusMissing:    "（不存在）",
+		StatusProcesses:  "运行中的进程",
+		LockSuccess:      "[√] 已为 storage.json 加写保护（{{.Level}}）",
 
 		// 进度消息
 		ReadingConfig:     "正在读取配置文件...",
 		GeneratingIds:     "正在生成新的标识符...",
+		SavingConfig:      "正在备份并保存配置...",
+		VerifyingConfig:   "正在验证写入结果...",
 		CheckingProcesses: "正在检查运行中的 Cursor 实例...",
 		ClosingProcesses:  "正在关闭 Cursor 实例...",
+		ClosingRemaining:  "正在关闭 {{.Total}} 个进程... 剩余 {{.Remaining}} 个",
 		ProcessesClosed:   "所有 Cursor 实例已关闭",
 		PleaseWait:        "请稍候...",
 
 		// 错误消息
-		ErrorPrefix:    "程序发生严重错误: %v",
-		PrivilegeError: "\n[!] 错误：需要管理员权限",
+		ErrorPrefix:     "程序发生严重错误: {{.Error}}",
+		PrivilegeError:  "\n[!] 错误：需要管理员权限",
+		CloseFailed:     "{{.App}} 关闭失败，请手动关闭后重试",
+		CloseIncomplete: "{{.App}} 未能完全关闭，请手动关闭后重试",
 
 		// 指令提示
-		RunAsAdmin:         "请右键点击程序，选择「以管理员身份运行」",
-		RunWithSudo:        "请使用 sudo 命令运行此程序",
-		SudoExample:        "示例: sudo %s",
-		PressEnterToExit:   "\n按回车键退出程序...",
-		SetReadOnlyMessage: "设置 storage.json 为只读模式, 这将导致 workspace 记录信息丢失等问题",
+		RunAsAdmin:           "请右键点击程序，选择「以管理员身份运行」",
+		RunWithSudo:          "请使用 sudo 命令运行此程序",
+		SudoExample:          "示例: sudo {{.Executable}}",
+		PressEnterToExit:     "\n按回车键退出程序...",
+		RequestingPrivileges: "\n请求管理员权限...",
+		SetReadOnlyMessage:   "设置 storage.json 为只读模式, 这将导致 workspace 记录信息丢失等问题",
 
 		// 信息消息
 		ConfigLocation: "配置文件位置:",
+
+		// 写保护消息
+		StrongProtectionWarning: "[!] 已启用系统级写保护，Cursor 将无法更新 storage.json，如需恢复请使用 -unlock 参数运行",
+		UnlockSuccess:           "[√] 已移除 storage.json 的写保护",
+
+		// 守护模式消息
+		GuardReapplied: "[!] 检测到 Cursor 改写了标识符，已重新写入: {{.Keys}}",
+		GuardUnchanged: "[√] 标识符未被改写",
+		GuardWatching:  "正在监视 storage.json，按 Ctrl+C 停止...",
+
+		// 备份消息
+		BackupCreated:     "配置已备份到: {{.Path}}",
+		RestoreSuccess:    "[√] 已从备份恢复配置: {{.Path}}",
+		RestoreInvalid:    "[!] 备份内容未通过校验：",
+		RestoreDiffHeader: "恢复后 storage.json 将发生以下变化：",
+		RestoreNoChanges:  "备份内容与当前配置相同，无需恢复",
+		RestorePrompt:     "确认恢复？",
+
+		// 快照消息
+		SnapshotCreated:  "[√] globalStorage 快照已保存到: {{.Path}}",
+		SnapshotRestored: "[√] 已从快照恢复 globalStorage: {{.Path}}",
+
+		// workspaceStorage清理消息
+		WorkspaceStorageHeader:  "工作区存储目录: {{.Dir}}",
+		WorkspaceStorageTotal:   "共 {{.Count}} 个工作区，合计 {{.Size}}",
+		WorkspaceStoragePrompt:  "请输入要删除的序号（如 1,3,5-7），输入 all 删除全部，直接回车跳过",
+		WorkspaceStorageCleared: "[√] 已删除 {{.Count}} 个工作区，释放 {{.Size}}",
+		WorkspaceStorageEmpty:   "没有需要清理的工作区存储",
+
+		// 登录状态清理消息
+		SignOutSuccess: "[√] 已清除 Cursor 登录状态（删除 {{.Count}} 个登录键）",
+		SignOutBackup:  "登录数据已备份到: {{.Path}}",
+
+		// hosts文件遥测屏蔽消息
+		TelemetryBlocked:        "[√] 已在 {{.Path}} 中屏蔽 {{.Count}} 个遥测域名",
+		TelemetryAlreadyBlocked: "{{.Path}} 中已屏蔽遥测域名",
+		TelemetryUnblocked:      "[√] 已从 {{.Path}} 中移除遥测屏蔽条目",
+		TelemetryNotBlocked:     "{{.Path}} 中没有需要移除的遥测屏蔽条目",
+		HostsBackupCreated:      "hosts 文件已备份到: {{.Path}}",
+
+		// Windows防火墙规则消息
+		FirewallRulesCreated: "[√] 已创建 {{.Count}} 条阻止遥测连接的出站防火墙规则",
+		FirewallRulesRemoved: "[√] 已移除 {{.Count}} 条防火墙规则",
+		FirewallNoRules:      "没有本工具创建的防火墙规则",
+		FirewallStatusTitle:  "遥测防火墙规则",
+		FirewallRuleDisabled: "（已停用）",
+
+		// HTTP API消息
+		ServeListening:     "HTTP API 正在监听 http://{{.Address}}（按 Ctrl+C 停止）",
+		ServeGRPCListening: "gRPC 服务正在监听 {{.Address}}",
+		ServeToken:         "访问令牌: {{.Token}}",
+		ServeNotLoopback:   "[!] API 可以从其他计算机访问，任何持有令牌的人都可以在本机上重置标识符",
+
+		// SSH批量处理消息
+		FleetStarting:    "正在通过 SSH 处理 {{.Inventory}} 中的 {{.Count}} 台主机...",
+		FleetHostUpdated: "[√] {{.Host}}: 已更新 {{.Path}}",
+		FleetHostFailed:  "[×] {{.Host}}: {{.Error}}",
+		FleetSummary:     "{{.Total}} 台主机中有 {{.Succeeded}} 台已更新",
+
+		// 审计日志消息
+		AuditVerified:      "[√] 审计日志完整：{{.Path}} 中的 {{.Count}} 条记录均通过校验",
+		AuditHead:          "最后一条记录: {{.Time}}，哈希 {{.Hash}}",
+		AuditEmpty:         "审计日志 {{.Path}} 中还没有记录",
+		AuditIntactEntries: "[!] 出错位置之前的 {{.Count}} 条记录完整",
+
+		// 并发修改消息
+		ConcurrentModification: "[!] 运行期间 storage.json 被其他进程（可能是 Cursor 或其更新程序）修改，已中止写入以免覆盖新数据",
+		RetryPrompt:            "是否重新读取并重试？",
+
+		// 已有写保护消息
+		WriteProtectedDetected: "[!] storage.json 已被之前的运行设置了写保护（{{.Level}}）",
+		LiftProtectionPrompt:   "是否临时移除写保护以写入新的标识符？写入后会按本次设置重新施加保护",
+
+		// OneDrive重定向消息
+		OneDriveDetected:       "[!] Cursor 的配置目录位于 OneDrive 同步文件夹中（{{.Dir}}），同步可能会覆盖新的标识符",
+		OneDriveAdvice:         "建议在修改期间暂停 OneDrive 同步，或使用 -pause-onedrive 参数自动暂停",
+		OneDrivePaused:         "已暂时关闭 OneDrive，修改完成后会重新启动",
+		OneDriveConflictCopies: "[!] 发现 OneDrive 同步冲突副本，请确认后删除：{{.Files}}",
+
+		// 数据目录扫描消息
+		DiscoverHeader:   "找到 {{.Count}} 个 storage.json（按修改时间从新到旧）：",
+		DiscoverEmpty:    "未找到任何 storage.json",
+		DiscoverPrompt:   "输入序号将其设为默认目标，直接回车跳过",
+		DiscoverSelected: "已将 {{.Path}} 保存为默认目标",
+
+		// 文件锁定消息
+		FileLocked: "[!] storage.json 正被其他进程锁定，可能是 Cursor 的后台更新程序或另一个正在运行的本工具，请关闭后重试",
+
+		// 备份集消息
+		BackupSetCreated: "[√] 已将 {{.Count}} 个文件打包为备份集: {{.Path}}",
+
+		// 标识符校验消息
+		VerifyHeader: "校验 {{.Path}} 中的标识符：",
+		VerifyPassed: "[√] 所有标识符格式有效",
+
+		// 自检消息
+		SelfTestRunning: "正在生成 {{.Count}} 组标识符并检查随机性...",
+		SelfTestPassed:  "[√] 自检通过，可以安全地生成标识符",
+
+		// 其他用户的进程消息
+		OtherUserProcesses:    "[!] 以下 Cursor 进程属于其他用户账户：",
+		OtherUserRefused:      "这些进程可能正在使用其他用户的配置，已停止操作。确认要关闭它们时请加上 -force 参数重新运行",
+		OtherUserProcessEntry: "  PID {{.PID}}  {{.Name}}（用户 {{.User}}）",
+
+		// 工作区消息
+		WorkspacesOpen:           "关闭 Cursor 前打开了以下工作区：",
+		RelaunchWorkspacesPrompt: "是否重新打开这 {{.Count}} 个工作区？",
+		WorkspacesRelaunched:     "[√] 已重新打开 {{.Count}} 个工作区",
+		CursorRestarted:          "[√] 已重新启动 {{.App}}",
+
+		// 未保存修改消息
+		UnsavedWorkWarning: "[!] Cursor 中有 {{.Count}} 个编辑器包含未保存的修改，强制关闭可能丢失这些修改，建议先保存",
+		UnsavedWorkPrompt:  "仍然关闭 Cursor 并继续？",
+
+		// 进程列表消息
+		ProcessListHeader: "关闭 {{.App}} 时将终止以下 {{.Count}} 个进程：",
+		ProcessListEmpty:  "[√] 没有找到需要关闭的 {{.App}} 进程",
+		ProcessListEntry:  "  PID {{.PID}} PPID {{.PPID}} {{.User}} {{.Started}}  {{.Name}}",
+		ProcessListDaemon: "{{.Name}}（守护进程）",
+
+		// 文件占用消息
+		FileInUse:     "[!] 以下进程仍打开着 {{.File}}，写入的修改可能被覆盖：",
+		FileInUseHint: "请关闭这些进程后重试",
+
+		// 提升权限的进程消息
+		ElevatedProcess: "[!] {{.App}} 的进程 {{.PID}} 以更高的权限（{{.Level}}）运行，当前权限无法关闭。请以管理员身份（Windows）或使用 sudo（macOS/Linux）运行本工具，或手动关闭它",
+
+		// 远程服务器消息
+		ServerMachineIDReset:   "[√] 已重置远程服务器的 machineid",
+		ServerMachineIDMissing: "[!] 没有找到远程服务器的 machineid 文件，已跳过",
+
+		// 全屏界面消息
+		TUITitle:           "Cursor ID 修改器",
+		TUIMenuReset:       "重置设备标识",
+		TUIMenuProcesses:   "查看运行中的 Cursor 进程",
+		TUIMenuQuit:        "退出",
+		TUIProcessesHeader: "运行中的 Cursor 进程（每秒刷新）：",
+		TUINoProcesses:     "没有运行中的 Cursor 进程",
+		TUIChangesHeader:   "标识符变化：",
+		TUIOldValue:        "旧",
+		TUINewValue:        "新",
+		TUISucceeded:       "[√] 完成，请重新启动 Cursor 以使用新的标识",
+		TUIFailed:          "[×] 操作未完成，请查看上方失败的步骤",
+		TUIMenuHelp:        "↑/↓ 选择 · Enter 确认 · l 语言 · q 退出",
+		TUIBackHelp:        "Enter/Esc 返回菜单 · Ctrl+C 退出",
+		TUIRunningHelp:     "正在执行… · Ctrl+C 中止",
+		TUIStepClose:       "关闭 Cursor",
+		TUIStepCheckFile:   "检查 storage.json 是否被占用",
+		TUIStepRead:        "读取现有配置",
+		TUIStepGenerate:    "生成新的标识符",
+		TUIStepSave:        "备份并保存配置",
+		TUIStepVerify:      "验证写入结果",
 	},
 	EN: {
 		// 成功消息
-		SuccessMessage: "[√] Configuration file updated successfully!",
-		RestartMessage: "[!] Please restart Cursor manually for changes to take effect",
+		SuccessMessage:     "[√] Configuration file updated successfully!",
+		RestartMessage:     "[!] Please restart Cursor manually for changes to take effect",
+		OperationCompleted: "Operation completed!",
+
+		// 版本信息
+		VersionInfo: "Cursor ID Modifier v{{.Version}}",
+
+		// 无障碍模式消息
+		AccessibleStepStarted:  "Step {{.Step}} of {{.Total}}: {{.Title}}",
+		AccessibleStepFinished: "Step {{.Step}} of {{.Total}} finished in {{.Elapsed}}.",
+		AccessibleStepFailed:   "Step {{.Step}} of {{.Total}} failed.",
+		AccessibleFinished:     "Done in {{.Elapsed}}.",
+		AccessibleFailed:       "Failed.",
+		AccessibleSuccess:      "Success: ",
+		AccessibleWarning:      "Warning: ",
+		AccessibleError:        "Error: ",
+
+		// 标识符表格列标题
+		TableField: "Field",
+		TableOld:   "Old value",
+		TableNew:   "New value",
+		TableFile:  "File",
+
+		// 提问消息
+		PromptYesHint:       "(Y/n)",
+		PromptNoHint:        "(y/N)",
+		PromptYes:           "yes",
+		PromptNo:            "no",
+		PromptInvalidChoice: "Please enter a number from 1 to {{.Count}}.",
+		PromptTimedOut:      "No answer within {{.Timeout}}, using the default.",
+
+		// 运行摘要消息
+		SummaryTitle:      "Summary",
+		SummaryFiles:      "Files modified",
+		SummaryBackup:     "Backup",
+		SummaryIDs:        "IDs changed",
+		SummaryIDsValue:   "{{.Changed}} of {{.Total}} ({{.Fields}})",
+		SummaryProtection: "Write protection",
+		SummaryProcesses:  "Processes closed",
+		SummaryTime:       "Total time",
+		SummaryNone:       "none",
+
+		// 启动菜单消息
+		MenuTitle:        "What would you like to do?",
+		MenuModify:       "Modify IDs",
+		MenuStatus:       "Show status",
+		MenuRestore:      "Restore a backup",
+		MenuLock:         "Write-protect storage.json",
+		MenuUnlock:       "Remove write protection from storage.json",
+		MenuLanguage:     "Language",
+		MenuExit:         "Exit",
+		MenuPrompt:       "Enter a number",
+		MenuBackupPrompt: "Choose the backup to restore",
+		MenuNoBackups:    "No backups found in {{.Dir}}",
+		LanguagePrompt:   "Choose the interface language",
+		LanguageSaved:    "Language saved to {{.Path}}",
+		StatusTitle:      "Current status",
+		StatusConfig:     "Config file",
+		StatusMissing:    "(missing)",
+		StatusProcesses:  "Running processes",
+		LockSuccess:      "[√] storage.json is now write-protected ({{.Level}})",
 
 		// 进度消息
 		ReadingConfig:     "Reading configuration file...",
 		GeneratingIds:     "Generating new identifiers...",
+		SavingConfig:      "Backing up and saving configuration...",
+		VerifyingConfig:   "Verifying the written file...",
 		CheckingProcesses: "Checking for running Cursor instances...",
 		ClosingProcesses:  "Closing Cursor instances...",
+		ClosingRemaining:  "Closing {{.Total}} {{plural .Total one \"process\" other \"processes\"}}... {{.Remaining}} remaining",
 		ProcessesClosed:   "All Cursor instances have been closed",
 		PleaseWait:        "Please wait...",
 
 		// 错误消息
-		ErrorPrefix:    "Program encountered a serious error: %v",
-		PrivilegeError: "\n[!] Error: Administrator privileges required",
+		ErrorPrefix:     "Program encountered a serious error: {{.Error}}",
+		PrivilegeError:  "\n[!] Error: Administrator privileges required",
+		CloseFailed:     "Failed to close {{.App}}. Please close it manually and try again.",
+		CloseIncomplete: "Failed to close {{.App}} completely. Please close it manually and try again.",
 
 		// 指令提示
-		RunAsAdmin:         "Please right-click and select 'Run as Administrator'",
-		RunWithSudo:        "Please run this program with sudo",
-		SudoExample:        "Example: sudo %s",
-		PressEnterToExit:   "\nPress Enter to exit...",
-		SetReadOnlyMessage: "Set storage.json to read-only mode, which will cause issues such as lost workspace records",
+		RunAsAdmin:           "Please right-click and select 'Run as Administrator'",
+		RunWithSudo:          "Please run this program with sudo",
+		SudoExample:          "Example: sudo {{.Executable}}",
+		PressEnterToExit:     "\nPress Enter to exit...",
+		RequestingPrivileges: "\nRequesting administrator privileges...",
+		SetReadOnlyMessage:   "Set storage.json to read-only mode, which will cause issues such as lost workspace records",
 
 		// 信息消息
 		ConfigLocation: "Config file location:",
+
+		// 写保护消息
+		StrongProtectionWarning: "[!] Strong write protection enabled, Cursor can no longer update storage.json. Run with -unlock to revert",
+		UnlockSuccess:           "[√] Write protection removed from storage.json",
+
+		// 守护模式消息
+		GuardReapplied: "[!] Cursor rewrote the identifiers, re-applied: {{.Keys}}",
+		GuardUnchanged: "[√] Identifiers are unchanged",
+		GuardWatching:  "Watching storage.json, press Ctrl+C to stop...",
+
+		// 备份消息
+		BackupCreated:     "Configuration backed up to: {{.Path}}",
+		RestoreSuccess:    "[√] Configuration restored from backup: {{.Path}}",
+		RestoreInvalid:    "[!] The backup failed validation:",
+		RestoreDiffHeader: "Restoring will make the following changes to storage.json:",
+		RestoreNoChanges:  "The backup matches the current configuration, nothing to restore",
+		RestorePrompt:     "Restore now?",
+
+		// 快照消息
+		SnapshotCreated:  "[√] globalStorage snapshot saved to: {{.Path}}",
+		SnapshotRestored: "[√] globalStorage restored from snapshot: {{.Path}}",
+
+		// workspaceStorage清理消息
+		WorkspaceStorageHeader:  "Workspace storage directory: {{.Dir}}",
+		WorkspaceStorageTotal:   "{{.Count}} {{plural .Count one \"workspace\" other \"workspaces\"}}, {{.Size}} in total",
+		WorkspaceStoragePrompt:  "Enter the numbers to delete (e.g. 1,3,5-7), type all to delete all, or press Enter to skip",
+		WorkspaceStorageCleared: "[√] Removed {{.Count}} {{plural .Count one \"workspace\" other \"workspaces\"}}, freed {{.Size}}",
+		WorkspaceStorageEmpty:   "No workspace storage to clean up",
+
+		// 登录状态清理消息
+		SignOutSuccess: "[√] Cursor login state cleared ({{.Count}} {{plural .Count one \"auth key\" other \"auth keys\"}} removed)",
+		SignOutBackup:  "Login data backed up to: {{.Path}}",
+
+		// hosts文件遥测屏蔽消息
+		TelemetryBlocked:        "[√] Blocked {{.Count}} telemetry {{plural .Count one \"domain\" other \"domains\"}} in {{.Path}}",
+		TelemetryAlreadyBlocked: "Telemetry domains are already blocked in {{.Path}}",
+		TelemetryUnblocked:      "[√] Removed the telemetry block from {{.Path}}",
+		TelemetryNotBlocked:     "{{.Path}} has no telemetry block to remove",
+		HostsBackupCreated:      "Hosts file backed up to: {{.Path}}",
+
+		// Windows防火墙规则消息
+		FirewallRulesCreated: "[√] Created {{.Count}} outbound firewall {{plural .Count one \"rule\" other \"rules\"}} blocking telemetry",
+		FirewallRulesRemoved: "[√] Removed {{.Count}} firewall {{plural .Count one \"rule\" other \"rules\"}}",
+		FirewallNoRules:      "No firewall rules created by this tool were found",
+		FirewallStatusTitle:  "Telemetry firewall rules",
+		FirewallRuleDisabled: "(disabled)",
+
+		// HTTP API消息
+		ServeListening:     "HTTP API listening on http://{{.Address}} (press Ctrl+C to stop)",
+		ServeGRPCListening: "gRPC service listening on {{.Address}}",
+		ServeToken:         "Access token: {{.Token}}",
+		ServeNotLoopback:   "[!] The API is reachable from other machines; anyone with the token can reset the identifiers on this computer",
+
+		// SSH批量处理消息
+		FleetStarting:    "Updating {{.Count}} hosts from {{.Inventory}} over SSH...",
+		FleetHostUpdated: "[√] {{.Host}}: updated {{.Path}}",
+		FleetHostFailed:  "[×] {{.Host}}: {{.Error}}",
+		FleetSummary:     "{{.Succeeded}} of {{.Total}} hosts updated",
+
+		// 审计日志消息
+		AuditVerified:      "[√] Audit log intact: all {{.Count}} entries in {{.Path}} verified",
+		AuditHead:          "Last entry: {{.Time}}, hash {{.Hash}}",
+		AuditEmpty:         "Audit log {{.Path}} has no entries yet",
+		AuditIntactEntries: "[!] The {{.Count}} entries before the error are intact",
+
+		// 并发修改消息
+		ConcurrentModification: "[!] storage.json was modified by another process (possibly Cursor or its updater) during the run, the write was aborted to avoid clobbering fresh data",
+		RetryPrompt:            "Re-read the file and retry?",
+
+		// 已有写保护消息
+		WriteProtectedDetected: "[!] storage.json is write-protected by a previous run ({{.Level}})",
+		LiftProtectionPrompt:   "Temporarily remove the protection to write the new identifiers? The requested protection is re-applied afterwards.",
+
+		// OneDrive重定向消息
+		OneDriveDetected:       "[!] Cursor's data folder is inside a OneDrive synced folder ({{.Dir}}); syncing may overwrite the new identifiers",
+		OneDriveAdvice:         "Consider pausing OneDrive sync during the modification, or use -pause-onedrive to pause it automatically",
+		OneDrivePaused:         "OneDrive has been closed temporarily and will be restarted afterwards",
+		OneDriveConflictCopies: "[!] OneDrive sync conflict copies found, please review and delete them: {{.Files}}",
+
+		// 数据目录扫描消息
+		DiscoverHeader:   "Found {{.Count}} storage.json {{plural .Count one \"file\" other \"files\"}} (newest first):",
+		DiscoverEmpty:    "No storage.json found",
+		DiscoverPrompt:   "Enter a number to make it the default target, or press Enter to skip",
+		DiscoverSelected: "Saved {{.Path}} as the default target",
+
+		// 文件锁定消息
+		FileLocked: "[!] storage.json is locked by another process, probably Cursor's background updater or another running instance of this tool; close it and try again",
+
+		// 备份集消息
+		BackupSetCreated: "[√] Packed {{.Count}} {{plural .Count one \"file\" other \"files\"}} into backup set: {{.Path}}",
+
+		// 标识符校验消息
+		VerifyHeader: "Checking identifiers in {{.Path}}:",
+		VerifyPassed: "[√] All identifiers are structurally valid",
+
+		// 自检消息
+		SelfTestRunning: "Generating {{.Count}} ID sets and checking randomness...",
+		SelfTestPassed:  "[√] Self-test passed, IDs can be generated safely",
+
+		// 其他用户的进程消息
+		OtherUserProcesses:    "[!] The following Cursor processes belong to another user account:",
+		OtherUserRefused:      "They may be using another user's configuration, so nothing was changed. Run again with -force to close them anyway",
+		OtherUserProcessEntry: "  PID {{.PID}}  {{.Name}}  ({{.User}})",
+
+		// 工作区消息
+		WorkspacesOpen:           "These workspaces were open before Cursor was closed:",
+		RelaunchWorkspacesPrompt: "Reopen {{plural .Count one \"this workspace\" other \"these {{.Count}} workspaces\"}}?",
+		WorkspacesRelaunched:     "[√] Reopened {{.Count}} {{plural .Count one \"workspace\" other \"workspaces\"}}",
+		CursorRestarted:          "[√] {{.App}} has been restarted",
+
+		// 未保存修改消息
+		UnsavedWorkWarning: "[!] Cursor has {{.Count}} {{plural .Count one \"editor\" other \"editors\"}} with unsaved changes; force-closing it may lose them, so save them first",
+		UnsavedWorkPrompt:  "Close Cursor and continue anyway?",
+
+		// 进程列表消息
+		ProcessListHeader: "Closing {{.App}} would terminate {{plural .Count one \"this process\" other \"these {{.Count}} processes\"}}:",
+		ProcessListEmpty:  "[√] No {{.App}} processes would be closed",
+		ProcessListEntry:  "  PID {{.PID}} PPID {{.PPID}} {{.User}} {{.Started}}  {{.Name}}",
+		ProcessListDaemon: "{{.Name}} (daemon)",
+
+		// 文件占用消息
+		FileInUse:     "[!] These processes still have {{.File}} open and may overwrite the changes:",
+		FileInUseHint: "Close them and try again",
+
+		// 提升权限的进程消息
+		ElevatedProcess: "[!] {{.App}} process {{.PID}} runs with higher privileges ({{.Level}}) and cannot be closed from here. Run this tool as administrator (Windows) or with sudo (macOS/Linux), or close it manually",
+
+		// 远程服务器消息
+		ServerMachineIDReset:   "[√] The remote server's machineid has been reset",
+		ServerMachineIDMissing: "[!] The remote server has no machineid file, skipped",
+
+		// 全屏界面消息
+		TUITitle:           "Cursor ID Modifier",
+		TUIMenuReset:       "Reset device identifiers",
+		TUIMenuProcesses:   "Show running Cursor processes",
+		TUIMenuQuit:        "Quit",
+		TUIProcessesHeader: "Running Cursor processes (refreshed every second):",
+		TUINoProcesses:     "No Cursor processes are running",
+		TUIChangesHeader:   "Identifier changes:",
+		TUIOldValue:        "old",
+		TUINewValue:        "new",
+		TUISucceeded:       "[√] Done. Restart Cursor to use the new identifiers",
+		TUIFailed:          "[×] Not completed, see the failed step above",
+		TUIMenuHelp:        "↑/↓ select · Enter confirm · l language · q quit",
+		TUIBackHelp:        "Enter/Esc back to menu · Ctrl+C quit",
+		TUIRunningHelp:     "Working… · Ctrl+C abort",
+		TUIStepClose:       "Close Cursor",
+		TUIStepCheckFile:   "Check that storage.json is not in use",
+		TUIStepRead:        "Read the existing configuration",
+		TUIStepGenerate:    "Generate new identifiers",
+		TUIStepSave:        "Back up and save the configuration",
+		TUIStepVerify:      "Verify the written file",
 	},
+	JA:   jaText,
+	KO:   koText,
+	RU:   ruText,
+	DE:   deText,
+	FR:   frText,
+	ES:   esText,
+	PTBR: ptBRText,
+	ZHTW: zhTWText,
+	PTPT: ptPTText,
+	AR:   arText,
+	HE:   heText,
 }
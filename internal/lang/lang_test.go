@@ -0,0 +1,52 @@
+package lang
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+)
+
+// usedNamePattern 匹配文本中的{{.Name}}占位符和复数占位符使用的数量
+var usedNamePattern = regexp.MustCompile(`\{\{\s*(?:plural\s+)?\.(\w+)`)
+
+// placeholders 返回文本中使用的占位符名称
+func placeholders(message string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, match := range usedNamePattern.FindAllStringSubmatch(message, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 地区语言只包含与回退语言不同的文本，其他语言必须翻译全部文本，并使用与英文相同的占位符
+func TestTranslationsComplete(t *testing.T) {
+	english := reflect.ValueOf(texts[EN])
+	fields := english.Type()
+	for language, text := range texts {
+		if _, regional := fallbacks[language]; regional || language == EN {
+			continue
+		}
+		value := reflect.ValueOf(text)
+		for i := 0; i < fields.NumField(); i++ {
+			if fields.Field(i).Type.Kind() != reflect.String {
+				continue
+			}
+			name := fields.Field(i).Name
+			translated := value.Field(i).String()
+			if translated == "" {
+				t.Errorf("%s: %s is not translated", language, name)
+				continue
+			}
+			want, got := placeholders(english.Field(i).String()), placeholders(translated)
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("%s: %s uses placeholders %v, want %v", language, name, got, want)
+			}
+		}
+	}
+}
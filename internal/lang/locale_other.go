@@ -0,0 +1,8 @@
+//go:build !windows
+
+package lang
+
+// windowsLanguage 非Windows系统上的空实现
+func windowsLanguage() (Language, bool) {
+	return "", false
+}
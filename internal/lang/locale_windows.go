@@ -0,0 +1,31 @@
+package lang
+
+import (
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+// windowsLanguage 根据Windows的界面语言判断系统语言
+// 直接调用系统API和读取注册表，不依赖PowerShell或已废弃的wmic
+func windowsLanguage() (Language, bool) {
+	// 用户首选的界面语言，按优先级排列，例如["ja-JP", "en-US"]
+	if languages, err := windows.GetUserPreferredUILanguages(windows.MUI_LANGUAGE_NAME); err == nil {
+		for _, name := range languages {
+			if language, ok := localeLanguage(name); ok {
+				return language, true
+			}
+		}
+	}
+
+	// 个别精简系统上API不可用时读取用户的区域设置
+	key, err := registry.OpenKey(registry.CURRENT_USER, `Control Panel\International`, registry.QUERY_VALUE)
+	if err != nil {
+		return "", false
+	}
+	defer key.Close()
+	name, _, err := key.GetStringValue("LocaleName")
+	if err != nil {
+		return "", false
+	}
+	return localeLanguage(name)
+}
@@ -0,0 +1,53 @@
+// 语言包，提供多语言支持功能
+package lang
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// overrides 用户在工具配置文件中覆盖的文本，键为TextResource的字段名，由languageMutex保护
+var overrides map[string]string
+
+// SetOverrides 设置覆盖内置文本的文本，键为TextResource的字段名（例如SudoExample），对所有语言生效
+// 覆盖的文本按原样显示，不做回退和应用程序名称替换，但可以使用与内置文本相同的占位符
+// 名称未知时返回错误，其余名称有效的文本仍然生效
+func SetOverrides(messages map[string]string) error {
+	resourceType := reflect.TypeOf(TextResource{})
+	valid := make(map[string]string, len(messages))
+	var unknown []string
+	for name, message := range messages {
+		if field, ok := resourceType.FieldByName(name); !ok || field.Type.Kind() != reflect.String {
+			unknown = append(unknown, name)
+			continue
+		}
+		valid[name] = message
+	}
+
+	languageMutex.Lock()
+	overrides = valid
+	languageMutex.Unlock()
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown message names: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// withOverrides 将用户覆盖的文本合并到文本资源上
+func withOverrides(text TextResource) TextResource {
+	languageMutex.RLock()
+	defer languageMutex.RUnlock()
+	if len(overrides) == 0 {
+		return text
+	}
+
+	value := reflect.ValueOf(&text).Elem()
+	for name, message := range overrides {
+		value.FieldByName(name).SetString(message)
+	}
+	return text
+}
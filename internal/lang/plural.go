@@ -0,0 +1,135 @@
+// 语言包，提供多语言支持功能
+package lang
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 复数类别，与CLDR的类别名称一致
+const (
+	pluralZero  = "zero"
+	pluralOne   = "one"
+	pluralTwo   = "two"
+	pluralFew   = "few"
+	pluralMany  = "many"
+	pluralOther = "other"
+)
+
+// pluralPattern 匹配{{plural .Count one "..." other "..."}}形式的复数占位符
+// 按.Count的值和当前语言的复数规则选择其中一个形式，形式中可以再使用{{.Name}}占位符
+var pluralPattern = regexp.MustCompile(`\{\{\s*plural\s+\.(\w+)((?:\s+(?:zero|one|two|few|many|other)\s+"[^"]*")+)\s*\}\}`)
+
+// pluralFormPattern 匹配复数占位符中的一个形式
+var pluralFormPattern = regexp.MustCompile(`(zero|one|two|few|many|other)\s+"([^"]*)"`)
+
+// expandPlurals 将文本中的复数占位符替换为按数量选择的形式
+// 数量不在values中或不是整数时保留占位符原样，便于发现遗漏
+func expandPlurals(message string, values Values, language Language) string {
+	return pluralPattern.ReplaceAllStringFunc(message, func(placeholder string) string {
+		match := pluralPattern.FindStringSubmatch(placeholder)
+		n, ok := pluralCount(values[match[1]])
+		if !ok {
+			return placeholder
+		}
+
+		forms := make(map[string]string)
+		var first string
+		for i, form := range pluralFormPattern.FindAllStringSubmatch(match[2], -1) {
+			if i == 0 {
+				first = form[2]
+			}
+			forms[form[1]] = form[2]
+		}
+		// 翻译中没有给出的类别使用other，再没有时使用第一个形式
+		if form, ok := forms[pluralCategory(language, n)]; ok {
+			return form
+		}
+		if form, ok := forms[pluralOther]; ok {
+			return form
+		}
+		return first
+	})
+}
+
+// pluralCount 将占位符的值转换为整数
+func pluralCount(value any) (int, bool) {
+	switch value := value.(type) {
+	case int:
+		return value, true
+	case int64:
+		return int(value), true
+	case int32:
+		return int(value), true
+	case uint:
+		return int(value), true
+	case uint64:
+		return int(value), true
+	case nil:
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(fmt.Sprint(value)))
+	return n, err == nil
+}
+
+// pluralCategory 返回整数n在语言中的复数类别，规则取自CLDR（只处理整数）
+func pluralCategory(language Language, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	switch language {
+	case CN, ZHTW, JA, KO:
+		return pluralOther
+	case RU:
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return pluralOne
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return pluralFew
+		default:
+			return pluralMany
+		}
+	case AR:
+		switch {
+		case n == 0:
+			return pluralZero
+		case n == 1:
+			return pluralOne
+		case n == 2:
+			return pluralTwo
+		case n%100 >= 3 && n%100 <= 10:
+			return pluralFew
+		case n%100 >= 11:
+			return pluralMany
+		}
+	case HE:
+		switch n {
+		case 1:
+			return pluralOne
+		case 2:
+			return pluralTwo
+		}
+	case FR, PTBR:
+		// 法语和巴西葡萄牙语中0也使用单数
+		if n == 0 || n == 1 {
+			return pluralOne
+		}
+		if n%1000000 == 0 {
+			return pluralMany
+		}
+	case ES, PTPT:
+		if n == 1 {
+			return pluralOne
+		}
+		if n != 0 && n%1000000 == 0 {
+			return pluralMany
+		}
+	default:
+		if n == 1 {
+			return pluralOne
+		}
+	}
+	return pluralOther
+}
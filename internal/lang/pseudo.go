@@ -0,0 +1,70 @@
+// 语言包，提供多语言支持功能
+package lang
+
+import (
+	"reflect"
+	"strings"
+)
+
+// PSEUDO 表示伪本地化语言，供开发和测试使用，不在语言选择列表中显示
+// 所有文本由英文转换而来：字母替换为带重音的形式，并用"[!!"和"!!]"包裹，
+// 这样没有经过文本资源的硬编码文本会保持原样，被截断的文本会缺少结尾标记
+const PSEUDO Language = "pseudo"
+
+// pseudoLetters 伪本地化时ASCII字母对应的带重音字母
+var pseudoLetters = map[rune]rune{
+	'A': 'Â', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'G': 'Ġ', 'H': 'Ĥ', 'I': 'Î', 'J': 'Ĵ', 'K': 'Ķ', 'L': 'Ĺ',
+	'N': 'Ñ', 'O': 'Ö', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ', 'U': 'Û', 'W': 'Ŵ', 'Y': 'Ý', 'Z': 'Ž',
+	'a': 'â', 'c': 'ç', 'd': 'ð', 'e': 'é', 'g': 'ĝ', 'h': 'ĥ', 'i': 'î', 'j': 'ĵ', 'k': 'ķ', 'l': 'ĺ',
+	'n': 'ñ', 'o': 'ö', 'r': 'ŕ', 's': 'š', 't': 'ţ', 'u': 'û', 'w': 'ŵ', 'y': 'ý', 'z': 'ž',
+}
+
+// pseudoText 将文本资源中的每条文本转换为伪本地化形式
+func pseudoText(text TextResource) TextResource {
+	value := reflect.ValueOf(&text).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if field := value.Field(i); field.Kind() == reflect.String {
+			field.SetString(pseudoString(field.String()))
+		}
+	}
+	return text
+}
+
+// pseudoString 转换单条文本，首尾的空白（例如开头的换行）保留在标记之外，
+// 占位符（例如{{.Path}}和复数占位符）保持不变以便之后替换
+func pseudoString(message string) string {
+	body := strings.TrimSpace(message)
+	if body == "" {
+		return message
+	}
+	start := strings.Index(message, body)
+	leading, trailing := message[:start], message[start+len(body):]
+
+	var b strings.Builder
+	b.WriteString(leading + "[!!")
+	for _, part := range splitPlaceholders(body) {
+		if tokenPattern.MatchString(part) {
+			b.WriteString(part)
+			continue
+		}
+		for _, r := range part {
+			if accented, ok := pseudoLetters[r]; ok {
+				r = accented
+			}
+			b.WriteRune(r)
+		}
+	}
+	b.WriteString("!!]" + trailing)
+	return b.String()
+}
+
+// splitPlaceholders 将文本拆分为普通文本和占位符交替的片段
+func splitPlaceholders(message string) []string {
+	var parts []string
+	last := 0
+	for _, loc := range tokenPattern.FindAllStringIndex(message, -1) {
+		parts = append(parts, message[last:loc[0]], message[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	return append(parts, message[last:])
+}
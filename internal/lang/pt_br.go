@@ -0,0 +1,248 @@
+// 语言包，提供多语言支持功能
+package lang
+
+// ptBRText 巴西葡萄牙语文本，未翻译的文本使用英文
+var ptBRText = TextResource{
+	// 成功消息
+	SuccessMessage:     "[√] Arquivo de configuração atualizado com sucesso!",
+	RestartMessage:     "[!] Reinicie o Cursor manualmente para aplicar as alterações",
+	OperationCompleted: "Operação concluída!",
+
+	// 版本信息
+	VersionInfo: "Cursor ID Modifier versão {{.Version}}",
+
+	// 标识符表格列标题
+	TableField: "Campo",
+	TableOld:   "Valor antigo",
+	TableNew:   "Valor novo",
+	TableFile:  "Arquivo",
+
+	// 提问消息
+	PromptYesHint:       "(S/n)",
+	PromptNoHint:        "(s/N)",
+	PromptYes:           "sim",
+	PromptNo:            "não",
+	PromptInvalidChoice: "Digite um número de 1 a {{.Count}}.",
+	PromptTimedOut:      "Nenhuma resposta em {{.Timeout}}, usando o valor padrão.",
+
+	// 运行摘要消息
+	SummaryTitle:      "Resumo",
+	SummaryFiles:      "Arquivos modificados",
+	SummaryBackup:     "Backup",
+	SummaryIDs:        "Identificadores alterados",
+	SummaryIDsValue:   "{{.Changed}} de {{.Total}} ({{.Fields}})",
+	SummaryProtection: "Proteção contra gravação",
+	SummaryProcesses:  "Processos encerrados",
+	SummaryTime:       "Tempo total",
+	SummaryNone:       "nenhum",
+
+	// 启动菜单消息
+	MenuTitle:        "O que você deseja fazer?",
+	MenuModify:       "Modificar identificadores",
+	MenuStatus:       "Mostrar status",
+	MenuRestore:      "Restaurar um backup",
+	MenuLock:         "Proteger storage.json contra gravação",
+	MenuUnlock:       "Remover a proteção contra gravação do storage.json",
+	MenuLanguage:     "Mudar idioma (Language)",
+	MenuExit:         "Sair",
+	MenuPrompt:       "Digite um número",
+	MenuBackupPrompt: "Escolha o backup a restaurar",
+	MenuNoBackups:    "Nenhum backup encontrado em {{.Dir}}",
+	LanguagePrompt:   "Escolha o idioma da interface",
+	LanguageSaved:    "Idioma salvo em {{.Path}}",
+	StatusTitle:      "Status atual",
+	StatusConfig:     "Arquivo de configuração",
+	StatusMissing:    "(ausente)",
+	StatusProcesses:  "Processos em execução",
+	LockSuccess:      "[√] storage.json agora está protegido contra gravação ({{.Level}})",
+
+	// 进度消息
+	ReadingConfig:     "Lendo o arquivo de configuração...",
+	GeneratingIds:     "Gerando novos identificadores...",
+	SavingConfig:      "Fazendo backup e salvando a configuração...",
+	VerifyingConfig:   "Verificando o arquivo gravado...",
+	CheckingProcesses: "Procurando instâncias do Cursor em execução...",
+	ClosingProcesses:  "Encerrando instâncias do Cursor...",
+	ClosingRemaining:  "Encerrando {{.Total}} {{plural .Total one \"processo\" other \"processos\"}}... {{plural .Remaining one \"falta\" other \"faltam\"}} {{.Remaining}}",
+	ProcessesClosed:   "Todas as instâncias do Cursor foram encerradas",
+	PleaseWait:        "Aguarde...",
+
+	// 错误消息
+	ErrorPrefix:     "O programa encontrou um erro grave: {{.Error}}",
+	PrivilegeError:  "\n[!] Erro: são necessários privilégios de administrador",
+	CloseFailed:     "Não foi possível fechar o {{.App}}. Feche-o manualmente e tente novamente.",
+	CloseIncomplete: "Não foi possível fechar o {{.App}} completamente. Feche-o manualmente e tente novamente.",
+
+	// 指令提示
+	RunAsAdmin:           "Clique com o botão direito e selecione \"Executar como administrador\"",
+	RunWithSudo:          "Execute este programa com sudo",
+	SudoExample:          "Exemplo: sudo {{.Executable}}",
+	PressEnterToExit:     "\nPressione Enter para sair...",
+	RequestingPrivileges: "\nSolicitando privilégios de administrador...",
+	SetReadOnlyMessage:   "O storage.json foi definido como somente leitura, o que pode causar problemas como a perda do histórico de espaços de trabalho",
+
+	// 信息消息
+	ConfigLocation: "Local do arquivo de configuração:",
+
+	// 写保护消息
+	StrongProtectionWarning: "[!] Proteção reforçada contra gravação ativada, o Cursor não pode mais atualizar o storage.json. Execute com -unlock para desfazer",
+	UnlockSuccess:           "[√] Proteção contra gravação removida do storage.json",
+
+	// 备份消息
+	BackupCreated:     "Backup da configuração salvo em: {{.Path}}",
+	RestoreSuccess:    "[√] Configuração restaurada do backup: {{.Path}}",
+	RestoreInvalid:    "[!] O backup não passou na validação:",
+	RestoreDiffHeader: "A restauração fará as seguintes alterações no storage.json:",
+	RestoreNoChanges:  "O backup é igual à configuração atual, não há nada para restaurar",
+	RestorePrompt:     "Restaurar agora?",
+
+	// hosts文件遥测屏蔽消息
+	TelemetryBlocked:        "[√] {{.Count}} {{plural .Count one \"domínio\" other \"domínios\"}} de telemetria {{plural .Count one \"bloqueado\" other \"bloqueados\"}} em {{.Path}}",
+	TelemetryAlreadyBlocked: "Os domínios de telemetria já estão bloqueados em {{.Path}}",
+	TelemetryUnblocked:      "[√] Bloqueio de telemetria removido de {{.Path}}",
+	TelemetryNotBlocked:     "{{.Path}} não contém nenhum bloqueio de telemetria",
+	HostsBackupCreated:      "Backup do arquivo hosts salvo em: {{.Path}}",
+
+	// Windows防火墙规则消息
+	FirewallRulesCreated: "[√] Regras de firewall de saída criadas para bloquear a telemetria: {{.Count}}",
+	FirewallRulesRemoved: "[√] Regras de firewall removidas: {{.Count}}",
+	FirewallNoRules:      "Nenhuma regra de firewall criada por esta ferramenta foi encontrada",
+	FirewallStatusTitle:  "Regras de firewall de telemetria",
+	FirewallRuleDisabled: "(desativada)",
+
+	// HTTP API消息
+	ServeListening:     "API HTTP escutando em http://{{.Address}} (pressione Ctrl+C para parar)",
+	ServeGRPCListening: "Serviço gRPC escutando em {{.Address}}",
+	ServeToken:         "Token de acesso: {{.Token}}",
+	ServeNotLoopback:   "[!] A API pode ser acessada de outras máquinas; qualquer pessoa com o token pode redefinir os identificadores deste computador",
+
+	// SSH批量处理消息
+	FleetStarting:    "Atualizando {{.Count}} hosts de {{.Inventory}} via SSH...",
+	FleetHostUpdated: "[√] {{.Host}}: {{.Path}} atualizado",
+	FleetHostFailed:  "[×] {{.Host}}: {{.Error}}",
+	FleetSummary:     "{{.Succeeded}} de {{.Total}} hosts atualizados",
+
+	// 审计日志消息
+	AuditVerified:      "[√] Log de auditoria íntegro: as {{.Count}} entradas de {{.Path}} foram verificadas",
+	AuditHead:          "Última entrada: {{.Time}}, hash {{.Hash}}",
+	AuditEmpty:         "O log de auditoria {{.Path}} ainda não tem entradas",
+	AuditIntactEntries: "[!] As {{.Count}} entradas anteriores ao erro estão íntegras",
+
+	// 全屏界面消息
+	TUITitle:           "Cursor ID Modifier",
+	TUIMenuReset:       "Redefinir os identificadores do dispositivo",
+	TUIMenuProcesses:   "Mostrar os processos do Cursor em execução",
+	TUIMenuQuit:        "Sair",
+	TUIProcessesHeader: "Processos do Cursor em execução (atualizado a cada segundo):",
+	TUINoProcesses:     "Nenhum processo do Cursor em execução",
+	TUIChangesHeader:   "Alterações de identificadores:",
+	TUIOldValue:        "antes",
+	TUINewValue:        "depois",
+	TUISucceeded:       "[√] Concluído. Reinicie o Cursor para usar os novos identificadores",
+	TUIFailed:          "[×] Não concluído, veja a etapa com falha acima",
+	TUIMenuHelp:        "↑/↓ selecionar · Enter confirmar · l idioma · q sair",
+	TUIBackHelp:        "Enter/Esc voltar ao menu · Ctrl+C sair",
+	TUIRunningHelp:     "Em andamento… · Ctrl+C cancelar",
+	TUIStepClose:       "Encerrar o Cursor",
+	TUIStepCheckFile:   "Verificar se o storage.json não está em uso",
+	TUIStepRead:        "Ler a configuração existente",
+	TUIStepGenerate:    "Gerar novos identificadores",
+	TUIStepSave:        "Fazer backup e salvar a configuração",
+	TUIStepVerify:      "Verificar o arquivo gravado",
+
+	// 无障碍模式消息
+	AccessibleStepStarted:  "Etapa {{.Step}} de {{.Total}}: {{.Title}}",
+	AccessibleStepFinished: "Etapa {{.Step}} de {{.Total}} concluída em {{.Elapsed}}.",
+	AccessibleStepFailed:   "Etapa {{.Step}} de {{.Total}} falhou.",
+	AccessibleFinished:     "Concluído em {{.Elapsed}}.",
+	AccessibleFailed:       "Falhou.",
+	AccessibleSuccess:      "Sucesso: ",
+	AccessibleWarning:      "Aviso: ",
+	AccessibleError:        "Erro: ",
+
+	// 守护模式消息
+	GuardReapplied: "[!] O Cursor reescreveu os identificadores, eles foram reaplicados: {{.Keys}}",
+	GuardUnchanged: "[√] Os identificadores não foram alterados",
+	GuardWatching:  "Monitorando storage.json, pressione Ctrl+C para parar...",
+
+	// 快照消息
+	SnapshotCreated:  "[√] Snapshot do globalStorage salvo em: {{.Path}}",
+	SnapshotRestored: "[√] globalStorage restaurado a partir do snapshot: {{.Path}}",
+
+	// workspaceStorage清理消息
+	WorkspaceStorageHeader:  "Diretório de armazenamento dos espaços de trabalho: {{.Dir}}",
+	WorkspaceStorageTotal:   "{{.Count}} {{plural .Count one \"espaço de trabalho\" other \"espaços de trabalho\"}}, {{.Size}} no total",
+	WorkspaceStoragePrompt:  "Digite os números a excluir (ex.: 1,3,5-7), digite all para excluir todos ou pressione Enter para pular",
+	WorkspaceStorageCleared: "[√] {{.Count}} {{plural .Count one \"espaço de trabalho removido\" other \"espaços de trabalho removidos\"}}, {{.Size}} liberados",
+	WorkspaceStorageEmpty:   "Nenhum armazenamento de espaço de trabalho para limpar",
+
+	// 登录状态清理消息
+	SignOutSuccess: "[√] Estado de login do Cursor apagado ({{.Count}} {{plural .Count one \"chave de autenticação removida\" other \"chaves de autenticação removidas\"}})",
+	SignOutBackup:  "Backup dos dados de login salvo em: {{.Path}}",
+
+	// 并发修改消息
+	ConcurrentModification: "[!] storage.json foi modificado por outro processo (possivelmente o Cursor ou o atualizador dele) durante a execução; a gravação foi cancelada para não sobrescrever dados recentes",
+	RetryPrompt:            "Ler o arquivo novamente e tentar de novo?",
+
+	// 已有写保护消息
+	WriteProtectedDetected: "[!] storage.json está protegido contra gravação por uma execução anterior ({{.Level}})",
+	LiftProtectionPrompt:   "Remover temporariamente a proteção para gravar os novos identificadores? A proteção solicitada é reaplicada em seguida.",
+
+	// OneDrive重定向消息
+	OneDriveDetected:       "[!] A pasta de dados do Cursor está dentro de uma pasta sincronizada pelo OneDrive ({{.Dir}}); a sincronização pode sobrescrever os novos identificadores",
+	OneDriveAdvice:         "Considere pausar a sincronização do OneDrive durante a modificação ou use -pause-onedrive para pausá-la automaticamente",
+	OneDrivePaused:         "O OneDrive foi fechado temporariamente e será reiniciado em seguida",
+	OneDriveConflictCopies: "[!] Cópias de conflito de sincronização do OneDrive encontradas, revise-as e exclua-as: {{.Files}}",
+
+	// 数据目录扫描消息
+	DiscoverHeader:   "{{.Count}} {{plural .Count one \"arquivo storage.json encontrado\" other \"arquivos storage.json encontrados\"}} (mais recentes primeiro):",
+	DiscoverEmpty:    "Nenhum storage.json encontrado",
+	DiscoverPrompt:   "Digite um número para torná-lo o destino padrão ou pressione Enter para pular",
+	DiscoverSelected: "{{.Path}} salvo como destino padrão",
+
+	// 文件锁定消息
+	FileLocked: "[!] storage.json está bloqueado por outro processo, provavelmente o atualizador em segundo plano do Cursor ou outra instância desta ferramenta; feche-o e tente novamente",
+
+	// 备份集消息
+	BackupSetCreated: "[√] {{.Count}} {{plural .Count one \"arquivo empacotado\" other \"arquivos empacotados\"}} no conjunto de backup: {{.Path}}",
+
+	// 标识符校验消息
+	VerifyHeader: "Verificando os identificadores em {{.Path}}:",
+	VerifyPassed: "[√] Todos os identificadores são estruturalmente válidos",
+
+	// 自检消息
+	SelfTestRunning: "Gerando {{.Count}} conjuntos de IDs e verificando a aleatoriedade...",
+	SelfTestPassed:  "[√] Autoteste aprovado, os IDs podem ser gerados com segurança",
+
+	// 其他用户的进程消息
+	OtherUserProcesses:    "[!] Os seguintes processos do Cursor pertencem a outra conta de usuário:",
+	OtherUserRefused:      "Eles podem estar usando a configuração de outro usuário, então nada foi alterado. Execute novamente com -force para fechá-los mesmo assim",
+	OtherUserProcessEntry: "  PID {{.PID}}  {{.Name}}  (usuário {{.User}})",
+
+	// 工作区消息
+	WorkspacesOpen:           "Estes espaços de trabalho estavam abertos antes de o Cursor ser fechado:",
+	RelaunchWorkspacesPrompt: "Reabrir {{plural .Count one \"este espaço de trabalho\" other \"estes {{.Count}} espaços de trabalho\"}}?",
+	WorkspacesRelaunched:     "[√] {{.Count}} {{plural .Count one \"espaço de trabalho reaberto\" other \"espaços de trabalho reabertos\"}}",
+	CursorRestarted:          "[√] O {{.App}} foi reiniciado",
+
+	// 未保存修改消息
+	UnsavedWorkWarning: "[!] O Cursor tem {{.Count}} {{plural .Count one \"editor\" other \"editores\"}} com alterações não salvas; forçar o fechamento pode fazer com que sejam perdidas, então salve-as primeiro",
+	UnsavedWorkPrompt:  "Fechar o Cursor e continuar mesmo assim?",
+
+	// 进程列表消息
+	ProcessListHeader: "Fechar o {{.App}} encerraria {{plural .Count one \"este processo\" other \"estes {{.Count}} processos\"}}:",
+	ProcessListEmpty:  "[√] Nenhum processo do {{.App}} seria fechado",
+	ProcessListEntry:  "  PID {{.PID}} PPID {{.PPID}} {{.User}} {{.Started}}  {{.Name}}",
+	ProcessListDaemon: "{{.Name}} (daemon)",
+
+	// 文件占用消息
+	FileInUse:     "[!] Estes processos ainda estão com {{.File}} aberto e podem sobrescrever as alterações:",
+	FileInUseHint: "Feche-os e tente novamente",
+
+	// 提升权限的进程消息
+	ElevatedProcess: "[!] O processo {{.PID}} do {{.App}} está sendo executado com privilégios mais altos ({{.Level}}) e não pode ser fechado daqui. Execute esta ferramenta como administrador (Windows) ou com sudo (macOS/Linux), ou feche-o manualmente",
+
+	// 远程服务器消息
+	ServerMachineIDReset:   "[√] O machineid do servidor remoto foi redefinido",
+	ServerMachineIDMissing: "[!] O servidor remoto não tem arquivo machineid, ignorado",
+}
@@ -0,0 +1,51 @@
+// 语言包，提供多语言支持功能
+package lang
+
+// ptPTText 欧洲葡萄牙语文本，只包含与巴西葡萄牙语用词不同的文本，其余依次使用巴西葡萄牙语和英文
+var ptPTText = TextResource{
+	// 成功消息
+	SuccessMessage: "[√] Ficheiro de configuração atualizado com sucesso!",
+
+	// 标识符表格列标题
+	TableFile: "Ficheiro",
+
+	// 提问消息
+	PromptInvalidChoice: "Introduza um número de 1 a {{.Count}}.",
+	PromptTimedOut:      "Sem resposta em {{.Timeout}}, a usar o valor predefinido.",
+
+	// 运行摘要消息
+	SummaryFiles:  "Ficheiros modificados",
+	SummaryBackup: "Cópia de segurança",
+
+	// 启动菜单消息
+	MenuTitle:        "O que pretende fazer?",
+	MenuStatus:       "Mostrar estado",
+	MenuRestore:      "Restaurar uma cópia de segurança",
+	MenuPrompt:       "Introduza um número",
+	MenuBackupPrompt: "Escolha a cópia de segurança a restaurar",
+	MenuNoBackups:    "Nenhuma cópia de segurança encontrada em {{.Dir}}",
+	LanguageSaved:    "Idioma guardado em {{.Path}}",
+	StatusTitle:      "Estado atual",
+	StatusConfig:     "Ficheiro de configuração",
+
+	// 进度消息
+	ReadingConfig:   "A ler o ficheiro de configuração...",
+	GeneratingIds:   "A gerar novos identificadores...",
+	SavingConfig:    "A criar cópia de segurança e a guardar a configuração...",
+	VerifyingConfig: "A verificar o ficheiro gravado...",
+	PleaseWait:      "Aguarde, por favor...",
+
+	// 指令提示
+	PressEnterToExit:     "\nPrima Enter para sair...",
+	RequestingPrivileges: "\nA solicitar privilégios de administrador...",
+
+	// 信息消息
+	ConfigLocation: "Localização do ficheiro de configuração:",
+
+	// 备份消息
+	BackupCreated:  "Cópia de segurança da configuração guardada em: {{.Path}}",
+	RestoreSuccess: "[√] Configuração restaurada a partir da cópia de segurança: {{.Path}}",
+
+	// hosts文件遥测屏蔽消息
+	HostsBackupCreated: "Cópia de segurança do ficheiro hosts guardada em: {{.Path}}",
+}
@@ -0,0 +1,248 @@
+// 语言包，提供多语言支持功能
+package lang
+
+// ruText 俄语文本，未翻译的文本使用英文
+var ruText = TextResource{
+	// 成功消息
+	SuccessMessage:     "[√] Файл конфигурации успешно обновлён!",
+	RestartMessage:     "[!] Перезапустите Cursor вручную, чтобы изменения вступили в силу",
+	OperationCompleted: "Операция завершена!",
+
+	// 版本信息
+	VersionInfo: "Cursor ID Modifier, версия {{.Version}}",
+
+	// 标识符表格列标题
+	TableField: "Поле",
+	TableOld:   "Старое значение",
+	TableNew:   "Новое значение",
+	TableFile:  "Файл",
+
+	// 提问消息
+	PromptYesHint:       "(Y/n)",
+	PromptNoHint:        "(y/N)",
+	PromptYes:           "да",
+	PromptNo:            "нет",
+	PromptInvalidChoice: "Введите число от 1 до {{.Count}}.",
+	PromptTimedOut:      "Нет ответа в течение {{.Timeout}}, используется значение по умолчанию.",
+
+	// 运行摘要消息
+	SummaryTitle:      "Итоги",
+	SummaryFiles:      "Изменённые файлы",
+	SummaryBackup:     "Резервная копия",
+	SummaryIDs:        "Изменённые идентификаторы",
+	SummaryIDsValue:   "{{.Changed}} из {{.Total}} ({{.Fields}})",
+	SummaryProtection: "Защита от записи",
+	SummaryProcesses:  "Закрыто процессов",
+	SummaryTime:       "Общее время",
+	SummaryNone:       "нет",
+
+	// 启动菜单消息
+	MenuTitle:        "Что вы хотите сделать?",
+	MenuModify:       "Изменить идентификаторы",
+	MenuStatus:       "Показать состояние",
+	MenuRestore:      "Восстановить из резервной копии",
+	MenuLock:         "Защитить storage.json от записи",
+	MenuUnlock:       "Снять защиту от записи со storage.json",
+	MenuLanguage:     "Сменить язык (Language)",
+	MenuExit:         "Выход",
+	MenuPrompt:       "Введите номер",
+	MenuBackupPrompt: "Выберите резервную копию для восстановления",
+	MenuNoBackups:    "Резервные копии не найдены в {{.Dir}}",
+	LanguagePrompt:   "Выберите язык интерфейса",
+	LanguageSaved:    "Язык сохранён в {{.Path}}",
+	StatusTitle:      "Текущее состояние",
+	StatusConfig:     "Файл конфигурации",
+	StatusMissing:    "(отсутствует)",
+	StatusProcesses:  "Запущенные процессы",
+	LockSuccess:      "[√] storage.json защищён от записи ({{.Level}})",
+
+	// 进度消息
+	ReadingConfig:     "Чтение файла конфигурации...",
+	GeneratingIds:     "Создание новых идентификаторов...",
+	SavingConfig:      "Резервное копирование и сохранение конфигурации...",
+	VerifyingConfig:   "Проверка записанного файла...",
+	CheckingProcesses: "Поиск запущенных экземпляров Cursor...",
+	ClosingProcesses:  "Закрытие экземпляров Cursor...",
+	ClosingRemaining:  "Закрытие {{.Total}} {{plural .Total one \"процесса\" other \"процессов\"}}... {{plural .Remaining one \"остался\" other \"осталось\"}} {{.Remaining}}",
+	ProcessesClosed:   "Все экземпляры Cursor закрыты",
+	PleaseWait:        "Пожалуйста, подождите...",
+
+	// 错误消息
+	ErrorPrefix:     "Произошла серьёзная ошибка: {{.Error}}",
+	PrivilegeError:  "\n[!] Ошибка: требуются права администратора",
+	CloseFailed:     "Не удалось закрыть {{.App}}. Закройте его вручную и повторите попытку.",
+	CloseIncomplete: "Не удалось полностью закрыть {{.App}}. Закройте его вручную и повторите попытку.",
+
+	// 指令提示
+	RunAsAdmin:           "Щёлкните правой кнопкой мыши и выберите «Запуск от имени администратора»",
+	RunWithSudo:          "Запустите программу через sudo",
+	SudoExample:          "Пример: sudo {{.Executable}}",
+	PressEnterToExit:     "\nНажмите Enter для выхода...",
+	RequestingPrivileges: "\nЗапрос прав администратора...",
+	SetReadOnlyMessage:   "storage.json переведён в режим только для чтения, из-за этого могут пропасть записи о рабочих областях",
+
+	// 信息消息
+	ConfigLocation: "Расположение файла конфигурации:",
+
+	// 写保护消息
+	StrongProtectionWarning: "[!] Включена строгая защита от записи, Cursor больше не сможет обновлять storage.json. Чтобы отменить её, запустите программу с -unlock",
+	UnlockSuccess:           "[√] Защита от записи снята со storage.json",
+
+	// 备份消息
+	BackupCreated:     "Резервная копия конфигурации сохранена: {{.Path}}",
+	RestoreSuccess:    "[√] Конфигурация восстановлена из резервной копии: {{.Path}}",
+	RestoreInvalid:    "[!] Резервная копия не прошла проверку:",
+	RestoreDiffHeader: "Восстановление внесёт в storage.json следующие изменения:",
+	RestoreNoChanges:  "Резервная копия совпадает с текущей конфигурацией, восстанавливать нечего",
+	RestorePrompt:     "Восстановить сейчас?",
+
+	// hosts文件遥测屏蔽消息
+	TelemetryBlocked:        "[√] Домены телеметрии заблокированы в {{.Path}} ({{.Count}})",
+	TelemetryAlreadyBlocked: "Домены телеметрии уже заблокированы в {{.Path}}",
+	TelemetryUnblocked:      "[√] Блокировка телеметрии удалена из {{.Path}}",
+	TelemetryNotBlocked:     "В {{.Path}} нет блокировки телеметрии",
+	HostsBackupCreated:      "Резервная копия файла hosts сохранена: {{.Path}}",
+
+	// Windows防火墙规则消息
+	FirewallRulesCreated: "[√] Создано исходящих правил брандмауэра для блокировки телеметрии: {{.Count}}",
+	FirewallRulesRemoved: "[√] Удалено правил брандмауэра: {{.Count}}",
+	FirewallNoRules:      "Правила брандмауэра, созданные этой программой, не найдены",
+	FirewallStatusTitle:  "Правила брандмауэра для телеметрии",
+	FirewallRuleDisabled: "(отключено)",
+
+	// HTTP API消息
+	ServeListening:     "HTTP API слушает http://{{.Address}} (Ctrl+C для остановки)",
+	ServeGRPCListening: "gRPC-сервис слушает {{.Address}}",
+	ServeToken:         "Токен доступа: {{.Token}}",
+	ServeNotLoopback:   "[!] API доступен с других компьютеров; любой, у кого есть токен, может сбросить идентификаторы на этом компьютере",
+
+	// SSH批量处理消息
+	FleetStarting:    "Обновление {{.Count}} хостов из {{.Inventory}} по SSH...",
+	FleetHostUpdated: "[√] {{.Host}}: обновлён {{.Path}}",
+	FleetHostFailed:  "[×] {{.Host}}: {{.Error}}",
+	FleetSummary:     "Обновлено хостов: {{.Succeeded}} из {{.Total}}",
+
+	// 审计日志消息
+	AuditVerified:      "[√] Журнал аудита не повреждён: проверено записей в {{.Path}}: {{.Count}}",
+	AuditHead:          "Последняя запись: {{.Time}}, хеш {{.Hash}}",
+	AuditEmpty:         "В журнале аудита {{.Path}} ещё нет записей",
+	AuditIntactEntries: "[!] Записи до места ошибки не повреждены: {{.Count}}",
+
+	// 全屏界面消息
+	TUITitle:           "Cursor ID Modifier",
+	TUIMenuReset:       "Сбросить идентификаторы устройства",
+	TUIMenuProcesses:   "Показать запущенные процессы Cursor",
+	TUIMenuQuit:        "Выход",
+	TUIProcessesHeader: "Запущенные процессы Cursor (обновляется каждую секунду):",
+	TUINoProcesses:     "Процессы Cursor не запущены",
+	TUIChangesHeader:   "Изменения идентификаторов:",
+	TUIOldValue:        "было",
+	TUINewValue:        "стало",
+	TUISucceeded:       "[√] Готово. Перезапустите Cursor, чтобы использовать новые идентификаторы",
+	TUIFailed:          "[×] Не завершено, см. шаг с ошибкой выше",
+	TUIMenuHelp:        "↑/↓ выбор · Enter подтвердить · l язык · q выход",
+	TUIBackHelp:        "Enter/Esc назад в меню · Ctrl+C выход",
+	TUIRunningHelp:     "Выполняется… · Ctrl+C прервать",
+	TUIStepClose:       "Закрыть Cursor",
+	TUIStepCheckFile:   "Проверить, что storage.json не используется",
+	TUIStepRead:        "Прочитать текущую конфигурацию",
+	TUIStepGenerate:    "Создать новые идентификаторы",
+	TUIStepSave:        "Сохранить резервную копию и конфигурацию",
+	TUIStepVerify:      "Проверить записанный файл",
+
+	// 无障碍模式消息
+	AccessibleStepStarted:  "Шаг {{.Step}} из {{.Total}}: {{.Title}}",
+	AccessibleStepFinished: "Шаг {{.Step}} из {{.Total}} выполнен за {{.Elapsed}}.",
+	AccessibleStepFailed:   "Шаг {{.Step}} из {{.Total}} завершился ошибкой.",
+	AccessibleFinished:     "Готово за {{.Elapsed}}.",
+	AccessibleFailed:       "Ошибка.",
+	AccessibleSuccess:      "Успех: ",
+	AccessibleWarning:      "Предупреждение: ",
+	AccessibleError:        "Ошибка: ",
+
+	// 守护模式消息
+	GuardReapplied: "[!] Cursor перезаписал идентификаторы, они применены повторно: {{.Keys}}",
+	GuardUnchanged: "[√] Идентификаторы не изменились",
+	GuardWatching:  "Отслеживание storage.json, нажмите Ctrl+C для остановки...",
+
+	// 快照消息
+	SnapshotCreated:  "[√] Снимок globalStorage сохранён в: {{.Path}}",
+	SnapshotRestored: "[√] globalStorage восстановлен из снимка: {{.Path}}",
+
+	// workspaceStorage清理消息
+	WorkspaceStorageHeader:  "Каталог хранилища рабочих областей: {{.Dir}}",
+	WorkspaceStorageTotal:   "{{.Count}} {{plural .Count one \"рабочая область\" few \"рабочие области\" many \"рабочих областей\" other \"рабочей области\"}}, всего {{.Size}}",
+	WorkspaceStoragePrompt:  "Введите номера для удаления (например, 1,3,5-7), введите all, чтобы удалить всё, или нажмите Enter, чтобы пропустить",
+	WorkspaceStorageCleared: "[√] Удалено рабочих областей: {{.Count}}, освобождено {{.Size}}",
+	WorkspaceStorageEmpty:   "Нет хранилища рабочих областей для очистки",
+
+	// 登录状态清理消息
+	SignOutSuccess: "[√] Данные входа в Cursor очищены (удалено ключей авторизации: {{.Count}})",
+	SignOutBackup:  "Данные входа сохранены в резервную копию: {{.Path}}",
+
+	// 并发修改消息
+	ConcurrentModification: "[!] Во время работы storage.json был изменён другим процессом (возможно, Cursor или его средством обновления), запись отменена, чтобы не затереть новые данные",
+	RetryPrompt:            "Перечитать файл и повторить попытку?",
+
+	// 已有写保护消息
+	WriteProtectedDetected: "[!] storage.json защищён от записи предыдущим запуском ({{.Level}})",
+	LiftProtectionPrompt:   "Временно снять защиту, чтобы записать новые идентификаторы? Запрошенная защита будет восстановлена после записи.",
+
+	// OneDrive重定向消息
+	OneDriveDetected:       "[!] Папка данных Cursor находится в синхронизируемой папке OneDrive ({{.Dir}}); синхронизация может перезаписать новые идентификаторы",
+	OneDriveAdvice:         "Приостановите синхронизацию OneDrive на время изменения или используйте -pause-onedrive для автоматической приостановки",
+	OneDrivePaused:         "OneDrive временно закрыт и будет перезапущен после завершения",
+	OneDriveConflictCopies: "[!] Найдены копии конфликтов синхронизации OneDrive, проверьте и удалите их: {{.Files}}",
+
+	// 数据目录扫描消息
+	DiscoverHeader:   "Найдено файлов storage.json: {{.Count}} (сначала самые новые):",
+	DiscoverEmpty:    "storage.json не найден",
+	DiscoverPrompt:   "Введите номер, чтобы сделать файл целью по умолчанию, или нажмите Enter, чтобы пропустить",
+	DiscoverSelected: "{{.Path}} сохранён как цель по умолчанию",
+
+	// 文件锁定消息
+	FileLocked: "[!] storage.json заблокирован другим процессом, вероятно фоновым средством обновления Cursor или другим экземпляром этой программы; закройте его и повторите попытку",
+
+	// 备份集消息
+	BackupSetCreated: "[√] В набор резервных копий {{.Path}} упаковано файлов: {{.Count}}",
+
+	// 标识符校验消息
+	VerifyHeader: "Проверка идентификаторов в {{.Path}}:",
+	VerifyPassed: "[√] Все идентификаторы структурно корректны",
+
+	// 自检消息
+	SelfTestRunning: "Генерация наборов идентификаторов ({{.Count}}) и проверка случайности...",
+	SelfTestPassed:  "[√] Самопроверка пройдена, идентификаторы можно генерировать безопасно",
+
+	// 其他用户的进程消息
+	OtherUserProcesses:    "[!] Следующие процессы Cursor принадлежат другой учётной записи:",
+	OtherUserRefused:      "Они могут использовать конфигурацию другого пользователя, поэтому ничего не изменено. Чтобы всё равно закрыть их, запустите программу снова с -force",
+	OtherUserProcessEntry: "  PID {{.PID}}  {{.Name}}  (пользователь {{.User}})",
+
+	// 工作区消息
+	WorkspacesOpen:           "Эти рабочие области были открыты до закрытия Cursor:",
+	RelaunchWorkspacesPrompt: "Снова открыть рабочие области ({{.Count}})?",
+	WorkspacesRelaunched:     "[√] Снова открыто рабочих областей: {{.Count}}",
+	CursorRestarted:          "[√] {{.App}} перезапущен",
+
+	// 未保存修改消息
+	UnsavedWorkWarning: "[!] В Cursor есть редакторы с несохранёнными изменениями ({{.Count}}); при принудительном закрытии они могут быть потеряны, поэтому сначала сохраните их",
+	UnsavedWorkPrompt:  "Всё равно закрыть Cursor и продолжить?",
+
+	// 进程列表消息
+	ProcessListHeader: "Закрытие {{.App}} завершит процессы ({{.Count}}):",
+	ProcessListEmpty:  "[√] Нет процессов {{.App}}, которые будут закрыты",
+	ProcessListEntry:  "  PID {{.PID}} PPID {{.PPID}} {{.User}} {{.Started}}  {{.Name}}",
+	ProcessListDaemon: "{{.Name}} (демон)",
+
+	// 文件占用消息
+	FileInUse:     "[!] Эти процессы всё ещё держат открытым {{.File}} и могут перезаписать изменения:",
+	FileInUseHint: "Закройте их и повторите попытку",
+
+	// 提升权限的进程消息
+	ElevatedProcess: "[!] Процесс {{.App}} {{.PID}} запущен с повышенными правами ({{.Level}}) и не может быть закрыт отсюда. Запустите эту программу от имени администратора (Windows) или через sudo (macOS/Linux) либо закройте процесс вручную",
+
+	// 远程服务器消息
+	ServerMachineIDReset:   "[√] machineid удалённого сервера сброшен",
+	ServerMachineIDMissing: "[!] На удалённом сервере нет файла machineid, пропущено",
+}
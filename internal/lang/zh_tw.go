@@ -0,0 +1,127 @@
+// 语言包，提供多语言支持功能
+package lang
+
+// zhTWText 繁体中文文本，未翻译的文本依次使用简体中文和英文
+var zhTWText = TextResource{
+	// 成功消息
+	SuccessMessage:     "[√] 設定檔已成功更新！",
+	RestartMessage:     "[!] 請手動重新啟動 Cursor 以套用變更",
+	OperationCompleted: "操作完成！",
+
+	// 标识符表格列标题
+	TableField: "欄位",
+	TableOld:   "舊值",
+	TableNew:   "新值",
+	TableFile:  "檔案",
+
+	// 提问消息
+	PromptYes:           "是",
+	PromptNo:            "否",
+	PromptInvalidChoice: "請輸入 1 到 {{.Count}} 之間的編號。",
+	PromptTimedOut:      "{{.Timeout}} 內沒有輸入，使用預設值。",
+
+	// 运行摘要消息
+	SummaryTitle:      "執行摘要",
+	SummaryFiles:      "修改的檔案",
+	SummaryBackup:     "備份",
+	SummaryIDs:        "修改的識別碼",
+	SummaryIDsValue:   "{{.Changed}}/{{.Total}} 個（{{.Fields}}）",
+	SummaryProtection: "防寫保護",
+	SummaryProcesses:  "關閉的處理程序",
+	SummaryTime:       "總耗時",
+	SummaryNone:       "無",
+
+	// 启动菜单消息
+	MenuTitle:        "請選擇要執行的操作",
+	MenuModify:       "修改識別碼",
+	MenuStatus:       "檢視目前狀態",
+	MenuRestore:      "從備份還原",
+	MenuLock:         "為 storage.json 加上防寫保護",
+	MenuUnlock:       "移除 storage.json 的防寫保護",
+	MenuLanguage:     "切換語言 (Language)",
+	MenuExit:         "結束",
+	MenuPrompt:       "請輸入編號",
+	MenuBackupPrompt: "請選擇要還原的備份",
+	MenuNoBackups:    "備份目錄中找不到備份：{{.Dir}}",
+	LanguagePrompt:   "請選擇介面語言",
+	LanguageSaved:    "介面語言已儲存到 {{.Path}}",
+	StatusTitle:      "目前狀態",
+	StatusConfig:     "設定檔",
+	StatusMissing:    "（不存在）",
+	StatusProcesses:  "執行中的處理程序",
+	LockSuccess:      "[√] 已為 storage.json 加上防寫保護（{{.Level}}）",
+
+	// 进度消息
+	ReadingConfig:     "正在讀取設定檔...",
+	GeneratingIds:     "正在產生新的識別碼...",
+	SavingConfig:      "正在備份並儲存設定...",
+	VerifyingConfig:   "正在驗證寫入結果...",
+	CheckingProcesses: "正在檢查執行中的 Cursor...",
+	ClosingProcesses:  "正在關閉 Cursor...",
+	ClosingRemaining:  "正在關閉 {{.Total}} 個處理程序... 剩餘 {{.Remaining}} 個",
+	ProcessesClosed:   "所有 Cursor 已關閉",
+	PleaseWait:        "請稍候...",
+
+	// 错误消息
+	ErrorPrefix:     "程式發生嚴重錯誤: {{.Error}}",
+	PrivilegeError:  "\n[!] 錯誤：需要系統管理員權限",
+	CloseFailed:     "無法關閉 {{.App}}，請手動關閉後再試一次",
+	CloseIncomplete: "無法完全關閉 {{.App}}，請手動關閉後再試一次",
+
+	// 指令提示
+	RunAsAdmin:           "請在程式上按右鍵，選擇「以系統管理員身分執行」",
+	RunWithSudo:          "請使用 sudo 指令執行此程式",
+	SudoExample:          "範例: sudo {{.Executable}}",
+	PressEnterToExit:     "\n按 Enter 鍵結束程式...",
+	RequestingPrivileges: "\n正在要求系統管理員權限...",
+	SetReadOnlyMessage:   "已將 storage.json 設為唯讀，這可能導致工作區記錄遺失等問題",
+
+	// 信息消息
+	ConfigLocation: "設定檔位置:",
+
+	// 写保护消息
+	UnlockSuccess: "[√] 已移除 storage.json 的防寫保護",
+
+	// 备份消息
+	BackupCreated:  "設定已備份到: {{.Path}}",
+	RestoreSuccess: "[√] 已從備份還原設定: {{.Path}}",
+	RestorePrompt:  "確定要還原嗎？",
+
+	// hosts文件遥测屏蔽消息
+	TelemetryBlocked:        "[√] 已在 {{.Path}} 中封鎖 {{.Count}} 個遙測網域",
+	TelemetryAlreadyBlocked: "{{.Path}} 中已封鎖遙測網域",
+	TelemetryUnblocked:      "[√] 已從 {{.Path}} 中移除遙測封鎖項目",
+	TelemetryNotBlocked:     "{{.Path}} 中沒有需要移除的遙測封鎖項目",
+	HostsBackupCreated:      "hosts 檔案已備份到: {{.Path}}",
+
+	// Windows防火墙规则消息
+	FirewallRulesCreated: "[√] 已建立 {{.Count}} 條阻擋遙測連線的輸出防火牆規則",
+	FirewallRulesRemoved: "[√] 已移除 {{.Count}} 條防火牆規則",
+	FirewallNoRules:      "沒有本工具建立的防火牆規則",
+	FirewallStatusTitle:  "遙測防火牆規則",
+	FirewallRuleDisabled: "（已停用）",
+
+	// HTTP API消息
+	ServeListening:     "HTTP API 正在監聽 http://{{.Address}}（按 Ctrl+C 停止）",
+	ServeGRPCListening: "gRPC 服務正在監聽 {{.Address}}",
+	ServeToken:         "存取權杖: {{.Token}}",
+	ServeNotLoopback:   "[!] API 可從其他電腦存取，任何持有權杖的人都可以在本機上重設識別碼",
+
+	// SSH批量处理消息
+	FleetStarting:    "正在透過 SSH 處理 {{.Inventory}} 中的 {{.Count}} 台主機...",
+	FleetHostUpdated: "[√] {{.Host}}: 已更新 {{.Path}}",
+	FleetHostFailed:  "[×] {{.Host}}: {{.Error}}",
+	FleetSummary:     "{{.Total}} 台主機中有 {{.Succeeded}} 台已更新",
+
+	// 审计日志消息
+	AuditVerified:      "[√] 稽核日誌完整：{{.Path}} 中的 {{.Count}} 筆記錄均通過驗證",
+	AuditHead:          "最後一筆記錄: {{.Time}}，雜湊 {{.Hash}}",
+	AuditEmpty:         "稽核日誌 {{.Path}} 中還沒有記錄",
+	AuditIntactEntries: "[!] 出錯位置之前的 {{.Count}} 筆記錄完整",
+
+	// 全屏界面消息
+	TUIMenuReset:     "重設裝置識別碼",
+	TUIMenuProcesses: "檢視執行中的 Cursor 處理程序",
+	TUIMenuQuit:      "結束",
+	TUIMenuHelp:      "↑/↓ 選擇 · Enter 確認 · l 語言 · q 結束",
+}
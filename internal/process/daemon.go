@@ -0,0 +1,49 @@
+package process
+
+import (
+	"strings"
+)
+
+// daemonSubcommands 编辑器CLI中以后台方式长期运行的子命令
+var daemonSubcommands = []string{"tunnel", "serve-web"}
+
+// isDaemonName 检查进程名称是否为编辑器CLI或隧道程序，例如cursor、cursor.exe、cursor-tunnel
+// 只使用不带通配符的名称模式，避免把名称中恰好包含cursor的其他程序当作守护进程
+func (m *Manager) isDaemonName(name string) bool {
+	name = strings.TrimSuffix(name, ".exe")
+	for _, pattern := range m.config.ProcessPatterns {
+		pattern = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(pattern)), ".exe")
+		if pattern == "" || strings.Contains(pattern, "*") {
+			continue
+		}
+		if name == pattern || name == pattern+"-tunnel" {
+			return true
+		}
+	}
+	return false
+}
+
+// isDaemonCommand 检查命令行是否为关闭编辑器窗口后仍会继续运行的后台进程：
+// CLI的tunnel、serve-web子命令，或--type=utility的更新程序
+func isDaemonCommand(cmdline []string) bool {
+	if len(cmdline) < 2 {
+		return false
+	}
+	for _, subcommand := range daemonSubcommands {
+		if cmdline[1] == subcommand {
+			return true
+		}
+	}
+
+	utility, updater := false, false
+	for _, arg := range cmdline[1:] {
+		lower := strings.ToLower(arg)
+		if lower == "--type=utility" {
+			utility = true
+		}
+		if strings.Contains(lower, "update") {
+			updater = true
+		}
+	}
+	return utility && updater
+}
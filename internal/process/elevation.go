@@ -0,0 +1,24 @@
+package process
+
+import "fmt"
+
+// ElevatedProcessError 表示进程以高于本工具的权限运行（例如以管理员身份、由服务或root启动），
+// 以当前权限无法终止
+type ElevatedProcessError struct {
+	// 进程ID
+	PID int32
+	// 进程的权限级别，例如high、system或root
+	Level string
+	// 终止失败的原始错误
+	Err error
+}
+
+// Error 实现error接口
+func (e *ElevatedProcessError) Error() string {
+	return fmt.Sprintf("process %d runs with %s privileges and cannot be closed without them: %v", e.PID, e.Level, e.Err)
+}
+
+// Unwrap 返回原始错误
+func (e *ElevatedProcessError) Unwrap() error {
+	return e.Err
+}
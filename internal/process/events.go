@@ -0,0 +1,66 @@
+package process
+
+// EventKind 表示关闭进程过程中的事件类型
+type EventKind string
+
+// 关闭进程过程中的事件
+const (
+	// EventAttempt 开始新的一次尝试，Remaining为当前运行中的进程数
+	EventAttempt EventKind = "attempt"
+	// EventTerminate 已请求进程正常退出
+	EventTerminate EventKind = "terminate"
+	// EventKill 宽限期后已强制终止进程
+	EventKill EventKind = "kill"
+	// EventKillFailed 强制终止进程失败，Error为失败原因
+	EventKillFailed EventKind = "kill_failed"
+	// EventWaiting 等待结束，Remaining为仍在运行的进程数
+	EventWaiting EventKind = "waiting"
+	// EventDone 所有进程都已退出
+	EventDone EventKind = "done"
+)
+
+// Event 描述关闭进程过程中的一个事件，可以直接序列化为JSON
+type Event struct {
+	// 事件类型
+	Kind EventKind `json:"kind"`
+	// 当前是第几次尝试，从1开始
+	Attempt int `json:"attempt"`
+	// 最大尝试次数
+	MaxAttempts int `json:"max_attempts"`
+	// 事件相关的进程，仅用于terminate、kill和kill_failed事件
+	Process *ProcessInfo `json:"process,omitempty"`
+	// 仍在运行的进程数，仅用于attempt和waiting事件
+	Remaining int `json:"remaining"`
+	// 失败原因，仅用于kill_failed事件
+	Error string `json:"error,omitempty"`
+}
+
+// ProgressFunc 接收关闭进程过程中的事件，在调用KillCursorProcesses的goroutine中同步调用
+type ProgressFunc func(Event)
+
+// SetProgressFunc 设置接收关闭进程事件的回调，为nil时不报告进度
+func (m *Manager) SetProgressFunc(fn ProgressFunc) {
+	m.progress = fn
+}
+
+// ProgressChannel 返回一个通过通道接收事件的回调，通道已满时丢弃事件以免阻塞关闭过程
+func ProgressChannel(events chan<- Event) ProgressFunc {
+	return func(event Event) {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+// processEvent 创建与单个进程相关的事件，进程信息为副本，回调可以保留
+func processEvent(kind EventKind, attempt, attempts int, p ProcessInfo) Event {
+	return Event{Kind: kind, Attempt: attempt, MaxAttempts: attempts, Process: &p}
+}
+
+// emit 向回调报告事件
+func (m *Manager) emit(event Event) {
+	if m.progress != nil {
+		m.progress(event)
+	}
+}
@@ -0,0 +1,77 @@
+//go:build !windows
+
+package process
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// FileHolders 返回打开了指定文件的进程（不包括本工具自身）
+// Linux上扫描/proc/*/fd，macOS上使用lsof；无权读取的进程会被跳过
+func FileHolders(ctx context.Context, path string) ([]ProcessInfo, error) {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	var pids []int32
+	switch runtime.GOOS {
+	case "linux":
+		running, err := process.ProcessesWithContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list processes: %w", err)
+		}
+		for _, p := range running {
+			files, err := p.OpenFilesWithContext(ctx)
+			if err != nil {
+				continue
+			}
+			for _, file := range files {
+				if file.Path == path {
+					pids = append(pids, p.Pid)
+					break
+				}
+			}
+		}
+	case "darwin":
+		// lsof在没有进程打开文件时以状态1退出，此时输出为空
+		output, err := exec.CommandContext(ctx, "lsof", "-t", "--", path).Output()
+		var exitErr *exec.ExitError
+		if err != nil && !errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("failed to run lsof: %w", err)
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(output))
+		for scanner.Scan() {
+			if pid, err := strconv.Atoi(scanner.Text()); err == nil {
+				pids = append(pids, int32(pid))
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
+	}
+
+	var holders []ProcessInfo
+	for _, pid := range pids {
+		if int(pid) == os.Getpid() {
+			continue
+		}
+		info := ProcessInfo{PID: pid}
+		if p, err := process.NewProcessWithContext(ctx, pid); err == nil {
+			info.Name, _ = p.NameWithContext(ctx)
+			info.Exe, _ = p.ExeWithContext(ctx)
+			info.Username, _ = p.UsernameWithContext(ctx)
+		}
+		holders = append(holders, info)
+	}
+	return holders, nil
+}
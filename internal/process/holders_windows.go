@@ -0,0 +1,92 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Restart Manager的函数，x/sys/windows中没有封装
+var (
+	rstrtmgr                = windows.NewLazySystemDLL("rstrtmgr.dll")
+	procRmStartSession      = rstrtmgr.NewProc("RmStartSession")
+	procRmRegisterResources = rstrtmgr.NewProc("RmRegisterResources")
+	procRmGetList           = rstrtmgr.NewProc("RmGetList")
+	procRmEndSession        = rstrtmgr.NewProc("RmEndSession")
+)
+
+// rmSessionKeyLength 会话密钥的长度（CCH_RM_SESSION_KEY）
+const rmSessionKeyLength = 32
+
+// rmProcessInfo 对应RM_PROCESS_INFO结构
+type rmProcessInfo struct {
+	ProcessID        uint32
+	ProcessStartTime windows.Filetime
+	AppName          [256]uint16
+	ServiceShortName [64]uint16
+	ApplicationType  uint32
+	AppStatus        uint32
+	TSSessionID      uint32
+	Restartable      int32
+}
+
+// FileHolders 返回打开了指定文件的进程（不包括本工具自身），通过Restart Manager API查询
+func FileHolders(ctx context.Context, path string) ([]ProcessInfo, error) {
+	var session uint32
+	key := make([]uint16, rmSessionKeyLength+1)
+	if ret, _, _ := procRmStartSession.Call(uintptr(unsafe.Pointer(&session)), 0, uintptr(unsafe.Pointer(&key[0]))); ret != 0 {
+		return nil, fmt.Errorf("failed to start restart manager session: %w", syscall.Errno(ret))
+	}
+	defer procRmEndSession.Call(uintptr(session))
+
+	name, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, err
+	}
+	if ret, _, _ := procRmRegisterResources.Call(uintptr(session), 1, uintptr(unsafe.Pointer(&name)), 0, 0, 0, 0); ret != 0 {
+		return nil, fmt.Errorf("failed to register %s: %w", path, syscall.Errno(ret))
+	}
+
+	// 第一次调用获取需要的数量，进程在两次调用之间启动时重试
+	var infos []rmProcessInfo
+	for attempt := 0; attempt < 3; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var needed, count, reasons uint32
+		count = uint32(len(infos))
+		var first uintptr
+		if count > 0 {
+			first = uintptr(unsafe.Pointer(&infos[0]))
+		}
+		ret, _, _ := procRmGetList.Call(uintptr(session), uintptr(unsafe.Pointer(&needed)), uintptr(unsafe.Pointer(&count)), first, uintptr(unsafe.Pointer(&reasons)))
+		switch syscall.Errno(ret) {
+		case 0:
+			return rmHolders(infos[:count]), nil
+		case windows.ERROR_MORE_DATA:
+			infos = make([]rmProcessInfo, needed)
+		default:
+			return nil, fmt.Errorf("failed to list processes using %s: %w", path, syscall.Errno(ret))
+		}
+	}
+	return nil, fmt.Errorf("failed to list processes using %s: the list kept changing", path)
+}
+
+// rmHolders 将Restart Manager的结果转换为进程信息，排除本工具自身
+func rmHolders(infos []rmProcessInfo) []ProcessInfo {
+	self := windows.GetCurrentProcessId()
+	var holders []ProcessInfo
+	for _, info := range infos {
+		if info.ProcessID == self {
+			continue
+		}
+		holders = append(holders, ProcessInfo{
+			PID:  int32(info.ProcessID),
+			Name: windows.UTF16ToString(info.AppName[:]),
+		})
+	}
+	return holders
+}
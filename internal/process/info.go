@@ -0,0 +1,72 @@
+package process
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ProcessInfo 描述一个运行中的进程
+type ProcessInfo struct {
+	// 进程ID
+	PID int32
+	// 父进程ID
+	PPID int32
+	// 进程名称
+	Name string
+	// 可执行文件路径，无权限读取时为空
+	Exe string
+	// 进程所属用户，无法获取时为空
+	Username string
+	// 进程的启动时间，无法获取时为零值
+	StartTime time.Time
+	// 启动进程时的命令行，第一个元素为程序路径，无法获取时为空
+	Cmdline []string
+	// 是否为CLI隧道、更新程序等关闭编辑器窗口后仍会运行的后台进程
+	Daemon bool
+}
+
+// String 返回用于日志的简短描述，例如1234 (Cursor, /opt/cursor/cursor, user alice)
+func (p ProcessInfo) String() string {
+	details := []string{p.Name}
+	if p.Exe != "" {
+		details = append(details, p.Exe)
+	}
+	if p.Username != "" {
+		details = append(details, "user "+p.Username)
+	}
+	if p.Daemon {
+		details = append(details, "daemon")
+	}
+	return fmt.Sprintf("%d (%s)", p.PID, strings.Join(details, ", "))
+}
+
+// CommandLine 返回以空格连接的命令行，无法获取时返回可执行文件路径
+func (p ProcessInfo) CommandLine() string {
+	if len(p.Cmdline) == 0 {
+		return p.Exe
+	}
+	return strings.Join(p.Cmdline, " ")
+}
+
+// MainProcesses 从进程列表中筛选出主进程，即父进程不在列表中的进程
+// 用于在重新启动时只启动主进程，由其自行创建Helper等子进程
+func MainProcesses(processes []ProcessInfo) []ProcessInfo {
+	pids := make(map[int32]bool, len(processes))
+	for _, p := range processes {
+		pids[p.PID] = true
+	}
+	var mains []ProcessInfo
+	for _, p := range processes {
+		if !pids[p.PPID] {
+			mains = append(mains, p)
+		}
+	}
+	return mains
+}
+
+// ListCursorProcesses 返回运行中的Cursor进程及其子孙进程的详细信息，即关闭Cursor时会终止的进程
+func (m *Manager) ListCursorProcesses(ctx context.Context) ([]ProcessInfo, error) {
+	return m.getCursorProcesses(ctx)
+}
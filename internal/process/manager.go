@@ -2,48 +2,133 @@
 package process
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os/exec"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/shirou/gopsutil/v3/process"
 	"github.com/sirupsen/logrus"
 )
 
 // Config 保存进程管理器的配置
 type Config struct {
 	// 终止进程的最大尝试次数
-	MaxAttempts     int           
-	// 重试之间的延迟时间
-	RetryDelay      time.Duration 
-	// 要查找的进程名称模式
-	ProcessPatterns []string      
+	MaxAttempts        int
+	// 第一次强制终止后等待进程退出的时间，之后每次按BackoffFactor增加，超时后重试
+	RetryDelay         time.Duration
+	// 每次重试的等待时间相对上一次的倍数，小于1时按1处理
+	BackoffFactor      float64
+	// 重试等待时间的上限，为0时不限制
+	MaxRetryDelay      time.Duration
+	// 要查找的进程名称模式，只在无法读取进程的可执行文件路径时使用
+	ProcessPatterns    []string
+	// 是否只按完整名称匹配，为true时模式中的*不再作为通配符
+	ExactMatch         bool
+	// 请求进程正常退出后等待的时间，超时后强制终止
+	GracePeriod        time.Duration
+	// 已知的安装目录，支持通配符；只终止可执行文件位于这些目录中或在AllowedExecutables中的进程
+	InstallDirs        []string
+	// 明确允许终止的可执行文件路径，不受安装目录限制
+	AllowedExecutables []string
+	// 不得终止的进程，每项为PID、可执行文件或目录的路径（支持通配符）、或进程名称模式
+	Exclude            []string
+	// 是否同时终止CLI隧道、更新程序等后台守护进程，即使其可执行文件不在安装目录中
+	IncludeDaemons     bool
 }
 
 // DefaultConfig 返回默认配置
 func DefaultConfig() *Config {
 	return &Config{
-		MaxAttempts: 3,
-		RetryDelay:  2 * time.Second,
-		ProcessPatterns: []string{
-			"Cursor.exe", // Windows可执行文件
-			"Cursor ",    // Linux/macOS可执行文件，带空格
-			"cursor ",    // Linux/macOS可执行文件，小写带空格
-			"cursor",     // Linux/macOS可执行文件，小写
-			"Cursor",     // Linux/macOS可执行文件
-			"*cursor*",   // 任何包含cursor的进程
-			"*Cursor*",   // 任何包含Cursor的进程
-		},
+		MaxAttempts:     3,
+		RetryDelay:      2 * time.Second,
+		BackoffFactor:   2,
+		MaxRetryDelay:   15 * time.Second,
+		ProcessPatterns: PatternsForApp("Cursor"),
+		GracePeriod:     5 * time.Second,
+		InstallDirs:     InstallDirsForApp("Cursor"),
+		IncludeDaemons:  true,
 	}
 }
 
+// InstallDirsForApp 返回指定应用程序在各系统上的常见安装目录，其中的*匹配任意用户名或后缀
+func InstallDirsForApp(appName string) []string {
+	lower := strings.ToLower(appName)
+	switch runtime.GOOS {
+	case "windows":
+		systemDrive := os.Getenv("SystemDrive") + `\`
+		return []string{
+			filepath.Join(systemDrive, "Users", "*", "AppData", "Local", "Programs", lower),
+			filepath.Join(os.Getenv("ProgramFiles"), appName),
+			filepath.Join(os.Getenv("ProgramFiles"), "WindowsApps", "*"+appName+"*"), // Microsoft Store（MSIX）安装
+		}
+	case "darwin":
+		return []string{
+			filepath.Join("/Applications", appName+".app"),
+			filepath.Join("/Users", "*", "Applications", appName+".app"),
+		}
+	case "linux":
+		return []string{
+			filepath.Join("/opt", appName),
+			filepath.Join("/opt", lower),
+			filepath.Join("/usr/share", lower),
+			filepath.Join("/usr/lib", lower),
+			filepath.Join("/snap", lower),
+			filepath.Join("/tmp", ".mount_"+appName+"*"), // AppImage的挂载目录
+		}
+	default:
+		return nil
+	}
+}
+
+// PatternsForApp 返回匹配指定应用程序进程的名称模式
+func PatternsForApp(appName string) []string {
+	lower := strings.ToLower(appName)
+	return []string{
+		appName + ".exe",    // Windows可执行文件
+		appName + " ",       // Linux/macOS可执行文件，带空格
+		lower + " ",         // Linux/macOS可执行文件，小写带空格
+		lower,               // Linux/macOS可执行文件，小写
+		appName,             // Linux/macOS可执行文件
+		"*" + lower + "*",   // 任何包含小写应用名的进程
+		"*" + appName + "*", // 任何包含应用名的进程
+	}
+}
+
+// MergePatterns 在基础模式上加入add中的模式并去掉remove中的模式，比较时不区分大小写
+// 结果保持原有顺序，重复的模式只保留一个
+func MergePatterns(base, add, remove []string) []string {
+	removed := make(map[string]bool, len(remove))
+	for _, pattern := range remove {
+		removed[strings.ToLower(pattern)] = true
+	}
+
+	seen := make(map[string]bool)
+	var merged []string
+	for _, pattern := range append(append([]string(nil), base...), add...) {
+		key := strings.ToLower(pattern)
+		if pattern == "" || removed[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, pattern)
+	}
+	return merged
+}
+
 // Manager 处理进程相关操作的管理器
 type Manager struct {
 	// 配置信息
 	config *Config
 	// 日志记录器
 	log    *logrus.Logger
+	// 关闭进程事件的回调，为nil时不报告
+	progress ProgressFunc
 }
 
 // NewManager 创建一个新的进程管理器，可选配置和日志记录器
@@ -61,8 +146,8 @@ func NewManager(config *Config, log *logrus.Logger) *Manager {
 }
 
 // IsCursorRunning 检查是否有Cursor进程当前正在运行
-func (m *Manager) IsCursorRunning() bool {
-	processes, err := m.getCursorProcesses()
+func (m *Manager) IsCursorRunning(ctx context.Context) bool {
+	processes, err := m.getCursorProcesses(ctx)
 	if err != nil {
 		m.log.Warn("Failed to get Cursor processes:", err)
 		return false
@@ -71,107 +156,440 @@ func (m *Manager) IsCursorRunning() bool {
 }
 
 // KillCursorProcesses 尝试终止所有运行中的Cursor进程
-func (m *Manager) KillCursorProcesses() error {
-	for attempt := 1; attempt <= m.config.MaxAttempts; attempt++ {
-		processes, err := m.getCursorProcesses()
+// 每次尝试后等待的时间按指数退避增加；所有尝试后仍有进程在运行时返回包含*StillRunningError的错误
+// ctx被取消时停止重试并返回ctx的错误，已发出的终止信号不会撤回
+func (m *Manager) KillCursorProcesses(ctx context.Context) error {
+	attempts := m.config.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		processes, err := m.getCursorProcesses(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get processes: %w", err)
 		}
 
 		if len(processes) == 0 {
+			m.emit(Event{Kind: EventDone, Attempt: attempt, MaxAttempts: attempts})
 			return nil
 		}
+		m.log.Debugf("Closing Cursor: attempt %d/%d, %d processes running", attempt, attempts, len(processes))
+		m.emit(Event{Kind: EventAttempt, Attempt: attempt, MaxAttempts: attempts, Remaining: len(processes)})
 
-		// 在Windows上先尝试优雅关闭
-		if runtime.GOOS == "windows" {
-			for _, pid := range processes {
-				exec.Command("taskkill", "/PID", pid).Run()
-				time.Sleep(500 * time.Millisecond)
+		// 先请求进程正常退出，让编辑器有机会保存状态
+		for _, p := range processes {
+			m.log.Debugf("Closing process %s", p)
+			if err := m.terminateProcess(ctx, p.PID); err != nil {
+				m.log.Debugf("Failed to ask process %d to exit: %v", p.PID, err)
 			}
+			m.emit(processEvent(EventTerminate, attempt, attempts, p))
 		}
 
-		// 强制终止剩余进程
-		remainingProcesses, _ := m.getCursorProcesses()
-		for _, pid := range remainingProcesses {
-			m.killProcess(pid)
+		// 宽限期内仍未退出的进程强制终止
+		killErrors := make(map[int32]error)
+		var running *StillRunningError
+		if err := m.waitFor(ctx, m.config.GracePeriod); errors.As(err, &running) {
+			for _, p := range running.Processes {
+				m.log.Debugf("Process %s did not exit within %s, killing it", p, m.config.GracePeriod)
+				if err := m.killProcess(ctx, p.PID); err != nil {
+					// 打包应用的进程可能受限于包的访问控制，只能通过关闭窗口或任务管理器结束
+					if isPackagedExecutable(p.Exe) {
+						err = fmt.Errorf("%w (Microsoft Store app: close its window or end it in Task Manager)", err)
+					}
+					m.log.Warnf("Failed to kill process %s: %v", p, err)
+					killErrors[p.PID] = err
+					event := processEvent(EventKillFailed, attempt, attempts, p)
+					event.Error = err.Error()
+					m.emit(event)
+					continue
+				}
+				m.emit(processEvent(EventKill, attempt, attempts, p))
+			}
 		}
 
-		time.Sleep(m.config.RetryDelay)
+		delay := m.config.retryDelay(attempt)
+		lastErr = m.waitFor(ctx, delay)
+		if lastErr == nil {
+			m.emit(Event{Kind: EventDone, Attempt: attempt, MaxAttempts: attempts})
+			return nil
+		}
+		if errors.As(lastErr, &running) {
+			running.Errors = killErrors
+			m.log.Infof("Attempt %d/%d: %d processes still running after %s", attempt, attempts, len(running.Processes), delay)
+			m.emit(Event{Kind: EventWaiting, Attempt: attempt, MaxAttempts: attempts, Remaining: len(running.Processes)})
+		}
+	}
+
+	return fmt.Errorf("giving up after %d attempts: %w", attempts, lastErr)
+}
+
+// retryDelay 返回第attempt次（从1开始）强制终止后等待进程退出的时间
+func (c *Config) retryDelay(attempt int) time.Duration {
+	factor := c.BackoffFactor
+	if factor < 1 {
+		factor = 1
+	}
+	delay := float64(c.RetryDelay)
+	for i := 1; i < attempt; i++ {
+		delay *= factor
+		if c.MaxRetryDelay > 0 && delay >= float64(c.MaxRetryDelay) {
+			return c.MaxRetryDelay
+		}
+	}
+	if c.MaxRetryDelay > 0 && delay > float64(c.MaxRetryDelay) {
+		return c.MaxRetryDelay
+	}
+	return time.Duration(delay)
+}
 
-		if processes, _ := m.getCursorProcesses(); len(processes) == 0 {
+// StillRunningError 表示等待结束时仍有Cursor进程在运行
+type StillRunningError struct {
+	// 仍在运行的进程
+	Processes []ProcessInfo
+	// 强制终止失败的进程及其原因，例如权限不足
+	Errors map[int32]error
+}
+
+// Error 实现error接口
+func (e *StillRunningError) Error() string {
+	pids := make([]string, len(e.Processes))
+	for i, p := range e.Processes {
+		pids[i] = strconv.Itoa(int(p.PID))
+		if err := e.Errors[p.PID]; err != nil {
+			pids[i] += fmt.Sprintf(" (%v)", err)
+		}
+	}
+	return fmt.Sprintf("%d Cursor processes still running (PIDs: %s)", len(e.Processes), strings.Join(pids, ", "))
+}
+
+// Unwrap 返回强制终止各进程时的错误，便于用errors.As查找*ElevatedProcessError等具体原因
+func (e *StillRunningError) Unwrap() []error {
+	var errs []error
+	for _, p := range e.Processes {
+		if err := e.Errors[p.PID]; err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// WaitForExit 轮询直到没有Cursor进程在运行，或ctx被取消、超过截止时间
+// 超时时返回*StillRunningError，其中包含仍在运行的进程
+func (m *Manager) WaitForExit(ctx context.Context) error {
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	var last []ProcessInfo
+	for {
+		processes, err := m.getCursorProcesses(ctx)
+		if err != nil {
+			// 枚举进程时到达截止时间，按上一次看到的进程报告
+			if ctx.Err() != nil && last != nil {
+				return &StillRunningError{Processes: last}
+			}
+			return fmt.Errorf("failed to get processes: %w", err)
+		}
+		if len(processes) == 0 {
 			return nil
 		}
+		last = processes
+
+		select {
+		case <-ctx.Done():
+			return &StillRunningError{Processes: processes}
+		case <-ticker.C:
+		}
 	}
+}
 
-	return nil
+// waitFor 在timeout内等待所有Cursor进程退出，parent被取消时返回parent的错误
+func (m *Manager) waitFor(parent context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+	err := m.WaitForExit(ctx)
+	if parent.Err() != nil {
+		return parent.Err()
+	}
+	return err
 }
 
-// getCursorProcesses 返回运行中的Cursor进程的PID列表
-func (m *Manager) getCursorProcesses() ([]string, error) {
-	cmd := m.getProcessListCommand()
-	if cmd == nil {
-		return nil, fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
+// getCursorProcesses 返回运行中的Cursor进程，以及按可执行文件路径匹配的进程的全部子孙进程
+// Electron启动的Cursor Helper（Renderer/GPU/Plugin）以及扩展启动的进程可能在主进程退出后继续运行并改写状态，
+// 因此一并返回；本工具自身及其祖先进程（例如在Cursor内置终端中运行时的shell）除外
+// 通过gopsutil直接读取进程表，不依赖tasklist、ps等命令的输出格式和系统语言
+func (m *Manager) getCursorProcesses(ctx context.Context) ([]ProcessInfo, error) {
+	running, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	all := make(map[int32]*process.Process, len(running))
+	children := make(map[int32][]int32)
+	parents := make(map[int32]int32)
+	for _, p := range running {
+		all[p.Pid] = p
+		if ppid, err := p.PpidWithContext(ctx); err == nil {
+			parents[p.Pid] = ppid
+			children[ppid] = append(children[ppid], p.Pid)
+		}
+	}
+
+	// 本工具及其祖先进程
+	protected := make(map[int32]bool)
+	for pid := int32(os.Getpid()); pid > 0 && !protected[pid]; pid = parents[pid] {
+		protected[pid] = true
+	}
+
+	// 先找出匹配的Cursor进程，再沿父子关系加入子孙进程
+	// 只展开按可执行文件路径匹配的进程；只按名称识别的进程和守护进程可能是误匹配，不连带终止其子孙进程
+	var queue []int32
+	tree := make(map[int32]bool)
+	for _, p := range running {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if protected[p.Pid] {
+			continue
+		}
+		name, err := p.NameWithContext(ctx)
+		if err != nil || name == "" {
+			continue // 进程已退出或无权访问
+		}
+
+		lowerName := strings.ToLower(name)
+		// 忽略本应用程序的进程
+		if m.isOwnProcess(lowerName) {
+			continue
+		}
+
+		exe, _ := p.ExeWithContext(ctx)
+		// 用户排除的进程及其子孙进程都不会被终止
+		if m.isExcluded(p.Pid, lowerName, exe) {
+			m.log.Debugf("Process %d (%s) is excluded", p.Pid, name)
+			protected[p.Pid] = true
+			continue
+		}
+		if matched, byPath := m.isCursorProcess(lowerName, exe); matched {
+			queue = append(queue, p.Pid)
+			tree[p.Pid] = byPath
+			continue
+		}
+		// 后台守护进程可能来自独立安装的CLI，按名称和命令行识别
+		if m.config.IncludeDaemons && m.isDaemonName(lowerName) {
+			if cmdline, err := p.CmdlineSliceWithContext(ctx); err == nil && isDaemonCommand(cmdline) {
+				queue = append(queue, p.Pid)
+			}
+		}
+	}
+
+	var processes []ProcessInfo
+	seen := make(map[int32]bool)
+	for len(queue) > 0 {
+		pid := queue[0]
+		queue = queue[1:]
+		if seen[pid] || protected[pid] {
+			continue
+		}
+		seen[pid] = true
+		if tree[pid] {
+			for _, child := range children[pid] {
+				tree[child] = true
+				queue = append(queue, child)
+			}
+		}
+
+		p := all[pid]
+		name, _ := p.NameWithContext(ctx)
+		exe, _ := p.ExeWithContext(ctx)
+		username, _ := p.UsernameWithContext(ctx)
+		cmdline, _ := p.CmdlineSliceWithContext(ctx)
+		var startTime time.Time
+		if createTime, err := p.CreateTimeWithContext(ctx); err == nil {
+			startTime = time.UnixMilli(createTime)
+		}
+		processes = append(processes, ProcessInfo{
+			PID:       pid,
+			PPID:      parents[pid],
+			Name:      name,
+			Exe:       exe,
+			Username:  username,
+			StartTime: startTime,
+			Cmdline:   cmdline,
+			Daemon:    isDaemonCommand(cmdline),
+		})
 	}
+	return processes, nil
+}
 
-	output, err := cmd.Output()
+// OtherUsersProcesses 返回属于其他用户账户的Cursor进程
+// root、SYSTEM等系统账户（例如Linux上setuid的chrome-sandbox）以及无法确定所属用户的进程不计入
+func (m *Manager) OtherUsersProcesses(ctx context.Context, username string) ([]ProcessInfo, error) {
+	processes, err := m.getCursorProcesses(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute command: %w", err)
+		return nil, err
 	}
 
-	return m.parseProcessList(string(output)), nil
+	var others []ProcessInfo
+	for _, p := range processes {
+		if p.Username == "" || isSystemAccount(p.Username) || sameUser(p.Username, username) {
+			continue
+		}
+		others = append(others, p)
+	}
+	return others, nil
 }
 
-// getProcessListCommand 根据操作系统返回适当的列出进程的命令
-func (m *Manager) getProcessListCommand() *exec.Cmd {
-	switch runtime.GOOS {
-	case "windows":
-		return exec.Command("tasklist", "/FO", "CSV", "/NH")
-	case "darwin":
-		return exec.Command("ps", "-ax")
-	case "linux":
-		return exec.Command("ps", "-A")
+// isSystemAccount 检查用户名是否为系统账户
+func isSystemAccount(username string) bool {
+	switch strings.ToUpper(stripDomain(username)) {
+	case "ROOT", "SYSTEM", "LOCAL SERVICE", "NETWORK SERVICE":
+		return true
 	default:
-		return nil
+		return false
+	}
+}
+
+// sameUser 比较两个用户名，忽略Windows用户名中的域名部分，Windows上不区分大小写
+func sameUser(a, b string) bool {
+	a, b = stripDomain(a), stripDomain(b)
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(a, b)
 	}
+	return a == b
 }
 
-// parseProcessList 从进程列表输出中提取Cursor进程的PID
-func (m *Manager) parseProcessList(output string) []string {
-	var processes []string
-	for _, line := range strings.Split(output, "\n") {
-		lowerLine := strings.ToLower(line)
+// stripDomain 去掉DOMAIN\user形式用户名中的域名
+func stripDomain(username string) string {
+	if index := strings.LastIndex(username, `\`); index >= 0 {
+		return username[index+1:]
+	}
+	return username
+}
 
-		// 忽略自身进程
-		if m.isOwnProcess(lowerLine) {
+// isOwnProcess 检查进程是否属于本应用程序
+func (m *Manager) isOwnProcess(name string) bool {
+	return strings.Contains(name, "cursor-id-modifier") ||
+		strings.Contains(name, "cursor-helper")
+}
+
+// isExcluded 检查进程是否在排除列表中
+// 纯数字的项按PID比较，包含路径分隔符的项按可执行文件或其所在目录比较，其余按名称模式比较
+func (m *Manager) isExcluded(pid int32, name, exe string) bool {
+	for _, entry := range m.config.Exclude {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case entry == "":
 			continue
+		case isPID(entry):
+			if strconv.Itoa(int(pid)) == entry {
+				return true
+			}
+		case strings.ContainsAny(entry, `/\`):
+			if exe == "" {
+				continue
+			}
+			path, pattern := normalizePath(exe), normalizePath(entry)
+			if matched, _ := filepath.Match(pattern, path); matched || isUnderDir(path, pattern) {
+				return true
+			}
+		default:
+			if m.matchPattern(name, strings.ToLower(entry)) {
+				return true
+			}
 		}
+	}
+	return false
+}
+
+// isPackagedExecutable 检查可执行文件是否位于MSIX包的安装目录WindowsApps中
+func isPackagedExecutable(exe string) bool {
+	return strings.Contains(strings.ToLower(filepath.ToSlash(exe)), "/windowsapps/")
+}
 
-		if pid := m.findCursorProcess(line, lowerLine); pid != "" {
-			processes = append(processes, pid)
+// isPID 检查字符串是否为PID，即不带符号的十进制数字
+func isPID(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
 		}
 	}
-	return processes
+	return value != ""
 }
 
-// isOwnProcess 检查进程是否属于本应用程序
-func (m *Manager) isOwnProcess(line string) bool {
-	return strings.Contains(line, "cursor-id-modifier") ||
-		strings.Contains(line, "cursor-helper")
+// isCursorProcess 判断进程是否属于Cursor
+// 按可执行文件的位置判断，避免误伤名称中恰好包含cursor的其他程序；没有配置安装目录和允许的可执行文件时不匹配任何进程
+// 无法读取可执行文件路径（例如权限不足）时退回到名称匹配，但不使用通配符模式
+// 返回值byPath表示是否按可执行文件路径匹配
+func (m *Manager) isCursorProcess(name, exe string) (matched, byPath bool) {
+	if len(m.config.InstallDirs) == 0 && len(m.config.AllowedExecutables) == 0 {
+		return false, false
+	}
+	if exe == "" {
+		return m.matchesPatterns(name), false
+	}
+
+	exe = normalizePath(exe)
+	for _, allowed := range m.config.AllowedExecutables {
+		if exe == normalizePath(allowed) {
+			return true, true
+		}
+	}
+	for _, dir := range m.config.InstallDirs {
+		if isUnderDir(exe, normalizePath(dir)) {
+			return true, true
+		}
+	}
+	return false, false
 }
 
-// findCursorProcess 检查进程行是否匹配Cursor模式并返回其PID
-func (m *Manager) findCursorProcess(line, lowerLine string) string {
+// matchesPatterns 检查进程名称是否匹配任一Cursor模式，跳过带通配符的模式
+func (m *Manager) matchesPatterns(name string) bool {
 	for _, pattern := range m.config.ProcessPatterns {
-		if m.matchPattern(lowerLine, strings.ToLower(pattern)) {
-			return m.extractPID(line)
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if !m.config.ExactMatch && strings.Contains(pattern, "*") {
+			continue
+		}
+		if m.matchPattern(name, pattern) {
+			return true
 		}
 	}
-	return ""
+	return false
+}
+
+// isUnderDir 检查路径是否位于目录中，目录可以包含filepath.Match支持的通配符
+func isUnderDir(path, dirPattern string) bool {
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		if matched, _ := filepath.Match(dirPattern, dir); matched {
+			return true
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return false
+		}
+	}
+}
+
+// normalizePath 解析符号链接并清理路径，Windows和macOS上的路径不区分大小写，统一转为小写
+func normalizePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	path = filepath.Clean(path)
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		path = strings.ToLower(path)
+	}
+	return path
 }
 
-// matchPattern 检查一行是否匹配模式，支持通配符
+// matchPattern 检查进程名称是否匹配模式，支持通配符
 func (m *Manager) matchPattern(line, pattern string) bool {
 	switch {
+	case m.config.ExactMatch:
+		// 精确匹配模式：名称必须与模式完全相同
+		return line == pattern
 	case strings.HasPrefix(pattern, "*") && strings.HasSuffix(pattern, "*"):
 		// *text* 模式：包含text
 		search := pattern[1 : len(pattern)-1]
@@ -187,41 +605,3 @@ func (m *Manager) matchPattern(line, pattern string) bool {
 		return line == pattern
 	}
 }
-
-// extractPID 根据操作系统格式从进程列表行中提取进程ID
-func (m *Manager) extractPID(line string) string {
-	switch runtime.GOOS {
-	case "windows":
-		parts := strings.Split(line, ",")
-		if len(parts) >= 2 {
-			return strings.Trim(parts[1], "\"")
-		}
-	case "darwin", "linux":
-		parts := strings.Fields(line)
-		if len(parts) >= 1 {
-			return parts[0]
-		}
-	}
-	return ""
-}
-
-// killProcess 通过PID强制终止进程
-func (m *Manager) killProcess(pid string) error {
-	cmd := m.getKillCommand(pid)
-	if cmd == nil {
-		return fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
-	}
-	return cmd.Run()
-}
-
-// getKillCommand 根据操作系统返回适当的终止进程的命令
-func (m *Manager) getKillCommand(pid string) *exec.Cmd {
-	switch runtime.GOOS {
-	case "windows":
-		return exec.Command("taskkill", "/F", "/PID", pid)
-	case "darwin", "linux":
-		return exec.Command("kill", "-9", pid)
-	default:
-		return nil
-	}
-}
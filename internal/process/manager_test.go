@@ -0,0 +1,91 @@
+package process
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchesPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		exact    bool
+		process  string
+		want     bool
+	}{
+		{name: "full name", patterns: []string{"cursor"}, process: "cursor", want: true},
+		{name: "pattern is trimmed and lowered", patterns: []string{" Cursor.exe "}, process: "cursor.exe", want: true},
+		{name: "different name", patterns: []string{"cursor"}, process: "cursor-helper", want: false},
+		{name: "wildcard patterns are skipped", patterns: []string{"cursor*", "*cursor*"}, process: "cursor-helper", want: false},
+		{name: "wildcard skipped but full name matches", patterns: []string{"cursor*", "cursor helper"}, process: "cursor helper", want: true},
+		{name: "exact match keeps star literal", patterns: []string{"cursor*"}, exact: true, process: "cursor*", want: true},
+		{name: "exact match does not expand star", patterns: []string{"cursor*"}, exact: true, process: "cursor-helper", want: false},
+		{name: "no patterns", process: "cursor", want: false},
+	}
+	for _, tt := range tests {
+		m := NewManager(&Config{ProcessPatterns: tt.patterns, ExactMatch: tt.exact}, nil)
+		if got := m.matchesPatterns(tt.process); got != tt.want {
+			t.Errorf("%s: matchesPatterns(%q) = %v, want %v", tt.name, tt.process, got, tt.want)
+		}
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	m := NewManager(&Config{}, nil)
+	tests := []struct {
+		line, pattern string
+		want          bool
+	}{
+		{"cursor helper", "*helper*", true},
+		{"cursor helper", "*cursor", false},
+		{"cursor helper", "*helper", true},
+		{"cursor helper", "cursor*", true},
+		{"cursor helper", "helper*", false},
+		{"cursor", "cursor", true},
+		{"cursor", "curso", false},
+	}
+	for _, tt := range tests {
+		if got := m.matchPattern(tt.line, tt.pattern); got != tt.want {
+			t.Errorf("matchPattern(%q, %q) = %v, want %v", tt.line, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestIsCursorProcess(t *testing.T) {
+	root := t.TempDir()
+	installDir := filepath.Join(root, "apps", "cursor")
+	allowed := filepath.Join(root, "bin", "cursor-wrapper")
+	patterns := []string{"cursor", "cursor*"}
+
+	tests := []struct {
+		name        string
+		installDirs []string
+		allowed     []string
+		process     string
+		exe         string
+		wantMatch   bool
+		wantByPath  bool
+	}{
+		{name: "executable in install dir", installDirs: []string{installDir}, process: "anything", exe: filepath.Join(installDir, "cursor"), wantMatch: true, wantByPath: true},
+		{name: "executable in nested install dir", installDirs: []string{installDir}, process: "helper", exe: filepath.Join(installDir, "resources", "helper"), wantMatch: true, wantByPath: true},
+		{name: "install dir with wildcard", installDirs: []string{filepath.Join(root, "*", "cursor")}, process: "cursor", exe: filepath.Join(installDir, "cursor"), wantMatch: true, wantByPath: true},
+		{name: "same name outside install dir", installDirs: []string{installDir}, process: "cursor", exe: filepath.Join(root, "other", "cursor"), wantMatch: false},
+		{name: "sibling dir with shared prefix", installDirs: []string{installDir}, process: "cursor", exe: filepath.Join(root, "apps", "cursor-old", "cursor"), wantMatch: false},
+		{name: "allowed executable", allowed: []string{allowed}, process: "cursor-wrapper", exe: allowed, wantMatch: true, wantByPath: true},
+		{name: "unknown executable falls back to name", installDirs: []string{installDir}, process: "cursor", wantMatch: true, wantByPath: false},
+		{name: "unknown executable with wildcard-only name", installDirs: []string{installDir}, process: "cursor-helper", wantMatch: false},
+		{name: "no install dirs or allowed executables", process: "cursor", exe: filepath.Join(installDir, "cursor"), wantMatch: false},
+	}
+	for _, tt := range tests {
+		m := NewManager(&Config{
+			ProcessPatterns:    patterns,
+			InstallDirs:        tt.installDirs,
+			AllowedExecutables: tt.allowed,
+		}, nil)
+		matched, byPath := m.isCursorProcess(tt.process, tt.exe)
+		if matched != tt.wantMatch || byPath != tt.wantByPath {
+			t.Errorf("%s: isCursorProcess(%q, %q) = (%v, %v), want (%v, %v)",
+				tt.name, tt.process, tt.exe, matched, byPath, tt.wantMatch, tt.wantByPath)
+		}
+	}
+}
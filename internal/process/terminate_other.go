@@ -0,0 +1,57 @@
+//go:build !windows
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// terminateProcess 请求进程正常退出，macOS/Linux上发送SIGTERM
+// 子孙进程已包含在getCursorProcesses的结果中，逐个发送即可
+func (m *Manager) terminateProcess(ctx context.Context, pid int32) error {
+	switch runtime.GOOS {
+	case "darwin", "linux":
+		p, err := process.NewProcessWithContext(ctx, pid)
+		if err != nil {
+			return err
+		}
+		return p.TerminateWithContext(ctx)
+	default:
+		return fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
+	}
+}
+
+// killProcess 通过PID强制终止进程，发送SIGKILL
+// 进程属于root而本工具不是以root运行时返回*ElevatedProcessError
+func (m *Manager) killProcess(ctx context.Context, pid int32) error {
+	switch runtime.GOOS {
+	case "darwin", "linux":
+		p, err := process.NewProcessWithContext(ctx, pid)
+		if err != nil {
+			return err
+		}
+		err = p.KillWithContext(ctx)
+		if err != nil && os.Geteuid() != 0 && ownedByRoot(ctx, pid) {
+			return &ElevatedProcessError{PID: pid, Level: "root", Err: err}
+		}
+		return err
+	default:
+		return fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
+	}
+}
+
+// ownedByRoot 检查进程的有效用户是否为root
+func ownedByRoot(ctx context.Context, pid int32) bool {
+	p, err := process.NewProcessWithContext(ctx, pid)
+	if err != nil {
+		return false
+	}
+	uids, err := p.UidsWithContext(ctx)
+	// 依次为真实、有效、保存的用户ID
+	return err == nil && len(uids) > 1 && uids[1] == 0
+}
@@ -0,0 +1,175 @@
+package process
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// WM_CLOSE 请求窗口关闭的消息，与点击窗口的关闭按钮相同
+const wmClose = 0x0010
+
+// 发送窗口消息的函数，x/sys/windows中没有封装
+var procPostMessageW = windows.NewLazySystemDLL("user32.dll").NewProc("PostMessageW")
+
+// closeRequest 枚举窗口时传给回调的参数
+type closeRequest struct {
+	// 要关闭窗口的进程ID
+	pid uint32
+	// 已发送WM_CLOSE的窗口数
+	closed int
+}
+
+// 当前的关闭请求，EnumWindows同步调用回调，由closeMu保证同一时间只有一个请求
+var (
+	closeMu      sync.Mutex
+	pendingClose *closeRequest
+)
+
+// closeWindowsCallback 对属于当前请求进程的顶层窗口发送WM_CLOSE
+// 系统允许创建的回调数量有限，因此只创建一次，通过pendingClose传入目标进程
+var closeWindowsCallback = windows.NewCallback(func(hwnd windows.HWND, _ uintptr) uintptr {
+	request := pendingClose
+	var owner uint32
+	if _, err := windows.GetWindowThreadProcessId(hwnd, &owner); err == nil && owner == request.pid {
+		if ret, _, _ := procPostMessageW.Call(uintptr(hwnd), wmClose, 0, 0); ret != 0 {
+			request.closed++
+		}
+	}
+	return 1 // 继续枚举
+})
+
+// terminateProcess 请求进程正常退出：向进程的所有顶层窗口发送WM_CLOSE
+// 直接调用系统API，不启动taskkill，避免其输出随系统语言变化以及被安全软件拦截
+// 没有窗口的进程（例如Helper）在主进程退出后自行结束，宽限期后仍在运行的由killProcess处理
+func (m *Manager) terminateProcess(ctx context.Context, pid int32) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	closeMu.Lock()
+	defer closeMu.Unlock()
+	request := &closeRequest{pid: uint32(pid)}
+	pendingClose = request
+	defer func() { pendingClose = nil }()
+	if err := windows.EnumWindows(closeWindowsCallback, nil); err != nil {
+		return fmt.Errorf("failed to enumerate windows: %w", err)
+	}
+	if request.closed == 0 {
+		return fmt.Errorf("process %d has no top-level windows", pid)
+	}
+	return nil
+}
+
+// killProcess 通过OpenProcess和TerminateProcess强制终止进程
+// 进程已经退出时视为成功；本工具以管理员身份运行时启用SeDebugPrivilege，以便终止服务或其他用户启动的进程；
+// 权限不足且目标进程以更高的完整性级别运行时返回*ElevatedProcessError，其他失败返回带PID的错误
+func (m *Manager) killProcess(ctx context.Context, pid int32) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if windows.GetCurrentProcessToken().IsElevated() {
+		debugPrivilegeOnce.Do(func() {
+			if err := enableDebugPrivilege(); err != nil {
+				m.log.Debug("Failed to enable SeDebugPrivilege: ", err)
+			}
+		})
+	}
+
+	handle, err := windows.OpenProcess(windows.PROCESS_TERMINATE|windows.SYNCHRONIZE, false, uint32(pid))
+	if err != nil {
+		// 进程不存在时OpenProcess返回ERROR_INVALID_PARAMETER
+		if errors.Is(err, windows.ERROR_INVALID_PARAMETER) {
+			return nil
+		}
+		if errors.Is(err, windows.ERROR_ACCESS_DENIED) {
+			if level := integrityLevel(pid); level == "high" || level == "system" {
+				if !windows.GetCurrentProcessToken().IsElevated() || level == "system" {
+					return &ElevatedProcessError{PID: pid, Level: level, Err: err}
+				}
+			}
+		}
+		return fmt.Errorf("failed to open process %d: %w", pid, err)
+	}
+	defer windows.CloseHandle(handle)
+
+	if err := windows.TerminateProcess(handle, 1); err != nil {
+		// 进程正在退出时TerminateProcess返回拒绝访问，等待片刻确认
+		if event, _ := windows.WaitForSingleObject(handle, 100); event == windows.WAIT_OBJECT_0 {
+			return nil
+		}
+		return fmt.Errorf("failed to terminate process %d: %w", pid, err)
+	}
+	return nil
+}
+
+// 只需启用一次SeDebugPrivilege
+var debugPrivilegeOnce sync.Once
+
+// enableDebugPrivilege 在当前进程的令牌中启用SeDebugPrivilege，管理员可借此打开任意进程
+func enableDebugPrivilege() error {
+	var token windows.Token
+	if err := windows.OpenProcessToken(windows.CurrentProcess(), windows.TOKEN_ADJUST_PRIVILEGES|windows.TOKEN_QUERY, &token); err != nil {
+		return fmt.Errorf("failed to open process token: %w", err)
+	}
+	defer token.Close()
+
+	var luid windows.LUID
+	if err := windows.LookupPrivilegeValue(nil, windows.StringToUTF16Ptr("SeDebugPrivilege"), &luid); err != nil {
+		return fmt.Errorf("failed to look up SeDebugPrivilege: %w", err)
+	}
+	privileges := windows.Tokenprivileges{PrivilegeCount: 1}
+	privileges.Privileges[0] = windows.LUIDAndAttributes{Luid: luid, Attributes: windows.SE_PRIVILEGE_ENABLED}
+	if err := windows.AdjustTokenPrivileges(token, false, &privileges, 0, nil, nil); err != nil {
+		return fmt.Errorf("failed to enable SeDebugPrivilege: %w", err)
+	}
+	return nil
+}
+
+// 强制完整性级别的RID
+const (
+	integrityHighRID   = 0x3000
+	integritySystemRID = 0x4000
+)
+
+// integrityLevel 返回进程的完整性级别：medium（普通用户）、high（以管理员身份运行）或system（服务）
+// 无法读取时返回空字符串
+func integrityLevel(pid int32) string {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return ""
+	}
+	defer windows.CloseHandle(handle)
+
+	var token windows.Token
+	if err := windows.OpenProcessToken(handle, windows.TOKEN_QUERY, &token); err != nil {
+		return ""
+	}
+	defer token.Close()
+
+	var size uint32
+	windows.GetTokenInformation(token, windows.TokenIntegrityLevel, nil, 0, &size)
+	if size == 0 {
+		return ""
+	}
+	buffer := make([]byte, size)
+	if err := windows.GetTokenInformation(token, windows.TokenIntegrityLevel, &buffer[0], size, &size); err != nil {
+		return ""
+	}
+	label := (*windows.Tokenmandatorylabel)(unsafe.Pointer(&buffer[0]))
+	sid := label.Label.Sid
+	if sid.SubAuthorityCount() == 0 {
+		return ""
+	}
+	switch rid := sid.SubAuthority(uint32(sid.SubAuthorityCount()) - 1); {
+	case rid >= integritySystemRID:
+		return "system"
+	case rid >= integrityHighRID:
+		return "high"
+	default:
+		return "medium"
+	}
+}
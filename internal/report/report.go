@@ -0,0 +1,169 @@
+// report包，定义重置流程报告进展的接口，使业务逻辑与控制台、JSON、全屏界面等前端解耦
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Reporter 接收重置流程中各步骤的进展和最终结果
+type Reporter interface {
+	// StepStarted 开始一个新的步骤
+	StepStarted(title string)
+	// StepProgress 更新当前步骤的进度说明，例如剩余的进程数
+	StepProgress(message string)
+	// StepSucceeded 当前步骤成功完成
+	StepSucceeded()
+	// StepFailed 当前步骤失败
+	StepFailed(err error)
+	// Summary 流程结束，报告各标识符修改前后的值
+	Summary(changes []Change)
+}
+
+// Change 一个标识符修改前后的值及其所在的文件
+type Change struct {
+	// 字段名，例如telemetry.machineId
+	Field string `json:"field"`
+	// 修改前的值，为空表示原来没有值
+	Old string `json:"old"`
+	// 修改后的值
+	New string `json:"new"`
+	// 写入的文件
+	File string `json:"file"`
+}
+
+// Multi 返回将进展同时报告给多个Reporter的Reporter，nil会被忽略
+func Multi(reporters ...Reporter) Reporter {
+	var list multi
+	for _, r := range reporters {
+		if r != nil {
+			list = append(list, r)
+		}
+	}
+	return list
+}
+
+// multi 依次转发给多个Reporter
+type multi []Reporter
+
+// StepStarted 实现Reporter
+func (m multi) StepStarted(title string) {
+	for _, r := range m {
+		r.StepStarted(title)
+	}
+}
+
+// StepProgress 实现Reporter
+func (m multi) StepProgress(message string) {
+	for _, r := range m {
+		r.StepProgress(message)
+	}
+}
+
+// StepSucceeded 实现Reporter
+func (m multi) StepSucceeded() {
+	for _, r := range m {
+		r.StepSucceeded()
+	}
+}
+
+// StepFailed 实现Reporter
+func (m multi) StepFailed(err error) {
+	for _, r := range m {
+		r.StepFailed(err)
+	}
+}
+
+// Summary 实现Reporter
+func (m multi) Summary(changes []Change) {
+	for _, r := range m {
+		r.Summary(changes)
+	}
+}
+
+// JSONReporter 将每个事件写为一行JSON，供脚本和其他程序解析
+type JSONReporter struct {
+	// 保护写入和步骤状态
+	mu sync.Mutex
+	// 编码器
+	encoder *json.Encoder
+	// 当前步骤的序号，从1开始
+	step int
+	// 当前步骤的标题和开始时间
+	title   string
+	started time.Time
+}
+
+// jsonEvent 写出的一行事件
+type jsonEvent struct {
+	// 事件类型：step_started、step_progress、step_succeeded、step_failed或summary
+	Event string `json:"event"`
+	// 事件发生的时间
+	Time time.Time `json:"time"`
+	// 步骤序号和标题，summary事件中省略
+	Step  int    `json:"step,omitempty"`
+	Title string `json:"title,omitempty"`
+	// 进度说明
+	Message string `json:"message,omitempty"`
+	// 步骤耗时（毫秒），仅用于步骤结束事件
+	ElapsedMs int64 `json:"elapsed_ms,omitempty"`
+	// 失败原因
+	Error string `json:"error,omitempty"`
+	// 各标识符修改前后的值
+	Changes []Change `json:"changes,omitempty"`
+}
+
+// NewJSONReporter 创建写入w的JSONReporter
+func NewJSONReporter(w io.Writer) *JSONReporter {
+	return &JSONReporter{encoder: json.NewEncoder(w)}
+}
+
+// StepStarted 实现Reporter
+func (j *JSONReporter) StepStarted(title string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.step++
+	j.title = title
+	j.started = time.Now()
+	j.write(jsonEvent{Event: "step_started", Step: j.step, Title: title})
+}
+
+// StepProgress 实现Reporter
+func (j *JSONReporter) StepProgress(message string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.write(jsonEvent{Event: "step_progress", Step: j.step, Title: j.title, Message: message})
+}
+
+// StepSucceeded 实现Reporter
+func (j *JSONReporter) StepSucceeded() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.write(jsonEvent{Event: "step_succeeded", Step: j.step, Title: j.title, ElapsedMs: time.Since(j.started).Milliseconds()})
+}
+
+// StepFailed 实现Reporter
+func (j *JSONReporter) StepFailed(err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	event := jsonEvent{Event: "step_failed", Step: j.step, Title: j.title, ElapsedMs: time.Since(j.started).Milliseconds()}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	j.write(event)
+}
+
+// Summary 实现Reporter
+func (j *JSONReporter) Summary(changes []Change) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.write(jsonEvent{Event: "summary", Changes: changes})
+}
+
+// write 写出一行事件，写入失败时忽略，报告不应影响重置流程
+func (j *JSONReporter) write(event jsonEvent) {
+	event.Time = time.Now()
+	_ = j.encoder.Encode(event)
+}
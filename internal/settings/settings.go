@@ -0,0 +1,208 @@
+// 设置包，负责读取和保存本工具自身的配置文件
+package settings
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 工具配置目录名和配置文件名
+const (
+	appDirName = "cursor-id-modifier"
+	fileName   = "config.yaml"
+)
+
+// Settings 表示工具配置文件中的默认设置
+// 所有字段均为可选，命令行参数优先于配置文件中的值
+type Settings struct {
+	// 界面语言，例如cn、en或ja
+	Language string `yaml:"language,omitempty"`
+	// 是否将storage.json设置为只读
+	ReadOnly bool `yaml:"read_only,omitempty"`
+	// 写保护级别：none、readonly或strong
+	Protection string `yaml:"protection,omitempty"`
+	// 写入storage.json时的合并策略：overwrite、fill-missing或replace-file
+	MergeStrategy string `yaml:"merge_strategy,omitempty"`
+	// storage.json的路径，为空时根据目标编辑器自动检测
+	StoragePath string `yaml:"storage_path,omitempty"`
+	// 备份目录，为空时使用globalStorage下的backups目录
+	BackupDir string `yaml:"backup_dir,omitempty"`
+	// 设备ID和SQM ID使用的UUID版本：4或7
+	UUIDVersion int `yaml:"uuid_version,omitempty"`
+	// 基于名称生成设备ID和SQM ID时使用的命名空间（UUID或dns、url、oid、x500）
+	UUIDNamespace string `yaml:"uuid_namespace,omitempty"`
+	// 基于名称生成UUID时使用的名称，为空时使用计算机名
+	UUIDName string `yaml:"uuid_name,omitempty"`
+	// 已有值时保留而不重新生成的字段，未设置时保留sqmId，空列表表示全部重新生成
+	Keep []string `yaml:"keep,omitempty"`
+	// 按真实Cursor安装的分布生成的字段，逗号分隔或all
+	Plausible string `yaml:"plausible,omitempty"`
+	// 目标编辑器，例如cursor或vscode
+	Editor string `yaml:"editor,omitempty"`
+	// Cursor衍生编辑器的产品名称
+	AppName string `yaml:"app_name,omitempty"`
+	// 进程设置
+	Process ProcessSettings `yaml:"process,omitempty"`
+	// 控制台输出设置
+	UI UISettings `yaml:"ui,omitempty"`
+	// 是否在hosts文件中屏蔽Cursor的遥测域名
+	BlockTelemetry bool `yaml:"block_telemetry,omitempty"`
+	// 要屏蔽的遥测域名，为空时使用内置列表
+	TelemetryDomains []string `yaml:"telemetry_domains,omitempty"`
+	// firewall子命令额外屏蔽的IP地址、网段或地址范围
+	TelemetryAddresses []string `yaml:"telemetry_addresses,omitempty"`
+	// 覆盖内置文本，键为文本名称（例如SudoExample），对所有语言生效
+	Messages map[string]string `yaml:"messages,omitempty"`
+}
+
+// UISettings 表示控制台输出相关的设置
+type UISettings struct {
+	// 是否启用供屏幕阅读器使用的无障碍模式
+	Accessible bool `yaml:"accessible,omitempty"`
+	// 配色主题：default、dark、light或monochrome
+	Theme string `yaml:"theme,omitempty"`
+	// 启动时显示的横幅，代替默认的Logo；为none时不显示
+	Banner string `yaml:"banner,omitempty"`
+}
+
+// ProcessSettings 表示关闭编辑器进程相关的设置
+type ProcessSettings struct {
+	// 要查找的进程名称模式，为空时使用目标编辑器的默认模式
+	// 进程按InstallDirs和AllowedExecutables匹配，名称模式只在无法读取可执行文件路径时使用
+	Patterns []string `yaml:"patterns,omitempty"`
+	// 在默认模式之外额外匹配的进程名称模式，例如自行编译的衍生版本
+	AddPatterns []string `yaml:"add_patterns,omitempty"`
+	// 从默认模式中去掉的进程名称模式，例如误伤其他程序的通配符模式
+	RemovePatterns []string `yaml:"remove_patterns,omitempty"`
+	// 是否只按完整进程名称匹配，不使用通配符和前后缀匹配
+	ExactMatch bool `yaml:"exact_match,omitempty"`
+	// 不得终止的进程：PID、可执行文件或目录的路径、或进程名称模式
+	Exclude []string `yaml:"exclude,omitempty"`
+	// 是否保留CLI隧道、更新程序等后台守护进程，不随编辑器一起关闭
+	SkipDaemons bool `yaml:"skip_daemons,omitempty"`
+	// 编辑器的安装目录，支持通配符，为空时使用目标编辑器的默认目录
+	InstallDirs []string `yaml:"install_dirs,omitempty"`
+	// 明确允许终止的可执行文件路径，例如便携版的可执行文件
+	AllowedExecutables []string `yaml:"allowed_executables,omitempty"`
+	// 终止进程的最大尝试次数
+	MaxAttempts int `yaml:"max_attempts,omitempty"`
+	// 第一次强制终止后等待进程退出的时间，例如2s
+	RetryDelay time.Duration `yaml:"retry_delay,omitempty"`
+	// 每次重试的等待时间相对上一次的倍数，例如2
+	BackoffFactor float64 `yaml:"backoff_factor,omitempty"`
+	// 重试等待时间的上限，例如30s
+	MaxRetryDelay time.Duration `yaml:"max_retry_delay,omitempty"`
+	// 请求进程正常退出后等待的时间，超时后强制终止，例如10s
+	GracePeriod time.Duration `yaml:"grace_period,omitempty"`
+}
+
+// Dir 返回指定用户的工具配置目录
+func Dir(username string) (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		return filepath.Join(os.Getenv("APPDATA"), appDirName), nil
+	case "darwin":
+		return filepath.Join("/Users", username, "Library", "Application Support", appDirName), nil
+	case "linux":
+		return filepath.Join("/home", username, ".config", appDirName), nil
+	default:
+		return "", fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
+	}
+}
+
+// StateDir 返回指定用户的工具状态目录，用于保存运行中产生的数据（例如已生成ID的记录）
+// Linux上遵循XDG规范使用~/.local/state，其他系统与配置目录相同
+func StateDir(username string) (string, error) {
+	if runtime.GOOS == "linux" {
+		return filepath.Join("/home", username, ".local", "state", appDirName), nil
+	}
+	return Dir(username)
+}
+
+// DefaultPath 返回指定用户的工具配置文件路径
+func DefaultPath(username string) (string, error) {
+	dir, err := Dir(username)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fileName), nil
+}
+
+// Load 读取配置文件，文件不存在时返回空设置
+func Load(path string) (*Settings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Settings{}, nil
+		}
+		return nil, fmt.Errorf("failed to read settings file: %w", err)
+	}
+
+	var settings Settings
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse settings file %s: %w", path, err)
+	}
+	return &settings, nil
+}
+
+// Save 将设置写入配置文件，必要时创建配置目录
+// 通过sudo运行时，新建的目录和配置文件归还给原始用户，避免之后以普通用户运行时无法修改
+func (s *Settings) Save(path string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings: %w", err)
+	}
+
+	if err := MkdirAllOwned(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create settings directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write settings file: %w", err)
+	}
+	return RestoreOwnership(path)
+}
+
+// MkdirAllOwned 创建目录（含父目录），通过sudo运行时把新建的各级目录归还给原始用户
+// 用于工具配置目录和状态目录，避免之后以普通用户运行时无法在其中写入
+func MkdirAllOwned(dir string, perm os.FileMode) error {
+	// 记录需要新建的目录，从最深一级开始
+	var created []string
+	for current := filepath.Clean(dir); ; current = filepath.Dir(current) {
+		if _, err := os.Lstat(current); err == nil || filepath.Dir(current) == current {
+			break
+		}
+		created = append(created, current)
+	}
+	if err := os.MkdirAll(dir, perm); err != nil {
+		return err
+	}
+	return RestoreOwnership(created...)
+}
+
+// RestoreOwnership 通过sudo运行时将文件和目录的所有者恢复为原始用户（SUDO_UID/SUDO_GID）
+// 在Windows上或未通过sudo运行时不做任何操作
+func RestoreOwnership(paths ...string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	uid, err := strconv.Atoi(os.Getenv("SUDO_UID"))
+	if err != nil {
+		return nil
+	}
+	gid, err := strconv.Atoi(os.Getenv("SUDO_GID"))
+	if err != nil {
+		return nil
+	}
+	for _, path := range paths {
+		if err := os.Lchown(path, uid, gid); err != nil {
+			return fmt.Errorf("failed to restore ownership of %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,437 @@
+// tui包，基于bubbletea的全屏终端界面，作为逐行输出的控制台流程之外的另一种前端
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/yuaotian/go-cursor-help/internal/process"
+	"github.com/yuaotian/go-cursor-help/internal/report"
+	"github.com/yuaotian/go-cursor-help/internal/ui"
+)
+
+// Step 重置流程中的一个步骤
+type Step struct {
+	// 步骤标题，例如"关闭 Cursor"
+	Title string
+	// 执行步骤，通过reporter报告进度说明和最终的标识符变化
+	// 步骤的开始和结束由界面根据返回值显示，StepStarted等调用会被忽略
+	Run func(ctx context.Context, reporter report.Reporter) error
+}
+
+// Labels 界面上显示的文本，由调用方按界面语言提供
+type Labels struct {
+	// 标题栏
+	Title string
+	// 当前界面语言的名称，显示在标题后，为空时不显示
+	Language string
+	// 菜单项：重置标识符、查看进程、退出
+	MenuReset     string
+	MenuProcesses string
+	MenuQuit      string
+	// 进程列表的标题和列表为空时的提示
+	ProcessesHeader string
+	NoProcesses     string
+	// 修改前后对比的标题和列名
+	ChangesHeader string
+	OldValue      string
+	NewValue      string
+	// 全部步骤成功和有步骤失败时的总结
+	Succeeded string
+	Failed    string
+	// 底部的按键说明
+	MenuHelp    string
+	BackHelp    string
+	RunningHelp string
+}
+
+// Options 界面的数据来源和操作
+type Options struct {
+	// 界面文本
+	Labels Labels
+	// 配色，未设置时使用默认主题
+	Palette ui.Palette
+	// 是否显示完整的标识符，为false时只显示开头和结尾
+	RevealIDs bool
+	// 返回当前运行中的编辑器进程，用于实时进程列表
+	Processes func(ctx context.Context) ([]process.ProcessInfo, error)
+	// 返回重置流程的各个步骤，每次选择重置时调用一次
+	ResetSteps func() []Step
+	// 在菜单中按l时切换到下一种界面语言，返回新语言的界面文本，为nil时不能切换
+	SwitchLanguage func() Labels
+}
+
+// Run 运行全屏界面，直到用户退出或ctx被取消
+func Run(ctx context.Context, opts Options) error {
+	if opts.Palette.Success == nil {
+		opts.Palette = ui.DefaultPalette()
+	}
+	m := &model{ctx: ctx, opts: opts}
+	program := tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(ctx), tea.WithOutput(ui.Console()))
+	m.send = program.Send
+	_, err := program.Run()
+	if err == tea.ErrProgramKilled && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// screen 当前显示的界面
+type screen int
+
+const (
+	screenMenu screen = iota
+	screenProcesses
+	screenRun
+)
+
+// 步骤的状态
+type stepState int
+
+const (
+	stepPending stepState = iota
+	stepRunning
+	stepDone
+	stepFailed
+)
+
+// stepStatus 步骤的执行情况
+type stepStatus struct {
+	// 步骤
+	step Step
+	// 状态
+	state stepState
+	// 步骤进行中的说明或失败原因
+	detail string
+	// 开始时间和耗时
+	started  time.Time
+	duration time.Duration
+}
+
+// 界面内部的消息
+type (
+	// processesMsg 进程列表刷新结果
+	processesMsg struct {
+		processes []process.ProcessInfo
+		err       error
+	}
+	// refreshMsg 定时刷新进程列表
+	refreshMsg struct{}
+	// progressMsg 步骤进行中的说明
+	progressMsg struct {
+		index  int
+		detail string
+	}
+	// stepDoneMsg 步骤执行结束
+	stepDoneMsg struct {
+		index int
+		err   error
+	}
+	// summaryMsg 步骤报告的标识符变化
+	summaryMsg struct {
+		changes []report.Change
+	}
+)
+
+// stepReporter 实现report.Reporter，将步骤中的报告转换为界面消息
+type stepReporter struct {
+	// 向界面发送消息
+	send func(tea.Msg)
+	// 步骤的序号
+	index int
+}
+
+// StepStarted 实现report.Reporter，步骤由界面自行开始
+func (r stepReporter) StepStarted(title string) {}
+
+// StepProgress 实现report.Reporter，显示在步骤标题后
+func (r stepReporter) StepProgress(message string) {
+	r.send(progressMsg{index: r.index, detail: message})
+}
+
+// StepSucceeded 实现report.Reporter，步骤结果由Run的返回值决定
+func (r stepReporter) StepSucceeded() {}
+
+// StepFailed 实现report.Reporter，步骤结果由Run的返回值决定
+func (r stepReporter) StepFailed(err error) {}
+
+// Summary 实现report.Reporter，在流程结束后显示
+func (r stepReporter) Summary(changes []report.Change) {
+	r.send(summaryMsg{changes: changes})
+}
+
+// model 界面状态，实现tea.Model
+type model struct {
+	// 传给各步骤和进程查询的上下文
+	ctx context.Context
+	// 数据来源和文本
+	opts Options
+	// 向界面发送消息，用于步骤进行中的进度
+	send func(tea.Msg)
+
+	// 当前界面和菜单光标
+	screen screen
+	cursor int
+
+	// 进程列表及最近一次刷新的错误
+	processes    []process.ProcessInfo
+	processesErr error
+
+	// 重置流程的步骤、是否全部结束以及修改前后的值
+	steps    []*stepStatus
+	finished bool
+	changes  []report.Change
+}
+
+// Init 实现tea.Model
+func (m *model) Init() tea.Cmd {
+	return nil
+}
+
+// Update 实现tea.Model
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case processesMsg:
+		m.processes, m.processesErr = msg.processes, msg.err
+		if m.screen == screenProcesses {
+			return m, tea.Tick(time.Second, func(time.Time) tea.Msg { return refreshMsg{} })
+		}
+	case refreshMsg:
+		if m.screen == screenProcesses {
+			return m, m.fetchProcesses()
+		}
+	case progressMsg:
+		if msg.index < len(m.steps) && m.steps[msg.index].state == stepRunning {
+			m.steps[msg.index].detail = msg.detail
+		}
+	case stepDoneMsg:
+		return m, m.finishStep(msg)
+	case summaryMsg:
+		m.changes = msg.changes
+	}
+	return m, nil
+}
+
+// handleKey 处理按键
+func (m *model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	switch m.screen {
+	case screenMenu:
+		switch key {
+		case "up", "k":
+			m.cursor = (m.cursor + 2) % 3
+		case "down", "j":
+			m.cursor = (m.cursor + 1) % 3
+		case "q", "esc":
+			return m, tea.Quit
+		case "enter", " ":
+			return m, m.choose()
+		case "l":
+			if m.opts.SwitchLanguage != nil {
+				m.opts.Labels = m.opts.SwitchLanguage()
+			}
+		}
+	case screenProcesses:
+		if key == "q" || key == "esc" || key == "enter" {
+			m.screen = screenMenu
+		}
+	case screenRun:
+		// 步骤执行中不响应返回，避免中途离开
+		if m.finished && (key == "q" || key == "esc" || key == "enter") {
+			m.screen = screenMenu
+		}
+	}
+	return m, nil
+}
+
+// choose 执行当前选中的菜单项
+func (m *model) choose() tea.Cmd {
+	switch m.cursor {
+	case 0:
+		m.screen = screenRun
+		m.finished = false
+		m.changes = nil
+		m.steps = nil
+		for _, step := range m.opts.ResetSteps() {
+			m.steps = append(m.steps, &stepStatus{step: step})
+		}
+		return m.startStep(0)
+	case 1:
+		m.screen = screenProcesses
+		return m.fetchProcesses()
+	default:
+		return tea.Quit
+	}
+}
+
+// fetchProcesses 在后台查询进程列表
+func (m *model) fetchProcesses() tea.Cmd {
+	return func() tea.Msg {
+		processes, err := m.opts.Processes(m.ctx)
+		return processesMsg{processes: processes, err: err}
+	}
+}
+
+// startStep 在后台执行第index个步骤，没有更多步骤时结束流程
+func (m *model) startStep(index int) tea.Cmd {
+	if index >= len(m.steps) {
+		m.finished = true
+		return nil
+	}
+	status := m.steps[index]
+	status.state = stepRunning
+	status.started = time.Now()
+	return func() tea.Msg {
+		err := status.step.Run(m.ctx, stepReporter{send: m.send, index: index})
+		return stepDoneMsg{index: index, err: err}
+	}
+}
+
+// finishStep 记录步骤结果，成功时继续下一个步骤，失败时停止
+func (m *model) finishStep(msg stepDoneMsg) tea.Cmd {
+	status := m.steps[msg.index]
+	status.duration = time.Since(status.started)
+	if msg.err != nil {
+		status.state = stepFailed
+		status.detail = msg.err.Error()
+		m.finished = true
+		return nil
+	}
+	status.state = stepDone
+	status.detail = ""
+	return m.startStep(msg.index + 1)
+}
+
+// View 实现tea.Model
+func (m *model) View() string {
+	labels := m.opts.Labels
+	var b strings.Builder
+	b.WriteString(m.opts.Palette.Accent.Sprint(labels.Title))
+	if labels.Language != "" {
+		b.WriteString(m.opts.Palette.Muted.Sprint("  · " + labels.Language))
+	}
+	b.WriteString("\n\n")
+
+	switch m.screen {
+	case screenMenu:
+		for i, item := range []string{labels.MenuReset, labels.MenuProcesses, labels.MenuQuit} {
+			if i == m.cursor {
+				b.WriteString(m.opts.Palette.Accent.Sprintf("> %s", item))
+			} else {
+				fmt.Fprintf(&b, "  %s", item)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n" + m.opts.Palette.Muted.Sprint(labels.MenuHelp))
+	case screenProcesses:
+		b.WriteString(m.processesView())
+		b.WriteString("\n" + m.opts.Palette.Muted.Sprint(labels.BackHelp))
+	case screenRun:
+		b.WriteString(m.stepsView())
+		if m.finished {
+			b.WriteString(m.changesView())
+			b.WriteString("\n" + m.opts.Palette.Muted.Sprint(labels.BackHelp))
+		} else {
+			b.WriteString("\n" + m.opts.Palette.Muted.Sprint(labels.RunningHelp))
+		}
+	}
+	return b.String()
+}
+
+// processesView 渲染实时进程列表
+func (m *model) processesView() string {
+	labels := m.opts.Labels
+	var b strings.Builder
+	b.WriteString(labels.ProcessesHeader + "\n\n")
+	if m.processesErr != nil {
+		b.WriteString(m.opts.Palette.Error.Sprint(m.processesErr.Error()) + "\n")
+		return b.String()
+	}
+	if len(m.processes) == 0 {
+		b.WriteString(m.opts.Palette.Muted.Sprint(labels.NoProcesses) + "\n")
+		return b.String()
+	}
+	for _, p := range m.processes {
+		fmt.Fprintf(&b, "  %-7d %s %s\n", p.PID, runewidth.FillRight(p.Username, 16), p.Name)
+		if p.Exe != "" {
+			b.WriteString(m.opts.Palette.Muted.Sprintf("          %s", p.Exe) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// stepsView 渲染各步骤的状态和耗时
+func (m *model) stepsView() string {
+	var b strings.Builder
+	succeeded, failed := m.opts.Palette.Marks("√", "×")
+	for i, status := range m.steps {
+		prefix := fmt.Sprintf("[%d/%d] ", i+1, len(m.steps))
+		switch status.state {
+		case stepPending:
+			b.WriteString(m.opts.Palette.Muted.Sprint("    " + prefix + status.step.Title))
+		case stepRunning:
+			b.WriteString(m.opts.Palette.Accent.Sprint(" >  " + prefix + status.step.Title))
+			if status.detail != "" {
+				b.WriteString(m.opts.Palette.Muted.Sprint("  " + status.detail))
+			}
+		case stepDone:
+			b.WriteString(m.opts.Palette.Success.Sprint(" " + succeeded + "  " + prefix + status.step.Title))
+			b.WriteString(m.opts.Palette.Muted.Sprintf("  (%s)", status.duration.Round(time.Millisecond)))
+		case stepFailed:
+			b.WriteString(m.opts.Palette.Error.Sprint(" " + failed + "  " + prefix + status.step.Title))
+			b.WriteString("\n      " + m.opts.Palette.Error.Sprint(status.detail))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// changesView 渲染修改前后的对比和总结
+func (m *model) changesView() string {
+	labels := m.opts.Labels
+	var b strings.Builder
+	b.WriteString("\n")
+
+	failed := false
+	for _, status := range m.steps {
+		failed = failed || status.state == stepFailed
+	}
+	if failed {
+		b.WriteString(m.opts.Palette.Error.Sprint(labels.Failed) + "\n")
+		return b.String()
+	}
+
+	if len(m.changes) > 0 {
+		b.WriteString(labels.ChangesHeader + "\n\n")
+		// 按显示宽度对齐旧值和新值，中文标签每个字占两列
+		labelWidth := max(runewidth.StringWidth(labels.OldValue), runewidth.StringWidth(labels.NewValue))
+		oldLabel := runewidth.FillRight(labels.OldValue, labelWidth)
+		newLabel := runewidth.FillRight(labels.NewValue, labelWidth)
+		for _, change := range m.changes {
+			old, updated := change.Old, change.New
+			if !m.opts.RevealIDs {
+				old, updated = ui.MaskID(old), ui.MaskID(updated)
+			}
+			if old == "" {
+				old = "-"
+			}
+			fmt.Fprintf(&b, "  %s\n", change.Field)
+			fmt.Fprintf(&b, "    %s %s\n", m.opts.Palette.Muted.Sprint(oldLabel), old)
+			fmt.Fprintf(&b, "    %s %s\n", m.opts.Palette.Success.Sprint(newLabel), updated)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(m.opts.Palette.Success.Sprint(labels.Succeeded) + "\n")
+	return b.String()
+}
@@ -0,0 +1,92 @@
+// UI包
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+)
+
+// AccessibleText 无障碍模式下使用的文本，由调用方按界面语言提供
+type AccessibleText struct {
+	// 步骤开始，占位符为当前步骤、步骤总数和步骤标题，例如"Step {{.Step}} of {{.Total}}: {{.Title}}"
+	StepStarted string
+	// 步骤完成，占位符为{{.Step}}、{{.Total}}和耗时{{.Elapsed}}
+	StepFinished string
+	// 步骤失败，占位符为{{.Step}}和{{.Total}}
+	StepFailed string
+	// 不带编号的步骤完成和失败，完成时占位符为耗时{{.Elapsed}}
+	Finished string
+	Failed   string
+	// 成功、警告和错误消息的前缀，代替颜色和[√]等符号表达消息的类型
+	Success string
+	Warning string
+	Error   string
+}
+
+// 消息开头表示消息类型的符号，无障碍模式下由文字前缀代替
+var messageMarkers = []string{"[√]", "[!]", "[×]"}
+
+// SetAccessible 启用无障碍模式，供屏幕阅读器使用
+// 不显示动画、颜色和Logo，不移动光标，每次状态变化输出一句完整的话
+func (d *Display) SetAccessible(text AccessibleText) {
+	d.accessible = &text
+	d.plain = true
+	d.spinner.SetPlain(true)
+	color.NoColor = true
+}
+
+// IsAccessible 返回是否启用了无障碍模式
+func (d *Display) IsAccessible() bool {
+	return d.accessible != nil
+}
+
+// accessibleMessage 去掉消息开头的符号，加上文字前缀并补全句末标点
+func (d *Display) accessibleMessage(prefix, message string) string {
+	message = strings.TrimSpace(message)
+	for _, marker := range messageMarkers {
+		message = strings.TrimSpace(strings.TrimPrefix(message, marker))
+	}
+	return prefix + sentence(message)
+}
+
+// sentence 将进度消息整理为完整的句子：去掉结尾的省略号，没有句末标点时补上
+func sentence(message string) string {
+	message = strings.TrimRight(strings.TrimSpace(message), ".…")
+	last, _ := utf8.DecodeLastRuneInString(message)
+	switch {
+	case message == "", unicode.IsPunct(last):
+		return message
+	case last > unicode.MaxLatin1:
+		// 中文等全角文本使用全角句号
+		return message + "。"
+	default:
+		return message + "."
+	}
+}
+
+// accessibleStep 返回步骤开始时输出的句子
+func (d *Display) accessibleStep(title string) string {
+	if d.steps.total == 0 {
+		return sentence(title)
+	}
+	return expand(d.accessible.StepStarted, map[string]any{"Step": d.steps.current, "Total": d.steps.total, "Title": sentence(title)})
+}
+
+// accessibleStepResult 输出步骤结束的句子
+func (d *Display) accessibleStepResult(failed bool, elapsed time.Duration) {
+	switch {
+	case d.steps.total == 0 && failed:
+		fmt.Println(d.accessible.Failed)
+	case d.steps.total == 0:
+		fmt.Println(expand(d.accessible.Finished, map[string]any{"Elapsed": elapsed}))
+	case failed:
+		fmt.Println(expand(d.accessible.StepFailed, map[string]any{"Step": d.steps.current, "Total": d.steps.total}))
+	default:
+		fmt.Println(expand(d.accessible.StepFinished, map[string]any{"Step": d.steps.current, "Total": d.steps.total, "Elapsed": elapsed}))
+	}
+}
@@ -0,0 +1,41 @@
+// UI包
+package ui
+
+// Unicode双向文本控制字符，支持双向文本的终端据此排列从右到左的文本
+const (
+	// rightToLeftMark 从右到左标记，放在行首使整行按从右到左的段落显示
+	rightToLeftMark = "\u200f"
+	// firstStrongIsolate 隔离一段方向由其第一个强方向字符决定的文本，例如路径和标识符
+	firstStrongIsolate = "\u2068"
+	// popDirectionalIsolate 结束隔离
+	popDirectionalIsolate = "\u2069"
+)
+
+// SetRTL 设置界面语言是否为从右到左书写（例如阿拉伯语、希伯来语）
+// 启用后每行以从右到左标记开头，状态前缀、表格单元格和摘要的值单独隔离，
+// 文本保持逻辑顺序，由终端的双向文本算法排列，避免嵌入的路径和标识符打乱整行的顺序
+func (d *Display) SetRTL(rtl bool) {
+	d.rtl = rtl
+}
+
+// rtlLine 从右到左界面中在行首加上从右到左标记
+func (d *Display) rtlLine(line string) string {
+	if !d.rtl {
+		return line
+	}
+	return rightToLeftMark + line
+}
+
+// isolate 从右到左界面中隔离一段可能包含从左到右文本的内容
+// 应在按显示宽度截断和补齐之后调用，控制字符不占显示宽度
+func (d *Display) isolate(text string) string {
+	if !d.rtl || text == "" {
+		return text
+	}
+	return firstStrongIsolate + text + popDirectionalIsolate
+}
+
+// isBidiControl 判断字符是否为双向文本控制字符，这些字符不占显示宽度
+func isBidiControl(r rune) bool {
+	return r == '\u200e' || r == '\u200f' || (r >= '\u202a' && r <= '\u202e') || (r >= '\u2066' && r <= '\u2069')
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+// UI包
+package ui
+
+// supportsVT 其他系统的终端都支持ANSI控制序列
+func supportsVT() bool {
+	return true
+}
+
+// clearConsole 其他系统通过控制序列清屏，不会调用到这里
+func clearConsole() error {
+	return nil
+}
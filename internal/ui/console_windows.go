@@ -0,0 +1,66 @@
+// UI包
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// 控制台填充函数，x/sys/windows中没有封装
+var (
+	kernel32                       = windows.NewLazySystemDLL("kernel32.dll")
+	procFillConsoleOutputCharacter = kernel32.NewProc("FillConsoleOutputCharacterW")
+	procFillConsoleOutputAttribute = kernel32.NewProc("FillConsoleOutputAttribute")
+)
+
+var (
+	// 确保只尝试启用一次虚拟终端处理
+	vtOnce sync.Once
+	// 标准输出是否已启用虚拟终端处理
+	vtEnabled bool
+)
+
+// supportsVT 为标准输出和标准错误启用ENABLE_VIRTUAL_TERMINAL_PROCESSING，返回标准输出是否支持ANSI控制序列
+// 较早的Windows 10 conhost默认不处理控制序列，旧版控制台无法启用时返回false
+func supportsVT() bool {
+	vtOnce.Do(func() {
+		vtEnabled = enableVT(windows.Stdout)
+		enableVT(windows.Stderr)
+	})
+	return vtEnabled
+}
+
+// enableVT 为控制台句柄启用虚拟终端处理，句柄不是控制台或控制台不支持时返回false
+func enableVT(handle windows.Handle) bool {
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return false
+	}
+	if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return true
+	}
+	return windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
+
+// clearConsole 通过控制台API清除屏幕缓冲区并将光标移到左上角，用于不支持控制序列的旧版控制台
+func clearConsole() error {
+	var info windows.ConsoleScreenBufferInfo
+	if err := windows.GetConsoleScreenBufferInfo(windows.Stdout, &info); err != nil {
+		return fmt.Errorf("failed to get console buffer info: %w", err)
+	}
+
+	var written uint32
+	cells := uint32(info.Size.X) * uint32(info.Size.Y)
+	// COORD按值传递，起点(0,0)打包后为0
+	const origin = 0
+	if ret, _, err := procFillConsoleOutputCharacter.Call(uintptr(windows.Stdout), uintptr(' '), uintptr(cells), origin, uintptr(unsafe.Pointer(&written))); ret == 0 {
+		return fmt.Errorf("failed to clear console: %w", err)
+	}
+	if ret, _, err := procFillConsoleOutputAttribute.Call(uintptr(windows.Stdout), uintptr(info.Attributes), uintptr(cells), origin, uintptr(unsafe.Pointer(&written))); ret == 0 {
+		return fmt.Errorf("failed to reset console attributes: %w", err)
+	}
+	return windows.SetConsoleCursorPosition(windows.Stdout, windows.Coord{})
+}
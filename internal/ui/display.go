@@ -3,18 +3,33 @@ package ui
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
-	"runtime"
 	"strings"
 
-	"github.com/fatih/color"
+	"golang.org/x/term"
 )
 
 // Display 处理终端输出的UI操作
 type Display struct {
 	// 进度旋转器
 	spinner *Spinner
+	// 多步骤流程的进度，为nil时进度消息不带步骤编号
+	steps *stepTracker
+	// 输出不是终端（例如重定向到文件）时不输出动画和光标控制序列
+	plain bool
+	// 是否为CI日志输出，每个步骤只输出开始和结束两行
+	ci bool
+	// 无障碍模式的文本，为nil时未启用无障碍模式
+	accessible *AccessibleText
+	// 各类消息使用的颜色
+	palette Palette
+	// 启动时显示的Logo，为空时不显示
+	logo string
+	// Summary输出的表格列标题
+	tableHeaders IDTableHeaders
+	// 是否显示完整的标识符，为false时只显示开头和结尾
+	revealIDs bool
+	// 界面语言是否为从右到左书写
+	rtl bool
 }
 
 // NewDisplay 创建一个新的显示实例，可选提供旋转器
@@ -22,32 +37,65 @@ func NewDisplay(spinner *Spinner) *Display {
 	if spinner == nil {
 		spinner = NewSpinner(nil)
 	}
-	return &Display{spinner: spinner}
+	return &Display{spinner: spinner, plain: !IsTerminal(console), palette: DefaultPalette(), logo: GetLogo(), tableHeaders: defaultTableHeaders}
 }
 
 // 终端操作
 
-// ClearScreen 根据操作系统清除终端屏幕
+// ClearScreen 清除终端屏幕，输出不是终端时不做任何操作
+// 优先使用ANSI控制序列，旧版Windows控制台不支持时改用控制台API
 func (d *Display) ClearScreen() error {
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "windows":
-		cmd = exec.Command("cmd", "/c", "cls")
-	default:
-		cmd = exec.Command("clear")
+	// 无障碍模式下清屏会让屏幕阅读器丢失之前的内容
+	if d.accessible != nil || d.ci || !term.IsTerminal(int(console.Fd())) {
+		return nil
 	}
-	cmd.Stdout = os.Stdout
-	return cmd.Run()
+	if supportsVT() {
+		// 光标移到左上角，清除屏幕和回滚缓冲区
+		fmt.Print("\033[H\033[2J\033[3J")
+		return nil
+	}
+	return clearConsole()
+}
+
+// SetCI 启用适合CI日志的输出：不显示动画，不用\r改写行，不清屏
+// 每个步骤开始时只输出一行以…结尾的消息，进度更新不另起新行，结束时输出结果和耗时
+func (d *Display) SetCI() {
+	d.ci = true
+	d.plain = true
+	d.spinner.SetPlain(true)
+	d.spinner.SetQuiet(true)
+}
+
+// IsPlain 返回是否为逐行输出模式，即输出不是终端
+func (d *Display) IsPlain() bool {
+	return d.plain
 }
 
 // 进度指示器
 
-// ShowProgress 显示带有旋转器的进度消息
+// ShowProgress 显示带有旋转器的进度消息，处于多步骤流程中时带有步骤编号
 func (d *Display) ShowProgress(message string) {
-	d.spinner.SetMessage(message)
+	d.spinner.SetMessage(d.progressMessage(message))
 	d.spinner.Start()
 }
 
+// UpdateProgress 更新正在显示的进度消息
+func (d *Display) UpdateProgress(message string) {
+	d.spinner.SetMessage(d.progressMessage(message))
+}
+
+// progressMessage 返回显示的进度消息，无障碍模式下整理为完整的句子
+func (d *Display) progressMessage(message string) string {
+	if d.accessible != nil {
+		return sentence(message)
+	}
+	if d.ci {
+		return d.rtlLine(d.stepPrefix() + strings.TrimRight(message, ".…") + "…")
+	}
+	// 从右到左标记不必位于行首，只需在第一个强方向字符之前，旋转器的符号没有方向
+	return d.rtlLine(d.stepPrefix() + message)
+}
+
 // StopProgress 停止进度旋转器
 func (d *Display) StopProgress() {
 	d.spinner.Stop()
@@ -55,47 +103,72 @@ func (d *Display) StopProgress() {
 
 // 消息显示
 
-// ShowSuccess 以绿色显示成功消息
+// ShowSuccess 以成功消息的颜色显示消息
 func (d *Display) ShowSuccess(messages ...string) {
-	green := color.New(color.FgGreen)
 	for _, msg := range messages {
-		green.Println(msg)
+		if d.accessible != nil {
+			msg = d.accessibleMessage(d.accessible.Success, msg)
+		} else {
+			msg = d.prefixedMessage(d.palette.SuccessPrefix, msg)
+		}
+		d.println(d.palette.Success, msg)
 	}
 }
 
-// ShowInfo 以青色显示信息消息
+// ShowInfo 以信息消息的颜色显示消息
 func (d *Display) ShowInfo(message string) {
-	cyan := color.New(color.FgCyan)
-	cyan.Println(message)
+	if d.accessible != nil {
+		message = d.accessibleMessage("", message)
+	}
+	d.println(d.palette.Info, message)
+}
+
+// ShowWarning 以警告消息的颜色显示消息
+func (d *Display) ShowWarning(message string) {
+	if d.accessible != nil {
+		message = d.accessibleMessage(d.accessible.Warning, message)
+	} else {
+		message = d.prefixedMessage(d.palette.WarningPrefix, message)
+	}
+	d.println(d.palette.Warning, message)
 }
 
-// ShowError 以红色显示错误消息
+// ShowError 以错误消息的颜色显示消息
 func (d *Display) ShowError(message string) {
-	red := color.New(color.FgRed)
-	red.Println(message)
+	if d.accessible != nil {
+		message = d.accessibleMessage(d.accessible.Error, message)
+	} else {
+		message = d.prefixedMessage(d.palette.ErrorPrefix, message)
+	}
+	d.println(d.palette.Error, message)
 }
 
-// ShowPrivilegeError 显示权限错误消息及操作指导
+// prefixedMessage 将消息开头的[√]等符号替换为配色主题的文字前缀，主题没有文字前缀时不修改
+func (d *Display) prefixedMessage(prefix, message string) string {
+	if prefix == "" {
+		return message
+	}
+	// 保留开头用于分隔的空行
+	body := strings.TrimLeft(message, "\n")
+	lead := message[:len(message)-len(body)]
+	for _, marker := range messageMarkers {
+		body = strings.TrimSpace(strings.TrimPrefix(body, marker))
+	}
+	// 从右到左界面中前缀在逻辑上位于开头，隔离后不会与之后的从左到右文本连在一起
+	return lead + d.isolate(prefix) + " " + body
+}
+
+// ShowPrivilegeError 显示权限错误消息及操作指导，指导说明由调用方格式化
 func (d *Display) ShowPrivilegeError(messages ...string) {
-	red := color.New(color.FgRed, color.Bold)
-	yellow := color.New(color.FgYellow)
+	red := d.palette.Error
+	yellow := d.palette.Warning
 
 	// 主要错误消息
-	red.Println(messages[0])
+	d.println(red, d.prefixedMessage(d.palette.ErrorPrefix, messages[0]))
 	fmt.Println()
 
 	// 附加指导说明
 	for _, msg := range messages[1:] {
-		if strings.Contains(msg, "%s") {
-			exe, _ := os.Executable()
-			yellow.Printf(msg+"\n", exe)
-		} else {
-			yellow.Println(msg)
-		}
+		d.println(yellow, msg)
 	}
 }
-
-// ShowLogo 显示应用程序的Logo
-func (d *Display) ShowLogo() {
-	fmt.Println(GetLogo())
-}
@@ -2,7 +2,10 @@
 package ui
 
 import (
-	"github.com/fatih/color"
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
 )
 
 // Cursor应用程序的ASCII艺术Logo
@@ -19,3 +22,32 @@ const cyberpunkLogo = `
 func GetLogo() string {
 	return cyberpunkLogo
 }
+
+// SetLogo 设置启动时显示的Logo，例如配置文件中的自定义横幅，为空时不显示
+func (d *Display) SetLogo(logo string) {
+	d.logo = logo
+}
+
+// ShowLogo 显示应用程序的Logo，返回是否显示了Logo
+// 无障碍模式下、Logo为空或终端宽度不足以完整显示Logo时不显示
+func (d *Display) ShowLogo() bool {
+	if d.accessible != nil || d.logo == "" {
+		return false
+	}
+	if width, ok := terminalWidth(console); ok && width < logoWidth(d.logo) {
+		return false
+	}
+	fmt.Println(d.logo)
+	return true
+}
+
+// logoWidth 返回Logo最宽一行的显示宽度
+func logoWidth(logo string) int {
+	width := 0
+	for _, line := range strings.Split(logo, "\n") {
+		if w := runewidth.StringWidth(line); w > width {
+			width = w
+		}
+	}
+	return width
+}
@@ -0,0 +1,31 @@
+// UI包
+package ui
+
+// 遮盖标识符时开头和结尾各保留的字符数
+const maskKeep = 4
+
+// MaskID 只保留标识符开头和结尾各4个字符，中间以****代替，避免截图公开分享时泄露完整的值
+// 不超过8个字符的值全部遮盖
+func MaskID(value string) string {
+	if value == "" {
+		return ""
+	}
+	runes := []rune(value)
+	if len(runes) <= maskKeep*2 {
+		return "****"
+	}
+	return string(runes[:maskKeep]) + "****" + string(runes[len(runes)-maskKeep:])
+}
+
+// SetRevealIDs 设置是否显示完整的标识符，默认只显示开头和结尾
+func (d *Display) SetRevealIDs(reveal bool) {
+	d.revealIDs = reveal
+}
+
+// FormatID 返回显示的标识符，未设置显示完整标识符时遮盖中间部分
+func (d *Display) FormatID(value string) string {
+	if d.revealIDs {
+		return value
+	}
+	return MaskID(value)
+}
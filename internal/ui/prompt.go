@@ -0,0 +1,222 @@
+// UI包
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// PromptText 提示使用的文本，按界面语言提供
+type PromptText struct {
+	// 默认回答为是和否时附加在问题后的提示，例如"(Y/n)"和"(y/N)"
+	YesHint string
+	NoHint  string
+	// 显示自动选择的回答时使用的是和否
+	Yes string
+	No  string
+	// 输入的序号无效，占位符{{.Count}}为选项数量
+	InvalidChoice string
+	// 等待超时后使用默认值，占位符{{.Timeout}}为等待时间
+	TimedOut string
+}
+
+// 未设置时使用的提示文本
+var defaultPromptText = PromptText{
+	YesHint:       "(Y/n)",
+	NoHint:        "(y/N)",
+	Yes:           "yes",
+	No:            "no",
+	InvalidChoice: "Please enter a number from 1 to {{.Count}}.",
+	TimedOut:      "No answer within {{.Timeout}}, using the default.",
+}
+
+// Prompt 向用户提问并读取回答，支持是/否确认、选择列表和文本输入
+// 问题文本不带结尾的冒号，由Prompt附加默认值提示和冒号
+// 指定-yes时确认直接回答是，自动化模式下直接使用默认值，
+// 输入不是终端时最多等待timeout，超时后使用默认值
+type Prompt struct {
+	// 读取回答的输入
+	in *os.File
+	// 输入是否为终端
+	terminal bool
+	// 逐行读取输入的通道，第一次提问时开始读取，输入结束时关闭
+	lines chan string
+	// 保证只启动一次读取
+	once sync.Once
+	// 提示文本
+	text PromptText
+	// 确认提示是否直接回答是
+	assumeYes bool
+	// 是否不提问，直接使用默认值
+	nonInteractive bool
+	// 输入不是终端时等待回答的时间，为0时一直等待
+	timeout time.Duration
+}
+
+// NewPrompt 创建一个从in读取回答的提示组件
+func NewPrompt(in *os.File) *Prompt {
+	return &Prompt{
+		in:       in,
+		terminal: term.IsTerminal(int(in.Fd())),
+		text:     defaultPromptText,
+	}
+}
+
+// SetText 设置提示文本
+func (p *Prompt) SetText(text PromptText) {
+	p.text = text
+}
+
+// SetAssumeYes 设置确认提示是否直接回答是，选择和输入仍使用默认值
+func (p *Prompt) SetAssumeYes(assumeYes bool) {
+	p.assumeYes = assumeYes
+}
+
+// SetNonInteractive 设置是否不提问，所有提示直接使用默认值，例如提权后的自动化模式
+func (p *Prompt) SetNonInteractive(nonInteractive bool) {
+	p.nonInteractive = nonInteractive
+}
+
+// SetTimeout 设置输入不是终端时等待回答的时间，为0时一直等待
+func (p *Prompt) SetTimeout(timeout time.Duration) {
+	p.timeout = timeout
+}
+
+// Confirm 询问是或否，直接回车时返回def
+func (p *Prompt) Confirm(question string, def bool) bool {
+	hint := p.text.NoHint
+	if def {
+		hint = p.text.YesHint
+	}
+	fmt.Printf("%s %s: ", question, hint)
+
+	if p.assumeYes || p.nonInteractive {
+		answer := p.assumeYes || def
+		fmt.Println(p.answerText(answer))
+		return answer
+	}
+	answer, ok := p.readLine()
+	if !ok || answer == "" {
+		return def
+	}
+	// 除y/yes外，也接受当前语言的"是"及其开头部分，例如德语的j和ja
+	answer = strings.ToLower(answer)
+	switch {
+	case answer == "y", answer == "yes", strings.HasPrefix(strings.ToLower(p.text.Yes), answer):
+		return true
+	default:
+		return false
+	}
+}
+
+// Select 显示编号的选项列表，然后提问并读取选择，返回选项的下标
+// def为-1时没有默认值，直接回车时返回-1；输入无效时重新询问
+func (p *Prompt) Select(question string, options []string, def int) int {
+	for i, option := range options {
+		fmt.Printf("  [%d] %s\n", i+1, option)
+	}
+
+	for {
+		if def >= 0 {
+			fmt.Printf("%s [%d]: ", question, def+1)
+		} else {
+			fmt.Printf("%s: ", question)
+		}
+		if p.assumeYes || p.nonInteractive {
+			if def >= 0 {
+				fmt.Println(def + 1)
+			} else {
+				fmt.Println()
+			}
+			return def
+		}
+
+		answer, ok := p.readLine()
+		if !ok || answer == "" {
+			return def
+		}
+		index, err := strconv.Atoi(answer)
+		if err == nil && index >= 1 && index <= len(options) {
+			return index - 1
+		}
+		fmt.Println(expand(p.text.InvalidChoice, map[string]any{"Count": len(options)}))
+	}
+}
+
+// Input 读取一行文本，直接回车时返回def
+func (p *Prompt) Input(question, def string) string {
+	if def != "" {
+		fmt.Printf("%s [%s]: ", question, def)
+	} else {
+		fmt.Printf("%s: ", question)
+	}
+	if p.assumeYes || p.nonInteractive {
+		fmt.Println(def)
+		return def
+	}
+	answer, ok := p.readLine()
+	if !ok || answer == "" {
+		return def
+	}
+	return answer
+}
+
+// Wait 显示消息并等待用户按下Enter键，自动化模式下不等待
+func (p *Prompt) Wait(message string) {
+	if p.nonInteractive {
+		return
+	}
+	fmt.Print(message)
+	os.Stdout.Sync()
+	p.readLine()
+}
+
+// answerText 返回自动选择的回答的显示文本
+func (p *Prompt) answerText(answer bool) string {
+	if answer {
+		return p.text.Yes
+	}
+	return p.text.No
+}
+
+// readLine 读取一行回答，输入结束或等待超时时返回false
+func (p *Prompt) readLine() (string, bool) {
+	p.once.Do(func() {
+		p.lines = make(chan string)
+		go func() {
+			defer close(p.lines)
+			scanner := bufio.NewScanner(p.in)
+			for scanner.Scan() {
+				p.lines <- scanner.Text()
+			}
+		}()
+	})
+
+	// 只有输入不是终端时才会超时，终端前的用户可能需要时间阅读提示
+	var timeout <-chan time.Time
+	if !p.terminal && p.timeout > 0 {
+		timer := time.NewTimer(p.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case line, ok := <-p.lines:
+		if !ok {
+			fmt.Println()
+			return "", false
+		}
+		return strings.TrimSpace(line), true
+	case <-timeout:
+		fmt.Println()
+		fmt.Println(expand(p.text.TimedOut, map[string]any{"Timeout": p.timeout}))
+		return "", false
+	}
+}
@@ -0,0 +1,37 @@
+// UI包
+package ui
+
+import "github.com/yuaotian/go-cursor-help/internal/report"
+
+// Display实现report.Reporter，作为控制台前端
+var _ report.Reporter = (*Display)(nil)
+
+// SetIDTableHeaders 设置Summary输出的表格列标题
+func (d *Display) SetIDTableHeaders(headers IDTableHeaders) {
+	d.tableHeaders = headers
+}
+
+// StepStarted 实现report.Reporter，显示带步骤编号的进度
+func (d *Display) StepStarted(title string) {
+	d.StartStep(title)
+}
+
+// StepProgress 实现report.Reporter，更新进度消息
+func (d *Display) StepProgress(message string) {
+	d.UpdateProgress(message)
+}
+
+// StepSucceeded 实现report.Reporter，输出步骤完成及其耗时
+func (d *Display) StepSucceeded() {
+	d.FinishStep()
+}
+
+// StepFailed 实现report.Reporter，输出步骤失败，错误详情由调用方显示
+func (d *Display) StepFailed(err error) {
+	d.FailStep()
+}
+
+// Summary 实现report.Reporter，以表格显示各标识符修改前后的值
+func (d *Display) Summary(changes []report.Change) {
+	d.ShowIDTable(d.tableHeaders, changes)
+}
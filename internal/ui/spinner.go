@@ -39,6 +39,12 @@ type Spinner struct {
 	stopCh  chan struct{}
 	// 同步互斥锁
 	mu      sync.RWMutex
+	// 输出不是终端时逐行输出消息，不显示动画
+	plain   bool
+	// 逐行输出时不输出消息的更新，每次Start只输出一行
+	quiet   bool
+	// 动画帧的颜色
+	color   *color.Color
 }
 
 // NewSpinner 创建一个具有给定配置的新旋转器
@@ -49,6 +55,8 @@ func NewSpinner(config *SpinnerConfig) *Spinner {
 	return &Spinner{
 		config: config,
 		stopCh: make(chan struct{}),
+		plain:  !IsTerminal(console),
+		color:  DefaultPalette().Accent,
 	}
 }
 
@@ -58,9 +66,34 @@ func NewSpinner(config *SpinnerConfig) *Spinner {
 func (s *Spinner) SetMessage(message string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	// 逐行输出时只在消息变化时输出新的一行
+	if s.plain && !s.quiet && s.active && message != s.message {
+		fmt.Println(message)
+	}
 	s.message = message
 }
 
+// SetColor 设置动画帧的颜色
+func (s *Spinner) SetColor(c *color.Color) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.color = c
+}
+
+// SetPlain 设置是否逐行输出消息而不显示动画
+func (s *Spinner) SetPlain(plain bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.plain = plain
+}
+
+// SetQuiet 设置逐行输出时是否只在Start时输出一行，不输出之后的消息更新
+func (s *Spinner) SetQuiet(quiet bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quiet = quiet
+}
+
 // IsActive 返回旋转器当前是否处于活动状态
 func (s *Spinner) IsActive() bool {
 	s.mu.RLock()
@@ -78,6 +111,11 @@ func (s *Spinner) Start() {
 		return
 	}
 	s.active = true
+	if s.plain {
+		fmt.Println(s.message)
+		s.mu.Unlock()
+		return
+	}
 	s.mu.Unlock()
 
 	go s.run()
@@ -93,6 +131,9 @@ func (s *Spinner) Stop() {
 	}
 
 	s.active = false
+	if s.plain {
+		return
+	}
 	close(s.stopCh)
 	s.stopCh = make(chan struct{})
 	fmt.Print("\r") // 清除旋转器行
@@ -105,11 +146,26 @@ func (s *Spinner) run() {
 	ticker := time.NewTicker(s.config.Delay)
 	defer ticker.Stop()
 
-	cyan := color.New(color.FgCyan, color.Bold)
+	s.mu.RLock()
+	accent := s.color
 	message := s.message
+	s.mu.RUnlock()
+
+	// 消息从第4列开始，超出终端宽度时截断，避免折行后\r无法回到行首
+	// 按显示宽度而不是字节数计算，中文每个字占两列
+	available := 0
+	if width, ok := terminalWidth(console); ok {
+		available = width - 4
+	}
+	fit := func(message string) string {
+		if available <= 0 {
+			return message
+		}
+		return truncate(message, available)
+	}
 
 	// 打印初始状态
-	fmt.Printf("\r %s %s", cyan.Sprint(s.config.Frames[0]), message)
+	fmt.Printf("\r %s %s", accent.Sprint(s.config.Frames[0]), fit(message))
 
 	for {
 		select {
@@ -123,10 +179,12 @@ func (s *Spinner) run() {
 			}
 			frame := s.config.Frames[s.current%len(s.config.Frames)]
 			s.current++
+			// 运行中可以通过SetMessage更新消息
+			message = s.message
 			s.mu.RUnlock()
 
-			fmt.Printf("\r %s", cyan.Sprint(frame))
-			fmt.Printf("\033[%dG%s", 4, message) // 移动光标并打印消息
+			fmt.Printf("\r %s", accent.Sprint(frame))
+			fmt.Printf("\033[%dG%s\033[K", 4, fit(message)) // 移动光标并打印消息，清除旧消息多余的部分
 		}
 	}
 }
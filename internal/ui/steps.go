@@ -0,0 +1,103 @@
+// UI包
+package ui
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// stepTracker 记录多步骤流程的进度
+type stepTracker struct {
+	// 步骤总数，为0表示步骤不带编号
+	total int
+	// 当前步骤的序号，从1开始，为0表示还没有开始
+	current int
+	// 当前步骤的标题
+	title string
+	// 当前步骤的开始时间
+	started time.Time
+	// 当前步骤是否已结束
+	finished bool
+}
+
+// BeginSteps 开始一个共有total个步骤的流程，之后的进度消息带有"[3/5]"形式的步骤编号
+func (d *Display) BeginSteps(total int) {
+	d.steps = &stepTracker{total: total}
+}
+
+// StartStep 开始下一个步骤，显示带步骤编号的进度消息
+// 没有调用BeginSteps时步骤不带编号，结束时同样输出结果和耗时
+func (d *Display) StartStep(title string) {
+	if d.steps == nil {
+		d.steps = &stepTracker{}
+	}
+	d.steps.current++
+	d.steps.title = title
+	d.steps.started = time.Now()
+	d.steps.finished = false
+	if d.accessible != nil {
+		d.ShowProgress(d.accessibleStep(title))
+		return
+	}
+	d.ShowProgress(title)
+}
+
+// FinishStep 停止进度显示，将步骤行重新输出为带✓和耗时的一行，留在回滚记录中
+func (d *Display) FinishStep() {
+	d.endStep(false)
+}
+
+// FailStep 停止进度显示，将步骤行重新输出为带✗和耗时的一行
+func (d *Display) FailStep() {
+	d.endStep(true)
+}
+
+// endStep 停止进度显示并输出当前步骤的结果
+func (d *Display) endStep(failed bool) {
+	d.StopProgress()
+	if d.steps == nil || d.steps.current == 0 || d.steps.finished {
+		// 不在多步骤流程中时保留进度消息，另起一行；逐行输出时消息已经换行
+		if !d.plain {
+			fmt.Println()
+		}
+		return
+	}
+	prefix := d.stepPrefix()
+	d.steps.finished = true
+	elapsed := time.Since(d.steps.started).Round(time.Millisecond)
+	if d.accessible != nil {
+		d.accessibleStepResult(failed, elapsed)
+		return
+	}
+
+	succeeded, failedMark := d.palette.Marks(stepMarks())
+	style, mark := d.palette.Success, succeeded
+	if failed {
+		style, mark = d.palette.Error, failedMark
+	}
+	title := strings.TrimRight(d.steps.title, ".…")
+	if !d.plain {
+		fmt.Print("\r\033[K")
+	}
+	style.Printf(" %s %s%s", mark, prefix, title)
+	d.palette.Muted.Printf(" (%s)\n", elapsed)
+}
+
+// stepPrefix 返回当前步骤的编号，例如"[3/5] "，不在步骤中时返回空字符串
+func (d *Display) stepPrefix() string {
+	if d.steps == nil || d.steps.total == 0 || d.steps.current == 0 || d.steps.finished {
+		return ""
+	}
+	return fmt.Sprintf("[%d/%d] ", d.steps.current, d.steps.total)
+}
+
+// stepMarks 返回步骤成功和失败的标记
+// Windows控制台的默认字体缺少✓和✗，改用√和×
+func stepMarks() (string, string) {
+	if runtime.GOOS == "windows" {
+		return "√", "×"
+	}
+	return "✓", "✗"
+}
@@ -0,0 +1,46 @@
+// UI包
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// SummaryItem 运行摘要中的一项，Value可以包含多行，例如多个文件路径
+type SummaryItem struct {
+	Label string
+	Value string
+}
+
+// ShowSummary 在运行结束时显示摘要，标签按显示宽度对齐，多行的值与第一行对齐
+// 值超出终端宽度时在标签右侧折行；无障碍模式下每项输出为一句话
+func (d *Display) ShowSummary(title string, items []SummaryItem) {
+	if d.accessible != nil {
+		fmt.Println(sentence(title))
+		for _, item := range items {
+			fmt.Println(sentence(item.Label + ": " + strings.ReplaceAll(item.Value, "\n", ", ")))
+		}
+		return
+	}
+
+	labelWidth := 0
+	for _, item := range items {
+		labelWidth = max(labelWidth, runewidth.StringWidth(item.Label))
+	}
+
+	d.palette.Emphasis.Println(d.rtlLine(title))
+	indent := strings.Repeat(" ", labelWidth+4)
+	available := 0
+	if width, ok := terminalWidth(console); ok && !d.plain {
+		available = width - len(indent)
+	}
+	for _, item := range items {
+		lines := wrapText(item.Value, available)
+		fmt.Println(d.rtlLine("  " + d.palette.Info.Sprint(pad(item.Label, labelWidth)) + "  " + d.isolate(lines[0])))
+		for _, line := range lines[1:] {
+			fmt.Println(d.rtlLine(indent + d.isolate(line)))
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// UI包
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+
+	"github.com/yuaotian/go-cursor-help/internal/report"
+)
+
+// IDChange 表格中的一行：一个标识符修改前后的值及其所在的文件
+type IDChange = report.Change
+
+// IDTableHeaders 表格的列标题，按界面语言提供
+type IDTableHeaders struct {
+	Field string
+	Old   string
+	New   string
+	File  string
+}
+
+// 未设置时使用的列标题
+var defaultTableHeaders = IDTableHeaders{Field: "Field", Old: "Old value", New: "New value", File: "File"}
+
+// 表格列之间的分隔符
+const tableSeparator = " │ "
+
+// 截断后每列至少保留的宽度
+const minColumnWidth = 8
+
+// ShowIDTable 以表格显示各标识符修改前后的值
+// 终端较窄时截断最宽的列，输出不是终端时改为不截断、不带颜色的制表符分隔文本
+func (d *Display) ShowIDTable(headers IDTableHeaders, changes []IDChange) {
+	header := []string{headers.Field, headers.Old, headers.New, headers.File}
+	rows := make([][]string, len(changes))
+	for i, change := range changes {
+		old := d.FormatID(change.Old)
+		if old == "" {
+			old = "-"
+		}
+		rows[i] = []string{change.Field, old, d.FormatID(change.New), change.File}
+	}
+
+	// 无障碍模式下每行输出为一句话，屏幕阅读器无法按列朗读表格
+	if d.accessible != nil {
+		for _, row := range rows {
+			var parts []string
+			for i, cell := range row {
+				parts = append(parts, header[i]+": "+cell)
+			}
+			fmt.Println(sentence(strings.Join(parts, ", ")))
+		}
+		return
+	}
+
+	width, ok := terminalWidth(console)
+	if !ok {
+		fmt.Println(strings.Join(header, "\t"))
+		for _, row := range rows {
+			fmt.Println(strings.Join(row, "\t"))
+		}
+		return
+	}
+
+	widths := columnWidths(append([][]string{header}, rows...), width-runewidth.StringWidth(tableSeparator)*(len(header)-1))
+	d.palette.Emphasis.Println(d.rtlLine(d.formatRow(header, widths)))
+	var rule []string
+	for _, w := range widths {
+		rule = append(rule, repeatToWidth("─", w))
+	}
+	fmt.Println(d.rtlLine(strings.Join(rule, repeatToWidth("─", 1)+"┼"+repeatToWidth("─", 1))))
+
+	// 字段、旧值和新值分别着色，文件路径保持默认颜色
+	styles := []*color.Color{d.palette.Info, d.palette.Muted, d.palette.Success, nil}
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			if i == len(row)-1 {
+				// 路径保留结尾的文件名
+				cell = truncateStart(cell, widths[i])
+			} else {
+				cell = truncate(cell, widths[i])
+			}
+			if i < len(row)-1 {
+				cell = pad(cell, widths[i])
+			}
+			// 从右到左界面中每个单元格单独隔离，列按从右到左排列，单元格内的标识符和路径保持原有顺序
+			cell = d.isolate(cell)
+			if styles[i] != nil {
+				cell = styles[i].Sprint(cell)
+			}
+			cells[i] = cell
+		}
+		fmt.Println(d.rtlLine(strings.Join(cells, tableSeparator)))
+	}
+}
+
+// terminalWidth 返回终端的列数，f不是终端时返回false
+func terminalWidth(f *os.File) (int, bool) {
+	if !IsTerminal(f) {
+		return 0, false
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil || width <= 0 {
+		// 无法获取尺寸时按常见的80列处理
+		return 80, true
+	}
+	return width, true
+}
+
+// columnWidths 计算各列的显示宽度，总宽度超过available时依次缩减最宽的列
+func columnWidths(rows [][]string, available int) []int {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			if w := runewidth.StringWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	for total > available {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// formatRow 按列宽对齐并截断一行，最后一列不补齐
+func (d *Display) formatRow(cells []string, widths []int) string {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		cell = truncate(cell, widths[i])
+		if i < len(cells)-1 {
+			cell = pad(cell, widths[i])
+		}
+		padded[i] = d.isolate(cell)
+	}
+	return strings.Join(padded, tableSeparator)
+}
+
+// truncate 将文本截断到指定显示宽度，被截断时以…结尾
+func truncate(text string, width int) string {
+	return runewidth.Truncate(text, width, "…")
+}
+
+// truncateStart 从开头截断文本到指定显示宽度，被截断时以…开头
+func truncateStart(text string, width int) string {
+	if runewidth.StringWidth(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for i := range runes {
+		if tail := string(runes[i:]); runewidth.StringWidth(tail)+1 <= width {
+			return "…" + tail
+		}
+	}
+	return "…"
+}
+
+// repeatToWidth 重复s直到达到指定显示宽度
+// 在东亚语言环境下制表符按两列计算，按字符数重复会使分隔线比表格更宽
+func repeatToWidth(s string, width int) string {
+	w := runewidth.StringWidth(s)
+	if w == 0 {
+		return ""
+	}
+	return strings.Repeat(s, max(width/w, 1))
+}
+
+// pad 在文本右侧补齐空格到指定显示宽度
+func pad(text string, width int) string {
+	return runewidth.FillRight(text, width)
+}
@@ -0,0 +1,20 @@
+// UI包
+package ui
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// placeholderPattern 匹配{{.Name}}形式的命名占位符，与语言包中的文本模板一致
+var placeholderPattern = regexp.MustCompile(`\{\{\s*\.(\w+)\s*\}\}`)
+
+// expand 将调用方提供的文本模板中的命名占位符替换为对应的值，没有值的占位符原样保留
+func expand(template string, values map[string]any) string {
+	return placeholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		if value, ok := values[placeholderPattern.FindStringSubmatch(placeholder)[1]]; ok {
+			return fmt.Sprint(value)
+		}
+		return placeholder
+	})
+}
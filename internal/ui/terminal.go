@@ -0,0 +1,17 @@
+// UI包
+package ui
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// IsTerminal 判断f是否连接到支持光标控制序列的终端
+// 重定向到文件或管道、TERM=dumb以及无法启用虚拟终端处理的旧版Windows控制台返回false
+func IsTerminal(f *os.File) bool {
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return term.IsTerminal(int(f.Fd())) && supportsVT()
+}
@@ -0,0 +1,139 @@
+// UI包
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Palette 控制台输出中各类内容使用的颜色
+type Palette struct {
+	// 成功消息和新增的内容
+	Success *color.Color
+	// 一般信息
+	Info *color.Color
+	// 警告和修改的内容
+	Warning *color.Color
+	// 错误消息和删除的内容
+	Error *color.Color
+	// 需要强调的内容，例如表头和标题
+	Emphasis *color.Color
+	// 次要内容，例如耗时和旧值
+	Muted *color.Color
+	// 旋转器和选中项等突出显示的元素
+	Accent *color.Color
+	// 成功、警告和错误消息的文字前缀，例如"[OK]"，代替[√]等符号；为空时保留原有的符号
+	SuccessPrefix string
+	WarningPrefix string
+	ErrorPrefix   string
+}
+
+// themes 内置的配色主题
+var themes = map[string]func() Palette{
+	// 默认主题，与之前的输出一致
+	"default": func() Palette {
+		return Palette{
+			Success:  color.New(color.FgGreen),
+			Info:     color.New(color.FgCyan),
+			Warning:  color.New(color.FgYellow),
+			Error:    color.New(color.FgRed),
+			Emphasis: color.New(color.Bold),
+			Muted:    color.New(color.Faint),
+			Accent:   color.New(color.FgCyan, color.Bold),
+		}
+	},
+	// 深色背景，使用高亮颜色
+	"dark": func() Palette {
+		return Palette{
+			Success:  color.New(color.FgHiGreen),
+			Info:     color.New(color.FgHiCyan),
+			Warning:  color.New(color.FgHiYellow),
+			Error:    color.New(color.FgHiRed),
+			Emphasis: color.New(color.FgHiWhite, color.Bold),
+			Muted:    color.New(color.FgHiBlack),
+			Accent:   color.New(color.FgHiMagenta, color.Bold),
+		}
+	},
+	// 浅色背景，避免黄色和青色等在白底上难以辨认的颜色
+	"light": func() Palette {
+		return Palette{
+			Success:  color.New(color.FgGreen),
+			Info:     color.New(color.FgBlue),
+			Warning:  color.New(color.FgMagenta),
+			Error:    color.New(color.FgRed),
+			Emphasis: color.New(color.FgBlack, color.Bold),
+			Muted:    color.New(color.FgHiBlack),
+			Accent:   color.New(color.FgBlue, color.Bold),
+		}
+	},
+	// 不使用颜色，只用粗体区分警告和错误
+	"monochrome": func() Palette {
+		return Palette{
+			Success:  color.New(color.Reset),
+			Info:     color.New(color.Reset),
+			Warning:  color.New(color.Bold),
+			Error:    color.New(color.Bold),
+			Emphasis: color.New(color.Bold),
+			Muted:    color.New(color.Reset),
+			Accent:   color.New(color.Bold),
+		}
+	},
+	// 适合色觉障碍的配色，用蓝色和橙色代替难以区分的绿色和红色，并用文字前缀标明消息类型
+	"cvd": func() Palette {
+		return Palette{
+			Success:       color.New(38, 5, 33),
+			Info:          color.New(color.Reset),
+			Warning:       color.New(color.FgHiYellow),
+			Error:         color.New(38, 5, 208),
+			Emphasis:      color.New(color.Bold),
+			Muted:         color.New(color.Faint),
+			Accent:        color.New(38, 5, 33, color.Bold),
+			SuccessPrefix: "[OK]",
+			WarningPrefix: "[WARN]",
+			ErrorPrefix:   "[ERROR]",
+		}
+	},
+}
+
+// ThemeNames 返回内置配色主题的名称
+func ThemeNames() []string {
+	return []string{"default", "dark", "light", "monochrome", "cvd"}
+}
+
+// DefaultPalette 返回默认主题的配色
+func DefaultPalette() Palette {
+	return themes["default"]()
+}
+
+// ThemeByName 根据名称返回配色主题
+func ThemeByName(name string) (Palette, error) {
+	theme, ok := themes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return Palette{}, fmt.Errorf("unknown theme %q, supported themes: %s", name, strings.Join(ThemeNames(), ", "))
+	}
+	return theme(), nil
+}
+
+// Marks 返回成功和失败的标记，主题有文字前缀时使用文字前缀，否则使用succeeded和failed
+func (p Palette) Marks(succeeded, failed string) (string, string) {
+	if p.SuccessPrefix != "" {
+		succeeded = p.SuccessPrefix
+	}
+	if p.ErrorPrefix != "" {
+		failed = p.ErrorPrefix
+	}
+	return succeeded, failed
+}
+
+// SetPalette 设置之后输出使用的配色
+func (d *Display) SetPalette(palette Palette) {
+	d.palette = palette
+	d.spinner.SetColor(palette.Accent)
+}
+
+// Palette 返回当前的配色，供需要自行着色的输出使用
+func (d *Display) Palette() Palette {
+	return d.palette
+}
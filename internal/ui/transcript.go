@@ -0,0 +1,163 @@
+// UI包
+package ui
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
+)
+
+// console 程序实际的标准输出，TeeOutput替换os.Stdout后仍用于判断终端和获取宽度
+var console = os.Stdout
+
+// Console 返回程序实际的标准输出，子进程和全屏界面应直接使用它而不是os.Stdout
+func Console() *os.File {
+	return console
+}
+
+// 解析控制序列的状态
+const (
+	// 普通文本
+	stateText = iota
+	// 读到ESC
+	stateEscape
+	// CSI序列，ESC [ 参数 结束字符
+	stateCSI
+	// OSC序列，以BEL或ESC \结束
+	stateOSC
+)
+
+// Transcript 将控制台输出整理为纯文本写入w：去除颜色和光标控制序列，
+// 被\r覆盖的行（例如旋转器动画）只保留最后显示的内容，使记录与用户在屏幕上看到的一致
+type Transcript struct {
+	mu sync.Mutex
+	// 写入的目标
+	w io.Writer
+	// 尚未结束的当前行
+	line []byte
+	// 控制序列的解析状态
+	state int
+	// 正在读取的CSI参数
+	params []byte
+}
+
+// NewTranscript 创建一个写入w的控制台记录
+func NewTranscript(w io.Writer) *Transcript {
+	return &Transcript{w: w}
+}
+
+// Write 写入控制台输出，整行结束时写入w
+// 写入w失败时不返回错误，记录失败不应影响控制台输出
+func (t *Transcript) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, b := range p {
+		switch t.state {
+		case stateEscape:
+			switch b {
+			case '[':
+				t.state, t.params = stateCSI, t.params[:0]
+			case ']':
+				t.state = stateOSC
+			default:
+				t.state = stateText
+			}
+		case stateCSI:
+			if b >= 0x40 && b <= 0x7e {
+				t.state = stateText
+				if b == 'G' {
+					t.moveToColumn()
+				}
+				continue
+			}
+			t.params = append(t.params, b)
+		case stateOSC:
+			if b == '\a' || b == '\\' {
+				t.state = stateText
+			}
+		default:
+			switch b {
+			case 0x1b:
+				t.state = stateEscape
+			case '\r':
+				t.line = t.line[:0]
+			case '\n':
+				t.line = append(t.line, '\n')
+				t.w.Write(t.line)
+				t.line = t.line[:0]
+			default:
+				t.line = append(t.line, b)
+			}
+		}
+	}
+	return len(p), nil
+}
+
+// WriteEntry 直接写入一条完整的记录，例如日志条目，不与尚未结束的控制台行混在一起
+func (t *Transcript) WriteEntry(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.w.Write(p)
+}
+
+// Flush 写入尚未结束的当前行
+func (t *Transcript) Flush() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.line) > 0 {
+		t.w.Write(append(t.line, '\n'))
+		t.line = t.line[:0]
+	}
+}
+
+// moveToColumn 处理移动光标到指定列的序列，截断或以空格补齐当前行
+func (t *Transcript) moveToColumn() {
+	column, err := strconv.Atoi(string(t.params))
+	if err != nil || column < 1 {
+		column = 1
+	}
+	line := runewidth.Truncate(string(t.line), column-1, "")
+	t.line = append(t.line[:0], runewidth.FillRight(line, column-1)...)
+}
+
+// TeeOutput 将之后写入标准输出的所有内容同时写入transcript，包括直接使用fmt输出的内容
+// 返回的函数恢复标准输出，并等待已写入的内容复制完成
+func TeeOutput(transcript *Transcript) (func(), error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	// 颜色输出在Windows上经过转换，复制时仍写入原来的目标
+	output := color.Output
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, 4096)
+		for {
+			n, err := reader.Read(buf)
+			if n > 0 {
+				output.Write(buf[:n])
+				transcript.Write(buf[:n])
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	os.Stdout = writer
+	color.Output = writer
+	return func() {
+		os.Stdout = console
+		color.Output = output
+		writer.Close()
+		<-done
+		reader.Close()
+		transcript.Flush()
+	}, nil
+}
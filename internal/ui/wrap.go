@@ -0,0 +1,80 @@
+// UI包
+package ui
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
+)
+
+// wrapText 按显示宽度将文本折行，保留原有的换行
+// 优先在空格和路径分隔符处断开，中文等全角字符之间可以直接断开，没有可断开的位置时强制断开
+func wrapText(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		lines = append(lines, wrapParagraph(paragraph, width)...)
+	}
+	return lines
+}
+
+// wrapParagraph 将不含换行的一段文本折行
+func wrapParagraph(paragraph string, width int) []string {
+	if width <= 0 || runewidth.StringWidth(paragraph) <= width {
+		return []string{paragraph}
+	}
+
+	var lines []string
+	runes := []rune(paragraph)
+	for len(runes) > 0 {
+		// cut为超出宽度的位置，breakAt为最后一个可以断开的位置：这一行为runes[:breakAt]
+		lineWidth, cut, breakAt := 0, len(runes), 0
+		for i, r := range runes {
+			w := runewidth.RuneWidth(r)
+			if isBidiControl(r) {
+				w = 0
+			}
+			if lineWidth+w > width {
+				cut = i
+				break
+			}
+			lineWidth += w
+			switch {
+			case r == ' ':
+				breakAt = i
+			case r == '/', r == '\\':
+				breakAt = i + 1
+			case w == 2:
+				breakAt = i + 1
+			}
+		}
+		if cut == len(runes) {
+			lines = append(lines, string(runes))
+			break
+		}
+		if runes[cut] == ' ' {
+			breakAt = cut
+		}
+		if breakAt == 0 {
+			breakAt = max(cut, 1)
+		}
+		lines = append(lines, strings.TrimRight(string(runes[:breakAt]), " "))
+		runes = []rune(strings.TrimLeft(string(runes[breakAt:]), " "))
+	}
+	return lines
+}
+
+// println 以指定颜色输出消息，终端较窄时按宽度折行，每行单独着色，避免颜色控制序列跨行
+// 逐行输出（例如重定向到文件或无障碍模式）时不折行
+func (d *Display) println(c *color.Color, message string) {
+	width, ok := terminalWidth(console)
+	if d.plain || !ok {
+		for _, line := range strings.Split(message, "\n") {
+			c.Println(d.rtlLine(line))
+		}
+		return
+	}
+	for _, line := range wrapText(message, width) {
+		c.Println(d.rtlLine(line))
+	}
+}
@@ -0,0 +1,52 @@
+package idgen
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// GenerateBatch 一次生成n组完整的标识符，供批量处理多个用户或机器时使用
+// 各组并行生成，缓冲区在各组之间复用；设置了历史记录时各组之间也不会重复
+func (g *Generator) GenerateBatch(n int) ([]IdentitySet, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("invalid batch size %d", n)
+	}
+
+	sets := make([]IdentitySet, n)
+	workers := runtime.NumCPU()
+	if workers > n {
+		workers = n
+	}
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	jobs := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				set, err := g.GenerateAll(GenerateOptions{})
+				if err != nil {
+					errOnce.Do(func() { firstErr = fmt.Errorf("failed to generate ID set %d: %w", i+1, err) })
+					continue
+				}
+				sets[i] = *set
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return sets, nil
+}
@@ -0,0 +1,108 @@
+package idgen
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Encoding 随机字节编码为标识符时使用的编码
+type Encoding int
+
+const (
+	// EncodingHex 小写十六进制，Cursor的machineId等字段使用
+	EncodingHex Encoding = iota
+	// EncodingBase32 RFC 4648标准base32，大写且不带填充
+	EncodingBase32
+	// EncodingBase64URL RFC 4648 URL安全的base64，不带填充
+	EncodingBase64URL
+)
+
+// 不带填充的base32编码
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// ParseEncoding 解析编码名称：hex、base32或base64url
+func ParseEncoding(name string) (Encoding, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "hex", "":
+		return EncodingHex, nil
+	case "base32":
+		return EncodingBase32, nil
+	case "base64url":
+		return EncodingBase64URL, nil
+	default:
+		return 0, fmt.Errorf("unsupported encoding %q, supported encodings: hex, base32, base64url", name)
+	}
+}
+
+// String 返回编码名称
+func (e Encoding) String() string {
+	switch e {
+	case EncodingBase32:
+		return "base32"
+	case EncodingBase64URL:
+		return "base64url"
+	default:
+		return "hex"
+	}
+}
+
+// Encode 将字节编码为字符串
+func (e Encoding) Encode(data []byte) string {
+	switch e {
+	case EncodingBase32:
+		return base32NoPadding.EncodeToString(data)
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.EncodeToString(data)
+	default:
+		return hex.EncodeToString(data)
+	}
+}
+
+// Decode 将字符串解码为字节
+func (e Encoding) Decode(s string) ([]byte, error) {
+	switch e {
+	case EncodingBase32:
+		return base32NoPadding.DecodeString(s)
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.DecodeString(s)
+	default:
+		return hex.DecodeString(s)
+	}
+}
+
+// ValidateEncoded 检查标识符是否为指定编码下恰好size个字节的值
+func ValidateEncoded(id string, encoding Encoding, size int) bool {
+	data, err := encoding.Decode(id)
+	if err != nil || len(data) != size {
+		return false
+	}
+	// 十六进制不区分大小写；其他编码解码后重新编码，拒绝尾部多余位不为零等非规范形式
+	if encoding == EncodingHex {
+		return true
+	}
+	return encoding.Encode(data) == id
+}
+
+// GenerateRandom 生成size个随机字节并按指定编码返回，供使用其他编码的目标
+// （例如崩溃报告ID、服务的机器ID）使用；随机字节同样来自生成器的随机来源
+func (g *Generator) GenerateRandom(size int, encoding Encoding) (string, error) {
+	if size <= 0 {
+		return "", fmt.Errorf("invalid random size %d", size)
+	}
+
+	// 从池中获取缓冲区，超出缓冲区大小时单独分配
+	buffer := g.bufferPool.Get().([]byte)
+	defer g.bufferPool.Put(buffer)
+	if size > len(buffer) {
+		buffer = make([]byte, size)
+	}
+
+	// 生成随机字节
+	if err := g.readRandom(buffer[:size]); err != nil {
+		return "", err
+	}
+	return encoding.Encode(buffer[:size]), nil
+}
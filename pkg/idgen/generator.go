@@ -5,24 +5,126 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"io"
+	"strings"
 	"sync"
+	"time"
 )
 
+// UUIDVersion 表示生成设备ID和SQM ID时使用的UUID版本
+type UUIDVersion int
+
+// 支持的UUID版本
+const (
+	// UUIDv4 随机UUID（RFC 4122），默认版本
+	UUIDv4 UUIDVersion = 4
+	// UUIDv5 基于命名空间和名称的SHA-1 UUID，需通过SetNameBased启用
+	UUIDv5 UUIDVersion = 5
+	// UUIDv7 以毫秒时间戳开头、按时间排序的UUID（RFC 9562）
+	UUIDv7 UUIDVersion = 7
+)
+
+// ParseUUIDVersion 解析UUID版本号，只支持4和7
+func ParseUUIDVersion(version int) (UUIDVersion, error) {
+	switch UUIDVersion(version) {
+	case UUIDv4, UUIDv7:
+		return UUIDVersion(version), nil
+	default:
+		return 0, fmt.Errorf("unsupported UUID version %d, supported versions: 4, 7", version)
+	}
+}
+
 // Generator 处理机器和设备的安全ID生成
 type Generator struct {
 	// 字节缓冲池，用于减少内存分配
 	bufferPool sync.Pool
+	// 生成UUID时使用的版本
+	uuidVersion UUIDVersion
+	// 标识符的格式配置
+	profile Profile
+	// 覆盖格式配置中的机器ID前缀，为nil时使用格式配置中的前缀
+	machineIDPrefix *string
+	// 以往生成过的标识符，为nil时不检查冲突
+	history *History
+	// 基于名称生成设备ID和SQM ID时使用的命名空间和名称，为nil时随机生成
+	nameBased *nameBasedSource
+	// 各字段的生成方式，未设置的字段使用ModeRandom
+	modes map[string]Mode
+	// 随机字节的来源，默认为crypto/rand
+	entropy io.Reader
+	// 保护entropy的并发读取，自定义来源不一定是并发安全的
+	entropyMu sync.Mutex
 }
 
-// NewGenerator 创建一个新的ID生成器
-func NewGenerator() *Generator {
-	return &Generator{
+// NewGenerator 创建一个新的ID生成器，默认生成UUID v4并使用最新的格式配置
+// 可以通过Option调整生成行为，例如NewGenerator(WithProfile(profile), WithSeed(seed))
+func NewGenerator(opts ...Option) *Generator {
+	g := &Generator{
 		bufferPool: sync.Pool{
 			New: func() interface{} {
 				return make([]byte, 64)
 			},
 		},
+		uuidVersion: UUIDv4,
+		profile:     LatestProfile(),
+		modes:       make(map[string]Mode),
+		entropy:     rand.Reader,
+	}
+	for _, opt := range opts {
+		opt(g)
 	}
+	return g
+}
+
+// SetEntropySource 设置随机字节的来源，例如硬件随机数生成器、FIPS模式下的字节源
+// 或测试中使用的固定字节序列；为nil时恢复为crypto/rand
+// 来源的读取由生成器串行化，无需自行保证并发安全
+func (g *Generator) SetEntropySource(source io.Reader) {
+	g.entropyMu.Lock()
+	defer g.entropyMu.Unlock()
+	if source == nil {
+		source = rand.Reader
+	}
+	g.entropy = source
+}
+
+// readRandom 从随机来源读满buffer，来源提前结束时返回错误
+func (g *Generator) readRandom(buffer []byte) error {
+	g.entropyMu.Lock()
+	defer g.entropyMu.Unlock()
+	if _, err := io.ReadFull(g.entropy, buffer); err != nil {
+		return fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return nil
+}
+
+// SetProfile 设置生成和验证标识符时使用的格式配置
+func (g *Generator) SetProfile(profile Profile) {
+	g.profile = profile
+}
+
+// Profile 返回当前的格式配置
+func (g *Generator) Profile() Profile {
+	return g.profile
+}
+
+// format 返回指定ID类型在当前格式配置下的格式，并应用WithPrefix设置的前缀
+func (g *Generator) format(idType string) (IDFormat, bool) {
+	format, ok := g.profile.format(idType)
+	if ok && idType == "machineID" && g.machineIDPrefix != nil {
+		format.Prefix = *g.machineIDPrefix
+	}
+	return format, ok
+}
+
+// SetHistory 设置历史记录，之后生成的ID不会与其中的值重复，并会被加入其中
+func (g *Generator) SetHistory(history *History) {
+	g.history = history
+}
+
+// SetUUIDVersion 设置生成设备ID和SQM ID时使用的UUID版本
+func (g *Generator) SetUUIDVersion(version UUIDVersion) {
+	g.uuidVersion = version
 }
 
 // ID生成的常量
@@ -33,83 +135,141 @@ const (
 	uuidFormat      = "%s-%s-%s-%s-%s"
 )
 
-// generateRandomHex 生成指定长度的随机十六进制字符串
-func (g *Generator) generateRandomHex(length int) (string, error) {
-	// 从池中获取缓冲区
-	buffer := g.bufferPool.Get().([]byte)
-	defer g.bufferPool.Put(buffer)
-
-	// 生成随机字节
-	if _, err := rand.Read(buffer[:length]); err != nil {
-		return "", fmt.Errorf("failed to generate random bytes: %w", err)
-	}
-	return hex.EncodeToString(buffer[:length]), nil
-}
-
-// GenerateMachineID 生成带有auth0|user_前缀的新机器ID
+// GenerateMachineID 按当前格式配置生成新的机器ID
+// 旧版本格式带有auth0|user_前缀，新版本为64位十六进制
 func (g *Generator) GenerateMachineID() (string, error) {
-	// 生成64字符的十六进制随机部分
-	randomPart, err := g.generateRandomHex(32) 
-	if err != nil {
-		return "", err
-	}
-
-	return fmt.Sprintf("%x%s", []byte(machineIDPrefix), randomPart), nil
+	return g.generateField("machineID")
 }
 
 // GenerateMacMachineID 生成新的64字节MAC机器ID
 func (g *Generator) GenerateMacMachineID() (string, error) {
-	// 生成64字符的十六进制
-	return g.generateRandomHex(32) 
+	return g.generateField("macMachineID")
 }
 
 // GenerateDeviceID 以UUID格式生成新的设备ID
 func (g *Generator) GenerateDeviceID() (string, error) {
-	id, err := g.generateRandomHex(16)
-	if err != nil {
-		return "", err
-	}
-	// 按照UUID格式拼接字符串
-	return fmt.Sprintf(uuidFormat,
-		id[0:8], id[8:12], id[12:16], id[16:20], id[20:32]), nil
+	return g.generateField("deviceID")
 }
 
 // GenerateSQMID 以带花括号的UUID格式生成新的SQM ID
 func (g *Generator) GenerateSQMID() (string, error) {
-	id, err := g.GenerateDeviceID()
+	return g.generateField("sqmID")
+}
+
+// generateField 按字段的生成方式和当前格式配置生成标识符
+func (g *Generator) generateField(idType string) (string, error) {
+	format, _ := g.format(idType)
+	// 基于名称的ID是确定的，重复运行时必然相同，因此不参与历史记录的冲突检查
+	if g.nameBased != nil && format.UUID {
+		return styleUUID(g.nameBased.uuid(idType), format), nil
+	}
+	if g.modes[idType] == ModePlausible {
+		return g.generatePlausible(idType, format)
+	}
+	return g.generateFormatted(format, g.uuidVersion)
+}
+
+// generateFormatted 按指定格式生成标识符，与历史记录冲突时重新生成
+func (g *Generator) generateFormatted(format IDFormat, version UUIDVersion) (string, error) {
+	for attempt := 0; attempt < maxRerolls; attempt++ {
+		id, err := g.formatID(format, version)
+		if err != nil {
+			return "", err
+		}
+		if g.history == nil || g.history.addIfAbsent(id) {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("failed to generate an unused ID after %d attempts", maxRerolls)
+}
+
+// formatID 按指定格式生成一个随机标识符
+func (g *Generator) formatID(format IDFormat, version UUIDVersion) (string, error) {
+	if !format.UUID {
+		// 前缀和随机部分一起编码，十六进制下等同于前缀编码后直接拼接随机部分
+		size := len(format.Prefix) + format.Bytes
+		buffer := g.bufferPool.Get().([]byte)
+		defer g.bufferPool.Put(buffer)
+		if size > len(buffer) {
+			buffer = make([]byte, size)
+		}
+		copy(buffer, format.Prefix)
+		if err := g.readRandom(buffer[len(format.Prefix):size]); err != nil {
+			return "", err
+		}
+		return format.Encoding.Encode(buffer[:size]), nil
+	}
+
+	id, err := g.generateUUID(version)
 	if err != nil {
 		return "", err
 	}
-	// 在UUID两侧添加花括号
-	return fmt.Sprintf("{%s}", id), nil
+	return styleUUID(id, format), nil
 }
 
-// ValidateID 验证各种ID类型的格式
-func (g *Generator) ValidateID(id string, idType string) bool {
-	switch idType {
-	case "machineID", "macMachineID":
-		// 机器ID应该是64个十六进制字符
-		return len(id) == 64 && isHexString(id)
-	case "deviceID":
-		// 设备ID应该是有效的UUID格式
-		return isValidUUID(id)
-	case "sqmID":
-		// SQM ID应该是带花括号的UUID
-		if len(id) < 2 || id[0] != '{' || id[len(id)-1] != '}' {
-			return false
+// styleUUID 按格式设置UUID的大小写和花括号
+func styleUUID(id string, format IDFormat) string {
+	if format.Upper {
+		id = strings.ToUpper(id)
+	}
+	if format.Braced {
+		// 在UUID两侧添加花括号
+		id = fmt.Sprintf("{%s}", id)
+	}
+	return id
+}
+
+// generateUUID 生成指定版本的UUID，版本位和变体位符合RFC 4122
+func (g *Generator) generateUUID(version UUIDVersion) (string, error) {
+	// 从池中获取缓冲区
+	buffer := g.bufferPool.Get().([]byte)
+	defer g.bufferPool.Put(buffer)
+	uuid := buffer[:16]
+
+	if err := g.readRandom(uuid); err != nil {
+		return "", err
+	}
+
+	// v7的前48位为Unix毫秒时间戳（大端序），其余位保持随机
+	if version == UUIDv7 {
+		ms := time.Now().UnixMilli()
+		for i := 0; i < 6; i++ {
+			uuid[i] = byte(ms >> (40 - 8*i))
 		}
-		return isValidUUID(id[1 : len(id)-1])
-	default:
-		return false
 	}
+	return encodeUUID(uuid, version), nil
+}
+
+// encodeUUID 设置16字节的版本位和变体位，并编码为UUID字符串
+func encodeUUID(uuid []byte, version UUIDVersion) string {
+	// 设置版本位（第7字节高4位）和RFC 4122变体位（第9字节高2位为10）
+	uuid[6] = uuid[6]&0x0f | byte(version)<<4
+	uuid[8] = uuid[8]&0x3f | 0x80
+
+	id := hex.EncodeToString(uuid)
+	// 按照UUID格式拼接字符串
+	return fmt.Sprintf(uuidFormat,
+		id[0:8], id[8:12], id[12:16], id[16:20], id[20:32])
+}
+
+// ValidateID 按当前格式配置验证各种ID类型的格式
+// UUID类型的ID还需要有效的版本位和变体位；本生成器会生成空值的字段（非Windows上plausible模式的SQM ID）允许为空
+func (g *Generator) ValidateID(id string, idType string) bool {
+	if id == "" && g.allowsEmpty(idType) {
+		return true
+	}
+	format, ok := g.format(idType)
+	return ok && format.matches(id)
 }
 
 // 辅助函数
 
-// isHexString 检查字符串是否为有效的十六进制字符串
-func isHexString(s string) bool {
-	_, err := hex.DecodeString(s)
-	return err == nil
+// hasValidUUIDBits 检查UUID的版本是否为4、5或7，且变体为RFC 4122
+// 调用前需确认字符串为有效的UUID格式
+func hasValidUUIDBits(uuid string) bool {
+	version := uuid[14]
+	variant := strings.ToLower(uuid[19:20])
+	return strings.ContainsRune("457", rune(version)) && strings.Contains("89ab", variant)
 }
 
 // isValidUUID 检查字符串是否为有效的UUID格式
@@ -0,0 +1,126 @@
+package idgen
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNameBasedUUID(t *testing.T) {
+	// 期望值由独立的RFC 4122实现（Python uuid.uuid5）按"<name>/<idType>"计算
+	tests := []struct {
+		namespace string
+		name      string
+		idType    string
+		want      string
+	}{
+		{namespace: "dns", name: "python.org", idType: "deviceID", want: "878a1798-c127-5442-9640-71e07d8dad53"},
+		{namespace: "dns", name: "python.org", idType: "sqmID", want: "63fd56cc-a3f2-56df-9bc6-df9cc8ccf4de"},
+		{namespace: "6ba7b811-9dad-11d1-80b4-00c04fd430c8", name: "lab-pc-01", idType: "deviceID", want: "1db863b3-2851-5778-9bc8-ebf08958477e"},
+		{namespace: "{6BA7B811-9DAD-11D1-80B4-00C04FD430C8}", name: "lab-pc-01", idType: "deviceID", want: "1db863b3-2851-5778-9bc8-ebf08958477e"},
+	}
+	for _, tt := range tests {
+		namespace, err := ParseNamespace(tt.namespace)
+		if err != nil {
+			t.Fatalf("ParseNamespace(%q) returned error: %v", tt.namespace, err)
+		}
+		g := NewGenerator()
+		if err := g.SetNameBased(namespace, tt.name); err != nil {
+			t.Fatalf("SetNameBased returned error: %v", err)
+		}
+		got, err := g.generateField(tt.idType)
+		if err != nil {
+			t.Fatalf("generateField(%q) returned error: %v", tt.idType, err)
+		}
+		if normalized := strings.ToLower(strings.Trim(got, "{}")); normalized != tt.want {
+			t.Errorf("v5 %s for %q in %s = %s, want %s", tt.idType, tt.name, tt.namespace, normalized, tt.want)
+		}
+		if !g.ValidateID(got, tt.idType) {
+			t.Errorf("ValidateID(%q, %q) = false", got, tt.idType)
+		}
+	}
+}
+
+func TestParseNamespace(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{input: "dns"},
+		{input: " URL "},
+		{input: "x500"},
+		{input: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{input: "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"},
+		{input: "", wantErr: true},
+		{input: "example", wantErr: true},
+		{input: "6ba7b810-9dad-11d1-80b4-00c04fd430", wantErr: true},
+		{input: "6ba7b810x9dad-11d1-80b4-00c04fd430c8", wantErr: true},
+	}
+	for _, tt := range tests {
+		_, err := ParseNamespace(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseNamespace(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+	}
+	if err := NewGenerator().SetNameBased([16]byte{}, ""); err == nil {
+		t.Error("SetNameBased with an empty name succeeded, want an error")
+	}
+}
+
+func TestGenerateUUIDVersions(t *testing.T) {
+	tests := []struct {
+		version UUIDVersion
+		digit   byte
+	}{
+		{version: UUIDv4, digit: '4'},
+		{version: UUIDv7, digit: '7'},
+	}
+	for _, tt := range tests {
+		g := NewGenerator(WithSeed([]byte("test")))
+		for i := 0; i < 100; i++ {
+			id, err := g.generateUUID(tt.version)
+			if err != nil {
+				t.Fatalf("generateUUID(%d) returned error: %v", tt.version, err)
+			}
+			if !isValidUUID(id) || !hasValidUUIDBits(id) {
+				t.Fatalf("generateUUID(%d) = %s, not a valid RFC 4122 UUID", tt.version, id)
+			}
+			if id[14] != tt.digit {
+				t.Fatalf("generateUUID(%d) = %s, version digit %c", tt.version, id, id[14])
+			}
+		}
+	}
+}
+
+func TestUUIDv7Timestamp(t *testing.T) {
+	g := NewGenerator()
+	before := time.Now().UnixMilli()
+	first, err := g.generateUUID(UUIDv7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now().UnixMilli()
+
+	raw, err := hex.DecodeString(strings.ReplaceAll(first, "-", "")[:12])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ms int64
+	for _, b := range raw {
+		ms = ms<<8 | int64(b)
+	}
+	if ms < before || ms > after {
+		t.Errorf("v7 timestamp %d outside [%d, %d]", ms, before, after)
+	}
+
+	// 不同毫秒生成的v7 UUID按字符串排序即按时间排序
+	time.Sleep(2 * time.Millisecond)
+	second, err := g.generateUUID(UUIDv7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second <= first {
+		t.Errorf("v7 UUIDs not time-ordered: %s then %s", first, second)
+	}
+}
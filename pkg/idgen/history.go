@@ -0,0 +1,134 @@
+package idgen
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 生成新ID与历史记录冲突时的最大重试次数
+const maxRerolls = 16
+
+// History 记录以往生成过的以及磁盘上已有的标识符，只保存其SHA-256哈希
+// 设置到Generator后，新生成的ID与历史记录冲突时会重新生成
+type History struct {
+	// 保存哈希的文件路径，为空时只在内存中记录
+	path string
+	// 已记录的哈希
+	hashes map[string]bool
+	// 保护hashes的并发访问
+	mu sync.Mutex
+}
+
+// LoadHistory 从文件中读取历史记录，文件不存在时返回空记录
+// 文件每行一个十六进制哈希
+func LoadHistory(path string) (*History, error) {
+	history := &History{path: path, hashes: make(map[string]bool)}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return history, nil
+		}
+		return nil, fmt.Errorf("failed to read ID history: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			history.hashes[line] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ID history: %w", err)
+	}
+	return history, nil
+}
+
+// Add 将标识符加入历史记录，空字符串会被忽略
+func (h *History) Add(ids ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, id := range ids {
+		if id != "" {
+			h.hashes[hashID(id)] = true
+		}
+	}
+}
+
+// Contains 检查标识符是否出现在历史记录中
+func (h *History) Contains(id string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.hashes[hashID(id)]
+}
+
+// addIfAbsent 在标识符不在历史记录中时加入并返回true，已存在时返回false
+// 检查和加入在同一次加锁中完成，并行生成时不会接受两个相同的值
+func (h *History) addIfAbsent(id string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hash := hashID(id)
+	if h.hashes[hash] {
+		return false
+	}
+	if id != "" {
+		h.hashes[hash] = true
+	}
+	return true
+}
+
+// Len 返回历史记录中的标识符数量
+func (h *History) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.hashes)
+}
+
+// Path 返回历史记录文件的路径，未指定文件时为空
+func (h *History) Path() string {
+	return h.path
+}
+
+// Save 将历史记录写回文件，必要时创建所在目录
+func (h *History) Save() error {
+	if h.path == "" {
+		return nil
+	}
+
+	h.mu.Lock()
+	lines := make([]string, 0, len(h.hashes))
+	for hash := range h.hashes {
+		lines = append(lines, hash)
+	}
+	h.mu.Unlock()
+	sort.Strings(lines)
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	tmpPath := h.path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to write ID history: %w", err)
+	}
+	if err := os.Rename(tmpPath, h.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to save ID history: %w", err)
+	}
+	return nil
+}
+
+// hashID 计算标识符的哈希，忽略大小写和UUID两侧的花括号，
+// 使同一个值在不同格式配置下仍被视为相同
+func hashID(id string) string {
+	normalized := strings.ToLower(strings.Trim(id, "{}"))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,120 @@
+package idgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IdentitySet 一组完整的标识符
+type IdentitySet struct {
+	// telemetry.machineId
+	MachineID string
+	// telemetry.macMachineId
+	MacMachineID string
+	// telemetry.devDeviceId
+	DeviceID string
+	// telemetry.sqmId
+	SQMID string
+}
+
+// GenerateOptions 控制GenerateAll的行为
+type GenerateOptions struct {
+	// 已有的标识符，Policy要求保留的字段从中取值，为nil时全部重新生成
+	Existing *IdentitySet
+	// 各字段保留还是重新生成，为nil时全部重新生成
+	Policy GenerationPolicy
+}
+
+// FieldAction 生成时对单个字段的处理方式
+type FieldAction int
+
+const (
+	// ActionRegenerate 生成新值
+	ActionRegenerate FieldAction = iota
+	// ActionKeep 保留已有的值，已有值为空时仍生成新值
+	ActionKeep
+)
+
+// GenerationPolicy 各字段的处理方式，键为字段名（与ValidateID的idType一致），未列出的字段重新生成
+type GenerationPolicy map[string]FieldAction
+
+// DefaultPolicy 返回命令行默认使用的策略：保留已有的SQM ID，重新生成其他字段
+func DefaultPolicy() GenerationPolicy {
+	return KeepFields("sqmID")
+}
+
+// KeepFields 返回保留指定字段、重新生成其他字段的策略，字段名可由ParseFields得到
+func KeepFields(fields ...string) GenerationPolicy {
+	policy := make(GenerationPolicy, len(fields))
+	for _, field := range fields {
+		policy[field] = ActionKeep
+	}
+	return policy
+}
+
+// value 返回指定字段的值
+func (s *IdentitySet) value(idType string) string {
+	switch idType {
+	case "machineID":
+		return s.MachineID
+	case "macMachineID":
+		return s.MacMachineID
+	case "deviceID":
+		return s.DeviceID
+	case "sqmID":
+		return s.SQMID
+	default:
+		return ""
+	}
+}
+
+// GenerateError 表示部分字段生成失败，键为字段名（与ValidateID的idType一致）
+type GenerateError struct {
+	// 各失败字段的错误
+	Fields map[string]error
+}
+
+// Error 实现error接口
+func (e *GenerateError) Error() string {
+	var messages []string
+	for _, field := range []string{"machineID", "macMachineID", "deviceID", "sqmID"} {
+		if err, ok := e.Fields[field]; ok {
+			messages = append(messages, fmt.Sprintf("%s: %v", field, err))
+		}
+	}
+	return "failed to generate " + strings.Join(messages, "; ")
+}
+
+// GenerateAll 生成一组完整的标识符，按opts.Policy保留opts.Existing中的部分字段
+// 部分字段失败时返回*GenerateError，其余字段仍然有效
+func (g *Generator) GenerateAll(opts GenerateOptions) (*IdentitySet, error) {
+	set := &IdentitySet{}
+	errs := make(map[string]error)
+
+	fields := []struct {
+		idType string
+		target *string
+	}{
+		{"machineID", &set.MachineID},
+		{"macMachineID", &set.MacMachineID},
+		{"deviceID", &set.DeviceID},
+		{"sqmID", &set.SQMID},
+	}
+	for _, field := range fields {
+		if opts.Policy[field.idType] == ActionKeep && opts.Existing != nil && opts.Existing.value(field.idType) != "" {
+			*field.target = opts.Existing.value(field.idType)
+			continue
+		}
+		id, err := g.generateField(field.idType)
+		if err != nil {
+			errs[field.idType] = err
+			continue
+		}
+		*field.target = id
+	}
+
+	if len(errs) > 0 {
+		return set, &GenerateError{Fields: errs}
+	}
+	return set, nil
+}
@@ -0,0 +1,60 @@
+package idgen
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// predefinedNamespaces RFC 4122附录C中预定义的命名空间
+var predefinedNamespaces = map[string]string{
+	"dns":  "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	"url":  "6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+	"oid":  "6ba7b812-9dad-11d1-80b4-00c04fd430c8",
+	"x500": "6ba7b814-9dad-11d1-80b4-00c04fd430c8",
+}
+
+// nameBasedSource 基于名称生成UUID时使用的命名空间和名称
+type nameBasedSource struct {
+	// 命名空间UUID的16个字节
+	namespace [16]byte
+	// 名称，例如计算机名或资产标签
+	name string
+}
+
+// ParseNamespace 解析命名空间，可以是UUID字符串，也可以是预定义的dns、url、oid或x500
+func ParseNamespace(namespace string) ([16]byte, error) {
+	var result [16]byte
+	value := strings.TrimSpace(namespace)
+	if predefined, ok := predefinedNamespaces[strings.ToLower(value)]; ok {
+		value = predefined
+	}
+	value = strings.Trim(value, "{}")
+	if !isValidUUID(value) {
+		return result, fmt.Errorf("invalid UUID namespace %q, use a UUID or one of: dns, url, oid, x500", namespace)
+	}
+	if _, err := hex.Decode(result[:], []byte(strings.ReplaceAll(value, "-", ""))); err != nil {
+		return result, fmt.Errorf("invalid UUID namespace %q: %w", namespace, err)
+	}
+	return result, nil
+}
+
+// SetNameBased 改为基于命名空间和名称生成设备ID和SQM ID（UUID v5）
+// 同一命名空间和名称总是得到相同的ID，便于组织为每台机器分配稳定、可复现的标识符；
+// 这些值与Cursor自身随机生成的v4 UUID不同。设备ID和SQM ID使用不同的名称派生，互不相同
+func (g *Generator) SetNameBased(namespace [16]byte, name string) error {
+	if name == "" {
+		return fmt.Errorf("name-based UUIDs require a non-empty name")
+	}
+	g.nameBased = &nameBasedSource{namespace: namespace, name: name}
+	return nil
+}
+
+// uuid 生成指定字段的v5 UUID，名称为"<name>/<idType>"
+func (s *nameBasedSource) uuid(idType string) string {
+	hash := sha1.New()
+	hash.Write(s.namespace[:])
+	hash.Write([]byte(s.name + "/" + idType))
+	return encodeUUID(hash.Sum(nil)[:16], UUIDv5)
+}
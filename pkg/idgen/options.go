@@ -0,0 +1,75 @@
+package idgen
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+)
+
+// Option 配置Generator的选项，传给NewGenerator
+type Option func(*Generator)
+
+// WithEntropySource 使用指定的随机字节来源，等同于SetEntropySource
+func WithEntropySource(source io.Reader) Option {
+	return func(g *Generator) {
+		g.SetEntropySource(source)
+	}
+}
+
+// WithProfile 使用指定的格式配置，等同于SetProfile
+func WithProfile(profile Profile) Option {
+	return func(g *Generator) {
+		g.SetProfile(profile)
+	}
+}
+
+// WithUUIDVersion 使用指定的UUID版本，等同于SetUUIDVersion
+func WithUUIDVersion(version UUIDVersion) Option {
+	return func(g *Generator) {
+		g.SetUUIDVersion(version)
+	}
+}
+
+// WithPrefix 覆盖机器ID的前缀，空字符串表示不带前缀
+// 与格式配置无关，之后调用SetProfile也不会改变
+func WithPrefix(prefix string) Option {
+	return func(g *Generator) {
+		g.machineIDPrefix = &prefix
+	}
+}
+
+// WithSeed 使用由种子确定的字节序列代替随机来源，相同的种子总是生成相同的标识符
+// 只用于测试数据和可复现的演示，不能用于真实环境；UUID v7中的时间戳部分不受种子影响
+func WithSeed(seed []byte) Option {
+	return func(g *Generator) {
+		g.SetEntropySource(&seededReader{seed: append([]byte(nil), seed...)})
+	}
+}
+
+// seededReader 以计数器模式对种子做SHA-256，得到确定的字节序列
+type seededReader struct {
+	// 种子
+	seed []byte
+	// 已生成的块数
+	counter uint64
+	// 当前块中尚未读取的字节
+	pending []byte
+}
+
+// Read 实现io.Reader接口
+func (r *seededReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.pending) == 0 {
+			var counter [8]byte
+			binary.BigEndian.PutUint64(counter[:], r.counter)
+			r.counter++
+			block := sha256.Sum256(append(append([]byte(nil), r.seed...), counter[:]...))
+			r.pending = block[:]
+		}
+		copied := copy(p[n:], r.pending)
+		r.pending = r.pending[copied:]
+		n += copied
+	}
+	return n, nil
+}
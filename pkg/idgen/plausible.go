@@ -0,0 +1,77 @@
+package idgen
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Mode 标识符的生成方式
+type Mode int
+
+const (
+	// ModeRandom 按格式配置和设置的UUID版本生成随机值
+	ModeRandom Mode = iota
+	// ModePlausible 生成与真实Cursor安装相同分布的值，避免在统计上显得突兀
+	ModePlausible
+)
+
+// fieldAliases 字段名到ID类型的映射，同时接受storage.json中的键名
+var fieldAliases = map[string]string{
+	"machineid":    "machineID",
+	"macmachineid": "macMachineID",
+	"deviceid":     "deviceID",
+	"devdeviceid":  "deviceID",
+	"sqmid":        "sqmID",
+}
+
+// ParseFields 解析逗号分隔的字段列表，例如machineId,sqmId；all表示所有字段
+// 返回与ValidateID的idType一致的字段名
+func ParseFields(spec string) ([]string, error) {
+	if strings.EqualFold(strings.TrimSpace(spec), "all") {
+		return []string{"machineID", "macMachineID", "deviceID", "sqmID"}, nil
+	}
+
+	var fields []string
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(name)), "telemetry.")
+		if name == "" {
+			continue
+		}
+		field, ok := fieldAliases[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown ID field %q, supported fields: machineId, macMachineId, devDeviceId, sqmId, all", name)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// SetFieldMode 设置指定字段的生成方式，field为ParseFields返回的字段名
+func (g *Generator) SetFieldMode(field string, mode Mode) error {
+	if _, ok := g.profile.format(field); !ok {
+		return fmt.Errorf("unknown ID field %q", field)
+	}
+	g.modes[field] = mode
+	return nil
+}
+
+// generatePlausible 按真实Cursor安装写入的方式生成标识符：
+// UUID始终为v4，devDeviceId为小写，sqmId为大写带花括号的GUID，
+// 且sqmId只在Windows上有值，其他系统上Cursor写入的是空字符串
+func (g *Generator) generatePlausible(idType string, format IDFormat) (string, error) {
+	if idType == "sqmID" {
+		if runtime.GOOS != "windows" {
+			return "", nil
+		}
+		format.Upper, format.Braced = true, true
+	} else if format.UUID {
+		format.Upper = false
+	}
+	return g.generateFormatted(format, UUIDv4)
+}
+
+// allowsEmpty 检查字段为空是否属于正常情况
+func (g *Generator) allowsEmpty(idType string) bool {
+	return idType == "sqmID" && g.modes[idType] == ModePlausible && runtime.GOOS != "windows"
+}
@@ -0,0 +1,175 @@
+package idgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IDFormat 描述一种标识符的形状
+type IDFormat struct {
+	// 与随机部分一起编码、放在随机部分之前的前缀，为空表示没有前缀
+	Prefix string
+	// 随机部分的字节数，仅用于非UUID格式
+	Bytes int
+	// 前缀和随机部分的编码，仅用于非UUID格式，默认为十六进制
+	Encoding Encoding
+	// 是否为UUID格式
+	UUID bool
+	// UUID是否带花括号
+	Braced bool
+	// UUID是否使用大写字母
+	Upper bool
+}
+
+// Profile 描述某个Cursor版本范围内各标识符的格式
+type Profile struct {
+	// 配置名称
+	Name string
+	// 适用的最低Cursor版本（包含），为空表示适用于所有更早的版本
+	MinVersion string
+	// telemetry.machineId的格式
+	MachineID IDFormat
+	// telemetry.macMachineId的格式
+	MacMachineID IDFormat
+	// telemetry.devDeviceId的格式
+	DeviceID IDFormat
+	// telemetry.sqmId的格式
+	SQMID IDFormat
+}
+
+// profiles 已知的格式配置，按MinVersion从旧到新排列
+// 新版本自身写入的machineId为不带前缀的64位十六进制（SHA-256），sqmId为大写的带花括号GUID，
+// 旧版本生成的auth0|user_前缀格式会被新版本视为无效并重新生成
+var profiles = []Profile{
+	{
+		Name:         "legacy",
+		MachineID:    IDFormat{Prefix: machineIDPrefix, Bytes: 32},
+		MacMachineID: IDFormat{Bytes: 32},
+		DeviceID:     IDFormat{UUID: true},
+		SQMID:        IDFormat{UUID: true, Braced: true},
+	},
+	{
+		Name:         "0.45",
+		MinVersion:   "0.45.0",
+		MachineID:    IDFormat{Bytes: 32},
+		MacMachineID: IDFormat{Bytes: 32},
+		DeviceID:     IDFormat{UUID: true},
+		SQMID:        IDFormat{UUID: true, Braced: true, Upper: true},
+	},
+}
+
+// Profiles 返回所有已知的格式配置，按适用版本从旧到新排列
+func Profiles() []Profile {
+	return append([]Profile(nil), profiles...)
+}
+
+// LatestProfile 返回最新的格式配置，无法检测Cursor版本时使用
+func LatestProfile() Profile {
+	return profiles[len(profiles)-1]
+}
+
+// ProfileForVersion 返回适用于指定Cursor版本的格式配置
+// 版本为空或无法解析时返回最新的配置，避免生成新版本不接受的格式
+func ProfileForVersion(version string) Profile {
+	if _, err := parseVersion(version); err != nil {
+		return LatestProfile()
+	}
+
+	selected := profiles[0]
+	for _, profile := range profiles[1:] {
+		if compareVersions(version, profile.MinVersion) >= 0 {
+			selected = profile
+		}
+	}
+	return selected
+}
+
+// ProfileByName 根据名称返回格式配置
+func ProfileByName(name string) (Profile, error) {
+	var names []string
+	for _, profile := range profiles {
+		if profile.Name == name {
+			return profile, nil
+		}
+		names = append(names, profile.Name)
+	}
+	return Profile{}, fmt.Errorf("unknown ID profile %q, supported profiles: %s", name, strings.Join(names, ", "))
+}
+
+// format 返回指定ID类型的格式
+func (p Profile) format(idType string) (IDFormat, bool) {
+	switch idType {
+	case "machineID":
+		return p.MachineID, true
+	case "macMachineID":
+		return p.MacMachineID, true
+	case "deviceID":
+		return p.DeviceID, true
+	case "sqmID":
+		return p.SQMID, true
+	default:
+		return IDFormat{}, false
+	}
+}
+
+// matches 检查标识符是否符合该格式
+func (f IDFormat) matches(id string) bool {
+	if f.UUID {
+		if f.Braced {
+			if len(id) < 2 || id[0] != '{' || id[len(id)-1] != '}' {
+				return false
+			}
+			id = id[1 : len(id)-1]
+		}
+		return isValidUUID(id) && hasValidUUIDBits(id)
+	}
+
+	if !ValidateEncoded(id, f.Encoding, len(f.Prefix)+f.Bytes) {
+		return false
+	}
+	data, _ := f.Encoding.Decode(id)
+	return strings.HasPrefix(string(data), f.Prefix)
+}
+
+// parseVersion 将形如0.45.11的版本号解析为数字列表，忽略预发布后缀
+func parseVersion(version string) ([]int, error) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if index := strings.IndexAny(version, "-+ "); index >= 0 {
+		version = version[:index]
+	}
+	if version == "" {
+		return nil, fmt.Errorf("empty version")
+	}
+
+	parts := strings.Split(version, ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q", version)
+		}
+		numbers[i] = number
+	}
+	return numbers, nil
+}
+
+// compareVersions 比较两个版本号，a较新时返回正数，相同返回0，较旧返回负数
+// 无法解析的版本视为最旧
+func compareVersions(a, b string) int {
+	left, _ := parseVersion(a)
+	right, _ := parseVersion(b)
+	for i := 0; i < len(left) || i < len(right); i++ {
+		var x, y int
+		if i < len(left) {
+			x = left[i]
+		}
+		if i < len(right) {
+			y = right[i]
+		}
+		if x != y {
+			return x - y
+		}
+	}
+	return 0
+}
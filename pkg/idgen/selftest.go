@@ -0,0 +1,131 @@
+package idgen
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"time"
+)
+
+// 字节分布卡方检验的临界值：自由度255，显著性水平0.0001
+// 正常的随机来源超过该值的概率极低，超过时说明分布明显不均匀
+const chiSquareCritical = 347.0
+
+// 读取crypto/rand的超时时间，熵池未初始化的环境中读取可能一直阻塞
+const entropyTimeout = 5 * time.Second
+
+// CheckResult 一项自检的结果
+type CheckResult struct {
+	// 检查项名称
+	Name string
+	// 是否通过
+	Passed bool
+	// 结果说明
+	Detail string
+}
+
+// SelfTest 检查当前环境能否安全地生成标识符
+// 依次验证crypto/rand可用、随机字节分布均匀、samples组标识符互不重复且UUID版本位和变体位正确，
+// 用于在chroot、精简容器等可能缺少随机来源的环境中提前发现问题
+func SelfTest(samples int) []CheckResult {
+	results := []CheckResult{checkEntropyAvailable()}
+	if !results[0].Passed {
+		return results
+	}
+
+	g := NewGenerator()
+	results = append(results, checkByteDistribution(g, samples))
+
+	sets, err := g.GenerateBatch(samples)
+	if err != nil {
+		return append(results, CheckResult{Name: "generate", Detail: err.Error()})
+	}
+	return append(results, checkUniqueness(sets), checkUUIDBits(g, sets))
+}
+
+// checkEntropyAvailable 检查crypto/rand能否在限定时间内返回数据
+func checkEntropyAvailable() CheckResult {
+	result := CheckResult{Name: "crypto/rand"}
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.ReadFull(rand.Reader, make([]byte, 32))
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			result.Detail = fmt.Sprintf("failed to read random bytes: %v", err)
+			return result
+		}
+		result.Passed = true
+		result.Detail = "available"
+	case <-time.After(entropyTimeout):
+		result.Detail = fmt.Sprintf("no random bytes after %s, the entropy source may be blocked", entropyTimeout)
+	}
+	return result
+}
+
+// checkByteDistribution 对随机字节做卡方检验
+func checkByteDistribution(g *Generator, samples int) CheckResult {
+	result := CheckResult{Name: "byte distribution"}
+	size := samples * 32
+	if size < 256*64 {
+		size = 256 * 64 // 每个取值平均至少出现64次，卡方检验才有意义
+	}
+
+	data := make([]byte, size)
+	if err := g.readRandom(data); err != nil {
+		result.Detail = err.Error()
+		return result
+	}
+
+	var counts [256]int
+	for _, b := range data {
+		counts[b]++
+	}
+	expected := float64(size) / 256
+	chiSquare := 0.0
+	for _, count := range counts {
+		diff := float64(count) - expected
+		chiSquare += diff * diff / expected
+	}
+
+	result.Passed = chiSquare < chiSquareCritical
+	result.Detail = fmt.Sprintf("chi-square %.1f over %d bytes (limit %.1f)", chiSquare, size, chiSquareCritical)
+	return result
+}
+
+// checkUniqueness 检查所有生成的标识符互不重复
+func checkUniqueness(sets []IdentitySet) CheckResult {
+	result := CheckResult{Name: "uniqueness"}
+	seen := make(map[string]bool, len(sets)*4)
+	for _, set := range sets {
+		for _, id := range []string{set.MachineID, set.MacMachineID, set.DeviceID, set.SQMID} {
+			if seen[id] {
+				result.Detail = fmt.Sprintf("duplicate ID %s", id)
+				return result
+			}
+			seen[id] = true
+		}
+	}
+	result.Passed = true
+	result.Detail = fmt.Sprintf("%d IDs, no duplicates", len(seen))
+	return result
+}
+
+// checkUUIDBits 检查所有标识符符合当前格式配置，UUID的版本位和变体位正确
+func checkUUIDBits(g *Generator, sets []IdentitySet) CheckResult {
+	result := CheckResult{Name: "ID format"}
+	for _, set := range sets {
+		for _, verdict := range g.ValidateAll(&set) {
+			if !verdict.Valid {
+				result.Detail = fmt.Sprintf("%s %s: %s", verdict.Field, verdict.Value, verdict.Reason)
+				return result
+			}
+		}
+	}
+	result.Passed = true
+	result.Detail = fmt.Sprintf("%d ID sets valid", len(sets))
+	return result
+}
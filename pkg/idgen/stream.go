@@ -0,0 +1,41 @@
+package idgen
+
+import "context"
+
+// IdentityResult 流式生成的一组标识符，生成失败时Err不为nil
+type IdentityResult struct {
+	// 生成的标识符，失败时为nil
+	Set *IdentitySet
+	// 生成错误
+	Err error
+}
+
+// Stream 按需逐组生成标识符，供批量处理远程目标时边处理边生成
+// 通道最多缓存buffer组（至少为0，即无缓冲），内存占用不随生成总数增长；
+// 生成失败时发送带错误的结果后关闭通道，ctx取消时停止生成并关闭通道
+// 多个协程可以同时从通道读取，也可以对同一个Generator同时开启多个Stream
+func (g *Generator) Stream(ctx context.Context, buffer int) <-chan IdentityResult {
+	if buffer < 0 {
+		buffer = 0
+	}
+	results := make(chan IdentityResult, buffer)
+
+	go func() {
+		defer close(results)
+		for ctx.Err() == nil {
+			set, err := g.GenerateAll(GenerateOptions{})
+			if err != nil {
+				set = nil
+			}
+			select {
+			case results <- IdentityResult{Set: set, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return results
+}
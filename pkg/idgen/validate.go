@@ -0,0 +1,48 @@
+package idgen
+
+import (
+	"fmt"
+)
+
+// FieldVerdict 单个标识符字段的校验结果
+type FieldVerdict struct {
+	// storage.json中的键名
+	Field string
+	// 字段的值
+	Value string
+	// 是否有效
+	Valid bool
+	// 无效的原因，有效时为空
+	Reason string
+}
+
+// ValidateAll 按当前格式配置校验一组标识符，按固定顺序返回每个字段的结果
+func (g *Generator) ValidateAll(set *IdentitySet) []FieldVerdict {
+	fields := []struct {
+		key    string
+		idType string
+		value  string
+	}{
+		{"telemetry.machineId", "machineID", set.MachineID},
+		{"telemetry.macMachineId", "macMachineID", set.MacMachineID},
+		{"telemetry.devDeviceId", "deviceID", set.DeviceID},
+		{"telemetry.sqmId", "sqmID", set.SQMID},
+	}
+
+	verdicts := make([]FieldVerdict, 0, len(fields))
+	for _, field := range fields {
+		verdict := FieldVerdict{Field: field.key, Value: field.value, Valid: true}
+		switch {
+		case field.value == "" && g.allowsEmpty(field.idType):
+			// 与ValidateID一致，允许为空的字段不算缺失
+		case field.value == "":
+			verdict.Valid = false
+			verdict.Reason = "missing"
+		case !g.ValidateID(field.value, field.idType):
+			verdict.Valid = false
+			verdict.Reason = fmt.Sprintf("does not match the %s format profile", g.profile.Name)
+		}
+		verdicts = append(verdicts, verdict)
+	}
+	return verdicts
+}
@@ -0,0 +1,4 @@
+// Package cursoridv1 是proto/cursorid/v1/cursorid.proto生成的gRPC客户端和服务端代码
+package cursoridv1
+
+//go:generate protoc -I ../../../proto --go_out=../../.. --go_opt=module=github.com/yuaotian/go-cursor-help --go-grpc_out=../../.. --go-grpc_opt=module=github.com/yuaotian/go-cursor-help cursorid/v1/cursorid.proto